@@ -4,8 +4,11 @@ package agentframework
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 )
 
 // Agent is the top-level conversational agent. It composes a [ChatClient] with
@@ -19,19 +22,26 @@ import (
 //	    agentframework.WithTools(weatherTool),
 //	)
 type Agent struct {
-	id                   string
-	name                 string
-	description          string
-	client               ChatClient
-	instructions         string
-	tools                []Tool
-	defaultOptions       *ChatOptions
-	messageStoreFactory  func() MessageStore
-	contextProvider      ContextProvider
-	agentMiddleware      []AgentMiddleware
-	chatMiddleware       []ChatMiddleware
-	functionMiddleware   []FunctionMiddleware
-	invocationConfig     InvocationConfig
+	id                      string
+	name                    string
+	description             string
+	client                  ChatClient
+	instructions            string
+	tools                   []Tool
+	defaultOptions          *ChatOptions
+	messageStoreFactory     func() MessageStore
+	contextProvider         ContextProvider
+	agentMiddleware         []AgentMiddleware
+	chatMiddleware          []ChatMiddleware
+	streamingChatMiddleware []StreamingChatMiddleware
+	functionMiddleware      []FunctionMiddleware
+	invocationConfig        InvocationConfig
+	approvalStore           RunStore
+	toolApprover            ToolApprover
+	tracer                  Tracer
+	meter                   Meter
+	profileRegistry         *ProfileRegistry
+	profileErr              error
 }
 
 // AgentOption configures an [Agent] via [NewAgent].
@@ -83,6 +93,12 @@ func WithChatMiddleware(mws ...ChatMiddleware) AgentOption {
 	return func(a *Agent) { a.chatMiddleware = append(a.chatMiddleware, mws...) }
 }
 
+// WithStreamingChatMiddleware adds [StreamingChatMiddleware] to the streaming
+// chat pipeline used by [Agent.RunStream].
+func WithStreamingChatMiddleware(mws ...StreamingChatMiddleware) AgentOption {
+	return func(a *Agent) { a.streamingChatMiddleware = append(a.streamingChatMiddleware, mws...) }
+}
+
 // WithFunctionMiddleware adds [FunctionMiddleware] to the tool invocation pipeline.
 func WithFunctionMiddleware(mws ...FunctionMiddleware) AgentOption {
 	return func(a *Agent) { a.functionMiddleware = append(a.functionMiddleware, mws...) }
@@ -94,16 +110,101 @@ func WithInvocationConfig(cfg InvocationConfig) AgentOption {
 	return func(a *Agent) { a.invocationConfig = cfg }
 }
 
+// WithRunStore sets the [RunStore] used to persist runs suspended for human
+// approval. Defaults to an [InMemoryRunStore], which does not survive
+// process restarts; use [NewFileRunStore] or [NewSQLRunStore] for runs that
+// must be resumable from a different process.
+func WithRunStore(store RunStore) AgentOption {
+	return func(a *Agent) { a.approvalStore = store }
+}
+
+// WithToolApprover sets the [ToolApprover] consulted, synchronously and
+// in-process, for every tool call requiring approval. When set, it takes
+// precedence over the suspend/resume flow ([PendingApprovalError],
+// [RunSnapshot]) for that call: a run never pauses for a call the approver
+// decides on. Leave unset to keep the default suspend/resume behavior.
+func WithToolApprover(approver ToolApprover) AgentOption {
+	return func(a *Agent) { a.toolApprover = approver }
+}
+
+// WithTracer sets the [Tracer] used to emit spans around agent runs and tool
+// invocations. Defaults to [NoopTracer].
+func WithTracer(t Tracer) AgentOption {
+	return func(a *Agent) { a.tracer = t }
+}
+
+// WithMeter sets the [Meter] used to record run-count, duration, and token
+// metrics. Defaults to [NoopMeter].
+func WithMeter(m Meter) AgentOption {
+	return func(a *Agent) { a.meter = m }
+}
+
+// WithProfileRegistry attaches a [ProfileRegistry] the agent can resolve
+// [WithProfile] and [WithProfileOverride] names against. Must appear before
+// [WithProfile] in the option list.
+func WithProfileRegistry(r *ProfileRegistry) AgentOption {
+	return func(a *Agent) { a.profileRegistry = r }
+}
+
+// WithProfile bakes the named [AgentProfile]'s Instructions, Tools,
+// ContextProviders, and DefaultOptions into the agent at construction time.
+// Tools are appended to, and DefaultOptions merged over, those set by earlier
+// options; a non-empty Instructions and the profile's ContextProviders (if
+// any) replace the earlier ones.
+// Requires [WithProfileRegistry] earlier in the option list; an
+// unresolvable name surfaces as an [ErrInitialization] error from the
+// first [Agent.Run] or [Agent.RunStream] call, since [NewAgent] itself
+// can't fail.
+func WithProfile(name string) AgentOption {
+	return func(a *Agent) {
+		if a.profileRegistry == nil {
+			a.profileErr = fmt.Errorf("%w: profile %q requested but no ProfileRegistry is configured (add WithProfileRegistry before WithProfile)", ErrInitialization, name)
+			return
+		}
+		profile, ok := a.profileRegistry.Get(name)
+		if !ok {
+			a.profileErr = fmt.Errorf("%w: %w", ErrInitialization, fmt.Errorf("%w: %q", ErrProfileNotFound, name))
+			return
+		}
+		a.applyProfile(profile)
+	}
+}
+
+// applyProfile bakes profile's fields into the agent's own defaults.
+func (a *Agent) applyProfile(p *AgentProfile) {
+	if p.Instructions != "" {
+		a.instructions = p.Instructions
+	}
+	a.tools = append(a.tools, p.Tools...)
+	if p.DefaultOptions != nil {
+		a.defaultOptions = MergeChatOptions(a.defaultOptions, p.DefaultOptions)
+	}
+	if cp := p.combinedContextProvider(); cp != nil {
+		a.contextProvider = cp
+	}
+}
+
 // NewAgent creates an Agent with the given [ChatClient] and options.
 func NewAgent(client ChatClient, opts ...AgentOption) *Agent {
 	a := &Agent{
 		id:               newUUID(),
 		client:           client,
 		invocationConfig: DefaultInvocationConfig(),
+		tracer:           NoopTracer,
+		meter:            NoopMeter,
 	}
 	for _, opt := range opts {
 		opt(a)
 	}
+	if a.approvalStore == nil {
+		a.approvalStore = NewInMemoryRunStore()
+	}
+	if a.tracer == nil {
+		a.tracer = NoopTracer
+	}
+	if a.meter == nil {
+		a.meter = NoopMeter
+	}
 	return a
 }
 
@@ -116,13 +217,25 @@ func (a *Agent) Name() string { return a.name }
 // Description returns the agent's description.
 func (a *Agent) Description() string { return a.description }
 
+// AvailableProfiles returns the names of every profile registered on the
+// agent's [ProfileRegistry] (see [WithProfileRegistry]), sorted, for UIs
+// that want to present a picker. Returns nil if no registry is configured.
+func (a *Agent) AvailableProfiles() []string {
+	if a.profileRegistry == nil {
+		return nil
+	}
+	return a.profileRegistry.Names()
+}
+
 // RunOption configures a single [Run] or [RunStream] call.
 type RunOption func(*runConfig)
 
 type runConfig struct {
-	session *Session
-	tools   []Tool
-	options *ChatOptions
+	session         *Session
+	tools           []Tool
+	options         *ChatOptions
+	profileOverride string
+	profile         *AgentProfile
 }
 
 // WithSession attaches a [Session] for multi-turn conversation.
@@ -140,9 +253,25 @@ func WithRunOptions(opts *ChatOptions) RunOption {
 	return func(c *runConfig) { c.options = opts }
 }
 
+// WithProfileOverride resolves name against the agent's [ProfileRegistry]
+// for this call only. Its Instructions and Tools replace the agent's
+// baseline for the call, its ContextProviders (if any) replace the agent's,
+// and its DefaultOptions are merged over the agent's defaults (tools and
+// options from [WithRunTools] / [WithRunOptions] are still layered on top),
+// letting a single long-lived Agent switch personas per request.
+func WithProfileOverride(name string) RunOption {
+	return func(c *runConfig) { c.profileOverride = name }
+}
+
 // Run sends messages to the agent and returns a complete response.
 func (a *Agent) Run(ctx context.Context, messages []Message, opts ...RunOption) (*AgentResponse, error) {
+	if a.profileErr != nil {
+		return nil, a.profileErr
+	}
 	cfg := a.buildRunConfig(opts)
+	if err := a.resolveProfileOverride(cfg); err != nil {
+		return nil, err
+	}
 
 	// Build the inner handler
 	handler := a.buildHandler(cfg)
@@ -160,8 +289,16 @@ func (a *Agent) Run(ctx context.Context, messages []Message, opts ...RunOption)
 }
 
 // RunStream sends messages to the agent and returns a streaming response.
+// If a tool call requires approval, the final update carries FinishReason
+// [FinishReasonApprovalRequired] and a Token to pass to [Agent.Resume].
 func (a *Agent) RunStream(ctx context.Context, messages []Message, opts ...RunOption) (*AgentResponseStream, error) {
+	if a.profileErr != nil {
+		return nil, a.profileErr
+	}
 	cfg := a.buildRunConfig(opts)
+	if err := a.resolveProfileOverride(cfg); err != nil {
+		return nil, err
+	}
 
 	// For streaming, we produce an AgentResponseStream that maps from ChatResponseUpdate
 	chatOpts := a.prepareChatOptions(cfg)
@@ -170,8 +307,23 @@ func (a *Agent) RunStream(ctx context.Context, messages []Message, opts ...RunOp
 		return nil, err
 	}
 
-	// Apply chat middleware to the streaming path
-	chatStream, err := a.client.StreamResponse(ctx, allMessages, chatOpts)
+	// Either way, run through the streaming chat middleware chain so hooks
+	// see the whole streamed turn, including any tool-calling iterations
+	// within it (mirroring how chatMiddleware wraps [Agent.buildHandler]).
+	streamHandler := chainStreamingChatMiddleware(func(ctx context.Context, msgs []Message, opts *ChatOptions) (*ResponseStream[ChatResponseUpdate], error) {
+		if len(opts.Tools) > 0 {
+			return NewResponseStream(ctx, func(ctx context.Context, ch chan<- ChatResponseUpdate) error {
+				return invokeFunctionsStream(ctx, a.client, msgs, opts, a.invocationConfig, a.functionMiddleware, a.tracer, preApprovalCheck(cfg.session), a.toolApprover, 0, ch,
+					func(ctx context.Context, pendingMessages []Message, pending []FunctionCallContent, iteration, consecutiveErrors int) (string, error) {
+						return a.suspendForApproval(ctx, pendingMessages, pending, opts, iteration, consecutiveErrors)
+					},
+				)
+			}), nil
+		}
+		return a.client.StreamResponse(ctx, msgs, opts)
+	}, a.streamingChatMiddleware...)
+
+	chatStream, err := streamHandler(ctx, allMessages, chatOpts)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %w", ErrExecution, err)
 	}
@@ -179,30 +331,40 @@ func (a *Agent) RunStream(ctx context.Context, messages []Message, opts ...RunOp
 	// Map ChatResponseUpdate → AgentResponseUpdate
 	agentStream := MapStream(ctx, chatStream, func(u ChatResponseUpdate) AgentResponseUpdate {
 		return AgentResponseUpdate{
-			Contents:   u.Contents,
-			Role:       u.Role,
-			AgentID:    a.id,
-			ResponseID: u.ResponseID,
-			Usage:      u.Usage,
-			Raw:        u.Raw,
+			Contents:     u.Contents,
+			Role:         u.Role,
+			AgentID:      a.id,
+			ResponseID:   u.ResponseID,
+			FinishReason: u.FinishReason,
+			Usage:        u.Usage,
+			Raw:          u.Raw,
+			Token:        u.Token,
 		}
 	})
 
+	if cfg.session != nil {
+		agentStream = a.persistOnCompletion(ctx, agentStream, cfg.session, messages)
+	}
+
 	return NewAgentResponseStream(agentStream), nil
 }
 
-// NewSession creates a new [Session] pre-configured for this agent.
-func (a *Agent) NewSession() *Session {
+// NewSession creates a new [Session] pre-configured for this agent. Extra
+// options are applied after the agent's defaults, so e.g.
+// [WithSessionCacheKey] can override the generated session ID as the
+// prompt-cache partition key.
+func (a *Agent) NewSession(opts ...SessionOption) *Session {
 	var store MessageStore
 	if a.messageStoreFactory != nil {
 		store = a.messageStoreFactory()
 	} else {
 		store = NewInMemoryStore()
 	}
-	return NewSession(
+	base := []SessionOption{
 		WithSessionStore(store),
 		WithSessionContextProvider(a.contextProvider),
-	)
+	}
+	return NewSession(append(base, opts...)...)
 }
 
 func (a *Agent) buildRunConfig(opts []RunOption) *runConfig {
@@ -213,24 +375,52 @@ func (a *Agent) buildRunConfig(opts []RunOption) *runConfig {
 	return cfg
 }
 
+// resolveProfileOverride looks up cfg.profileOverride, if set, against the
+// agent's [ProfileRegistry] and stashes the result on cfg for
+// prepareChatOptions and prepareMessages to apply in place of the agent's
+// baseline Instructions, Tools, and ContextProviders.
+func (a *Agent) resolveProfileOverride(cfg *runConfig) error {
+	if cfg.profileOverride == "" {
+		return nil
+	}
+	if a.profileRegistry == nil {
+		return fmt.Errorf("%w: profile override %q requested but no ProfileRegistry is configured", ErrInitialization, cfg.profileOverride)
+	}
+	profile, ok := a.profileRegistry.Get(cfg.profileOverride)
+	if !ok {
+		return fmt.Errorf("%w: %w", ErrInitialization, fmt.Errorf("%w: %q", ErrProfileNotFound, cfg.profileOverride))
+	}
+	cfg.profile = profile
+	return nil
+}
+
 func (a *Agent) prepareChatOptions(cfg *runConfig) *ChatOptions {
-	// Start with default options
-	opts := MergeChatOptions(a.defaultOptions, cfg.options)
+	instructions := a.instructions
+	baseTools := a.tools
+	opts := a.defaultOptions
+	if cfg.profile != nil {
+		instructions = cfg.profile.Instructions
+		baseTools = cfg.profile.Tools
+		opts = MergeChatOptions(opts, cfg.profile.DefaultOptions)
+	}
 
-	// Merge tools: agent defaults + per-call overrides
-	allTools := make([]Tool, 0, len(a.tools)+len(cfg.tools))
-	allTools = append(allTools, a.tools...)
+	// Start with default options, then the per-call override on top.
+	opts = MergeChatOptions(opts, cfg.options)
+
+	// Merge tools: baseline (agent or profile) + per-call overrides
+	allTools := make([]Tool, 0, len(baseTools)+len(cfg.tools))
+	allTools = append(allTools, baseTools...)
 	allTools = append(allTools, cfg.tools...)
 	if len(allTools) > 0 {
 		opts.Tools = allTools
 	}
 
 	// Set instructions
-	if a.instructions != "" {
+	if instructions != "" {
 		if opts.Instructions != "" {
-			opts.Instructions = a.instructions + "\n" + opts.Instructions
+			opts.Instructions = instructions + "\n" + opts.Instructions
 		} else {
-			opts.Instructions = a.instructions
+			opts.Instructions = instructions
 		}
 	}
 
@@ -253,12 +443,22 @@ func (a *Agent) prepareMessages(ctx context.Context, messages []Message, cfg *ru
 		if sid := cfg.session.ServiceID(); sid != "" {
 			opts.ConversationID = sid
 		}
+		// Default the prompt-cache partition key to the session's, unless
+		// a per-call ChatOptions already set one explicitly.
+		if opts.CacheKey == "" {
+			opts.CacheKey = cfg.session.CacheKey()
+		}
 	}
 
 	allMessages = append(allMessages, messages...)
 
 	// Apply context provider
 	cp := a.contextProvider
+	if cfg.profile != nil {
+		if pcp := cfg.profile.combinedContextProvider(); pcp != nil {
+			cp = pcp
+		}
+	}
 	if cfg.session != nil && cfg.session.ContextProvider() != nil {
 		cp = cfg.session.ContextProvider()
 	}
@@ -290,11 +490,26 @@ func (a *Agent) prepareMessages(ctx context.Context, messages []Message, cfg *ru
 	return allMessages, nil
 }
 
+// preApprovalCheck returns a function invokeFunctions can use to skip
+// approval for tools previously pre-approved on session via
+// [Session.ApproveToolForSession], or nil if session is nil.
+func preApprovalCheck(session *Session) func(name string) bool {
+	if session == nil {
+		return nil
+	}
+	return session.IsToolAlwaysApproved
+}
+
 func (a *Agent) buildHandler(cfg *runConfig) AgentHandler {
 	return func(ctx context.Context, req *AgentRequest) (*AgentResponse, error) {
+		ctx, span := a.tracer.Start(ctx, "agent.run", Attr("agent_id", a.id), Attr("agent_name", a.name))
+		start := time.Now()
+		defer span.End()
+
 		chatOpts := a.prepareChatOptions(cfg)
 		allMessages, err := a.prepareMessages(ctx, req.Messages, cfg, chatOpts)
 		if err != nil {
+			span.RecordError(err)
 			return nil, err
 		}
 
@@ -305,16 +520,28 @@ func (a *Agent) buildHandler(cfg *runConfig) AgentHandler {
 			"tool_count", len(chatOpts.Tools),
 		)
 
-		// If tools are present, use the function invocation loop
-		var chatResp *ChatResponse
-		if len(chatOpts.Tools) > 0 {
-			chatResp, err = invokeFunctions(ctx, a.client, allMessages, chatOpts, a.invocationConfig, a.functionMiddleware)
-		} else {
-			chatResp, err = a.client.Response(ctx, allMessages, chatOpts)
-		}
+		// If tools are present, use the function invocation loop. Either
+		// way, run through the chat middleware chain so hooks like
+		// [CitationMiddleware] and [UsageMiddleware] see the final response.
+		chatHandler := chainChatMiddleware(func(ctx context.Context, msgs []Message, opts *ChatOptions) (*ChatResponse, error) {
+			if len(opts.Tools) > 0 {
+				return invokeFunctions(ctx, a.client, msgs, opts, a.invocationConfig, a.functionMiddleware, a.tracer, preApprovalCheck(cfg.session), a.toolApprover, 0)
+			}
+			return a.client.Response(ctx, msgs, opts)
+		}, a.chatMiddleware...)
+		chatResp, err := chatHandler(ctx, allMessages, chatOpts)
+		a.meter.Counter("agent.run.count").Add(ctx, 1, Attr("agent_name", a.name))
+		a.meter.Histogram("agent.run.duration_ms").Record(ctx, float64(time.Since(start).Milliseconds()), Attr("agent_name", a.name))
 		if err != nil {
+			span.RecordError(err)
+			var pa *pendingApproval
+			if errors.As(err, &pa) {
+				return nil, a.pause(ctx, pa, chatOpts)
+			}
 			return nil, fmt.Errorf("%w: %w", ErrExecution, err)
 		}
+		a.meter.Counter("agent.tokens.input").Add(ctx, int64(chatResp.Usage.InputTokens), Attr("agent_name", a.name))
+		a.meter.Counter("agent.tokens.output").Add(ctx, int64(chatResp.Usage.OutputTokens), Attr("agent_name", a.name))
 
 		// Update session
 		if cfg.session != nil {
@@ -325,6 +552,11 @@ func (a *Agent) buildHandler(cfg *runConfig) AgentHandler {
 
 		// Notify context provider
 		cp := a.contextProvider
+		if cfg.profile != nil {
+			if pcp := cfg.profile.combinedContextProvider(); pcp != nil {
+				cp = pcp
+			}
+		}
 		if cfg.session != nil && cfg.session.ContextProvider() != nil {
 			cp = cfg.session.ContextProvider()
 		}
@@ -363,9 +595,267 @@ func (a *Agent) updateSession(ctx context.Context, session *Session, request []M
 		}
 	}
 
+	// If the store branches and the session has forked, make sure we write
+	// into the active branch rather than whatever branch the store last
+	// left active (e.g. another session sharing the same store).
+	if branch := session.Branch(); branch != "" {
+		if bs, ok := store.(BranchingMessageStore); ok {
+			if err := bs.SwitchBranch(ctx, branch); err != nil {
+				return err
+			}
+		}
+	}
+
 	// Persist messages
 	if err := store.AddMessages(ctx, request); err != nil {
 		return err
 	}
 	return store.AddMessages(ctx, resp.Messages)
 }
+
+// updateSessionFromAgentResponse mirrors updateSession for a merged
+// [AgentResponse]. Unlike [ChatResponse], [AgentResponse] carries no
+// conversation ID, so there is no service-mode switch to consider here.
+func (a *Agent) updateSessionFromAgentResponse(ctx context.Context, session *Session, request []Message, resp *AgentResponse) error {
+	store := session.Store()
+	if store == nil {
+		if a.messageStoreFactory != nil {
+			store = a.messageStoreFactory()
+		} else {
+			store = NewInMemoryStore()
+		}
+		if err := session.SetStore(store); err != nil {
+			return err
+		}
+	}
+
+	if branch := session.Branch(); branch != "" {
+		if bs, ok := store.(BranchingMessageStore); ok {
+			if err := bs.SwitchBranch(ctx, branch); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := store.AddMessages(ctx, request); err != nil {
+		return err
+	}
+	return store.AddMessages(ctx, resp.Messages)
+}
+
+// persistOnCompletion wraps src so that once it is fully drained, the
+// accumulated updates are merged via [AgentResponseFromUpdates] and
+// persisted to session — mirroring the automatic session update
+// [Agent.buildHandler] performs after a non-streaming run. Persistence is
+// skipped if the run paused for approval (FinishReason
+// [FinishReasonApprovalRequired]), since that run is not actually complete.
+// Persistence failures are logged rather than surfaced, so a storage hiccup
+// doesn't fail an otherwise-successful stream.
+func (a *Agent) persistOnCompletion(ctx context.Context, src *ResponseStream[AgentResponseUpdate], session *Session, request []Message) *ResponseStream[AgentResponseUpdate] {
+	return NewResponseStream(ctx, func(ctx context.Context, ch chan<- AgentResponseUpdate) error {
+		defer src.Close()
+		var updates []AgentResponseUpdate
+		for {
+			u, ok, err := src.Next(ctx)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				break
+			}
+			updates = append(updates, u)
+			select {
+			case ch <- u:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		if len(updates) > 0 && updates[len(updates)-1].FinishReason == FinishReasonApprovalRequired {
+			return nil
+		}
+		resp := AgentResponseFromUpdates(updates)
+		if err := a.updateSessionFromAgentResponse(ctx, session, request, resp); err != nil {
+			slog.WarnContext(ctx, "failed to update session from stream", "error", err)
+		}
+		return nil
+	})
+}
+
+// Resume reloads a run previously suspended by [Agent.Run] or
+// [Agent.RunStream] for human approval, applies responses to the pending
+// tool calls (invoking approved ones and recording denials as tool errors),
+// and continues the tool-calling loop to completion or the next approval.
+//
+// responses need not cover every pending call; any call without a matching
+// CallID is treated as denied. The Agent must be configured with the same
+// tools as the one that produced the snapshot (see [RunSnapshot]). The
+// tool-calling loop resumes with the iteration and consecutive-error counts
+// it was suspended at, rather than a fresh budget.
+//
+// Passing [WithSession] lets a response with AlwaysApproveForSession set
+// record the decision on that session (see
+// [Session.ApproveToolForSession]), so later calls to the same tool — in
+// this run and any future one sharing the session — skip approval.
+func (a *Agent) Resume(ctx context.Context, token string, responses []ApprovalResponseContent, opts ...RunOption) (*AgentResponse, error) {
+	cfg := a.buildRunConfig(opts)
+
+	snap, err := a.approvalStore.LoadRun(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	ctx = restorePersistableContext(ctx, snap.ContextValues)
+
+	decisions := make(map[string]ApprovalResponseContent, len(responses))
+	for _, r := range responses {
+		decisions[r.CallID] = r
+	}
+
+	toolMap := make(map[string]Tool, len(a.tools))
+	for _, t := range a.tools {
+		toolMap[t.Name()] = t
+	}
+
+	messages := append([]Message{}, snap.Messages...)
+	var resultMessages []Message
+	for _, call := range snap.Pending {
+		decision, ok := decisions[call.CallID]
+		if !ok || !decision.Approved {
+			reason := "no approval decision received"
+			if ok {
+				reason = decision.Reason
+				if reason == "" {
+					reason = "denied by reviewer"
+				}
+			}
+			resultMessages = append(resultMessages, NewToolMessage(call.CallID, "denied: "+reason))
+			continue
+		}
+
+		if decision.AlwaysApproveForSession && cfg.session != nil {
+			cfg.session.ApproveToolForSession(call.Name)
+		}
+
+		tool, ok := toolMap[call.Name]
+		if !ok {
+			resultMessages = append(resultMessages, NewToolMessage(call.CallID, "error: unknown tool"))
+			continue
+		}
+		result, invokeErr := invokeToolWithMiddleware(ctx, tool, json.RawMessage(call.Arguments), a.functionMiddleware, a.tracer, call.CallID)
+		if invokeErr != nil {
+			errMsg := "error invoking tool"
+			if a.invocationConfig.IncludeDetailedErrors {
+				errMsg = invokeErr.Error()
+			}
+			resultMessages = append(resultMessages, NewToolMessage(call.CallID, errMsg))
+			continue
+		}
+		resultMessages = append(resultMessages, NewToolMessage(call.CallID, result))
+	}
+	messages = append(messages, resultMessages...)
+
+	if err := a.approvalStore.DeleteRun(ctx, token); err != nil {
+		slog.WarnContext(ctx, "failed to delete resumed run snapshot", "token", token, "error", err)
+	}
+
+	chatOpts := &ChatOptions{}
+	if snap.Options != nil {
+		cp := *snap.Options
+		chatOpts = &cp
+	}
+	chatOpts.Tools = append([]Tool{}, a.tools...)
+
+	config := a.invocationConfig
+	if config.MaxIterations <= 0 {
+		config.MaxIterations = 40
+	}
+	// snap.Iteration+1 loop iterations were already spent reaching the
+	// suspension point (the iteration that produced the pending calls
+	// counts too), so only the remainder continues here.
+	spent := snap.Iteration + 1
+	config.MaxIterations -= spent
+	if config.MaxIterations < 1 {
+		config.MaxIterations = 1
+	}
+
+	chatResp, err := invokeFunctions(ctx, a.client, messages, chatOpts, config, a.functionMiddleware, a.tracer, preApprovalCheck(cfg.session), a.toolApprover, snap.ConsecutiveErrors)
+	if err != nil {
+		var pa *pendingApproval
+		if errors.As(err, &pa) {
+			// pa.iteration is relative to this resumed call's (already
+			// reduced) budget; add back the iterations already spent
+			// before the original suspension so a further resume keeps
+			// counting against the run's original MaxIterations.
+			pa.iteration += spent
+			return nil, a.pause(ctx, pa, chatOpts)
+		}
+		return nil, fmt.Errorf("%w: %w", ErrExecution, err)
+	}
+
+	return &AgentResponse{
+		Messages:   chatResp.Messages,
+		ResponseID: chatResp.ResponseID,
+		AgentID:    a.id,
+		Usage:      chatResp.Usage,
+		Extra:      chatResp.Extra,
+		Raw:        chatResp.Raw,
+	}, nil
+}
+
+// pause turns a pendingApproval signal from the tool-calling loop into a
+// persisted [RunSnapshot] and the [PendingApprovalError] returned to the
+// caller of [Agent.Run] or [Agent.Resume].
+func (a *Agent) pause(ctx context.Context, pa *pendingApproval, opts *ChatOptions) error {
+	token, err := a.suspendForApproval(ctx, pa.messages, pa.pending, opts, pa.iteration, pa.consecutiveErrors)
+	if err != nil {
+		return err
+	}
+	return &PendingApprovalError{
+		Token:   token,
+		Pending: approvalRequestsFromPending(pa.pending),
+		Err:     ErrApproval,
+	}
+}
+
+// suspendForApproval persists a [RunSnapshot] for a run paused at the given
+// pending tool calls and returns its resumable token.
+func (a *Agent) suspendForApproval(ctx context.Context, messages []Message, pending []FunctionCallContent, opts *ChatOptions, iteration, consecutiveErrors int) (string, error) {
+	token := newUUID()
+
+	storedOpts := *opts
+	storedOpts.Tools = nil
+
+	reqs := approvalRequestsFromPending(pending)
+	approvalContents := make(Contents, len(reqs))
+	for i := range reqs {
+		approvalContents[i] = &reqs[i]
+	}
+
+	snap := &RunSnapshot{
+		Token:   token,
+		AgentID: a.id,
+		Messages: append(append([]Message{}, messages...), Message{
+			Role:     RoleAssistant,
+			Contents: approvalContents,
+		}),
+		Pending:           pending,
+		Iteration:         iteration,
+		ConsecutiveErrors: consecutiveErrors,
+		Options:           &storedOpts,
+		ContextValues:     capturePersistableContext(ctx),
+	}
+	if err := a.approvalStore.SaveRun(ctx, snap); err != nil {
+		return "", fmt.Errorf("%w: save run snapshot: %w", ErrApproval, err)
+	}
+	return token, nil
+}
+
+// approvalRequestsFromPending converts pending function calls awaiting
+// approval into the [ApprovalRequestContent] form surfaced to callers.
+func approvalRequestsFromPending(pending []FunctionCallContent) []ApprovalRequestContent {
+	reqs := make([]ApprovalRequestContent, len(pending))
+	for i, p := range pending {
+		reqs[i] = ApprovalRequestContent{CallID: p.CallID, Name: p.Name, Arguments: p.Arguments}
+	}
+	return reqs
+}
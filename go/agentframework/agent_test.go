@@ -4,7 +4,12 @@ package agentframework_test
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	af "github.com/microsoft/agent-framework/go/agentframework"
 )
@@ -96,6 +101,280 @@ func TestAgent_WithToolInvocation(t *testing.T) {
 	}
 }
 
+func TestAgent_InvalidToolArguments_FeedBackWithoutCountingAsError(t *testing.T) {
+	type addArgs struct {
+		A int `json:"a" jsonschema:"required"`
+		B int `json:"b" jsonschema:"required"`
+	}
+	var invoked int
+	tool := af.NewTypedTool("add", "Adds two numbers",
+		func(ctx context.Context, args addArgs) (any, error) {
+			invoked++
+			return args.A + args.B, nil
+		},
+	)
+
+	callCount := 0
+	var secondTurnMessages []af.Message
+	client := &mockClient{
+		responseFn: func(ctx context.Context, msgs []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error) {
+			callCount++
+			if callCount == 1 {
+				// Missing required field "b".
+				return &af.ChatResponse{
+					Messages: []af.Message{{
+						Role: af.RoleAssistant,
+						Contents: af.Contents{
+							&af.FunctionCallContent{CallID: "call-1", Name: "add", Arguments: `{"a":3}`},
+						},
+					}},
+				}, nil
+			}
+			secondTurnMessages = msgs
+			return &af.ChatResponse{Messages: []af.Message{af.NewAssistantMessage("The answer is 7.")}}, nil
+		},
+	}
+
+	// Even with no error budget at all, a validation failure must not
+	// count against consecutiveErrors.
+	agent := af.NewAgent(client, af.WithTools(tool), af.WithInvocationConfig(af.InvocationConfig{MaxConsecutiveErrors: 1}))
+	resp, err := agent.Run(context.Background(), []af.Message{af.NewUserMessage("what is 3 plus something?")})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if invoked != 0 {
+		t.Errorf("tool invoked %d times, want 0 (invalid arguments)", invoked)
+	}
+	if resp.Text() != "The answer is 7." {
+		t.Errorf("Text = %q", resp.Text())
+	}
+
+	found := false
+	for _, m := range secondTurnMessages {
+		if m.Role != af.RoleTool {
+			continue
+		}
+		if fr, ok := m.Contents[0].(*af.FunctionResultContent); ok {
+			if result, ok := fr.Result.(string); ok && strings.HasPrefix(result, "invalid arguments:") {
+				found = true
+			}
+		}
+	}
+	if !found {
+		t.Error("expected a tool message describing the invalid arguments")
+	}
+}
+
+func TestAgent_ConcurrentToolDispatch_ValidationFailureDoesNotCountAsError(t *testing.T) {
+	type addArgs struct {
+		A int `json:"a" jsonschema:"required"`
+		B int `json:"b" jsonschema:"required"`
+	}
+	var addInvoked int
+	add := af.NewTypedTool("add", "Adds two numbers",
+		func(ctx context.Context, args addArgs) (any, error) {
+			addInvoked++
+			return args.A + args.B, nil
+		},
+	)
+	var boomInvoked int
+	boom := af.NewTypedTool("boom", "Always fails",
+		func(ctx context.Context, args struct{}) (any, error) {
+			boomInvoked++
+			return nil, errors.New("boom")
+		},
+	)
+
+	// With MaxConsecutiveErrors: 2, a neutral (validation-failure) batch
+	// sandwiched between two batches of real tool errors must leave
+	// consecutiveErrors at 1 rather than resetting it to 0 — otherwise the
+	// third batch's error would only bring the streak to 1 and the run
+	// would complete, instead of reaching the threshold and aborting.
+	callCount := 0
+	client := &mockClient{
+		responseFn: func(ctx context.Context, msgs []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error) {
+			callCount++
+			switch callCount {
+			case 1:
+				return &af.ChatResponse{
+					Messages: []af.Message{{
+						Role: af.RoleAssistant,
+						Contents: af.Contents{
+							&af.FunctionCallContent{CallID: "call-1", Name: "boom", Arguments: `{}`},
+						},
+					}},
+				}, nil
+			case 2:
+				// Missing required field "b" on both calls in the batch.
+				return &af.ChatResponse{
+					Messages: []af.Message{{
+						Role: af.RoleAssistant,
+						Contents: af.Contents{
+							&af.FunctionCallContent{CallID: "call-2", Name: "add", Arguments: `{"a":1}`},
+							&af.FunctionCallContent{CallID: "call-3", Name: "add", Arguments: `{"a":2}`},
+						},
+					}},
+				}, nil
+			default:
+				return &af.ChatResponse{
+					Messages: []af.Message{{
+						Role: af.RoleAssistant,
+						Contents: af.Contents{
+							&af.FunctionCallContent{CallID: "call-4", Name: "boom", Arguments: `{}`},
+						},
+					}},
+				}, nil
+			}
+		},
+	}
+
+	agent := af.NewAgent(client,
+		af.WithTools(add, boom),
+		af.WithInvocationConfig(af.InvocationConfig{Concurrency: 2, MaxConsecutiveErrors: 2}),
+	)
+	_, err := agent.Run(context.Background(), []af.Message{af.NewUserMessage("add some numbers")})
+	if !errors.Is(err, af.ErrToolExecution) {
+		t.Fatalf("Run error = %v, want ErrToolExecution (consecutiveErrors should have reached 1, then 2 on the third batch)", err)
+	}
+	if addInvoked != 0 {
+		t.Errorf("add invoked %d times, want 0 (invalid arguments)", addInvoked)
+	}
+	if boomInvoked != 2 {
+		t.Errorf("boom invoked %d times, want 2", boomInvoked)
+	}
+}
+
+func TestAgent_ConcurrentToolDispatch_PreservesOrder(t *testing.T) {
+	var mu sync.Mutex
+	var invoked []string
+	makeTool := func(name string, delay time.Duration) *af.FunctionTool {
+		return af.NewTool(name, "a tool", json.RawMessage(`{"type":"object"}`),
+			func(ctx context.Context, args json.RawMessage) (any, error) {
+				time.Sleep(delay)
+				mu.Lock()
+				invoked = append(invoked, name)
+				mu.Unlock()
+				return name + "-result", nil
+			},
+		)
+	}
+	// slow is dispatched first but finishes last; order in the resulting
+	// tool messages must still follow the original call order, not
+	// completion order.
+	slow := makeTool("slow", 20*time.Millisecond)
+	fast := makeTool("fast", 0)
+
+	callCount := 0
+	client := &mockClient{
+		responseFn: func(ctx context.Context, msgs []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error) {
+			callCount++
+			if callCount == 1 {
+				return &af.ChatResponse{
+					Messages: []af.Message{{
+						Role: af.RoleAssistant,
+						Contents: af.Contents{
+							&af.FunctionCallContent{CallID: "call-1", Name: "slow", Arguments: `{}`},
+							&af.FunctionCallContent{CallID: "call-2", Name: "fast", Arguments: `{}`},
+						},
+					}},
+				}, nil
+			}
+			return &af.ChatResponse{Messages: []af.Message{af.NewAssistantMessage("done")}}, nil
+		},
+	}
+
+	agent := af.NewAgent(client,
+		af.WithTools(slow, fast),
+		af.WithInvocationConfig(af.InvocationConfig{Concurrency: 2}),
+	)
+	resp, err := agent.Run(context.Background(), []af.Message{af.NewUserMessage("go")})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if resp.Text() != "done" {
+		t.Errorf("Text = %q", resp.Text())
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(invoked) != 2 || invoked[0] != "fast" || invoked[1] != "slow" {
+		t.Errorf("completion order = %v, want fast before slow (proves concurrency)", invoked)
+	}
+}
+
+func TestAgent_ConcurrentToolDispatch_ExclusiveToolRunsAlone(t *testing.T) {
+	var mu sync.Mutex
+	var activeA int
+	var exclusiveActive bool
+	var violation bool
+
+	a := af.NewTool("a", "a", json.RawMessage(`{"type":"object"}`),
+		func(ctx context.Context, args json.RawMessage) (any, error) {
+			mu.Lock()
+			activeA++
+			if exclusiveActive {
+				violation = true
+			}
+			mu.Unlock()
+			time.Sleep(10 * time.Millisecond)
+			mu.Lock()
+			activeA--
+			mu.Unlock()
+			return "a-result", nil
+		},
+	)
+	exclusive := af.NewTool("exclusive", "must run alone", json.RawMessage(`{"type":"object"}`),
+		func(ctx context.Context, args json.RawMessage) (any, error) {
+			mu.Lock()
+			exclusiveActive = true
+			if activeA > 0 {
+				violation = true
+			}
+			mu.Unlock()
+			time.Sleep(10 * time.Millisecond)
+			mu.Lock()
+			exclusiveActive = false
+			mu.Unlock()
+			return "exclusive-result", nil
+		},
+		af.WithExclusiveExecution(),
+	)
+
+	callCount := 0
+	client := &mockClient{
+		responseFn: func(ctx context.Context, msgs []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error) {
+			callCount++
+			if callCount == 1 {
+				return &af.ChatResponse{
+					Messages: []af.Message{{
+						Role: af.RoleAssistant,
+						Contents: af.Contents{
+							&af.FunctionCallContent{CallID: "call-1", Name: "a", Arguments: `{}`},
+							&af.FunctionCallContent{CallID: "call-2", Name: "a", Arguments: `{}`},
+							&af.FunctionCallContent{CallID: "call-3", Name: "exclusive", Arguments: `{}`},
+						},
+					}},
+				}, nil
+			}
+			return &af.ChatResponse{Messages: []af.Message{af.NewAssistantMessage("done")}}, nil
+		},
+	}
+
+	agent := af.NewAgent(client,
+		af.WithTools(a, exclusive),
+		af.WithInvocationConfig(af.InvocationConfig{Concurrency: 3}),
+	)
+	if _, err := agent.Run(context.Background(), []af.Message{af.NewUserMessage("go")}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if violation {
+		t.Error("exclusive tool overlapped with a parallel tool call")
+	}
+}
+
 func TestAgent_WithSession(t *testing.T) {
 	callCount := 0
 	client := &mockClient{
@@ -161,6 +440,27 @@ func TestAgent_NewSession(t *testing.T) {
 	}
 }
 
+func TestAgent_Run_PropagatesSessionCacheKey(t *testing.T) {
+	var receivedCacheKey string
+	client := &mockClient{
+		responseFn: func(ctx context.Context, msgs []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error) {
+			receivedCacheKey = opts.CacheKey
+			return &af.ChatResponse{Messages: []af.Message{af.NewAssistantMessage("ok")}}, nil
+		},
+	}
+
+	agent := af.NewAgent(client)
+	session := agent.NewSession(af.WithSessionCacheKey("user-7"))
+
+	if _, err := agent.Run(context.Background(), []af.Message{af.NewUserMessage("hi")}, af.WithSession(session)); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if receivedCacheKey != "user-7" {
+		t.Errorf("CacheKey = %q, want user-7", receivedCacheKey)
+	}
+}
+
 func TestAgent_RunWithOptions(t *testing.T) {
 	var receivedModel string
 	client := &mockClient{
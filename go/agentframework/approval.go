@@ -0,0 +1,276 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package agentframework
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// RunSnapshot captures the state of an agent run suspended at a tool call
+// requiring human approval. A [RunStore] persists snapshots keyed by Token
+// so the run can be resumed later, possibly from a different process.
+//
+// Tools themselves are not persisted — [Agent.Resume] re-executes approved
+// calls using the tool set configured on the [Agent] it is called on, so
+// that Agent must be configured with the same tools as the one that
+// produced the snapshot.
+type RunSnapshot struct {
+	Token string `json:"token"`
+
+	// AgentID identifies the agent that produced this snapshot.
+	AgentID string `json:"agentId"`
+
+	// Messages is the full conversation history up to and including the
+	// assistant turn that requested approval and any tool results already
+	// produced by calls in the same turn that did not require approval.
+	Messages []Message `json:"messages"`
+
+	// Pending holds the function calls awaiting an approval decision.
+	Pending []FunctionCallContent `json:"pending"`
+
+	// Iteration is the tool-calling loop iteration the run was suspended
+	// at, and ConsecutiveErrors is the consecutive-tool-error count at
+	// that point. [Agent.Resume] continues the loop from these values
+	// instead of granting a fresh iteration/error budget.
+	Iteration         int `json:"iteration,omitempty"`
+	ConsecutiveErrors int `json:"consecutiveErrors,omitempty"`
+
+	// Options carries the request options in effect for this run (with
+	// Tools cleared, since tools are not serializable — see above).
+	Options *ChatOptions `json:"options,omitempty"`
+
+	// ContextValues holds values captured from the run's context via the
+	// [RegisterPersistableContext] registry, restored into the context
+	// passed to [Agent.Resume].
+	ContextValues map[string]any `json:"contextValues,omitempty"`
+}
+
+// RunStore persists [RunSnapshot] values for pending approvals.
+// Implementations must be safe for concurrent use.
+type RunStore interface {
+	// SaveRun persists a snapshot, keyed by snap.Token.
+	SaveRun(ctx context.Context, snap *RunSnapshot) error
+
+	// LoadRun retrieves a previously saved snapshot by token.
+	LoadRun(ctx context.Context, token string) (*RunSnapshot, error)
+
+	// DeleteRun removes a snapshot, e.g. after a successful resume.
+	DeleteRun(ctx context.Context, token string) error
+}
+
+// InMemoryRunStore is a [RunStore] backed by a map. Snapshots do not
+// survive process restarts; use [FileRunStore] or [SQLRunStore] for
+// durability across processes.
+type InMemoryRunStore struct {
+	mu   sync.Mutex
+	runs map[string]*RunSnapshot
+}
+
+// NewInMemoryRunStore creates an empty [InMemoryRunStore].
+func NewInMemoryRunStore() *InMemoryRunStore {
+	return &InMemoryRunStore{runs: make(map[string]*RunSnapshot)}
+}
+
+func (s *InMemoryRunStore) SaveRun(_ context.Context, snap *RunSnapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.runs[snap.Token] = snap
+	return nil
+}
+
+func (s *InMemoryRunStore) LoadRun(_ context.Context, token string) (*RunSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snap, ok := s.runs[token]
+	if !ok {
+		return nil, fmt.Errorf("%w: no pending run for token %q", ErrApproval, token)
+	}
+	return snap, nil
+}
+
+func (s *InMemoryRunStore) DeleteRun(_ context.Context, token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.runs, token)
+	return nil
+}
+
+// FileRunStore is a [RunStore] that persists each snapshot as a JSON file
+// named "<token>.json" under the directory given to [NewFileRunStore].
+type FileRunStore struct {
+	dir string
+}
+
+// NewFileRunStore creates a [FileRunStore] rooted at dir, creating it if
+// it does not already exist.
+func NewFileRunStore(dir string) (*FileRunStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("%w: create run store dir: %w", ErrApproval, err)
+	}
+	return &FileRunStore{dir: dir}, nil
+}
+
+func (s *FileRunStore) path(token string) string {
+	return filepath.Join(s.dir, token+".json")
+}
+
+func (s *FileRunStore) SaveRun(_ context.Context, snap *RunSnapshot) error {
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf("%w: marshal run snapshot: %w", ErrApproval, err)
+	}
+	if err := os.WriteFile(s.path(snap.Token), data, 0o600); err != nil {
+		return fmt.Errorf("%w: write run snapshot: %w", ErrApproval, err)
+	}
+	return nil
+}
+
+func (s *FileRunStore) LoadRun(_ context.Context, token string) (*RunSnapshot, error) {
+	data, err := os.ReadFile(s.path(token))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: no pending run for token %q", ErrApproval, token)
+		}
+		return nil, fmt.Errorf("%w: read run snapshot: %w", ErrApproval, err)
+	}
+	var snap RunSnapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("%w: unmarshal run snapshot: %w", ErrApproval, err)
+	}
+	return &snap, nil
+}
+
+func (s *FileRunStore) DeleteRun(_ context.Context, token string) error {
+	if err := os.Remove(s.path(token)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("%w: delete run snapshot: %w", ErrApproval, err)
+	}
+	return nil
+}
+
+// SQLExecutor is the subset of *sql.DB (or *sql.Tx) that [SQLRunStore]
+// needs. Use [WrapSQLDB] to adapt a *sql.DB.
+type SQLExecutor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// WrapSQLDB adapts a *sql.DB (or any driver-specific pool exposing the same
+// methods) to [SQLExecutor].
+func WrapSQLDB(db *sql.DB) SQLExecutor { return db }
+
+// SQLRunStore is a [RunStore] backed by a SQL table with columns
+// (token TEXT PRIMARY KEY, data TEXT). Use [NewSQLRunStore] with a
+// *sql.DB wrapped by [WrapSQLDB] to construct one; the table must already
+// exist (see [SQLRunStoreSchema]).
+type SQLRunStore struct {
+	db        SQLExecutor
+	tableName string
+}
+
+// SQLRunStoreSchema is a portable DDL statement creating the table expected
+// by [SQLRunStore], using the default table name "agent_runs".
+const SQLRunStoreSchema = `CREATE TABLE IF NOT EXISTS agent_runs (token TEXT PRIMARY KEY, data TEXT NOT NULL)`
+
+// NewSQLRunStore creates a [SQLRunStore] using db to execute queries against
+// tableName (typically "agent_runs", matching [SQLRunStoreSchema]).
+func NewSQLRunStore(db SQLExecutor, tableName string) *SQLRunStore {
+	return &SQLRunStore{db: db, tableName: tableName}
+}
+
+func (s *SQLRunStore) SaveRun(ctx context.Context, snap *RunSnapshot) error {
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf("%w: marshal run snapshot: %w", ErrApproval, err)
+	}
+	query := fmt.Sprintf(`INSERT INTO %s (token, data) VALUES (?, ?)
+		ON CONFLICT (token) DO UPDATE SET data = excluded.data`, s.tableName)
+	if _, err := s.db.ExecContext(ctx, query, snap.Token, string(data)); err != nil {
+		return fmt.Errorf("%w: save run snapshot: %w", ErrApproval, err)
+	}
+	return nil
+}
+
+func (s *SQLRunStore) LoadRun(ctx context.Context, token string) (*RunSnapshot, error) {
+	query := fmt.Sprintf(`SELECT data FROM %s WHERE token = ?`, s.tableName)
+	var data string
+	if err := s.db.QueryRowContext(ctx, query, token).Scan(&data); err != nil {
+		return nil, fmt.Errorf("%w: no pending run for token %q: %w", ErrApproval, token, err)
+	}
+	var snap RunSnapshot
+	if err := json.Unmarshal([]byte(data), &snap); err != nil {
+		return nil, fmt.Errorf("%w: unmarshal run snapshot: %w", ErrApproval, err)
+	}
+	return &snap, nil
+}
+
+func (s *SQLRunStore) DeleteRun(ctx context.Context, token string) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE token = ?`, s.tableName)
+	if _, err := s.db.ExecContext(ctx, query, token); err != nil {
+		return fmt.Errorf("%w: delete run snapshot: %w", ErrApproval, err)
+	}
+	return nil
+}
+
+// persistableContextEntry captures and restores one named context value
+// across a pause/resume boundary.
+type persistableContextEntry struct {
+	extract func(ctx context.Context) (any, bool)
+	apply   func(ctx context.Context, v any) context.Context
+}
+
+var (
+	persistableContextMu       sync.RWMutex
+	persistableContextRegistry = map[string]persistableContextEntry{}
+)
+
+// RegisterPersistableContext registers a named context value to be carried
+// across [Agent] pause/resume boundaries. Middleware or context providers
+// that stash request-scoped state in the context (a tenant ID, a trace
+// span, a feature flag set) should call this once at startup so that state
+// is captured into the [RunSnapshot] when a run pauses for approval and
+// restored into the context passed to [Agent.Resume].
+//
+// extract reads the value from a live context (ok is false if absent).
+// apply installs a previously extracted value (round-tripped through JSON)
+// into a fresh context.
+func RegisterPersistableContext(key string, extract func(ctx context.Context) (any, bool), apply func(ctx context.Context, v any) context.Context) {
+	persistableContextMu.Lock()
+	defer persistableContextMu.Unlock()
+	persistableContextRegistry[key] = persistableContextEntry{extract: extract, apply: apply}
+}
+
+// capturePersistableContext extracts all registered context values present
+// in ctx, for inclusion in a [RunSnapshot].
+func capturePersistableContext(ctx context.Context) map[string]any {
+	persistableContextMu.RLock()
+	defer persistableContextMu.RUnlock()
+	if len(persistableContextRegistry) == 0 {
+		return nil
+	}
+	values := make(map[string]any, len(persistableContextRegistry))
+	for key, entry := range persistableContextRegistry {
+		if v, ok := entry.extract(ctx); ok {
+			values[key] = v
+		}
+	}
+	return values
+}
+
+// restorePersistableContext installs previously captured context values
+// into ctx, for use when resuming a suspended run.
+func restorePersistableContext(ctx context.Context, values map[string]any) context.Context {
+	persistableContextMu.RLock()
+	defer persistableContextMu.RUnlock()
+	for key, v := range values {
+		if entry, ok := persistableContextRegistry[key]; ok {
+			ctx = entry.apply(ctx, v)
+		}
+	}
+	return ctx
+}
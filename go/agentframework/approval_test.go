@@ -0,0 +1,427 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package agentframework_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+func approvalTool(t *testing.T, name string, invoked *int) *af.FunctionTool {
+	t.Helper()
+	return af.NewTypedTool(name, "Requires approval",
+		func(ctx context.Context, args struct{}) (any, error) {
+			*invoked++
+			return "done:" + name, nil
+		},
+		af.WithApprovalRequired(),
+	)
+}
+
+func TestAgent_Run_PendingApproval(t *testing.T) {
+	var invoked int
+	tool := approvalTool(t, "charge_card", &invoked)
+
+	client := &mockClient{
+		responseFn: func(ctx context.Context, msgs []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error) {
+			return &af.ChatResponse{
+				Messages: []af.Message{{
+					Role: af.RoleAssistant,
+					Contents: af.Contents{
+						&af.FunctionCallContent{CallID: "call-1", Name: "charge_card", Arguments: `{}`},
+					},
+				}},
+			}, nil
+		},
+	}
+
+	agent := af.NewAgent(client, af.WithTools(tool))
+	_, err := agent.Run(context.Background(), []af.Message{af.NewUserMessage("charge me $10")})
+
+	var pendingErr *af.PendingApprovalError
+	if !errors.As(err, &pendingErr) {
+		t.Fatalf("err = %v, want *PendingApprovalError", err)
+	}
+	if pendingErr.Token == "" {
+		t.Error("Token should not be empty")
+	}
+	if len(pendingErr.Pending) != 1 || pendingErr.Pending[0].CallID != "call-1" {
+		t.Errorf("Pending = %+v", pendingErr.Pending)
+	}
+	if invoked != 0 {
+		t.Errorf("tool invoked %d times before approval, want 0", invoked)
+	}
+}
+
+func TestAgent_Resume_Approve(t *testing.T) {
+	var invoked int
+	tool := approvalTool(t, "charge_card", &invoked)
+
+	callCount := 0
+	client := &mockClient{
+		responseFn: func(ctx context.Context, msgs []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error) {
+			callCount++
+			if callCount == 1 {
+				return &af.ChatResponse{
+					Messages: []af.Message{{
+						Role: af.RoleAssistant,
+						Contents: af.Contents{
+							&af.FunctionCallContent{CallID: "call-1", Name: "charge_card", Arguments: `{}`},
+						},
+					}},
+				}, nil
+			}
+			return &af.ChatResponse{Messages: []af.Message{af.NewAssistantMessage("Charged.")}}, nil
+		},
+	}
+
+	agent := af.NewAgent(client, af.WithTools(tool))
+	_, err := agent.Run(context.Background(), []af.Message{af.NewUserMessage("charge me $10")})
+	var pendingErr *af.PendingApprovalError
+	if !errors.As(err, &pendingErr) {
+		t.Fatalf("err = %v, want *PendingApprovalError", err)
+	}
+
+	resp, err := agent.Resume(context.Background(), pendingErr.Token, []af.ApprovalResponseContent{
+		{CallID: "call-1", Approved: true},
+	})
+	if err != nil {
+		t.Fatalf("Resume: %v", err)
+	}
+	if invoked != 1 {
+		t.Errorf("tool invoked %d times, want 1", invoked)
+	}
+	if resp.Text() != "Charged." {
+		t.Errorf("Text = %q", resp.Text())
+	}
+}
+
+func TestAgent_Resume_Deny(t *testing.T) {
+	var invoked int
+	tool := approvalTool(t, "charge_card", &invoked)
+
+	callCount := 0
+	var secondTurnMessages []af.Message
+	client := &mockClient{
+		responseFn: func(ctx context.Context, msgs []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error) {
+			callCount++
+			if callCount == 1 {
+				return &af.ChatResponse{
+					Messages: []af.Message{{
+						Role: af.RoleAssistant,
+						Contents: af.Contents{
+							&af.FunctionCallContent{CallID: "call-1", Name: "charge_card", Arguments: `{}`},
+						},
+					}},
+				}, nil
+			}
+			secondTurnMessages = msgs
+			return &af.ChatResponse{Messages: []af.Message{af.NewAssistantMessage("Not charged.")}}, nil
+		},
+	}
+
+	agent := af.NewAgent(client, af.WithTools(tool))
+	_, err := agent.Run(context.Background(), []af.Message{af.NewUserMessage("charge me $10")})
+	var pendingErr *af.PendingApprovalError
+	if !errors.As(err, &pendingErr) {
+		t.Fatalf("err = %v, want *PendingApprovalError", err)
+	}
+
+	resp, err := agent.Resume(context.Background(), pendingErr.Token, []af.ApprovalResponseContent{
+		{CallID: "call-1", Approved: false, Reason: "exceeds limit"},
+	})
+	if err != nil {
+		t.Fatalf("Resume: %v", err)
+	}
+	if invoked != 0 {
+		t.Errorf("tool invoked %d times, want 0 (denied)", invoked)
+	}
+	if resp.Text() != "Not charged." {
+		t.Errorf("Text = %q", resp.Text())
+	}
+
+	found := false
+	for _, m := range secondTurnMessages {
+		if m.Role == af.RoleTool {
+			if fr, ok := m.Contents[0].(*af.FunctionResultContent); ok {
+				if result, ok := fr.Result.(string); ok && result == "denied: exceeds limit" {
+					found = true
+				}
+			}
+		}
+	}
+	if !found {
+		t.Error("expected a tool message recording the denial reason")
+	}
+}
+
+func TestAgent_Resume_PartialApprove(t *testing.T) {
+	var invokedA, invokedB int
+	toolA := approvalTool(t, "tool_a", &invokedA)
+	toolB := approvalTool(t, "tool_b", &invokedB)
+
+	callCount := 0
+	client := &mockClient{
+		responseFn: func(ctx context.Context, msgs []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error) {
+			callCount++
+			if callCount == 1 {
+				return &af.ChatResponse{
+					Messages: []af.Message{{
+						Role: af.RoleAssistant,
+						Contents: af.Contents{
+							&af.FunctionCallContent{CallID: "call-a", Name: "tool_a", Arguments: `{}`},
+							&af.FunctionCallContent{CallID: "call-b", Name: "tool_b", Arguments: `{}`},
+						},
+					}},
+				}, nil
+			}
+			return &af.ChatResponse{Messages: []af.Message{af.NewAssistantMessage("done")}}, nil
+		},
+	}
+
+	agent := af.NewAgent(client, af.WithTools(toolA, toolB))
+	_, err := agent.Run(context.Background(), []af.Message{af.NewUserMessage("go")})
+	var pendingErr *af.PendingApprovalError
+	if !errors.As(err, &pendingErr) {
+		t.Fatalf("err = %v, want *PendingApprovalError", err)
+	}
+	if len(pendingErr.Pending) != 2 {
+		t.Fatalf("Pending = %d calls, want 2", len(pendingErr.Pending))
+	}
+
+	_, err = agent.Resume(context.Background(), pendingErr.Token, []af.ApprovalResponseContent{
+		{CallID: "call-a", Approved: true},
+		{CallID: "call-b", Approved: false},
+	})
+	if err != nil {
+		t.Fatalf("Resume: %v", err)
+	}
+	if invokedA != 1 {
+		t.Errorf("tool_a invoked %d times, want 1", invokedA)
+	}
+	if invokedB != 0 {
+		t.Errorf("tool_b invoked %d times, want 0", invokedB)
+	}
+}
+
+func TestAgent_Resume_AlwaysApproveForSession(t *testing.T) {
+	var invoked int
+	tool := approvalTool(t, "charge_card", &invoked)
+
+	callCount := 0
+	client := &mockClient{
+		responseFn: func(ctx context.Context, msgs []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error) {
+			callCount++
+			if callCount%2 == 1 {
+				return &af.ChatResponse{
+					Messages: []af.Message{{
+						Role: af.RoleAssistant,
+						Contents: af.Contents{
+							&af.FunctionCallContent{CallID: fmt.Sprintf("call-%d", callCount), Name: "charge_card", Arguments: `{}`},
+						},
+					}},
+				}, nil
+			}
+			return &af.ChatResponse{Messages: []af.Message{af.NewAssistantMessage("Charged.")}}, nil
+		},
+	}
+
+	agent := af.NewAgent(client, af.WithTools(tool))
+	session := agent.NewSession()
+
+	_, err := agent.Run(context.Background(), []af.Message{af.NewUserMessage("charge me $10")}, af.WithSession(session))
+	var pendingErr *af.PendingApprovalError
+	if !errors.As(err, &pendingErr) {
+		t.Fatalf("err = %v, want *PendingApprovalError", err)
+	}
+
+	if _, err := agent.Resume(context.Background(), pendingErr.Token, []af.ApprovalResponseContent{
+		{CallID: "call-1", Approved: true, AlwaysApproveForSession: true},
+	}, af.WithSession(session)); err != nil {
+		t.Fatalf("Resume: %v", err)
+	}
+	if invoked != 1 {
+		t.Fatalf("tool invoked %d times, want 1", invoked)
+	}
+	if !session.IsToolAlwaysApproved("charge_card") {
+		t.Fatal("charge_card should be pre-approved on the session")
+	}
+
+	// A later run against the same session should invoke the tool
+	// directly rather than pausing for approval again.
+	_, err = agent.Run(context.Background(), []af.Message{af.NewUserMessage("charge me again")}, af.WithSession(session))
+	if err != nil {
+		t.Fatalf("second Run: %v", err)
+	}
+	if invoked != 2 {
+		t.Errorf("tool invoked %d times, want 2 (session pre-approval should skip the pause)", invoked)
+	}
+}
+
+func TestAgent_Resume_ContinuesIterationBudget(t *testing.T) {
+	var normalInvoked, approvalInvoked int
+	normalTool := af.NewTypedTool("lookup", "Looks something up",
+		func(ctx context.Context, args struct{}) (any, error) {
+			normalInvoked++
+			return "ok", nil
+		},
+	)
+	approval := approvalTool(t, "charge_card", &approvalInvoked)
+
+	callCount := 0
+	client := &mockClient{
+		responseFn: func(ctx context.Context, msgs []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error) {
+			callCount++
+			if callCount == 2 {
+				return &af.ChatResponse{
+					Messages: []af.Message{{
+						Role: af.RoleAssistant,
+						Contents: af.Contents{
+							&af.FunctionCallContent{CallID: "call-2", Name: "charge_card", Arguments: `{}`},
+						},
+					}},
+				}, nil
+			}
+			return &af.ChatResponse{
+				Messages: []af.Message{{
+					Role: af.RoleAssistant,
+					Contents: af.Contents{
+						&af.FunctionCallContent{CallID: fmt.Sprintf("call-%d", callCount), Name: "lookup", Arguments: `{}`},
+					},
+				}},
+			}, nil
+		},
+	}
+
+	// MaxIterations of 3: one normal-tool round is spent before the
+	// approval pause, leaving only 1 of the original 3 for Resume to
+	// continue with, not a fresh 3.
+	agent := af.NewAgent(client, af.WithTools(normalTool, approval), af.WithInvocationConfig(af.InvocationConfig{MaxIterations: 3}))
+	_, err := agent.Run(context.Background(), []af.Message{af.NewUserMessage("go")})
+	var pendingErr *af.PendingApprovalError
+	if !errors.As(err, &pendingErr) {
+		t.Fatalf("err = %v, want *PendingApprovalError", err)
+	}
+	if callCount != 2 {
+		t.Fatalf("callCount = %d before resume, want 2", callCount)
+	}
+
+	_, err = agent.Resume(context.Background(), pendingErr.Token, []af.ApprovalResponseContent{
+		{CallID: "call-2", Approved: true},
+	})
+	if !errors.Is(err, af.ErrExecution) {
+		t.Fatalf("Resume err = %v, want wrapping ErrExecution (budget exhausted)", err)
+	}
+	if callCount != 3 {
+		t.Errorf("callCount = %d after resume, want 3 (only 1 iteration left of the original budget of 3)", callCount)
+	}
+}
+
+func TestAgent_RunStream_PendingApproval(t *testing.T) {
+	var invoked int
+	tool := approvalTool(t, "charge_card", &invoked)
+
+	client := &mockClient{
+		responseFn: func(ctx context.Context, msgs []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error) {
+			return &af.ChatResponse{
+				Messages: []af.Message{{
+					Role: af.RoleAssistant,
+					Contents: af.Contents{
+						&af.FunctionCallContent{CallID: "call-1", Name: "charge_card", Arguments: `{}`},
+					},
+				}},
+			}, nil
+		},
+	}
+
+	agent := af.NewAgent(client, af.WithTools(tool))
+	stream, err := agent.RunStream(context.Background(), []af.Message{af.NewUserMessage("charge me $10")})
+	if err != nil {
+		t.Fatalf("RunStream: %v", err)
+	}
+	defer stream.Close()
+
+	var token string
+	for {
+		u, ok, err := stream.Next(context.Background())
+		if err != nil {
+			t.Fatalf("Next: %v", err)
+		}
+		if !ok {
+			break
+		}
+		if u.FinishReason == af.FinishReasonApprovalRequired {
+			token = u.Token
+		}
+	}
+	if token == "" {
+		t.Fatal("expected a terminal update carrying an approval token")
+	}
+}
+
+func TestInMemoryRunStore_SaveLoadDelete(t *testing.T) {
+	store := af.NewInMemoryRunStore()
+	ctx := context.Background()
+
+	if _, err := store.LoadRun(ctx, "missing"); !errors.Is(err, af.ErrApproval) {
+		t.Errorf("LoadRun(missing) err = %v, want wrapping ErrApproval", err)
+	}
+
+	snap := &af.RunSnapshot{Token: "tok-1", AgentID: "agent-1"}
+	if err := store.SaveRun(ctx, snap); err != nil {
+		t.Fatalf("SaveRun: %v", err)
+	}
+
+	loaded, err := store.LoadRun(ctx, "tok-1")
+	if err != nil {
+		t.Fatalf("LoadRun: %v", err)
+	}
+	if loaded.AgentID != "agent-1" {
+		t.Errorf("AgentID = %q", loaded.AgentID)
+	}
+
+	if err := store.DeleteRun(ctx, "tok-1"); err != nil {
+		t.Fatalf("DeleteRun: %v", err)
+	}
+	if _, err := store.LoadRun(ctx, "tok-1"); !errors.Is(err, af.ErrApproval) {
+		t.Errorf("LoadRun after delete err = %v, want wrapping ErrApproval", err)
+	}
+}
+
+func TestFileRunStore_SaveLoadDelete(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "runs")
+	store, err := af.NewFileRunStore(dir)
+	if err != nil {
+		t.Fatalf("NewFileRunStore: %v", err)
+	}
+	ctx := context.Background()
+
+	snap := &af.RunSnapshot{
+		Token:   "tok-1",
+		AgentID: "agent-1",
+		Pending: []af.FunctionCallContent{{CallID: "call-1", Name: "charge_card", Arguments: `{}`}},
+	}
+	if err := store.SaveRun(ctx, snap); err != nil {
+		t.Fatalf("SaveRun: %v", err)
+	}
+
+	loaded, err := store.LoadRun(ctx, "tok-1")
+	if err != nil {
+		t.Fatalf("LoadRun: %v", err)
+	}
+	if len(loaded.Pending) != 1 || loaded.Pending[0].Name != "charge_card" {
+		t.Errorf("Pending = %+v", loaded.Pending)
+	}
+
+	if err := store.DeleteRun(ctx, "tok-1"); err != nil {
+		t.Fatalf("DeleteRun: %v", err)
+	}
+	if _, err := store.LoadRun(ctx, "tok-1"); !errors.Is(err, af.ErrApproval) {
+		t.Errorf("LoadRun after delete err = %v, want wrapping ErrApproval", err)
+	}
+}
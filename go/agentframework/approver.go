@@ -0,0 +1,95 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package agentframework
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ToolCall is the function call a [ToolApprover] is asked to approve. It's
+// an alias for [FunctionCallContent], the type the model returns it as.
+type ToolCall = FunctionCallContent
+
+// ApprovalOutcome is the decision a [ToolApprover] returns for a [ToolCall].
+type ApprovalOutcome int
+
+const (
+	// ApproveCall lets the call through to the tool unchanged.
+	ApproveCall ApprovalOutcome = iota
+	// DenyCall skips invocation and feeds a synthetic error result back to
+	// the model instead.
+	DenyCall
+	// EditArgsCall replaces the call's arguments with
+	// [ApprovalDecision.Arguments] before invocation.
+	EditArgsCall
+)
+
+// ApprovalDecision is returned by [ToolApprover.Approve].
+type ApprovalDecision struct {
+	Outcome ApprovalOutcome
+
+	// Arguments holds the replacement JSON arguments when Outcome is
+	// EditArgsCall; re-validated against the tool's schema before invocation.
+	Arguments string
+
+	// Reason is an optional human-readable explanation included in the
+	// synthesized tool result when Outcome is DenyCall.
+	Reason string
+}
+
+// ToolApprover decides, synchronously and in-process, whether a tool call
+// requiring approval (see [ApprovalAlways]) may proceed. Unlike the
+// suspend/resume flow built on [PendingApprovalError] and [RunSnapshot] —
+// meant for approvals that arrive from another process, possibly much
+// later — a ToolApprover is for callers that can block the current
+// goroutine for a decision, e.g. a CLI prompting its own user. Configure one
+// with [WithToolApprover]; when set, it takes precedence over the
+// suspend/resume flow for every tool requiring approval.
+type ToolApprover interface {
+	Approve(ctx context.Context, call ToolCall) (ApprovalDecision, error)
+}
+
+// InteractiveApprover is a [ToolApprover] that prompts a human over r/w —
+// typically os.Stdin/os.Stdout for a CLI. It prints the call's name and
+// arguments and reads a single line: "y" to approve, "n" to deny, or a JSON
+// object to approve with edited arguments.
+type InteractiveApprover struct {
+	r *bufio.Reader
+	w io.Writer
+}
+
+// NewInteractiveApprover creates an [InteractiveApprover] reading decisions
+// from r and writing prompts to w.
+func NewInteractiveApprover(r io.Reader, w io.Writer) *InteractiveApprover {
+	return &InteractiveApprover{r: bufio.NewReader(r), w: w}
+}
+
+var _ ToolApprover = (*InteractiveApprover)(nil)
+
+// Approve implements [ToolApprover].
+func (a *InteractiveApprover) Approve(_ context.Context, call ToolCall) (ApprovalDecision, error) {
+	fmt.Fprintf(a.w, "Approve call to %q with arguments %s? [y/N/edit JSON]: ", call.Name, call.Arguments)
+	line, err := a.r.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return ApprovalDecision{}, fmt.Errorf("%w: read approval decision: %w", ErrApproval, err)
+	}
+	line = strings.TrimSpace(line)
+
+	switch {
+	case strings.EqualFold(line, "y") || strings.EqualFold(line, "yes"):
+		return ApprovalDecision{Outcome: ApproveCall}, nil
+	case line == "" || strings.EqualFold(line, "n") || strings.EqualFold(line, "no"):
+		return ApprovalDecision{Outcome: DenyCall, Reason: "denied by user"}, nil
+	default:
+		var js json.RawMessage
+		if err := json.Unmarshal([]byte(line), &js); err != nil {
+			return ApprovalDecision{}, fmt.Errorf("%w: expected y/n or a JSON arguments object, got %q", ErrApproval, line)
+		}
+		return ApprovalDecision{Outcome: EditArgsCall, Arguments: line}, nil
+	}
+}
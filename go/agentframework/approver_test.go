@@ -0,0 +1,183 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package agentframework_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+// fixedApprover is a [af.ToolApprover] that always returns the same decision.
+type fixedApprover struct {
+	decision af.ApprovalDecision
+}
+
+func (a *fixedApprover) Approve(context.Context, af.ToolCall) (af.ApprovalDecision, error) {
+	return a.decision, nil
+}
+
+func TestAgent_ToolApprover_ApproveLetsCallThrough(t *testing.T) {
+	var invoked int
+	tool := approvalTool(t, "charge_card", &invoked)
+
+	callCount := 0
+	client := &mockClient{
+		responseFn: func(ctx context.Context, msgs []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error) {
+			callCount++
+			if callCount == 1 {
+				return &af.ChatResponse{
+					Messages: []af.Message{{
+						Role: af.RoleAssistant,
+						Contents: af.Contents{
+							&af.FunctionCallContent{CallID: "call-1", Name: "charge_card", Arguments: `{}`},
+						},
+					}},
+				}, nil
+			}
+			return &af.ChatResponse{Messages: []af.Message{af.NewAssistantMessage("charged.")}}, nil
+		},
+	}
+
+	agent := af.NewAgent(client, af.WithTools(tool), af.WithToolApprover(&fixedApprover{decision: af.ApprovalDecision{Outcome: af.ApproveCall}}))
+	resp, err := agent.Run(context.Background(), []af.Message{af.NewUserMessage("charge me $10")})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if invoked != 1 {
+		t.Errorf("tool invoked %d times, want 1", invoked)
+	}
+	if resp.Text() != "charged." {
+		t.Errorf("Text = %q", resp.Text())
+	}
+}
+
+func TestAgent_ToolApprover_DenyCallSynthesizesResult(t *testing.T) {
+	var invoked int
+	tool := approvalTool(t, "charge_card", &invoked)
+
+	var sentResult string
+	callCount := 0
+	client := &mockClient{
+		responseFn: func(ctx context.Context, msgs []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error) {
+			callCount++
+			if callCount == 1 {
+				return &af.ChatResponse{
+					Messages: []af.Message{{
+						Role: af.RoleAssistant,
+						Contents: af.Contents{
+							&af.FunctionCallContent{CallID: "call-1", Name: "charge_card", Arguments: `{}`},
+						},
+					}},
+				}, nil
+			}
+			for _, m := range msgs {
+				for _, c := range m.Contents {
+					if fr, ok := c.(*af.FunctionResultContent); ok {
+						sentResult, _ = fr.Result.(string)
+					}
+				}
+			}
+			return &af.ChatResponse{Messages: []af.Message{af.NewAssistantMessage("not charged.")}}, nil
+		},
+	}
+
+	approver := &fixedApprover{decision: af.ApprovalDecision{Outcome: af.DenyCall, Reason: "over budget"}}
+	agent := af.NewAgent(client, af.WithTools(tool), af.WithToolApprover(approver))
+	resp, err := agent.Run(context.Background(), []af.Message{af.NewUserMessage("charge me $10")})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if invoked != 0 {
+		t.Errorf("tool invoked %d times, want 0", invoked)
+	}
+	if !strings.Contains(sentResult, "over budget") {
+		t.Errorf("tool result = %q, want it to mention the deny reason", sentResult)
+	}
+	if resp.Text() != "not charged." {
+		t.Errorf("Text = %q", resp.Text())
+	}
+}
+
+func TestAgent_ToolApprover_EditArgsCallRevalidates(t *testing.T) {
+	type chargeArgs struct {
+		Amount int `json:"amount" jsonschema:"required"`
+	}
+	var gotAmount int
+	tool := af.NewTypedTool("charge_card", "Charges a card",
+		func(ctx context.Context, args chargeArgs) (any, error) {
+			gotAmount = args.Amount
+			return "charged", nil
+		},
+		af.WithApprovalRequired(),
+	)
+
+	callCount := 0
+	client := &mockClient{
+		responseFn: func(ctx context.Context, msgs []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error) {
+			callCount++
+			if callCount == 1 {
+				return &af.ChatResponse{
+					Messages: []af.Message{{
+						Role: af.RoleAssistant,
+						Contents: af.Contents{
+							&af.FunctionCallContent{CallID: "call-1", Name: "charge_card", Arguments: `{"amount":100}`},
+						},
+					}},
+				}, nil
+			}
+			return &af.ChatResponse{Messages: []af.Message{af.NewAssistantMessage("charged 10.")}}, nil
+		},
+	}
+
+	approver := &fixedApprover{decision: af.ApprovalDecision{Outcome: af.EditArgsCall, Arguments: `{"amount":10}`}}
+	agent := af.NewAgent(client, af.WithTools(tool), af.WithToolApprover(approver))
+	if _, err := agent.Run(context.Background(), []af.Message{af.NewUserMessage("charge me $100")}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if gotAmount != 10 {
+		t.Errorf("invoked with amount = %d, want 10 (the approver's edited arguments)", gotAmount)
+	}
+}
+
+func TestInteractiveApprover_ParsesResponses(t *testing.T) {
+	call := af.ToolCall{CallID: "call-1", Name: "charge_card", Arguments: `{}`}
+
+	t.Run("yes", func(t *testing.T) {
+		var out bytes.Buffer
+		approver := af.NewInteractiveApprover(strings.NewReader("y\n"), &out)
+		decision, err := approver.Approve(context.Background(), call)
+		if err != nil || decision.Outcome != af.ApproveCall {
+			t.Errorf("decision = %+v, err = %v, want ApproveCall", decision, err)
+		}
+	})
+
+	t.Run("empty defaults to deny", func(t *testing.T) {
+		var out bytes.Buffer
+		approver := af.NewInteractiveApprover(strings.NewReader("\n"), &out)
+		decision, err := approver.Approve(context.Background(), call)
+		if err != nil || decision.Outcome != af.DenyCall {
+			t.Errorf("decision = %+v, err = %v, want DenyCall", decision, err)
+		}
+	})
+
+	t.Run("JSON edits arguments", func(t *testing.T) {
+		var out bytes.Buffer
+		approver := af.NewInteractiveApprover(strings.NewReader(`{"amount":10}`+"\n"), &out)
+		decision, err := approver.Approve(context.Background(), call)
+		if err != nil || decision.Outcome != af.EditArgsCall || decision.Arguments != `{"amount":10}` {
+			t.Errorf("decision = %+v, err = %v, want EditArgsCall with edited arguments", decision, err)
+		}
+	})
+
+	t.Run("garbage input errors", func(t *testing.T) {
+		var out bytes.Buffer
+		approver := af.NewInteractiveApprover(strings.NewReader("maybe later\n"), &out)
+		if _, err := approver.Approve(context.Background(), call); err == nil {
+			t.Error("expected an error for unrecognized input")
+		}
+	})
+}
@@ -0,0 +1,259 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package auth_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+	"github.com/microsoft/agent-framework/go/agentframework/auth"
+)
+
+const testKid = "test-key-1"
+
+func generateTestToken(t *testing.T, key *rsa.PrivateKey, claims map[string]any) string {
+	t.Helper()
+
+	header := map[string]any{"alg": "RS256", "typ": "JWT", "kid": testKid}
+	headerJSON, err := json.Marshal(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signedInput := base64.RawURLEncoding.EncodeToString(headerJSON) + "." + base64.RawURLEncoding.EncodeToString(claimsJSON)
+	hashed := sha256.Sum256([]byte(signedInput))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hashed[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signedInput + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func startJWKSServer(t *testing.T, key *rsa.PrivateKey) *httptest.Server {
+	t.Helper()
+	n := base64.RawURLEncoding.EncodeToString(key.PublicKey.N.Bytes())
+	e := base64.RawURLEncoding.EncodeToString(bigEndianFromInt(key.PublicKey.E))
+
+	doc := map[string]any{
+		"keys": []map[string]any{
+			{"kty": "RSA", "kid": testKid, "n": n, "e": e, "alg": "RS256", "use": "sig"},
+		},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(doc)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func bigEndianFromInt(n int) []byte {
+	b := []byte{byte(n >> 16), byte(n >> 8), byte(n)}
+	i := 0
+	for i < len(b)-1 && b[i] == 0 {
+		i++
+	}
+	return b[i:]
+}
+
+func TestJWTVerifier_ValidToken(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := startJWKSServer(t, key)
+
+	token := generateTestToken(t, key, map[string]any{
+		"sub": "user-123",
+		"iss": "https://issuer.example",
+		"aud": "my-agent",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+
+	verifier := auth.NewJWTVerifier(server.URL, auth.WithIssuer("https://issuer.example"), auth.WithAudience("my-agent"))
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	principal, err := verifier.Verify(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if principal.Subject != "user-123" {
+		t.Errorf("Subject = %q, want user-123", principal.Subject)
+	}
+	if principal.Claims["iss"] != "https://issuer.example" {
+		t.Errorf("Claims[iss] = %v", principal.Claims["iss"])
+	}
+}
+
+func TestJWTVerifier_ExpiredToken(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := startJWKSServer(t, key)
+
+	token := generateTestToken(t, key, map[string]any{
+		"sub": "user-123",
+		"exp": time.Now().Add(-time.Hour).Unix(),
+	})
+
+	verifier := auth.NewJWTVerifier(server.URL)
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	_, err = verifier.Verify(context.Background(), req)
+	if !errors.Is(err, auth.ErrUnauthenticated) {
+		t.Fatalf("err = %v, want ErrUnauthenticated", err)
+	}
+}
+
+func TestJWTVerifier_WrongAudience(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := startJWKSServer(t, key)
+
+	token := generateTestToken(t, key, map[string]any{
+		"sub": "user-123",
+		"aud": "someone-else",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+
+	verifier := auth.NewJWTVerifier(server.URL, auth.WithAudience("my-agent"))
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	_, err = verifier.Verify(context.Background(), req)
+	if !errors.Is(err, auth.ErrUnauthenticated) {
+		t.Fatalf("err = %v, want ErrUnauthenticated", err)
+	}
+}
+
+func TestJWTVerifier_TamperedSignature(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := startJWKSServer(t, key)
+
+	// Signed with a key the JWKS endpoint never published.
+	token := generateTestToken(t, other, map[string]any{
+		"sub": "user-123",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+
+	verifier := auth.NewJWTVerifier(server.URL)
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	_, err = verifier.Verify(context.Background(), req)
+	if !errors.Is(err, auth.ErrUnauthenticated) {
+		t.Fatalf("err = %v, want ErrUnauthenticated", err)
+	}
+}
+
+func TestJWTVerifier_MissingBearerToken(t *testing.T) {
+	verifier := auth.NewJWTVerifier("http://unused.example/jwks.json")
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	_, err := verifier.Verify(context.Background(), req)
+	if !errors.Is(err, auth.ErrUnauthenticated) {
+		t.Fatalf("err = %v, want ErrUnauthenticated", err)
+	}
+}
+
+func TestChainVerifiers_FallsThroughToSecond(t *testing.T) {
+	alwaysFail := auth.RequestVerifierFunc(func(context.Context, *http.Request) (auth.Principal, error) {
+		return auth.Principal{}, errors.New("nope")
+	})
+	succeeds := auth.RequestVerifierFunc(func(context.Context, *http.Request) (auth.Principal, error) {
+		return auth.Principal{Subject: "fallback-user"}, nil
+	})
+
+	chain := auth.ChainVerifiers(alwaysFail, succeeds)
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	principal, err := chain.Verify(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if principal.Subject != "fallback-user" {
+		t.Errorf("Subject = %q, want fallback-user", principal.Subject)
+	}
+}
+
+func TestChainVerifiers_AllFail(t *testing.T) {
+	alwaysFail := auth.RequestVerifierFunc(func(context.Context, *http.Request) (auth.Principal, error) {
+		return auth.Principal{}, errors.New("nope")
+	})
+
+	chain := auth.ChainVerifiers(alwaysFail, alwaysFail)
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	_, err := chain.Verify(context.Background(), req)
+	if !errors.Is(err, auth.ErrUnauthenticated) {
+		t.Fatalf("err = %v, want ErrUnauthenticated", err)
+	}
+}
+
+func TestPrincipalFromContext(t *testing.T) {
+	if _, ok := auth.PrincipalFromContext(context.Background()); ok {
+		t.Error("expected no principal in bare context")
+	}
+
+	ctx := auth.WithPrincipal(context.Background(), auth.Principal{Subject: "user-1"})
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok || principal.Subject != "user-1" {
+		t.Errorf("principal = %+v, ok = %v", principal, ok)
+	}
+}
+
+func TestToolAuthorizer_DefaultAllowsUnlistedTools(t *testing.T) {
+	authorizer := auth.NewToolAuthorizer()
+	call := &af.FunctionCallContent{Name: "search"}
+
+	if err := authorizer.Authorize(context.Background(), auth.Principal{Subject: "user-1"}, call); err != nil {
+		t.Errorf("Authorize: %v", err)
+	}
+}
+
+func TestToolAuthorizer_PerToolPolicyDenies(t *testing.T) {
+	authorizer := auth.NewToolAuthorizer(
+		auth.WithToolPolicy("delete_account", func(_ context.Context, p auth.Principal, _ *af.FunctionCallContent) error {
+			if p.Subject != "admin" {
+				return errors.New("requires admin")
+			}
+			return nil
+		}),
+	)
+
+	call := &af.FunctionCallContent{Name: "delete_account"}
+	err := authorizer.Authorize(context.Background(), auth.Principal{Subject: "user-1"}, call)
+	if !errors.Is(err, auth.ErrForbidden) {
+		t.Fatalf("err = %v, want ErrForbidden", err)
+	}
+
+	if err := authorizer.Authorize(context.Background(), auth.Principal{Subject: "admin"}, call); err != nil {
+		t.Errorf("Authorize(admin): %v", err)
+	}
+}
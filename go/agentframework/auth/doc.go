@@ -0,0 +1,11 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+// Package auth verifies inbound HTTP requests before an agent run is
+// initiated — the ingress counterpart to [af.Tool.Approval]'s in-run human
+// approval. [RequestVerifier] is the extension point any HTTP transport
+// (the SSE handler included) calls before starting a run; [JWTVerifier] is
+// the included implementation, validating a bearer token against a JWKS
+// document fetched from an OIDC-style issuer. [ChainVerifiers] composes
+// several verifiers, and [ToolAuthorizer] layers per-tool policies on top
+// of the resulting [Principal] once a run has started.
+package auth
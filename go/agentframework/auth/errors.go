@@ -0,0 +1,19 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package auth
+
+import "errors"
+
+// ErrUnauthenticated is returned by a [RequestVerifier] when the request
+// carries no usable credential, or the credential fails validation
+// (bad signature, expired, wrong audience/issuer).
+var ErrUnauthenticated = errors.New("auth: unauthenticated")
+
+// ErrForbidden is returned by [ToolAuthorizer.Authorize] when the
+// [Principal] is valid but not allowed to invoke the requested tool.
+var ErrForbidden = errors.New("auth: forbidden")
+
+// ErrNoPrincipal reports that a context carries no [Principal] —
+// [RequestVerifier.Verify] was never called, or failed. [PrincipalFromContext]
+// itself reports this case with ok == false rather than with this error.
+var ErrNoPrincipal = errors.New("auth: no principal in context")
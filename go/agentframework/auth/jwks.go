@@ -0,0 +1,128 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package auth
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// jwk is one entry of a JWKS document (RFC 7517), restricted to the RSA
+// fields [jwksCache] needs to build a [rsa.PublicKey].
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+type jwksDocument struct {
+	Keys []jwk `json:"keys"`
+}
+
+// jwksCache fetches a JWKS document from url, caching parsed RSA public
+// keys by kid and refreshing the document no more often than
+// refreshInterval, or immediately on a cache miss (the issuer may have
+// rotated to a key this cache hasn't seen yet).
+type jwksCache struct {
+	url             string
+	refreshInterval time.Duration
+	httpClient      *http.Client
+
+	mu          sync.Mutex
+	keys        map[string]*rsa.PublicKey
+	lastFetched time.Time
+}
+
+func newJWKSCache(url string, refreshInterval time.Duration, httpClient *http.Client) *jwksCache {
+	return &jwksCache{
+		url:             url,
+		refreshInterval: refreshInterval,
+		httpClient:      httpClient,
+		keys:            make(map[string]*rsa.PublicKey),
+	}
+}
+
+// key returns the RSA public key for kid, fetching (or re-fetching) the
+// JWKS document if it is stale or kid isn't cached yet.
+func (c *jwksCache) key(kid string) (*rsa.PublicKey, error) {
+	c.mu.Lock()
+	key, ok := c.keys[kid]
+	stale := time.Since(c.lastFetched) > c.refreshInterval
+	c.mu.Unlock()
+	if ok && !stale {
+		return key, nil
+	}
+
+	if err := c.refresh(); err != nil {
+		if ok {
+			// Serve the stale key rather than fail a request outright
+			// because of a transient JWKS endpoint outage.
+			return key, nil
+		}
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key, ok = c.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("auth: no JWKS key for kid %q", kid)
+	}
+	return key, nil
+}
+
+func (c *jwksCache) refresh() error {
+	resp, err := c.httpClient.Get(c.url)
+	if err != nil {
+		return fmt.Errorf("auth: fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("auth: fetch JWKS: unexpected status %d", resp.StatusCode)
+	}
+
+	var doc jwksDocument
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return fmt.Errorf("auth: decode JWKS: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(doc.Keys))
+	for _, k := range doc.Keys {
+		if k.Kty != "RSA" || k.Kid == "" {
+			continue
+		}
+		pub, err := rsaPublicKeyFromJWK(k)
+		if err != nil {
+			continue
+		}
+		keys[k.Kid] = pub
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.keys = keys
+	c.lastFetched = time.Now()
+	return nil
+}
+
+func rsaPublicKeyFromJWK(k jwk) (*rsa.PublicKey, error) {
+	nBytes, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, fmt.Errorf("decode n: %w", err)
+	}
+	eBytes, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, fmt.Errorf("decode e: %w", err)
+	}
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(nBytes),
+		E: int(new(big.Int).SetBytes(eBytes).Int64()),
+	}, nil
+}
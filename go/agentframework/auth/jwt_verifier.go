@@ -0,0 +1,194 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// JWTVerifier is a [RequestVerifier] that validates an RS256-signed bearer
+// token from the Authorization header against a JWKS document fetched from
+// jwksURL, with periodic key refresh handled by [jwksCache].
+type JWTVerifier struct {
+	jwks     *jwksCache
+	issuer   string
+	audience string
+}
+
+// JWTVerifierOption configures a [JWTVerifier].
+type JWTVerifierOption func(*JWTVerifier)
+
+// WithIssuer requires the token's "iss" claim to equal issuer. If unset,
+// the issuer claim isn't checked.
+func WithIssuer(issuer string) JWTVerifierOption {
+	return func(v *JWTVerifier) { v.issuer = issuer }
+}
+
+// WithAudience requires the token's "aud" claim to contain audience. If
+// unset, the audience claim isn't checked.
+func WithAudience(audience string) JWTVerifierOption {
+	return func(v *JWTVerifier) { v.audience = audience }
+}
+
+// WithJWKSRefreshInterval overrides how often the JWKS document is
+// re-fetched. Defaults to 1 hour; a cache miss on an unknown kid always
+// triggers an immediate refresh regardless of this interval.
+func WithJWKSRefreshInterval(d time.Duration) JWTVerifierOption {
+	return func(v *JWTVerifier) { v.jwks.refreshInterval = d }
+}
+
+// WithHTTPClient overrides the client used to fetch the JWKS document.
+// Defaults to [http.DefaultClient].
+func WithHTTPClient(client *http.Client) JWTVerifierOption {
+	return func(v *JWTVerifier) { v.jwks.httpClient = client }
+}
+
+// NewJWTVerifier creates a [JWTVerifier] fetching its signing keys from
+// jwksURL (e.g. an issuer's "/.well-known/jwks.json").
+func NewJWTVerifier(jwksURL string, opts ...JWTVerifierOption) *JWTVerifier {
+	v := &JWTVerifier{
+		jwks: newJWKSCache(jwksURL, time.Hour, http.DefaultClient),
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
+}
+
+// Verify extracts the bearer token from r's Authorization header, validates
+// its signature against the JWKS document and its exp/nbf/aud/iss claims,
+// and returns the resulting [Principal].
+func (v *JWTVerifier) Verify(ctx context.Context, r *http.Request) (Principal, error) {
+	token, err := bearerToken(r)
+	if err != nil {
+		return Principal{}, err
+	}
+
+	claims, err := v.parseAndVerify(token)
+	if err != nil {
+		return Principal{}, err
+	}
+
+	if err := validateClaims(claims, v.issuer, v.audience); err != nil {
+		return Principal{}, err
+	}
+
+	subject, _ := claims["sub"].(string)
+	return Principal{Subject: subject, Claims: claims}, nil
+}
+
+func bearerToken(r *http.Request) (string, error) {
+	header := r.Header.Get("Authorization")
+	const prefix = "Bearer "
+	if !strings.HasPrefix(header, prefix) {
+		return "", fmt.Errorf("%w: missing bearer token", ErrUnauthenticated)
+	}
+	return strings.TrimPrefix(header, prefix), nil
+}
+
+// parseAndVerify splits token into its three dot-separated segments,
+// verifies the RS256 signature using the key named by the header's "kid",
+// and returns the decoded claim set.
+func (v *JWTVerifier) parseAndVerify(token string) (map[string]any, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed token", ErrUnauthenticated)
+	}
+	headerB64, payloadB64, sigB64 := parts[0], parts[1], parts[2]
+
+	headerJSON, err := base64.RawURLEncoding.DecodeString(headerB64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed header: %w", ErrUnauthenticated, err)
+	}
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := json.Unmarshal(headerJSON, &header); err != nil {
+		return nil, fmt.Errorf("%w: malformed header: %w", ErrUnauthenticated, err)
+	}
+	if header.Alg != "RS256" {
+		return nil, fmt.Errorf("%w: unsupported alg %q", ErrUnauthenticated, header.Alg)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(sigB64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed signature: %w", ErrUnauthenticated, err)
+	}
+
+	key, err := v.jwks.key(header.Kid)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnauthenticated, err)
+	}
+
+	signedInput := headerB64 + "." + payloadB64
+	hashed := sha256.Sum256([]byte(signedInput))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, hashed[:], sig); err != nil {
+		return nil, fmt.Errorf("%w: signature verification failed", ErrUnauthenticated)
+	}
+
+	payloadJSON, err := base64.RawURLEncoding.DecodeString(payloadB64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed payload: %w", ErrUnauthenticated, err)
+	}
+	var claims map[string]any
+	if err := json.Unmarshal(payloadJSON, &claims); err != nil {
+		return nil, fmt.Errorf("%w: malformed payload: %w", ErrUnauthenticated, err)
+	}
+	return claims, nil
+}
+
+// validateClaims checks exp/nbf against the current time and, if issuer or
+// audience are non-empty, the token's iss/aud claims against them.
+func validateClaims(claims map[string]any, issuer, audience string) error {
+	now := time.Now()
+
+	if exp, ok := claimTime(claims["exp"]); ok && now.After(exp) {
+		return fmt.Errorf("%w: token expired", ErrUnauthenticated)
+	}
+	if nbf, ok := claimTime(claims["nbf"]); ok && now.Before(nbf) {
+		return fmt.Errorf("%w: token not yet valid", ErrUnauthenticated)
+	}
+	if issuer != "" {
+		if iss, _ := claims["iss"].(string); iss != issuer {
+			return fmt.Errorf("%w: unexpected issuer %q", ErrUnauthenticated, iss)
+		}
+	}
+	if audience != "" && !claimContainsAudience(claims["aud"], audience) {
+		return fmt.Errorf("%w: missing required audience %q", ErrUnauthenticated, audience)
+	}
+	return nil
+}
+
+func claimTime(v any) (time.Time, bool) {
+	n, ok := v.(float64)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(n), 0), true
+}
+
+// claimContainsAudience reports whether aud (a single string or a list of
+// strings, per RFC 7519) contains want.
+func claimContainsAudience(aud any, want string) bool {
+	switch v := aud.(type) {
+	case string:
+		return v == want
+	case []any:
+		for _, item := range v {
+			if s, ok := item.(string); ok && s == want {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,32 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package auth
+
+import "context"
+
+// Principal identifies the caller a [RequestVerifier] authenticated.
+type Principal struct {
+	// Subject is the caller's identifier — typically the token's "sub"
+	// claim.
+	Subject string
+
+	// Claims holds every claim the credential carried, including Subject's
+	// own "sub" entry, so callers needing provider-specific claims (e.g.
+	// "scope", "tid") aren't limited to what Principal promotes to a field.
+	Claims map[string]any
+}
+
+type contextKey struct{}
+
+// WithPrincipal returns a context carrying principal, retrievable later via
+// [PrincipalFromContext].
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, contextKey{}, principal)
+}
+
+// PrincipalFromContext returns the [Principal] a [RequestVerifier] attached
+// to ctx via [WithPrincipal]. ok is false if none was attached.
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(contextKey{}).(Principal)
+	return principal, ok
+}
@@ -0,0 +1,62 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+// ToolPolicy decides whether principal may invoke call. Return an error
+// (typically wrapping [ErrForbidden]) to deny.
+type ToolPolicy func(ctx context.Context, principal Principal, call *af.FunctionCallContent) error
+
+// ToolAuthorizer authorizes tool invocations against a [Principal],
+// keyed on [af.FunctionCallContent.Name]. Tools without an explicit policy
+// fall back to the default policy, which allows every call unless
+// [WithDefaultToolPolicy] overrides it.
+type ToolAuthorizer struct {
+	policies      map[string]ToolPolicy
+	defaultPolicy ToolPolicy
+}
+
+// ToolAuthorizerOption configures a [ToolAuthorizer].
+type ToolAuthorizerOption func(*ToolAuthorizer)
+
+// WithToolPolicy registers policy for the tool named name.
+func WithToolPolicy(name string, policy ToolPolicy) ToolAuthorizerOption {
+	return func(a *ToolAuthorizer) { a.policies[name] = policy }
+}
+
+// WithDefaultToolPolicy overrides the policy applied to tools with no
+// policy registered via [WithToolPolicy]. Defaults to allowing the call.
+func WithDefaultToolPolicy(policy ToolPolicy) ToolAuthorizerOption {
+	return func(a *ToolAuthorizer) { a.defaultPolicy = policy }
+}
+
+// NewToolAuthorizer creates a [ToolAuthorizer].
+func NewToolAuthorizer(opts ...ToolAuthorizerOption) *ToolAuthorizer {
+	a := &ToolAuthorizer{
+		policies:      make(map[string]ToolPolicy),
+		defaultPolicy: func(context.Context, Principal, *af.FunctionCallContent) error { return nil },
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+// Authorize runs the policy registered for call.Name, or the default
+// policy if none was registered.
+func (a *ToolAuthorizer) Authorize(ctx context.Context, principal Principal, call *af.FunctionCallContent) error {
+	policy, ok := a.policies[call.Name]
+	if !ok {
+		policy = a.defaultPolicy
+	}
+	if err := policy(ctx, principal, call); err != nil {
+		return fmt.Errorf("%w: tool %q: %w", ErrForbidden, call.Name, err)
+	}
+	return nil
+}
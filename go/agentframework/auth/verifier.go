@@ -0,0 +1,53 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// RequestVerifier authenticates an inbound HTTP request before an agent run
+// begins. Implementations return a [Principal] identifying the caller, or
+// an error (typically wrapping [ErrUnauthenticated]) if the request should
+// be rejected.
+type RequestVerifier interface {
+	Verify(ctx context.Context, r *http.Request) (Principal, error)
+}
+
+// RequestVerifierFunc adapts a function to a [RequestVerifier].
+type RequestVerifierFunc func(ctx context.Context, r *http.Request) (Principal, error)
+
+// Verify calls f.
+func (f RequestVerifierFunc) Verify(ctx context.Context, r *http.Request) (Principal, error) {
+	return f(ctx, r)
+}
+
+// chainVerifier tries each verifier in order, returning the first
+// [Principal] any of them produces.
+type chainVerifier struct {
+	verifiers []RequestVerifier
+}
+
+// ChainVerifiers composes several [RequestVerifier]s, trying each in order
+// and returning the first successful [Principal]. If every verifier fails,
+// it returns the last verifier's error wrapped in [ErrUnauthenticated].
+func ChainVerifiers(verifiers ...RequestVerifier) RequestVerifier {
+	return &chainVerifier{verifiers: verifiers}
+}
+
+func (c *chainVerifier) Verify(ctx context.Context, r *http.Request) (Principal, error) {
+	var lastErr error
+	for _, v := range c.verifiers {
+		principal, err := v.Verify(ctx, r)
+		if err == nil {
+			return principal, nil
+		}
+		lastErr = err
+	}
+	if lastErr == nil {
+		return Principal{}, ErrUnauthenticated
+	}
+	return Principal{}, errors.Join(ErrUnauthenticated, lastErr)
+}
@@ -0,0 +1,286 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package agentframework
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// BranchingMessageStore extends [MessageStore] with the ability to fork the
+// conversation at an earlier message, edit and re-run from there, and keep
+// the original branch around for inspection — the store-level primitive
+// behind an "edit and resubmit" UX. AddMessages on an implementation of this
+// interface assigns each message's [Message.MessageID] if it is empty, so
+// later calls can address it with [BranchingMessageStore.Fork].
+type BranchingMessageStore interface {
+	MessageStore
+
+	// Fork creates a new branch containing every message up to and
+	// including atMessageID, switches the active branch to it, and
+	// returns the new branch's ID. If atMessageID falls inside an
+	// unanswered tool-call round (a [FunctionCallContent] whose
+	// [FunctionResultContent] hasn't arrived yet within the included
+	// messages), the cut point is extended forward to include the
+	// matching results, so a fork never leaves a dangling tool_call_id
+	// that would fail provider-side message validation.
+	Fork(ctx context.Context, atMessageID string) (branchID string, err error)
+
+	// SwitchBranch makes branchID the active branch; subsequent
+	// ListMessages and AddMessages calls operate on it.
+	SwitchBranch(ctx context.Context, branchID string) error
+
+	// ListBranches returns the ID of every branch, including the root.
+	ListBranches(ctx context.Context) ([]string, error)
+
+	// Branches returns every branch along with its fork ancestry, for
+	// callers (see [Session.Branches]) that want to render a tree rather
+	// than a flat list.
+	Branches(ctx context.Context) ([]BranchInfo, error)
+}
+
+// BranchInfo describes one branch of a [BranchingMessageStore]'s history:
+// the branch it was forked from and the message it was forked at. The root
+// branch has an empty ParentID and ForkPoint.
+type BranchInfo struct {
+	ID        string
+	ParentID  string
+	ForkPoint string
+}
+
+// branchNode is one message in the shared history tree: its parent is the
+// message it was appended after, so branches forked at different points
+// share the nodes before their fork point instead of copying them.
+type branchNode struct {
+	message  Message
+	parentID string // "" for the first message in the tree
+}
+
+// InMemoryBranchingStore is a tree-structured [BranchingMessageStore]: every
+// message is a node with a parent pointer, and each branch is just a name
+// for a leaf node. Forking doesn't copy history — it only points a new
+// branch name at an existing node.
+type InMemoryBranchingStore struct {
+	mu       sync.Mutex
+	nodes    map[string]*branchNode // message ID -> node
+	branches map[string]string      // branch ID -> tip message ID ("" if branch is empty)
+	ancestry map[string]BranchInfo  // branch ID -> its parent branch and fork point
+	current  string
+	nextID   int
+}
+
+// rootBranchID is the branch an [InMemoryBranchingStore] starts on.
+const rootBranchID = "root"
+
+// NewInMemoryBranchingStore creates an [InMemoryBranchingStore] with a
+// single empty branch, rootBranchID, as the active one.
+func NewInMemoryBranchingStore() *InMemoryBranchingStore {
+	return &InMemoryBranchingStore{
+		nodes:    make(map[string]*branchNode),
+		branches: map[string]string{rootBranchID: ""},
+		ancestry: map[string]BranchInfo{rootBranchID: {ID: rootBranchID}},
+		current:  rootBranchID,
+	}
+}
+
+var _ BranchingMessageStore = (*InMemoryBranchingStore)(nil)
+
+// ListMessages returns the active branch's messages, root-to-tip.
+func (s *InMemoryBranchingStore) ListMessages(_ context.Context) ([]Message, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lineage(s.branches[s.current]), nil
+}
+
+// lineage walks parent pointers from tipID back to the root and returns the
+// messages in root-to-tip order. Caller must hold s.mu.
+func (s *InMemoryBranchingStore) lineage(tipID string) []Message {
+	var reversed []Message
+	for id := tipID; id != ""; {
+		node, ok := s.nodes[id]
+		if !ok {
+			break
+		}
+		reversed = append(reversed, node.message)
+		id = node.parentID
+	}
+	msgs := make([]Message, len(reversed))
+	for i, m := range reversed {
+		msgs[len(reversed)-1-i] = m
+	}
+	return msgs
+}
+
+// AddMessages appends msgs to the active branch, assigning each message's
+// MessageID if it is empty.
+func (s *InMemoryBranchingStore) AddMessages(_ context.Context, msgs []Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	parent := s.branches[s.current]
+	for i := range msgs {
+		if msgs[i].MessageID == "" {
+			msgs[i].MessageID = s.generateID()
+		}
+		s.nodes[msgs[i].MessageID] = &branchNode{message: msgs[i], parentID: parent}
+		parent = msgs[i].MessageID
+	}
+	s.branches[s.current] = parent
+	return nil
+}
+
+func (s *InMemoryBranchingStore) generateID() string {
+	s.nextID++
+	return "msg-" + strconv.Itoa(s.nextID)
+}
+
+// Fork creates a new branch at atMessageID (see [BranchingMessageStore.Fork]
+// for the tool-call pairing guarantee) and switches to it.
+func (s *InMemoryBranchingStore) Fork(_ context.Context, atMessageID string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.nodes[atMessageID]; !ok {
+		return "", fmt.Errorf("%w: message %q not found", ErrSession, atMessageID)
+	}
+
+	tip := s.extendPastPendingToolCalls(atMessageID)
+
+	branchID := "branch-" + strconv.Itoa(len(s.branches)+1)
+	s.branches[branchID] = tip
+	s.ancestry[branchID] = BranchInfo{ID: branchID, ParentID: s.current, ForkPoint: atMessageID}
+	s.current = branchID
+	return branchID, nil
+}
+
+// Truncate discards every message after messageID on the active branch,
+// in place, without creating a new branch (unlike [InMemoryBranchingStore.Fork]).
+// Returns [ErrSession] if messageID isn't in the active branch's lineage.
+func (s *InMemoryBranchingStore) Truncate(_ context.Context, messageID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.nodes[messageID]; !ok {
+		return fmt.Errorf("%w: message %q not found", ErrSession, messageID)
+	}
+	full := s.lineage(s.branches[s.current])
+	found := false
+	for _, m := range full {
+		if m.MessageID == messageID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("%w: message %q not in active branch", ErrSession, messageID)
+	}
+
+	s.branches[s.current] = messageID
+	return nil
+}
+
+// extendPastPendingToolCalls walks forward from atMessageID along the
+// lineage of the branch it was forked from, pulling in any subsequent
+// messages needed to answer every [FunctionCallContent] seen so far with a
+// matching [FunctionResultContent]. Caller must hold s.mu.
+func (s *InMemoryBranchingStore) extendPastPendingToolCalls(atMessageID string) string {
+	full := s.lineage(s.branches[s.current])
+	cut := -1
+	for i, m := range full {
+		if m.MessageID == atMessageID {
+			cut = i
+			break
+		}
+	}
+	if cut == -1 {
+		// atMessageID belongs to a different branch than s.current; the
+		// best we can do is fork it as-is with no further history to pull
+		// tool results from.
+		return atMessageID
+	}
+
+	pending := map[string]bool{}
+	trackCalls(full[:cut+1], pending)
+
+	tip := atMessageID
+	for i := cut + 1; i < len(full) && len(pending) > 0; i++ {
+		trackCalls([]Message{full[i]}, pending)
+		tip = full[i].MessageID
+	}
+	return tip
+}
+
+// trackCalls records each FunctionCallContent's CallID in pending and clears
+// it on a matching FunctionResultContent.
+func trackCalls(msgs []Message, pending map[string]bool) {
+	for _, m := range msgs {
+		for _, c := range m.Contents {
+			switch v := c.(type) {
+			case *FunctionCallContent:
+				pending[v.CallID] = true
+			case *FunctionResultContent:
+				delete(pending, v.CallID)
+			}
+		}
+	}
+}
+
+// SwitchBranch makes branchID the active branch.
+func (s *InMemoryBranchingStore) SwitchBranch(_ context.Context, branchID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.branches[branchID]; !ok {
+		return fmt.Errorf("%w: branch %q not found", ErrSession, branchID)
+	}
+	s.current = branchID
+	return nil
+}
+
+// ListBranches returns every branch ID, sorted.
+func (s *InMemoryBranchingStore) ListBranches(_ context.Context) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := make([]string, 0, len(s.branches))
+	for id := range s.branches {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// Branches returns every branch's [BranchInfo], sorted by ID.
+func (s *InMemoryBranchingStore) Branches(_ context.Context) ([]BranchInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	infos := make([]BranchInfo, 0, len(s.ancestry))
+	for _, info := range s.ancestry {
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].ID < infos[j].ID })
+	return infos, nil
+}
+
+// Serialize returns every branch's tip and the full node tree, keyed by
+// message ID.
+func (s *InMemoryBranchingStore) Serialize() (map[string]any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	nodes := make(map[string]any, len(s.nodes))
+	for id, n := range s.nodes {
+		nodes[id] = map[string]any{"message": n.message, "parentId": n.parentID}
+	}
+	ancestry := make(map[string]any, len(s.ancestry))
+	for id, info := range s.ancestry {
+		ancestry[id] = map[string]any{"parentId": info.ParentID, "forkPoint": info.ForkPoint}
+	}
+	return map[string]any{
+		"currentBranch": s.current,
+		"branches":      s.branches,
+		"nodes":         nodes,
+		"ancestry":      ancestry,
+	}, nil
+}
@@ -0,0 +1,145 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package agentframework_test
+
+import (
+	"context"
+	"testing"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+func TestInMemoryBranchingStore_ForkKeepsOriginalBranchIntact(t *testing.T) {
+	ctx := context.Background()
+	store := af.NewInMemoryBranchingStore()
+
+	if err := store.AddMessages(ctx, []af.Message{af.NewUserMessage("hi")}); err != nil {
+		t.Fatalf("AddMessages: %v", err)
+	}
+	msgs, err := store.ListMessages(ctx)
+	if err != nil {
+		t.Fatalf("ListMessages: %v", err)
+	}
+	forkAt := msgs[0].MessageID
+	if forkAt == "" {
+		t.Fatal("expected AddMessages to assign a MessageID")
+	}
+
+	if err := store.AddMessages(ctx, []af.Message{af.NewAssistantMessage("original reply")}); err != nil {
+		t.Fatalf("AddMessages: %v", err)
+	}
+
+	branchID, err := store.Fork(ctx, forkAt)
+	if err != nil {
+		t.Fatalf("Fork: %v", err)
+	}
+	if err := store.AddMessages(ctx, []af.Message{af.NewUserMessage("edited question")}); err != nil {
+		t.Fatalf("AddMessages: %v", err)
+	}
+
+	forked, err := store.ListMessages(ctx)
+	if err != nil {
+		t.Fatalf("ListMessages: %v", err)
+	}
+	if len(forked) != 2 || forked[1].Text() != "edited question" {
+		t.Errorf("forked branch = %v, want [hi, edited question]", forked)
+	}
+
+	branches, err := store.ListBranches(ctx)
+	if err != nil {
+		t.Fatalf("ListBranches: %v", err)
+	}
+	foundOriginal, foundFork := false, false
+	for _, b := range branches {
+		if b == "root" {
+			foundOriginal = true
+		}
+		if b == branchID {
+			foundFork = true
+		}
+	}
+	if !foundOriginal || !foundFork {
+		t.Errorf("branches = %v, want root and %s", branches, branchID)
+	}
+
+	if err := store.SwitchBranch(ctx, "root"); err != nil {
+		t.Fatalf("SwitchBranch: %v", err)
+	}
+	original, err := store.ListMessages(ctx)
+	if err != nil {
+		t.Fatalf("ListMessages: %v", err)
+	}
+	if len(original) != 2 || original[1].Text() != "original reply" {
+		t.Errorf("original branch = %v, want [hi, original reply]", original)
+	}
+}
+
+func TestInMemoryBranchingStore_ForkExtendsPastPendingToolCall(t *testing.T) {
+	ctx := context.Background()
+	store := af.NewInMemoryBranchingStore()
+
+	if err := store.AddMessages(ctx, []af.Message{af.NewUserMessage("what's the weather?")}); err != nil {
+		t.Fatalf("AddMessages: %v", err)
+	}
+
+	callMsg := af.Message{
+		Role:     af.RoleAssistant,
+		Contents: af.Contents{&af.FunctionCallContent{CallID: "call-1", Name: "get_weather", Arguments: "{}"}},
+	}
+	if err := store.AddMessages(ctx, []af.Message{callMsg}); err != nil {
+		t.Fatalf("AddMessages: %v", err)
+	}
+	msgs, _ := store.ListMessages(ctx)
+	callMessageID := msgs[len(msgs)-1].MessageID
+
+	if err := store.AddMessages(ctx, []af.Message{af.NewToolMessage("call-1", "sunny")}); err != nil {
+		t.Fatalf("AddMessages: %v", err)
+	}
+
+	// Forking right at the function-call message, before its result, must
+	// pull the tool result in too so the branch doesn't end with a
+	// dangling tool_call_id.
+	branchID, err := store.Fork(ctx, callMessageID)
+	if err != nil {
+		t.Fatalf("Fork: %v", err)
+	}
+	if err := store.SwitchBranch(ctx, branchID); err != nil {
+		t.Fatalf("SwitchBranch: %v", err)
+	}
+	forked, err := store.ListMessages(ctx)
+	if err != nil {
+		t.Fatalf("ListMessages: %v", err)
+	}
+	if len(forked) != 3 {
+		t.Fatalf("forked branch has %d messages, want 3 (including the pulled-in tool result)", len(forked))
+	}
+	if _, ok := forked[2].Contents[0].(*af.FunctionResultContent); !ok {
+		t.Errorf("forked branch's last message = %T, want *af.FunctionResultContent", forked[2].Contents[0])
+	}
+}
+
+func TestSession_Fork_RequiresBranchingStore(t *testing.T) {
+	session := af.NewSession(af.WithSessionStore(af.NewInMemoryStore()))
+	if _, err := session.Fork(context.Background(), "anything"); err == nil {
+		t.Error("expected error forking a non-branching store")
+	}
+}
+
+func TestSession_Fork_SwitchesActiveBranch(t *testing.T) {
+	ctx := context.Background()
+	store := af.NewInMemoryBranchingStore()
+	session := af.NewSession(af.WithSessionStore(store))
+
+	if err := store.AddMessages(ctx, []af.Message{af.NewUserMessage("hi")}); err != nil {
+		t.Fatalf("AddMessages: %v", err)
+	}
+	msgs, _ := store.ListMessages(ctx)
+
+	branchID, err := session.Fork(ctx, msgs[0].MessageID)
+	if err != nil {
+		t.Fatalf("Fork: %v", err)
+	}
+	if session.Branch() != branchID {
+		t.Errorf("session.Branch() = %q, want %q", session.Branch(), branchID)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package agentframework
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+)
+
+var citationMarkerPattern = regexp.MustCompile(`\[doc(\d+)\]`)
+
+// CitationMiddleware returns a [ChatMiddleware] that post-processes
+// assistant messages: it deduplicates citations carried in
+// [CitationAnnotationContent] items and rewrites inline "[docN]" markers
+// in [TextContent] into shared, 1-based footnote references ("[1]", "[2]",
+// ...) in first-seen order. This normalizes citation formats that differ
+// across providers.
+func CitationMiddleware() ChatMiddleware {
+	return func(next ChatHandler) ChatHandler {
+		return func(ctx context.Context, messages []Message, opts *ChatOptions) (*ChatResponse, error) {
+			resp, err := next(ctx, messages, opts)
+			if err != nil || resp == nil {
+				return resp, err
+			}
+			for i := range resp.Messages {
+				resp.Messages[i].Contents = dedupeCitations(resp.Messages[i].Contents)
+			}
+			return resp, nil
+		}
+	}
+}
+
+// citationKey identifies a citation for deduplication purposes, ignoring
+// which marker a provider happened to attach it to.
+type citationKey struct {
+	title, url, chunkID string
+}
+
+// dedupeCitations deduplicates CitationAnnotationContent items within a
+// single message's content and rewrites the markers they reference in any
+// TextContent items to shared footnote numbers.
+func dedupeCitations(contents Contents) Contents {
+	footnote := make(map[citationKey]int)
+	markerFootnote := make(map[string]int)
+	var deduped Contents
+
+	for _, c := range contents {
+		ann, ok := c.(*CitationAnnotationContent)
+		if !ok {
+			continue
+		}
+		for _, cit := range ann.Citations {
+			key := citationKey{cit.Title, cit.URL, cit.ChunkID}
+			n, exists := footnote[key]
+			if !exists {
+				n = len(footnote) + 1
+				footnote[key] = n
+				deduped = append(deduped, &CitationAnnotationContent{Marker: ann.Marker, Citations: []Citation{cit}})
+			}
+			if ann.Marker != "" {
+				markerFootnote[ann.Marker] = n
+			}
+		}
+	}
+
+	if len(footnote) == 0 {
+		return contents
+	}
+
+	result := make(Contents, 0, len(contents))
+	for _, c := range contents {
+		switch v := c.(type) {
+		case *TextContent:
+			result = append(result, &TextContent{Text: rewriteCitationMarkers(v.Text, markerFootnote)})
+		case *CitationAnnotationContent:
+			// Folded into deduped below.
+		default:
+			result = append(result, c)
+		}
+	}
+	return append(result, deduped...)
+}
+
+// rewriteCitationMarkers replaces "[docN]" markers with their assigned
+// footnote number, leaving unrecognized markers untouched.
+func rewriteCitationMarkers(text string, markerFootnote map[string]int) string {
+	return citationMarkerPattern.ReplaceAllStringFunc(text, func(m string) string {
+		sub := citationMarkerPattern.FindStringSubmatch(m)
+		if n, ok := markerFootnote["doc"+sub[1]]; ok {
+			return fmt.Sprintf("[%d]", n)
+		}
+		return m
+	})
+}
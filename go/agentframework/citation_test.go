@@ -0,0 +1,54 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package agentframework_test
+
+import (
+	"context"
+	"testing"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+func TestCitationMiddleware_DedupesAndRewritesMarkers(t *testing.T) {
+	client := &mockClient{
+		responseFn: func(ctx context.Context, msgs []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error) {
+			return &af.ChatResponse{
+				Messages: []af.Message{{
+					Role: af.RoleAssistant,
+					Contents: af.Contents{
+						&af.TextContent{Text: "Seattle is rainy [doc1]. So is Portland [doc2]."},
+						&af.CitationAnnotationContent{
+							Marker:    "doc1",
+							Citations: []af.Citation{{Title: "Weather", URL: "https://example.com/a"}},
+						},
+						&af.CitationAnnotationContent{
+							Marker:    "doc2",
+							Citations: []af.Citation{{Title: "Weather", URL: "https://example.com/a"}},
+						},
+					},
+				}},
+			}, nil
+		},
+	}
+
+	agent := af.NewAgent(client, af.WithChatMiddleware(af.CitationMiddleware()))
+	resp, err := agent.Run(context.Background(), []af.Message{af.NewUserMessage("weather?")})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	want := "Seattle is rainy [1]. So is Portland [1]."
+	if got := resp.Text(); got != want {
+		t.Errorf("text = %q, want %q", got, want)
+	}
+
+	var citations int
+	for _, c := range resp.Messages[0].Contents {
+		if _, ok := c.(*af.CitationAnnotationContent); ok {
+			citations++
+		}
+	}
+	if citations != 1 {
+		t.Errorf("citation annotations = %d, want 1 (deduped)", citations)
+	}
+}
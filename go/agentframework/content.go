@@ -2,28 +2,42 @@
 
 package agentframework
 
+import "time"
+
 // ContentType identifies the kind of content within a message.
 type ContentType string
 
 const (
-	ContentTypeText                   ContentType = "text"
-	ContentTypeTextReasoning          ContentType = "reasoning"
-	ContentTypeData                   ContentType = "data"
-	ContentTypeURI                    ContentType = "uri"
-	ContentTypeError                  ContentType = "error"
-	ContentTypeFunctionCall           ContentType = "functionCall"
-	ContentTypeFunctionResult         ContentType = "functionResult"
-	ContentTypeUsage                  ContentType = "usage"
-	ContentTypeHostedFile             ContentType = "hostedFile"
-	ContentTypeHostedVectorStore      ContentType = "hostedVectorStore"
-	ContentTypeCodeInterpreterCall    ContentType = "codeInterpreterToolCall"
-	ContentTypeCodeInterpreterResult  ContentType = "codeInterpreterToolResult"
-	ContentTypeImageGenCall           ContentType = "imageGenerationToolCall"
-	ContentTypeImageGenResult         ContentType = "imageGenerationToolResult"
-	ContentTypeMCPServerCall          ContentType = "mcpServerToolCall"
-	ContentTypeMCPServerResult        ContentType = "mcpServerToolResult"
-	ContentTypeApprovalRequest        ContentType = "functionApprovalRequest"
-	ContentTypeApprovalResponse       ContentType = "functionApprovalResponse"
+	ContentTypeText                     ContentType = "text"
+	ContentTypeTextReasoning            ContentType = "reasoning"
+	ContentTypeData                     ContentType = "data"
+	ContentTypeURI                      ContentType = "uri"
+	ContentTypeError                    ContentType = "error"
+	ContentTypeFunctionCall             ContentType = "functionCall"
+	ContentTypeFunctionResult           ContentType = "functionResult"
+	ContentTypeUsage                    ContentType = "usage"
+	ContentTypeHostedFile               ContentType = "hostedFile"
+	ContentTypeHostedVectorStore        ContentType = "hostedVectorStore"
+	ContentTypeCodeInterpreterCall      ContentType = "codeInterpreterToolCall"
+	ContentTypeCodeInterpreterResult    ContentType = "codeInterpreterToolResult"
+	ContentTypeImageGenCall             ContentType = "imageGenerationToolCall"
+	ContentTypeImageGenResult           ContentType = "imageGenerationToolResult"
+	ContentTypeMCPServerCall            ContentType = "mcpServerToolCall"
+	ContentTypeMCPServerResult          ContentType = "mcpServerToolResult"
+	ContentTypeApprovalRequest          ContentType = "functionApprovalRequest"
+	ContentTypeApprovalResponse         ContentType = "functionApprovalResponse"
+	ContentTypeCitationAnnotation       ContentType = "citationAnnotation"
+	ContentTypeStructuredData           ContentType = "structuredData"
+	ContentTypeAudio                    ContentType = "audio"
+	ContentTypeAudioTranscription       ContentType = "audioTranscription"
+	ContentTypeSpeechCall               ContentType = "speechCall"
+	ContentTypeSpeechResult             ContentType = "speechResult"
+	ContentTypeContentFilterResult      ContentType = "contentFilterResult"
+	ContentTypeImageGenOperation        ContentType = "imageGenOperation"
+	ContentTypeCodeInterpreterOperation ContentType = "codeInterpreterOperation"
+	ContentTypeTextDelta                ContentType = "textDelta"
+	ContentTypeFunctionCallDelta        ContentType = "functionCallDelta"
+	ContentTypeUsageDelta               ContentType = "usageDelta"
 )
 
 // Content is a sealed interface representing a piece of content within a [Message].
@@ -114,9 +128,13 @@ type UsageContent struct {
 func (c *UsageContent) Type() ContentType { return ContentTypeUsage }
 
 // HostedFileContent references a service-hosted file.
+// Size and SHA256 are optional integrity metadata: populated when known
+// (e.g. by [UploadInParallel]), zero/empty otherwise.
 type HostedFileContent struct {
 	base
 	FileID string
+	Size   int64
+	SHA256 string
 }
 
 func (c *HostedFileContent) Type() ContentType { return ContentTypeHostedFile }
@@ -195,11 +213,257 @@ type ApprovalRequestContent struct {
 func (c *ApprovalRequestContent) Type() ContentType { return ContentTypeApprovalRequest }
 
 // ApprovalResponseContent carries the user's approval decision.
+//
+// AlwaysApproveForSession, when set alongside Approved, asks the [Session]
+// passed to [Agent.Resume] to remember the decision: later calls to the
+// same tool within that session (see [Session.ApproveToolForSession]) skip
+// the approval prompt entirely.
 type ApprovalResponseContent struct {
 	base
-	CallID   string
-	Approved bool
-	Reason   string
+	CallID                  string
+	Approved                bool
+	Reason                  string
+	AlwaysApproveForSession bool
 }
 
 func (c *ApprovalResponseContent) Type() ContentType { return ContentTypeApprovalResponse }
+
+// Citation describes a single retrieval-augmentation source (document
+// title, URL, snippet, and originating chunk) backing part of a response.
+type Citation struct {
+	Title   string `json:"title,omitempty"`
+	URL     string `json:"url,omitempty"`
+	Snippet string `json:"snippet,omitempty"`
+	ChunkID string `json:"chunkId,omitempty"`
+}
+
+// CitationAnnotationContent attaches retrieval citations to a span of
+// assistant text. Marker holds the inline reference some providers emit
+// (e.g. "doc1") so it can be rewritten into a footnote by [CitationMiddleware];
+// providers that annotate verbatim text instead of a marker can leave Marker
+// empty and set Text.
+type CitationAnnotationContent struct {
+	base
+	Marker    string
+	Text      string
+	Citations []Citation
+}
+
+func (c *CitationAnnotationContent) Type() ContentType { return ContentTypeCitationAnnotation }
+
+// StructuredDataContent carries an arbitrary JSON-shaped payload attached to
+// a message — e.g. an A2A "data" part — as distinct from [DataContent],
+// which represents binary data via a data URI.
+type StructuredDataContent struct {
+	base
+	Data any
+}
+
+func (c *StructuredDataContent) Type() ContentType { return ContentTypeStructuredData }
+
+// AudioContent holds audio, either by reference (URI) or inline (Data).
+// SampleRate is in Hz; Language, if known, is a BCP-47 tag.
+type AudioContent struct {
+	base
+	URI        string
+	Data       []byte
+	MediaType  string
+	SampleRate int
+	Channels   int
+	Language   string
+}
+
+func (c *AudioContent) Type() ContentType { return ContentTypeAudio }
+
+// AudioSegment is a single timed span of a transcription, as produced by
+// Whisper-style segment-level output.
+type AudioSegment struct {
+	Start      float64 `json:"start"`
+	End        float64 `json:"end"`
+	Text       string  `json:"text"`
+	Confidence float64 `json:"confidence,omitempty"`
+}
+
+// AudioTranscriptionContent holds the text transcription of an
+// [AudioContent], produced by a [TranscriptionClient].
+type AudioTranscriptionContent struct {
+	base
+	Text     string
+	Language string
+	Segments []AudioSegment
+}
+
+func (c *AudioTranscriptionContent) Type() ContentType { return ContentTypeAudioTranscription }
+
+// SpeechCallContent requests text-to-speech synthesis from a
+// [TranscriptionClient].
+type SpeechCallContent struct {
+	base
+	CallID string
+	Voice  string
+	Format string // e.g. "mp3", "wav"
+	Text   string
+}
+
+func (c *SpeechCallContent) Type() ContentType { return ContentTypeSpeechCall }
+
+// SpeechResultContent carries synthesized audio, either by reference (URI)
+// or inline (Data), produced in response to a [SpeechCallContent].
+type SpeechResultContent struct {
+	base
+	CallID    string
+	URI       string
+	Data      []byte
+	MediaType string
+}
+
+func (c *SpeechResultContent) Type() ContentType { return ContentTypeSpeechResult }
+
+// ContentFilterCategoryResult reports one content-safety category's
+// evaluation of a prompt or completion.
+type ContentFilterCategoryResult struct {
+	Severity string   `json:"severity,omitempty"`
+	Filtered bool     `json:"filtered"`
+	Detected bool     `json:"detected,omitempty"`
+	Citation []string `json:"citation,omitempty"`
+}
+
+// ContentFilterResultContent carries a structured content-filter
+// evaluation, so middleware and callers can make routing decisions per
+// category instead of string-matching [ErrContentFilter]'s message.
+// A zero-value category means that category wasn't evaluated.
+type ContentFilterResultContent struct {
+	base
+	Hate                  ContentFilterCategoryResult
+	SelfHarm              ContentFilterCategoryResult
+	Sexual                ContentFilterCategoryResult
+	Violence              ContentFilterCategoryResult
+	Jailbreak             ContentFilterCategoryResult
+	ProtectedMaterialCode ContentFilterCategoryResult
+	ProtectedMaterialText ContentFilterCategoryResult
+}
+
+func (c *ContentFilterResultContent) Type() ContentType { return ContentTypeContentFilterResult }
+
+// OperationStatus is the lifecycle state of a long-running operation
+// tracked by [Operation].
+type OperationStatus string
+
+const (
+	OperationStatusQueued    OperationStatus = "queued"
+	OperationStatusRunning   OperationStatus = "running"
+	OperationStatusSucceeded OperationStatus = "succeeded"
+	OperationStatusFailed    OperationStatus = "failed"
+	OperationStatusCanceled  OperationStatus = "canceled"
+)
+
+// terminal reports whether status is one an [Operation] stops polling at.
+func (s OperationStatus) terminal() bool {
+	return s == OperationStatusSucceeded || s == OperationStatusFailed || s == OperationStatusCanceled
+}
+
+// operationContent is implemented by content types that represent an
+// in-flight long-running operation, letting [OperationMiddleware] handle
+// [ImageGenOperationContent] and [CodeInterpreterOperationContent]
+// generically instead of duplicating its poll loop per content type.
+type operationContent interface {
+	Content
+	operationCallID() string
+	operationLocation() string
+	operationStatus() OperationStatus
+}
+
+// ImageGenOperationContent carries the state of an in-flight image
+// generation operation, returned in place of a blocking
+// [ImageGenResultContent] when the provider replies with a 202-style
+// "still working" response. OperationLocation is an opaque
+// provider-specific handle passed to [Operation.Poll]; the pair
+// (OperationLocation, Status, LastPolledAt) round-trips through
+// [Operation.ResumeToken] so a suspended operation can be persisted to a
+// [Session] and resumed in a different process.
+type ImageGenOperationContent struct {
+	base
+	CallID            string
+	OperationLocation string
+	Status            OperationStatus
+	LastPolledAt      time.Time
+}
+
+func (c *ImageGenOperationContent) Type() ContentType                { return ContentTypeImageGenOperation }
+func (c *ImageGenOperationContent) operationCallID() string          { return c.CallID }
+func (c *ImageGenOperationContent) operationLocation() string        { return c.OperationLocation }
+func (c *ImageGenOperationContent) operationStatus() OperationStatus { return c.Status }
+
+// CodeInterpreterOperationContent mirrors [ImageGenOperationContent] for a
+// code interpreter tool call.
+type CodeInterpreterOperationContent struct {
+	base
+	CallID            string
+	OperationLocation string
+	Status            OperationStatus
+	LastPolledAt      time.Time
+}
+
+func (c *CodeInterpreterOperationContent) Type() ContentType {
+	return ContentTypeCodeInterpreterOperation
+}
+func (c *CodeInterpreterOperationContent) operationCallID() string          { return c.CallID }
+func (c *CodeInterpreterOperationContent) operationLocation() string        { return c.OperationLocation }
+func (c *CodeInterpreterOperationContent) operationStatus() OperationStatus { return c.Status }
+
+var (
+	_ operationContent = (*ImageGenOperationContent)(nil)
+	_ operationContent = (*CodeInterpreterOperationContent)(nil)
+)
+
+// PromptFilterResult is one prompt position's content-filter evaluation,
+// attached to a [ChatResponse] when the provider evaluates the prompt
+// itself (as distinct from the completion).
+type PromptFilterResult struct {
+	PromptIndex int
+	Result      ContentFilterResultContent
+}
+
+// TextDeltaContent carries an incremental fragment of assistant text during
+// streaming, as distinct from a completed [TextContent]. Index identifies
+// which text span this fragment belongs to when a provider interleaves more
+// than one (e.g. multiple choices); providers that stream a single span
+// leave it at the zero value. [ContentAccumulator] concatenates deltas
+// sharing the same Index in arrival order.
+type TextDeltaContent struct {
+	base
+	Index int
+	Text  string
+}
+
+func (c *TextDeltaContent) Type() ContentType { return ContentTypeTextDelta }
+
+// FunctionCallDeltaContent carries an incremental fragment of a tool call
+// during streaming, as distinct from a completed [FunctionCallContent].
+// Index identifies the call's slot across its deltas (the CallID is typically
+// only present on the first delta for a call). ArgumentsDelta is a raw JSON
+// fragment to be concatenated, not a complete document on its own.
+// [ContentAccumulator] merges deltas sharing the same Index (or, for a delta
+// whose Index has no group yet, the same CallID) by concatenating NameDelta
+// and ArgumentsDelta in arrival order.
+type FunctionCallDeltaContent struct {
+	base
+	Index          int
+	CallID         string
+	NameDelta      string
+	ArgumentsDelta string
+}
+
+func (c *FunctionCallDeltaContent) Type() ContentType { return ContentTypeFunctionCallDelta }
+
+// UsageDeltaContent carries a partial token-usage tally during streaming,
+// as distinct from a completed [UsageContent]. Some providers emit usage
+// incrementally rather than only in a final summary chunk.
+// [ContentAccumulator] sums PartialUsage across every UsageDeltaContent it
+// sees.
+type UsageDeltaContent struct {
+	base
+	PartialUsage UsageDetails
+}
+
+func (c *UsageDeltaContent) Type() ContentType { return ContentTypeUsageDelta }
@@ -0,0 +1,123 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package agentframework
+
+import "strings"
+
+// textDeltaGroup accumulates the [TextDeltaContent] fragments sharing a
+// single Index into one completed [TextContent].
+type textDeltaGroup struct {
+	text strings.Builder
+}
+
+// functionCallDeltaGroup accumulates the [FunctionCallDeltaContent]
+// fragments sharing a single Index (a provider's stable per-call-slot
+// identifier, e.g. OpenAI's tool_calls[].index) into one completed
+// [FunctionCallContent]. CallID is usually only present on the first delta
+// for a call, so it's also tracked so a later delta with the same CallID
+// but no Index set resolves to the same group.
+type functionCallDeltaGroup struct {
+	callID    string
+	name      strings.Builder
+	arguments strings.Builder
+}
+
+// ContentAccumulator merges streaming delta content — [TextDeltaContent],
+// [FunctionCallDeltaContent], and [UsageDeltaContent] — into the completed
+// [Content] values a non-streaming caller expects, so a [ResponseStream]
+// consumer can pass deltas straight through the wire format instead of
+// re-emitting whole content on every update. Non-delta content is passed
+// through unchanged. The zero value is not usable; use
+// [NewContentAccumulator].
+type ContentAccumulator struct {
+	items     []any // Content (passthrough), *textDeltaGroup, *functionCallDeltaGroup, or *UsageDetails
+	textByIdx map[int]*textDeltaGroup
+	callByID  map[string]*functionCallDeltaGroup
+	callByIdx map[int]*functionCallDeltaGroup
+	usage     *UsageDetails
+}
+
+// NewContentAccumulator creates an empty [ContentAccumulator].
+func NewContentAccumulator() *ContentAccumulator {
+	return &ContentAccumulator{
+		textByIdx: make(map[int]*textDeltaGroup),
+		callByID:  make(map[string]*functionCallDeltaGroup),
+		callByIdx: make(map[int]*functionCallDeltaGroup),
+	}
+}
+
+// Add folds c into the accumulator. A [TextDeltaContent] is merged into the
+// group for its Index; a [FunctionCallDeltaContent] into the group for its
+// Index or, lacking that, its CallID — each created on first sight and
+// keeping that position in [Finalize]'s output. A [UsageDeltaContent] is
+// summed into a single running total. Any other [Content] is appended
+// as-is.
+func (a *ContentAccumulator) Add(c Content) {
+	switch v := c.(type) {
+	case *TextDeltaContent:
+		g, ok := a.textByIdx[v.Index]
+		if !ok {
+			g = &textDeltaGroup{}
+			a.textByIdx[v.Index] = g
+			a.items = append(a.items, g)
+		}
+		g.text.WriteString(v.Text)
+
+	case *FunctionCallDeltaContent:
+		g, ok := a.callByIdx[v.Index]
+		if !ok && v.CallID != "" {
+			g, ok = a.callByID[v.CallID]
+		}
+		if !ok {
+			g = &functionCallDeltaGroup{}
+			a.items = append(a.items, g)
+		}
+		a.callByIdx[v.Index] = g
+		if v.CallID != "" {
+			g.callID = v.CallID
+			a.callByID[v.CallID] = g
+		}
+		g.name.WriteString(v.NameDelta)
+		g.arguments.WriteString(v.ArgumentsDelta)
+
+	case *UsageDeltaContent:
+		if a.usage == nil {
+			a.usage = &UsageDetails{}
+			a.items = append(a.items, a.usage)
+		}
+		a.usage.InputTokens += v.PartialUsage.InputTokens
+		a.usage.OutputTokens += v.PartialUsage.OutputTokens
+		a.usage.TotalTokens += v.PartialUsage.TotalTokens
+		a.usage.CachedInputTokens += v.PartialUsage.CachedInputTokens
+		a.usage.CacheCreationTokens += v.PartialUsage.CacheCreationTokens
+
+	default:
+		a.items = append(a.items, c)
+	}
+}
+
+// Finalize returns the accumulated content, in the order each item (or
+// delta group) was first seen, with every delta group converted to its
+// completed counterpart: [TextDeltaContent] to [TextContent],
+// [FunctionCallDeltaContent] to [FunctionCallContent], and
+// [UsageDeltaContent] to [UsageContent].
+func (a *ContentAccumulator) Finalize() Contents {
+	result := make(Contents, 0, len(a.items))
+	for _, item := range a.items {
+		switch v := item.(type) {
+		case *textDeltaGroup:
+			result = append(result, &TextContent{Text: v.text.String()})
+		case *functionCallDeltaGroup:
+			result = append(result, &FunctionCallContent{
+				CallID:    v.callID,
+				Name:      v.name.String(),
+				Arguments: v.arguments.String(),
+			})
+		case *UsageDetails:
+			result = append(result, &UsageContent{Usage: *v})
+		case Content:
+			result = append(result, v)
+		}
+	}
+	return result
+}
@@ -0,0 +1,102 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package agentframework_test
+
+import (
+	"testing"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+func TestContentAccumulator_MergesTextDeltasByIndex(t *testing.T) {
+	acc := af.NewContentAccumulator()
+	acc.Add(&af.TextDeltaContent{Index: 0, Text: "Hel"})
+	acc.Add(&af.TextDeltaContent{Index: 0, Text: "lo"})
+
+	got := acc.Finalize()
+	if len(got) != 1 {
+		t.Fatalf("len = %d, want 1", len(got))
+	}
+	tc, ok := got[0].(*af.TextContent)
+	if !ok {
+		t.Fatalf("type = %T, want *TextContent", got[0])
+	}
+	if tc.Text != "Hello" {
+		t.Errorf("Text = %q, want %q", tc.Text, "Hello")
+	}
+}
+
+func TestContentAccumulator_MergesFunctionCallDeltasByCallID(t *testing.T) {
+	acc := af.NewContentAccumulator()
+	acc.Add(&af.FunctionCallDeltaContent{CallID: "call-1", NameDelta: "get_", ArgumentsDelta: `{"a":`})
+	acc.Add(&af.FunctionCallDeltaContent{CallID: "call-1", NameDelta: "weather", ArgumentsDelta: `1}`})
+
+	got := acc.Finalize()
+	if len(got) != 1 {
+		t.Fatalf("len = %d, want 1", len(got))
+	}
+	fc, ok := got[0].(*af.FunctionCallContent)
+	if !ok {
+		t.Fatalf("type = %T, want *FunctionCallContent", got[0])
+	}
+	if fc.CallID != "call-1" || fc.Name != "get_weather" || fc.Arguments != `{"a":1}` {
+		t.Errorf("FunctionCallContent = %+v", fc)
+	}
+}
+
+func TestContentAccumulator_MergesFunctionCallDeltasByIndexBeforeCallIDKnown(t *testing.T) {
+	acc := af.NewContentAccumulator()
+	acc.Add(&af.FunctionCallDeltaContent{Index: 0, NameDelta: "add"})
+	acc.Add(&af.FunctionCallDeltaContent{Index: 0, CallID: "call-1", ArgumentsDelta: `{}`})
+
+	got := acc.Finalize()
+	if len(got) != 1 {
+		t.Fatalf("len = %d, want 1", len(got))
+	}
+	fc, ok := got[0].(*af.FunctionCallContent)
+	if !ok {
+		t.Fatalf("type = %T, want *FunctionCallContent", got[0])
+	}
+	if fc.CallID != "call-1" || fc.Name != "add" || fc.Arguments != "{}" {
+		t.Errorf("FunctionCallContent = %+v", fc)
+	}
+}
+
+func TestContentAccumulator_SumsUsageDeltas(t *testing.T) {
+	acc := af.NewContentAccumulator()
+	acc.Add(&af.UsageDeltaContent{PartialUsage: af.UsageDetails{InputTokens: 10, OutputTokens: 1}})
+	acc.Add(&af.UsageDeltaContent{PartialUsage: af.UsageDetails{OutputTokens: 4, TotalTokens: 15}})
+
+	got := acc.Finalize()
+	if len(got) != 1 {
+		t.Fatalf("len = %d, want 1", len(got))
+	}
+	uc, ok := got[0].(*af.UsageContent)
+	if !ok {
+		t.Fatalf("type = %T, want *UsageContent", got[0])
+	}
+	if uc.Usage.InputTokens != 10 || uc.Usage.OutputTokens != 5 || uc.Usage.TotalTokens != 15 {
+		t.Errorf("Usage = %+v", uc.Usage)
+	}
+}
+
+func TestContentAccumulator_PassesThroughCompletedContentInOrder(t *testing.T) {
+	acc := af.NewContentAccumulator()
+	acc.Add(&af.TextDeltaContent{Index: 0, Text: "part-a"})
+	acc.Add(&af.FunctionResultContent{CallID: "call-1", Result: "ok"})
+	acc.Add(&af.TextDeltaContent{Index: 1, Text: "part-b"})
+
+	got := acc.Finalize()
+	if len(got) != 3 {
+		t.Fatalf("len = %d, want 3", len(got))
+	}
+	if got[0].Type() != af.ContentTypeText {
+		t.Errorf("got[0] type = %q", got[0].Type())
+	}
+	if got[1].Type() != af.ContentTypeFunctionResult {
+		t.Errorf("got[1] type = %q", got[1].Type())
+	}
+	if got[2].Type() != af.ContentTypeText {
+		t.Errorf("got[2] type = %q", got[2].Type())
+	}
+}
@@ -5,6 +5,7 @@ package agentframework
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 )
 
 // contentEnvelope is the JSON wire format using a $type discriminator,
@@ -76,7 +77,9 @@ func MarshalContentJSON(c Content) ([]byte, error) {
 		return json.Marshal(struct {
 			Type   string `json:"$type"`
 			FileID string `json:"fileId"`
-		}{string(ContentTypeHostedFile), v.FileID})
+			Size   int64  `json:"size,omitempty"`
+			SHA256 string `json:"sha256,omitempty"`
+		}{string(ContentTypeHostedFile), v.FileID, v.Size, v.SHA256})
 
 	case *HostedVectorStoreContent:
 		return json.Marshal(struct {
@@ -137,11 +140,118 @@ func MarshalContentJSON(c Content) ([]byte, error) {
 
 	case *ApprovalResponseContent:
 		return json.Marshal(struct {
-			Type     string `json:"$type"`
-			CallID   string `json:"callId"`
-			Approved bool   `json:"approved"`
-			Reason   string `json:"reason,omitempty"`
-		}{string(ContentTypeApprovalResponse), v.CallID, v.Approved, v.Reason})
+			Type                    string `json:"$type"`
+			CallID                  string `json:"callId"`
+			Approved                bool   `json:"approved"`
+			Reason                  string `json:"reason,omitempty"`
+			AlwaysApproveForSession bool   `json:"alwaysApproveForSession,omitempty"`
+		}{string(ContentTypeApprovalResponse), v.CallID, v.Approved, v.Reason, v.AlwaysApproveForSession})
+
+	case *CitationAnnotationContent:
+		return json.Marshal(struct {
+			Type      string     `json:"$type"`
+			Marker    string     `json:"marker,omitempty"`
+			Text      string     `json:"text,omitempty"`
+			Citations []Citation `json:"citations,omitempty"`
+		}{string(ContentTypeCitationAnnotation), v.Marker, v.Text, v.Citations})
+
+	case *StructuredDataContent:
+		return json.Marshal(struct {
+			Type string `json:"$type"`
+			Data any    `json:"data,omitempty"`
+		}{string(ContentTypeStructuredData), v.Data})
+
+	case *AudioContent:
+		return json.Marshal(struct {
+			Type       string `json:"$type"`
+			URI        string `json:"uri,omitempty"`
+			Data       []byte `json:"data,omitempty"`
+			MediaType  string `json:"mediaType,omitempty"`
+			SampleRate int    `json:"sampleRate,omitempty"`
+			Channels   int    `json:"channels,omitempty"`
+			Language   string `json:"language,omitempty"`
+		}{string(ContentTypeAudio), v.URI, v.Data, v.MediaType, v.SampleRate, v.Channels, v.Language})
+
+	case *AudioTranscriptionContent:
+		return json.Marshal(struct {
+			Type     string         `json:"$type"`
+			Text     string         `json:"text,omitempty"`
+			Language string         `json:"language,omitempty"`
+			Segments []AudioSegment `json:"segments,omitempty"`
+		}{string(ContentTypeAudioTranscription), v.Text, v.Language, v.Segments})
+
+	case *SpeechCallContent:
+		return json.Marshal(struct {
+			Type   string `json:"$type"`
+			CallID string `json:"callId,omitempty"`
+			Voice  string `json:"voice,omitempty"`
+			Format string `json:"format,omitempty"`
+			Text   string `json:"text"`
+		}{string(ContentTypeSpeechCall), v.CallID, v.Voice, v.Format, v.Text})
+
+	case *SpeechResultContent:
+		return json.Marshal(struct {
+			Type      string `json:"$type"`
+			CallID    string `json:"callId,omitempty"`
+			URI       string `json:"uri,omitempty"`
+			Data      []byte `json:"data,omitempty"`
+			MediaType string `json:"mediaType,omitempty"`
+		}{string(ContentTypeSpeechResult), v.CallID, v.URI, v.Data, v.MediaType})
+
+	case *ContentFilterResultContent:
+		return json.Marshal(struct {
+			Type                  string                      `json:"$type"`
+			Hate                  ContentFilterCategoryResult `json:"hate"`
+			SelfHarm              ContentFilterCategoryResult `json:"selfHarm"`
+			Sexual                ContentFilterCategoryResult `json:"sexual"`
+			Violence              ContentFilterCategoryResult `json:"violence"`
+			Jailbreak             ContentFilterCategoryResult `json:"jailbreak"`
+			ProtectedMaterialCode ContentFilterCategoryResult `json:"protectedMaterialCode"`
+			ProtectedMaterialText ContentFilterCategoryResult `json:"protectedMaterialText"`
+		}{
+			string(ContentTypeContentFilterResult),
+			v.Hate, v.SelfHarm, v.Sexual, v.Violence, v.Jailbreak, v.ProtectedMaterialCode, v.ProtectedMaterialText,
+		})
+
+	case *ImageGenOperationContent:
+		return json.Marshal(struct {
+			Type              string          `json:"$type"`
+			CallID            string          `json:"callId,omitempty"`
+			OperationLocation string          `json:"operationLocation"`
+			Status            OperationStatus `json:"status"`
+			LastPolledAt      time.Time       `json:"lastPolledAt,omitempty"`
+		}{string(ContentTypeImageGenOperation), v.CallID, v.OperationLocation, v.Status, v.LastPolledAt})
+
+	case *CodeInterpreterOperationContent:
+		return json.Marshal(struct {
+			Type              string          `json:"$type"`
+			CallID            string          `json:"callId,omitempty"`
+			OperationLocation string          `json:"operationLocation"`
+			Status            OperationStatus `json:"status"`
+			LastPolledAt      time.Time       `json:"lastPolledAt,omitempty"`
+		}{string(ContentTypeCodeInterpreterOperation), v.CallID, v.OperationLocation, v.Status, v.LastPolledAt})
+
+	case *TextDeltaContent:
+		return json.Marshal(struct {
+			Type  string `json:"$type"`
+			Index int    `json:"index,omitempty"`
+			Text  string `json:"text,omitempty"`
+		}{string(ContentTypeTextDelta), v.Index, v.Text})
+
+	case *FunctionCallDeltaContent:
+		return json.Marshal(struct {
+			Type           string `json:"$type"`
+			Index          int    `json:"index,omitempty"`
+			CallID         string `json:"callId,omitempty"`
+			NameDelta      string `json:"nameDelta,omitempty"`
+			ArgumentsDelta string `json:"argumentsDelta,omitempty"`
+		}{string(ContentTypeFunctionCallDelta), v.Index, v.CallID, v.NameDelta, v.ArgumentsDelta})
+
+	case *UsageDeltaContent:
+		return json.Marshal(struct {
+			Type         string       `json:"$type"`
+			PartialUsage UsageDetails `json:"partialUsage"`
+		}{string(ContentTypeUsageDelta), v.PartialUsage})
 
 	default:
 		return nil, fmt.Errorf("unknown content type: %T", c)
@@ -240,11 +350,13 @@ func UnmarshalContentJSON(data []byte) (Content, error) {
 	case ContentTypeHostedFile:
 		var v struct {
 			FileID string `json:"fileId"`
+			Size   int64  `json:"size"`
+			SHA256 string `json:"sha256"`
 		}
 		if err := json.Unmarshal(data, &v); err != nil {
 			return nil, err
 		}
-		return &HostedFileContent{FileID: v.FileID}, nil
+		return &HostedFileContent{FileID: v.FileID, Size: v.Size, SHA256: v.SHA256}, nil
 
 	case ContentTypeHostedVectorStore:
 		var v struct {
@@ -329,14 +441,157 @@ func UnmarshalContentJSON(data []byte) (Content, error) {
 
 	case ContentTypeApprovalResponse:
 		var v struct {
-			CallID   string `json:"callId"`
-			Approved bool   `json:"approved"`
-			Reason   string `json:"reason"`
+			CallID                  string `json:"callId"`
+			Approved                bool   `json:"approved"`
+			Reason                  string `json:"reason"`
+			AlwaysApproveForSession bool   `json:"alwaysApproveForSession"`
+		}
+		if err := json.Unmarshal(data, &v); err != nil {
+			return nil, err
+		}
+		return &ApprovalResponseContent{CallID: v.CallID, Approved: v.Approved, Reason: v.Reason, AlwaysApproveForSession: v.AlwaysApproveForSession}, nil
+
+	case ContentTypeCitationAnnotation:
+		var v struct {
+			Marker    string     `json:"marker"`
+			Text      string     `json:"text"`
+			Citations []Citation `json:"citations"`
+		}
+		if err := json.Unmarshal(data, &v); err != nil {
+			return nil, err
+		}
+		return &CitationAnnotationContent{Marker: v.Marker, Text: v.Text, Citations: v.Citations}, nil
+
+	case ContentTypeStructuredData:
+		var v struct {
+			Data any `json:"data"`
+		}
+		if err := json.Unmarshal(data, &v); err != nil {
+			return nil, err
+		}
+		return &StructuredDataContent{Data: v.Data}, nil
+
+	case ContentTypeAudio:
+		var v struct {
+			URI        string `json:"uri"`
+			Data       []byte `json:"data"`
+			MediaType  string `json:"mediaType"`
+			SampleRate int    `json:"sampleRate"`
+			Channels   int    `json:"channels"`
+			Language   string `json:"language"`
+		}
+		if err := json.Unmarshal(data, &v); err != nil {
+			return nil, err
+		}
+		return &AudioContent{URI: v.URI, Data: v.Data, MediaType: v.MediaType, SampleRate: v.SampleRate, Channels: v.Channels, Language: v.Language}, nil
+
+	case ContentTypeAudioTranscription:
+		var v struct {
+			Text     string         `json:"text"`
+			Language string         `json:"language"`
+			Segments []AudioSegment `json:"segments"`
+		}
+		if err := json.Unmarshal(data, &v); err != nil {
+			return nil, err
+		}
+		return &AudioTranscriptionContent{Text: v.Text, Language: v.Language, Segments: v.Segments}, nil
+
+	case ContentTypeSpeechCall:
+		var v struct {
+			CallID string `json:"callId"`
+			Voice  string `json:"voice"`
+			Format string `json:"format"`
+			Text   string `json:"text"`
+		}
+		if err := json.Unmarshal(data, &v); err != nil {
+			return nil, err
+		}
+		return &SpeechCallContent{CallID: v.CallID, Voice: v.Voice, Format: v.Format, Text: v.Text}, nil
+
+	case ContentTypeSpeechResult:
+		var v struct {
+			CallID    string `json:"callId"`
+			URI       string `json:"uri"`
+			Data      []byte `json:"data"`
+			MediaType string `json:"mediaType"`
+		}
+		if err := json.Unmarshal(data, &v); err != nil {
+			return nil, err
+		}
+		return &SpeechResultContent{CallID: v.CallID, URI: v.URI, Data: v.Data, MediaType: v.MediaType}, nil
+
+	case ContentTypeContentFilterResult:
+		var v struct {
+			Hate                  ContentFilterCategoryResult `json:"hate"`
+			SelfHarm              ContentFilterCategoryResult `json:"selfHarm"`
+			Sexual                ContentFilterCategoryResult `json:"sexual"`
+			Violence              ContentFilterCategoryResult `json:"violence"`
+			Jailbreak             ContentFilterCategoryResult `json:"jailbreak"`
+			ProtectedMaterialCode ContentFilterCategoryResult `json:"protectedMaterialCode"`
+			ProtectedMaterialText ContentFilterCategoryResult `json:"protectedMaterialText"`
+		}
+		if err := json.Unmarshal(data, &v); err != nil {
+			return nil, err
+		}
+		return &ContentFilterResultContent{
+			Hate: v.Hate, SelfHarm: v.SelfHarm, Sexual: v.Sexual, Violence: v.Violence,
+			Jailbreak: v.Jailbreak, ProtectedMaterialCode: v.ProtectedMaterialCode, ProtectedMaterialText: v.ProtectedMaterialText,
+		}, nil
+
+	case ContentTypeImageGenOperation:
+		var v struct {
+			CallID            string          `json:"callId"`
+			OperationLocation string          `json:"operationLocation"`
+			Status            OperationStatus `json:"status"`
+			LastPolledAt      time.Time       `json:"lastPolledAt"`
+		}
+		if err := json.Unmarshal(data, &v); err != nil {
+			return nil, err
+		}
+		return &ImageGenOperationContent{CallID: v.CallID, OperationLocation: v.OperationLocation, Status: v.Status, LastPolledAt: v.LastPolledAt}, nil
+
+	case ContentTypeCodeInterpreterOperation:
+		var v struct {
+			CallID            string          `json:"callId"`
+			OperationLocation string          `json:"operationLocation"`
+			Status            OperationStatus `json:"status"`
+			LastPolledAt      time.Time       `json:"lastPolledAt"`
+		}
+		if err := json.Unmarshal(data, &v); err != nil {
+			return nil, err
+		}
+		return &CodeInterpreterOperationContent{CallID: v.CallID, OperationLocation: v.OperationLocation, Status: v.Status, LastPolledAt: v.LastPolledAt}, nil
+
+	case ContentTypeTextDelta:
+		var v struct {
+			Index int    `json:"index"`
+			Text  string `json:"text"`
+		}
+		if err := json.Unmarshal(data, &v); err != nil {
+			return nil, err
+		}
+		return &TextDeltaContent{Index: v.Index, Text: v.Text}, nil
+
+	case ContentTypeFunctionCallDelta:
+		var v struct {
+			Index          int    `json:"index"`
+			CallID         string `json:"callId"`
+			NameDelta      string `json:"nameDelta"`
+			ArgumentsDelta string `json:"argumentsDelta"`
+		}
+		if err := json.Unmarshal(data, &v); err != nil {
+			return nil, err
+		}
+		return &FunctionCallDeltaContent{Index: v.Index, CallID: v.CallID, NameDelta: v.NameDelta, ArgumentsDelta: v.ArgumentsDelta}, nil
+
+	case ContentTypeUsageDelta:
+		var v struct {
+			PartialUsage UsageDetails `json:"partialUsage"`
 		}
 		if err := json.Unmarshal(data, &v); err != nil {
 			return nil, err
 		}
-		return &ApprovalResponseContent{CallID: v.CallID, Approved: v.Approved, Reason: v.Reason}, nil
+		return &UsageDeltaContent{PartialUsage: v.PartialUsage}, nil
 
 	default:
 		return nil, fmt.Errorf("unknown content $type: %q", env.Type)
@@ -5,6 +5,7 @@ package agentframework_test
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	af "github.com/microsoft/agent-framework/go/agentframework"
 )
@@ -129,20 +130,20 @@ func TestContentJSONRoundTrip(t *testing.T) {
 		},
 		{
 			name:    "ApprovalResponseContent",
-			content: &af.ApprovalResponseContent{CallID: "c2", Approved: true, Reason: "ok"},
+			content: &af.ApprovalResponseContent{CallID: "c2", Approved: true, Reason: "ok", AlwaysApproveForSession: true},
 			check: func(t *testing.T, got af.Content) {
 				ar, ok := got.(*af.ApprovalResponseContent)
 				if !ok {
 					t.Fatalf("expected *ApprovalResponseContent, got %T", got)
 				}
-				if !ar.Approved || ar.Reason != "ok" {
-					t.Errorf("Approved=%v Reason=%q", ar.Approved, ar.Reason)
+				if !ar.Approved || ar.Reason != "ok" || !ar.AlwaysApproveForSession {
+					t.Errorf("Approved=%v Reason=%q AlwaysApproveForSession=%v", ar.Approved, ar.Reason, ar.AlwaysApproveForSession)
 				}
 			},
 		},
 		{
 			name:    "HostedFileContent",
-			content: &af.HostedFileContent{FileID: "file-123"},
+			content: &af.HostedFileContent{FileID: "file-123", Size: 4096, SHA256: "abc123"},
 			check: func(t *testing.T, got af.Content) {
 				hf, ok := got.(*af.HostedFileContent)
 				if !ok {
@@ -151,6 +152,9 @@ func TestContentJSONRoundTrip(t *testing.T) {
 				if hf.FileID != "file-123" {
 					t.Errorf("FileID = %q", hf.FileID)
 				}
+				if hf.Size != 4096 || hf.SHA256 != "abc123" {
+					t.Errorf("Size=%d SHA256=%q", hf.Size, hf.SHA256)
+				}
 			},
 		},
 		{
@@ -166,6 +170,193 @@ func TestContentJSONRoundTrip(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "CitationAnnotationContent",
+			content: &af.CitationAnnotationContent{
+				Marker:    "doc1",
+				Citations: []af.Citation{{Title: "Docs", URL: "https://example.com", ChunkID: "c1"}},
+			},
+			check: func(t *testing.T, got af.Content) {
+				cc, ok := got.(*af.CitationAnnotationContent)
+				if !ok {
+					t.Fatalf("expected *CitationAnnotationContent, got %T", got)
+				}
+				if cc.Marker != "doc1" || len(cc.Citations) != 1 {
+					t.Errorf("Marker=%q Citations=%v", cc.Marker, cc.Citations)
+				}
+				if cc.Citations[0].URL != "https://example.com" {
+					t.Errorf("URL = %q", cc.Citations[0].URL)
+				}
+			},
+		},
+		{
+			name:    "StructuredDataContent",
+			content: &af.StructuredDataContent{Data: map[string]any{"rows": float64(3)}},
+			check: func(t *testing.T, got af.Content) {
+				sc, ok := got.(*af.StructuredDataContent)
+				if !ok {
+					t.Fatalf("expected *StructuredDataContent, got %T", got)
+				}
+				m, ok := sc.Data.(map[string]any)
+				if !ok || m["rows"] != float64(3) {
+					t.Errorf("Data = %v", sc.Data)
+				}
+			},
+		},
+		{
+			name:    "AudioContent",
+			content: &af.AudioContent{Data: []byte{1, 2, 3}, MediaType: "audio/wav", SampleRate: 16000, Channels: 1, Language: "en"},
+			check: func(t *testing.T, got af.Content) {
+				ac, ok := got.(*af.AudioContent)
+				if !ok {
+					t.Fatalf("expected *AudioContent, got %T", got)
+				}
+				if string(ac.Data) != "\x01\x02\x03" || ac.SampleRate != 16000 {
+					t.Errorf("Data=%v SampleRate=%d", ac.Data, ac.SampleRate)
+				}
+			},
+		},
+		{
+			name: "AudioTranscriptionContent",
+			content: &af.AudioTranscriptionContent{
+				Text:     "hello world",
+				Language: "en",
+				Segments: []af.AudioSegment{{Start: 0, End: 1.2, Text: "hello world", Confidence: 0.9}},
+			},
+			check: func(t *testing.T, got af.Content) {
+				tc, ok := got.(*af.AudioTranscriptionContent)
+				if !ok {
+					t.Fatalf("expected *AudioTranscriptionContent, got %T", got)
+				}
+				if tc.Text != "hello world" || len(tc.Segments) != 1 || tc.Segments[0].End != 1.2 {
+					t.Errorf("got = %+v", tc)
+				}
+			},
+		},
+		{
+			name:    "SpeechCallContent",
+			content: &af.SpeechCallContent{CallID: "c1", Voice: "alloy", Format: "mp3", Text: "hi there"},
+			check: func(t *testing.T, got af.Content) {
+				sc, ok := got.(*af.SpeechCallContent)
+				if !ok {
+					t.Fatalf("expected *SpeechCallContent, got %T", got)
+				}
+				if sc.Voice != "alloy" || sc.Text != "hi there" {
+					t.Errorf("got = %+v", sc)
+				}
+			},
+		},
+		{
+			name:    "SpeechResultContent",
+			content: &af.SpeechResultContent{CallID: "c1", Data: []byte{4, 5}, MediaType: "audio/mpeg"},
+			check: func(t *testing.T, got af.Content) {
+				sc, ok := got.(*af.SpeechResultContent)
+				if !ok {
+					t.Fatalf("expected *SpeechResultContent, got %T", got)
+				}
+				if string(sc.Data) != "\x04\x05" {
+					t.Errorf("Data = %v", sc.Data)
+				}
+			},
+		},
+		{
+			name: "ContentFilterResultContent",
+			content: &af.ContentFilterResultContent{
+				Hate:     af.ContentFilterCategoryResult{Severity: "low", Filtered: false},
+				Violence: af.ContentFilterCategoryResult{Severity: "high", Filtered: true, Detected: true},
+			},
+			check: func(t *testing.T, got af.Content) {
+				cf, ok := got.(*af.ContentFilterResultContent)
+				if !ok {
+					t.Fatalf("expected *ContentFilterResultContent, got %T", got)
+				}
+				if cf.Hate.Severity != "low" || cf.Hate.Filtered {
+					t.Errorf("Hate = %+v", cf.Hate)
+				}
+				if !cf.Violence.Filtered || !cf.Violence.Detected || cf.Violence.Severity != "high" {
+					t.Errorf("Violence = %+v", cf.Violence)
+				}
+			},
+		},
+		{
+			name: "ImageGenOperationContent",
+			content: &af.ImageGenOperationContent{
+				CallID:            "call-1",
+				OperationLocation: "https://example.com/operations/op-1",
+				Status:            af.OperationStatusRunning,
+				LastPolledAt:      time.Unix(1700000000, 0).UTC(),
+			},
+			check: func(t *testing.T, got af.Content) {
+				oc, ok := got.(*af.ImageGenOperationContent)
+				if !ok {
+					t.Fatalf("expected *ImageGenOperationContent, got %T", got)
+				}
+				if oc.CallID != "call-1" || oc.OperationLocation != "https://example.com/operations/op-1" || oc.Status != af.OperationStatusRunning {
+					t.Errorf("ImageGenOperationContent = %+v", oc)
+				}
+			},
+		},
+		{
+			name: "CodeInterpreterOperationContent",
+			content: &af.CodeInterpreterOperationContent{
+				CallID:            "call-2",
+				OperationLocation: "https://example.com/operations/op-2",
+				Status:            af.OperationStatusQueued,
+			},
+			check: func(t *testing.T, got af.Content) {
+				oc, ok := got.(*af.CodeInterpreterOperationContent)
+				if !ok {
+					t.Fatalf("expected *CodeInterpreterOperationContent, got %T", got)
+				}
+				if oc.CallID != "call-2" || oc.OperationLocation != "https://example.com/operations/op-2" || oc.Status != af.OperationStatusQueued {
+					t.Errorf("CodeInterpreterOperationContent = %+v", oc)
+				}
+			},
+		},
+		{
+			name:    "TextDeltaContent",
+			content: &af.TextDeltaContent{Index: 1, Text: "chunk"},
+			check: func(t *testing.T, got af.Content) {
+				td, ok := got.(*af.TextDeltaContent)
+				if !ok {
+					t.Fatalf("expected *TextDeltaContent, got %T", got)
+				}
+				if td.Index != 1 || td.Text != "chunk" {
+					t.Errorf("TextDeltaContent = %+v", td)
+				}
+			},
+		},
+		{
+			name: "FunctionCallDeltaContent",
+			content: &af.FunctionCallDeltaContent{
+				Index:          0,
+				CallID:         "call-1",
+				NameDelta:      "get_",
+				ArgumentsDelta: `{"a":`,
+			},
+			check: func(t *testing.T, got af.Content) {
+				fd, ok := got.(*af.FunctionCallDeltaContent)
+				if !ok {
+					t.Fatalf("expected *FunctionCallDeltaContent, got %T", got)
+				}
+				if fd.CallID != "call-1" || fd.NameDelta != "get_" || fd.ArgumentsDelta != `{"a":` {
+					t.Errorf("FunctionCallDeltaContent = %+v", fd)
+				}
+			},
+		},
+		{
+			name:    "UsageDeltaContent",
+			content: &af.UsageDeltaContent{PartialUsage: af.UsageDetails{OutputTokens: 5}},
+			check: func(t *testing.T, got af.Content) {
+				ud, ok := got.(*af.UsageDeltaContent)
+				if !ok {
+					t.Fatalf("expected *UsageDeltaContent, got %T", got)
+				}
+				if ud.PartialUsage.OutputTokens != 5 {
+					t.Errorf("UsageDeltaContent = %+v", ud)
+				}
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -2,7 +2,12 @@
 
 package agentframework
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+)
 
 // ContextProvider injects dynamic context into each agent invocation.
 // Implementations can supply additional instructions, messages, or tools
@@ -47,3 +52,93 @@ func (NoOpContextProvider) Invoked(_ context.Context, _, _ []Message) error {
 func (NoOpContextProvider) SessionCreated(_ context.Context, _ string) error {
 	return nil
 }
+
+// compositeContextProvider chains multiple [ContextProvider]s, merging each
+// one's [InvocationContext] in order. Used by [AgentProfile] to combine its
+// ContextProviders into the single provider [Agent.prepareMessages] expects.
+type compositeContextProvider struct {
+	providers []ContextProvider
+}
+
+func (c *compositeContextProvider) Invoking(ctx context.Context, messages []Message) (*InvocationContext, error) {
+	merged := &InvocationContext{}
+	for _, p := range c.providers {
+		ic, err := p.Invoking(ctx, messages)
+		if err != nil {
+			return nil, err
+		}
+		if ic == nil {
+			continue
+		}
+		if ic.Instructions != "" {
+			if merged.Instructions != "" {
+				merged.Instructions += "\n" + ic.Instructions
+			} else {
+				merged.Instructions = ic.Instructions
+			}
+		}
+		merged.Messages = append(merged.Messages, ic.Messages...)
+		merged.Tools = append(merged.Tools, ic.Tools...)
+	}
+	return merged, nil
+}
+
+func (c *compositeContextProvider) Invoked(ctx context.Context, request, response []Message) error {
+	for _, p := range c.providers {
+		if err := p.Invoked(ctx, request, response); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *compositeContextProvider) SessionCreated(ctx context.Context, sessionID string) error {
+	for _, p := range c.providers {
+		if err := p.SessionCreated(ctx, sessionID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FileGlobContextProvider injects the contents of files matching Globs as
+// additional instructions on every invocation — the simplest form of
+// retrieval-augmented generation: no chunking or embedding, just "always
+// include these docs." Intended for small, stable reference material
+// attached to an [AgentProfile].
+type FileGlobContextProvider struct {
+	NoOpContextProvider
+
+	// Globs are patterns passed to [filepath.Glob]; every match's contents
+	// are concatenated into the injected instructions.
+	Globs []string
+}
+
+// NewFileGlobContextProvider creates a [FileGlobContextProvider] for the
+// given glob patterns.
+func NewFileGlobContextProvider(globs ...string) *FileGlobContextProvider {
+	return &FileGlobContextProvider{Globs: globs}
+}
+
+// Invoking reads every file matched by Globs and returns their contents as
+// additional instructions.
+func (p *FileGlobContextProvider) Invoking(_ context.Context, _ []Message) (*InvocationContext, error) {
+	var combined string
+	for _, pattern := range p.Globs {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("glob %q: %w", pattern, err)
+		}
+		for _, path := range matches {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("read %q: %w", path, err)
+			}
+			combined += fmt.Sprintf("\n--- %s ---\n%s\n", path, data)
+		}
+	}
+	if combined == "" {
+		return &InvocationContext{}, nil
+	}
+	return &InvocationContext{Instructions: "Reference material:\n" + combined}, nil
+}
@@ -32,6 +32,7 @@
 //   - [Session]: manages multi-turn conversation state (service-managed or local).
 //   - [ResponseStream]: generic pull-based iterator for streaming responses.
 //   - Middleware: three levels (Agent, Chat, Function) for cross-cutting concerns.
+//   - [RunStore]: persists runs suspended for human approval of a tool call.
 //
 // # Tools
 //
@@ -64,4 +65,23 @@
 //	session := agent.NewSession()
 //	resp1, _ := agent.Run(ctx, msgs1, agentframework.WithSession(session))
 //	resp2, _ := agent.Run(ctx, msgs2, agentframework.WithSession(session))
+//
+// # Human-in-the-loop approval
+//
+// Tools created with [WithApprovalRequired] pause the run instead of being
+// auto-invoked. [Agent.Run] and [Agent.RunStream] return a resumable token
+// (via [PendingApprovalError] or a terminal update with FinishReason
+// [FinishReasonApprovalRequired]); pass the human's decisions to
+// [Agent.Resume] to continue:
+//
+//	resp, err := agent.Run(ctx, msgs)
+//	var pending *agentframework.PendingApprovalError
+//	if errors.As(err, &pending) {
+//	    resp, err = agent.Resume(ctx, pending.Token, []agentframework.ApprovalResponseContent{
+//	        {CallID: pending.Pending[0].CallID, Approved: true},
+//	    })
+//	}
+//
+// By default snapshots live in an [InMemoryRunStore]; use [WithRunStore]
+// with [NewFileRunStore] or [NewSQLRunStore] to resume from another process.
 package agentframework
@@ -51,6 +51,43 @@ var (
 
 	// ErrMiddleware is the base error for middleware failures.
 	ErrMiddleware = errors.New("middleware error")
+
+	// ErrApproval is the base error for human-in-the-loop approval failures.
+	ErrApproval = fmt.Errorf("%w: approval", ErrAgent)
+
+	// ErrProfile is the base error for [AgentProfile] / [ProfileRegistry] failures.
+	ErrProfile = fmt.Errorf("%w: profile", ErrAgent)
+
+	// ErrProfileNotFound is returned, wrapped in [ErrInitialization], by an
+	// [Agent] run when the profile named by [WithProfile] or
+	// [WithProfileOverride] was never registered. [ProfileRegistry.Get]
+	// reports a missing profile with ok == false instead.
+	ErrProfileNotFound = fmt.Errorf("%w: not found", ErrProfile)
+
+	// ErrStream is the base error for stream combinator failures.
+	ErrStream = errors.New("stream error")
+
+	// ErrStreamOverflow is returned by [BufferStream] using the [Error]
+	// overflow policy when its buffer is full.
+	ErrStreamOverflow = fmt.Errorf("%w: buffer overflow", ErrStream)
+
+	// ErrModel is the base error for [ModelCatalog]-related failures.
+	ErrModel = errors.New("model error")
+
+	// ErrModelNotFound is returned by [ValidateModelID] when the model ID
+	// has no entry in the consulted [ModelCatalog].
+	ErrModelNotFound = fmt.Errorf("%w: not found", ErrModel)
+
+	// ErrModelCapability is returned by [ValidateModelID] when the model is
+	// known but doesn't support a capability the request requires (e.g.
+	// tool calls against a model with SupportsTools false).
+	ErrModelCapability = fmt.Errorf("%w: unsupported capability", ErrModel)
+
+	// ErrFileStore is the base error for [FileStore] upload/download failures.
+	ErrFileStore = errors.New("file store error")
+
+	// ErrOperation is the base error for [Operation] polling failures.
+	ErrOperation = errors.New("operation error")
 )
 
 // ServiceError provides rich context for backend service failures.
@@ -60,6 +97,11 @@ type ServiceError struct {
 	Message    string
 	Code       string
 	Err        error
+
+	// Details carries provider-specific structured context about the
+	// failure, e.g. a [ContentFilterResultContent] when Err wraps
+	// [ErrContentFilter]. Nil when no structured detail is available.
+	Details any
 }
 
 func (e *ServiceError) Error() string {
@@ -83,3 +125,20 @@ func (e *ToolError) Error() string {
 }
 
 func (e *ToolError) Unwrap() error { return e.Err }
+
+// PendingApprovalError is returned by [Agent.Run] when the tool-calling loop
+// encounters a tool whose [Tool.Approval] is [ApprovalAlways]. Token
+// identifies the suspended run; pass it, together with the human's
+// [ApprovalResponseContent] for each pending call, to [Agent.Resume] to
+// continue execution.
+type PendingApprovalError struct {
+	Token   string
+	Pending []ApprovalRequestContent
+	Err     error
+}
+
+func (e *PendingApprovalError) Error() string {
+	return fmt.Sprintf("run %s paused: %d approval(s) pending", e.Token, len(e.Pending))
+}
+
+func (e *PendingApprovalError) Unwrap() error { return e.Err }
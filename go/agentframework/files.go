@@ -0,0 +1,255 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package agentframework
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// UploadOptions carries metadata for a single [FileStore.Upload] call.
+type UploadOptions struct {
+	Filename  string
+	MediaType string
+}
+
+// FileStore uploads and downloads bytes to/from a hosted file backend
+// (e.g. a provider's Files API), producing and consuming the FileID
+// referenced by [HostedFileContent].
+type FileStore interface {
+	// Upload streams r to the backend and returns the assigned file ID.
+	Upload(ctx context.Context, r io.Reader, opts UploadOptions) (fileID string, err error)
+
+	// Download writes the named file's full contents into w.
+	Download(ctx context.Context, fileID string, w io.WriterAt) error
+}
+
+// ChunkedFileStore is implemented by a [FileStore] that accepts a file as
+// independently-uploadable parts. [UploadInParallel] uses it to fan out
+// chunk uploads concurrently instead of streaming through Upload serially;
+// a store that doesn't implement it still works with [UploadInParallel],
+// just without parallelism.
+type ChunkedFileStore interface {
+	FileStore
+
+	// BeginUpload starts a new chunked upload and returns an opaque ID
+	// used to address its parts and, later, to commit it.
+	BeginUpload(ctx context.Context, opts UploadOptions) (uploadID string, err error)
+
+	// UploadPart uploads the partIndex'th (0-based) part of uploadID. Parts
+	// may be uploaded concurrently and out of order.
+	UploadPart(ctx context.Context, uploadID string, partIndex int, r io.Reader) error
+
+	// CommitUpload finalizes an upload once all partCount parts have
+	// succeeded, and returns the assigned file ID.
+	CommitUpload(ctx context.Context, uploadID string, partCount int) (fileID string, err error)
+}
+
+// RangedFileStore is implemented by a [FileStore] that supports downloading
+// a byte range of a file. [DownloadInParallel] uses it to fan out ranged
+// reads concurrently instead of streaming through Download serially; a
+// store that doesn't implement it still works with [DownloadInParallel],
+// just without parallelism.
+type RangedFileStore interface {
+	FileStore
+
+	// DownloadRange writes length bytes of fileID starting at offset into w.
+	DownloadRange(ctx context.Context, fileID string, offset, length int64, w io.WriterAt) error
+}
+
+// ParallelOptions configures [UploadInParallel] and [DownloadInParallel].
+// The zero value is valid: a zero BlockSize or Parallelism falls back to
+// its default, and a zero MaxRetries means no retries.
+type ParallelOptions struct {
+	// BlockSize is the chunk size in bytes. Defaults to 4 MiB.
+	BlockSize int64
+
+	// Parallelism is the maximum number of chunks in flight at once.
+	// Defaults to 4.
+	Parallelism int
+
+	// MaxRetries is the number of additional attempts made for a chunk
+	// that fails, beyond the first. Zero means a single attempt; a
+	// negative value selects the default of 2 (3 attempts total).
+	MaxRetries int
+}
+
+const (
+	defaultBlockSize   int64 = 4 << 20 // 4 MiB
+	defaultParallelism       = 4
+	defaultMaxRetries        = 2
+)
+
+func (o ParallelOptions) withDefaults() ParallelOptions {
+	if o.BlockSize <= 0 {
+		o.BlockSize = defaultBlockSize
+	}
+	if o.Parallelism <= 0 {
+		o.Parallelism = defaultParallelism
+	}
+	if o.MaxRetries < 0 {
+		o.MaxRetries = defaultMaxRetries
+	}
+	return o
+}
+
+// chunkBounds returns the [offset, offset+length) bounds of each chunk
+// needed to cover size bytes at blockSize each.
+func chunkBounds(size, blockSize int64) [][2]int64 {
+	var chunks [][2]int64
+	for offset := int64(0); offset < size; offset += blockSize {
+		length := blockSize
+		if remaining := size - offset; remaining < length {
+			length = remaining
+		}
+		chunks = append(chunks, [2]int64{offset, length})
+	}
+	return chunks
+}
+
+// UploadInParallel uploads size bytes read from r through store, splitting
+// the input into ParallelOptions.BlockSize-sized chunks and fanning out up
+// to ParallelOptions.Parallelism concurrent chunk uploads, retrying each
+// chunk up to ParallelOptions.MaxRetries times on failure. If store doesn't
+// implement [ChunkedFileStore], it falls back to a single call to
+// store.Upload.
+func UploadInParallel(ctx context.Context, store FileStore, r io.ReaderAt, size int64, opts UploadOptions, popts ParallelOptions) (string, error) {
+	cs, ok := store.(ChunkedFileStore)
+	if !ok {
+		return store.Upload(ctx, io.NewSectionReader(r, 0, size), opts)
+	}
+	popts = popts.withDefaults()
+
+	uploadID, err := cs.BeginUpload(ctx, opts)
+	if err != nil {
+		return "", fmt.Errorf("%w: begin upload: %w", ErrFileStore, err)
+	}
+
+	chunks := chunkBounds(size, popts.BlockSize)
+
+	var (
+		wg       sync.WaitGroup
+		sem      = make(chan struct{}, popts.Parallelism)
+		mu       sync.Mutex
+		firstErr error
+	)
+	groupCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	for i, bounds := range chunks {
+		if groupCtx.Err() != nil {
+			break
+		}
+		i, offset, length := i, bounds[0], bounds[1]
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			err := withRetry(groupCtx, popts.MaxRetries, func() error {
+				return cs.UploadPart(groupCtx, uploadID, i, io.NewSectionReader(r, offset, length))
+			})
+			if err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = fmt.Errorf("%w: upload part %d: %w", ErrFileStore, i, err)
+					cancel()
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return "", firstErr
+	}
+
+	fileID, err := cs.CommitUpload(ctx, uploadID, len(chunks))
+	if err != nil {
+		return "", fmt.Errorf("%w: commit upload: %w", ErrFileStore, err)
+	}
+	return fileID, nil
+}
+
+// DownloadInParallel downloads size bytes of fileID from store into w,
+// splitting the range into ParallelOptions.BlockSize-sized chunks and
+// fanning out up to ParallelOptions.Parallelism concurrent ranged reads,
+// retrying each chunk up to ParallelOptions.MaxRetries times on failure.
+// If store doesn't implement [RangedFileStore], it falls back to a single
+// call to store.Download.
+func DownloadInParallel(ctx context.Context, store FileStore, fileID string, size int64, w io.WriterAt, popts ParallelOptions) error {
+	rs, ok := store.(RangedFileStore)
+	if !ok {
+		return store.Download(ctx, fileID, w)
+	}
+	popts = popts.withDefaults()
+
+	chunks := chunkBounds(size, popts.BlockSize)
+
+	var (
+		wg       sync.WaitGroup
+		sem      = make(chan struct{}, popts.Parallelism)
+		mu       sync.Mutex
+		firstErr error
+	)
+	groupCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	for _, bounds := range chunks {
+		if groupCtx.Err() != nil {
+			break
+		}
+		offset, length := bounds[0], bounds[1]
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			err := withRetry(groupCtx, popts.MaxRetries, func() error {
+				return rs.DownloadRange(groupCtx, fileID, offset, length, w)
+			})
+			if err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = fmt.Errorf("%w: download range [%d,%d): %w", ErrFileStore, offset, offset+length, err)
+					cancel()
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	return firstErr
+}
+
+// withRetry calls fn, retrying up to maxRetries additional times
+// (exponential backoff starting at 200ms) if it returns an error, unless
+// ctx is done first.
+func withRetry(ctx context.Context, maxRetries int, fn func() error) error {
+	var err error
+	delay := 200 * time.Millisecond
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		if err = fn(); err == nil {
+			return nil
+		}
+		if attempt == maxRetries {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+	return err
+}
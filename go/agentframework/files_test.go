@@ -0,0 +1,230 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package agentframework_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+// memChunkedStore is an in-memory [af.ChunkedFileStore] / [af.RangedFileStore]
+// for testing [af.UploadInParallel] and [af.DownloadInParallel]. failParts, if
+// set, makes UploadPart fail the first time it's called for the given part
+// index, and DownloadRange the first time it's called for the given byte
+// offset, to exercise retry.
+type memChunkedStore struct {
+	mu    sync.Mutex
+	files map[string][]byte
+	parts map[string]map[int][]byte
+
+	failParts       map[int]bool
+	failedOnce      map[int]bool
+	alwaysFailParts map[int]bool
+}
+
+func newMemChunkedStore() *memChunkedStore {
+	return &memChunkedStore{
+		files:           make(map[string][]byte),
+		parts:           make(map[string]map[int][]byte),
+		failParts:       make(map[int]bool),
+		failedOnce:      make(map[int]bool),
+		alwaysFailParts: make(map[int]bool),
+	}
+}
+
+func (s *memChunkedStore) Upload(_ context.Context, r io.Reader, _ af.UploadOptions) (string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := "file-single"
+	s.files[id] = data
+	return id, nil
+}
+
+func (s *memChunkedStore) Download(_ context.Context, fileID string, w io.WriterAt) error {
+	s.mu.Lock()
+	data, ok := s.files[fileID]
+	s.mu.Unlock()
+	if !ok {
+		return errors.New("not found")
+	}
+	_, err := w.WriteAt(data, 0)
+	return err
+}
+
+func (s *memChunkedStore) BeginUpload(_ context.Context, _ af.UploadOptions) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := "upload-1"
+	s.parts[id] = make(map[int][]byte)
+	return id, nil
+}
+
+func (s *memChunkedStore) UploadPart(_ context.Context, uploadID string, partIndex int, r io.Reader) error {
+	s.mu.Lock()
+	shouldFail := s.alwaysFailParts[partIndex] || (s.failParts[partIndex] && !s.failedOnce[partIndex])
+	if shouldFail {
+		s.failedOnce[partIndex] = true
+	}
+	s.mu.Unlock()
+	if shouldFail {
+		return errors.New("transient upload error")
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.parts[uploadID][partIndex] = data
+	return nil
+}
+
+func (s *memChunkedStore) CommitUpload(_ context.Context, uploadID string, partCount int) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	parts := s.parts[uploadID]
+	if len(parts) != partCount {
+		return "", errors.New("missing parts")
+	}
+	var data []byte
+	for i := 0; i < partCount; i++ {
+		data = append(data, parts[i]...)
+	}
+	id := "file-committed"
+	s.files[id] = data
+	return id, nil
+}
+
+func (s *memChunkedStore) DownloadRange(_ context.Context, fileID string, offset, length int64, w io.WriterAt) error {
+	s.mu.Lock()
+	shouldFail := s.failParts[int(offset)] && !s.failedOnce[int(offset)]
+	if shouldFail {
+		s.failedOnce[int(offset)] = true
+	}
+	data, ok := s.files[fileID]
+	s.mu.Unlock()
+	if shouldFail {
+		return errors.New("transient download error")
+	}
+	if !ok {
+		return errors.New("not found")
+	}
+	_, err := w.WriteAt(data[offset:offset+length], offset)
+	return err
+}
+
+var (
+	_ af.ChunkedFileStore = (*memChunkedStore)(nil)
+	_ af.RangedFileStore  = (*memChunkedStore)(nil)
+)
+
+func TestUploadInParallel_SplitsIntoChunksAndCommits(t *testing.T) {
+	store := newMemChunkedStore()
+	data := bytes.Repeat([]byte("a"), 10)
+
+	fileID, err := af.UploadInParallel(context.Background(), store, bytes.NewReader(data), int64(len(data)), af.UploadOptions{Filename: "x"}, af.ParallelOptions{BlockSize: 3, Parallelism: 2})
+	if err != nil {
+		t.Fatalf("UploadInParallel: %v", err)
+	}
+	if fileID != "file-committed" {
+		t.Errorf("fileID = %q, want file-committed", fileID)
+	}
+	if got := store.files[fileID]; !bytes.Equal(got, data) {
+		t.Errorf("uploaded content = %q, want %q", got, data)
+	}
+}
+
+func TestUploadInParallel_RetriesFailedChunk(t *testing.T) {
+	store := newMemChunkedStore()
+	store.failParts[1] = true
+	data := bytes.Repeat([]byte("b"), 10)
+
+	fileID, err := af.UploadInParallel(context.Background(), store, bytes.NewReader(data), int64(len(data)), af.UploadOptions{}, af.ParallelOptions{BlockSize: 3, Parallelism: 2, MaxRetries: 1})
+	if err != nil {
+		t.Fatalf("UploadInParallel: %v", err)
+	}
+	if got := store.files[fileID]; !bytes.Equal(got, data) {
+		t.Errorf("uploaded content = %q, want %q", got, data)
+	}
+}
+
+func TestUploadInParallel_FallsBackWhenNotChunked(t *testing.T) {
+	store := &singleShotStore{files: make(map[string][]byte)}
+	data := []byte("hello world")
+
+	fileID, err := af.UploadInParallel(context.Background(), store, bytes.NewReader(data), int64(len(data)), af.UploadOptions{}, af.ParallelOptions{})
+	if err != nil {
+		t.Fatalf("UploadInParallel: %v", err)
+	}
+	if !bytes.Equal(store.files[fileID], data) {
+		t.Errorf("uploaded content = %q, want %q", store.files[fileID], data)
+	}
+}
+
+func TestDownloadInParallel_SplitsIntoRanges(t *testing.T) {
+	store := newMemChunkedStore()
+	data := bytes.Repeat([]byte("c"), 10)
+	store.files["file-1"] = data
+
+	var buf bytes.Buffer
+	buf.Write(make([]byte, len(data)))
+	err := af.DownloadInParallel(context.Background(), store, "file-1", int64(len(data)), &offsetWriter{buf: &buf}, af.ParallelOptions{BlockSize: 3, Parallelism: 2})
+	if err != nil {
+		t.Fatalf("DownloadInParallel: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), data) {
+		t.Errorf("downloaded = %q, want %q", buf.Bytes(), data)
+	}
+}
+
+func TestUploadInParallel_FailsAfterExhaustingRetries(t *testing.T) {
+	store := newMemChunkedStore()
+	store.alwaysFailParts[0] = true
+	data := bytes.Repeat([]byte("d"), 6)
+
+	_, err := af.UploadInParallel(context.Background(), store, bytes.NewReader(data), int64(len(data)), af.UploadOptions{}, af.ParallelOptions{BlockSize: 2, Parallelism: 2, MaxRetries: 0})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if !errors.Is(err, af.ErrFileStore) {
+		t.Errorf("error = %v, want wrapping ErrFileStore", err)
+	}
+}
+
+type singleShotStore struct {
+	files map[string][]byte
+}
+
+func (s *singleShotStore) Upload(_ context.Context, r io.Reader, _ af.UploadOptions) (string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	s.files["file-single"] = data
+	return "file-single", nil
+}
+
+func (s *singleShotStore) Download(_ context.Context, fileID string, w io.WriterAt) error {
+	_, err := w.WriteAt(s.files[fileID], 0)
+	return err
+}
+
+type offsetWriter struct {
+	buf *bytes.Buffer
+}
+
+func (w *offsetWriter) WriteAt(p []byte, off int64) (int, error) {
+	copy(w.buf.Bytes()[off:], p)
+	return len(p), nil
+}
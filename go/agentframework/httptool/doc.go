@@ -0,0 +1,27 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+// Package httptool wraps remote HTTP endpoints as [agentframework.Tool]
+// values, so external systems (webhook-style automations, internal
+// microservices) can be wired into an [agentframework.Agent] without
+// per-endpoint Go glue.
+//
+// Use [NewHTTPTool] to wrap a single endpoint with an explicit JSON
+// schema:
+//
+//	tool := httptool.NewHTTPTool("get_weather", "Look up current weather",
+//	    "https://api.example.com/weather?city={city}", schema,
+//	    httptool.WithAuth(httptool.BearerAuth(token)),
+//	)
+//
+// or [NewOpenAPITool] to derive one tool per operation from an OpenAPI 3
+// document:
+//
+//	tools, err := httptool.NewOpenAPITool("https://api.example.com", doc,
+//	    httptool.WithAuth(httptool.BearerAuth(token)),
+//	)
+//
+// Then pass the result to [agentframework.WithTools]. Non-2xx responses
+// surface as an [agentframework.ToolError] wrapping
+// [agentframework.ErrToolExecution], carrying the status code and a
+// truncated response body.
+package httptool
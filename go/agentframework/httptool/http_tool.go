@@ -0,0 +1,263 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package httptool
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+// maxBodyLog bounds how much of a response body is echoed back in a
+// [af.ToolError.Message] on a non-2xx response.
+const maxBodyLog = 2048
+
+// defaultTimeout bounds an HTTP tool call that doesn't set [WithTimeout].
+const defaultTimeout = 30 * time.Second
+
+// Auth injects credentials into an outgoing request. Returned by
+// [BearerAuth], [BasicAuth], and [HeaderAuth], or implement it directly for
+// a custom scheme.
+type Auth func(req *http.Request)
+
+// BearerAuth sets the Authorization header to "Bearer <token>".
+func BearerAuth(token string) Auth {
+	return func(req *http.Request) { req.Header.Set("Authorization", "Bearer "+token) }
+}
+
+// BasicAuth sets HTTP basic auth credentials.
+func BasicAuth(username, password string) Auth {
+	return func(req *http.Request) { req.SetBasicAuth(username, password) }
+}
+
+// HeaderAuth injects an arbitrary static header, e.g. an API key carried
+// outside the Authorization header: HeaderAuth("X-Api-Key", key).
+func HeaderAuth(name, value string) Auth {
+	return func(req *http.Request) { req.Header.Set(name, value) }
+}
+
+// config holds the shared, per-tool-settable options for [NewHTTPTool] and
+// [NewOpenAPITool].
+type config struct {
+	client   *http.Client
+	auth     Auth
+	headers  map[string]string
+	timeout  time.Duration
+	redactFn func([]byte) []byte
+	method   string
+}
+
+// Option configures an HTTP-backed tool.
+type Option func(*config)
+
+// WithMethod sets the HTTP method for [NewHTTPTool]. Defaults to GET.
+// Ignored by [NewOpenAPITool], which takes the method from the OpenAPI
+// document instead.
+func WithMethod(method string) Option {
+	return func(c *config) { c.method = method }
+}
+
+// WithAuth injects credentials into every outgoing request.
+func WithAuth(auth Auth) Option {
+	return func(c *config) { c.auth = auth }
+}
+
+// WithHeader adds a static header to every outgoing request.
+func WithHeader(name, value string) Option {
+	return func(c *config) {
+		if c.headers == nil {
+			c.headers = make(map[string]string)
+		}
+		c.headers[name] = value
+	}
+}
+
+// WithTimeout bounds how long a single call may take. Defaults to 30s.
+func WithTimeout(d time.Duration) Option {
+	return func(c *config) { c.timeout = d }
+}
+
+// WithHTTPClient overrides the [http.Client] used to make requests.
+// Defaults to a client constructed with [WithTimeout]'s duration.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *config) { c.client = client }
+}
+
+// WithRedactor sets a hook that transforms a request/response body before
+// it is included in debug logging, so secrets embedded in payloads (API
+// keys, PII) never reach logs unredacted. Does not affect the body used
+// for the actual HTTP call or for [af.ToolError] messages.
+func WithRedactor(fn func([]byte) []byte) Option {
+	return func(c *config) { c.redactFn = fn }
+}
+
+func newConfig(opts ...Option) *config {
+	c := &config{timeout: defaultTimeout}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.client == nil {
+		c.client = &http.Client{Timeout: c.timeout}
+	}
+	return c
+}
+
+// httpTool invokes a single HTTP endpoint, substituting JSON arguments into
+// a URL template and (for methods that carry one) a JSON request body.
+type httpTool struct {
+	name        string
+	description string
+	parameters  json.RawMessage
+	urlTemplate string
+	cfg         *config
+}
+
+// NewHTTPTool wraps a single HTTP endpoint as an [af.Tool]. urlTemplate may
+// reference argument names as "{name}", substituted with the argument's
+// value URL-escaped; method defaults to GET, override with [WithMethod].
+// parameters is the JSON Schema describing the tool's arguments, as passed
+// to [af.NewTool].
+//
+// For methods other than GET and HEAD, any argument not consumed by a
+// "{name}" placeholder in urlTemplate is sent as a field in a JSON request
+// body.
+func NewHTTPTool(name, description, urlTemplate string, parameters json.RawMessage, opts ...Option) af.Tool {
+	return &httpTool{
+		name:        name,
+		description: description,
+		parameters:  parameters,
+		urlTemplate: urlTemplate,
+		cfg:         newConfig(opts...),
+	}
+}
+
+func (t *httpTool) Name() string                    { return t.name }
+func (t *httpTool) Description() string             { return t.description }
+func (t *httpTool) Parameters() json.RawMessage     { return t.parameters }
+func (t *httpTool) DeclarationOnly() bool           { return false }
+func (t *httpTool) Approval() af.ApprovalMode       { return af.ApprovalNever }
+func (t *httpTool) Concurrency() af.ConcurrencyMode { return af.ConcurrencyParallel }
+
+func (t *httpTool) Invoke(ctx context.Context, args json.RawMessage) (any, error) {
+	var values map[string]any
+	if len(args) > 0 {
+		if err := json.Unmarshal(args, &values); err != nil {
+			return nil, &af.ToolError{ToolName: t.name, Message: "invalid arguments: " + err.Error(), Err: af.ErrToolExecution}
+		}
+	}
+
+	resolvedURL, consumed := substitutePlaceholders(t.urlTemplate, values)
+
+	ctx, cancel := context.WithTimeout(ctx, t.cfg.timeout)
+	defer cancel()
+
+	var bodyReader io.Reader
+	method := t.cfg.method
+	if method == "" {
+		method = http.MethodGet
+	}
+	if method != http.MethodGet && method != http.MethodHead {
+		remaining := make(map[string]any, len(values))
+		for k, v := range values {
+			if !consumed[k] {
+				remaining[k] = v
+			}
+		}
+		body, err := json.Marshal(remaining)
+		if err != nil {
+			return nil, &af.ToolError{ToolName: t.name, Message: "encode request body: " + err.Error(), Err: af.ErrToolExecution}
+		}
+		bodyReader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, resolvedURL, bodyReader)
+	if err != nil {
+		return nil, &af.ToolError{ToolName: t.name, Message: "build request: " + err.Error(), Err: af.ErrToolExecution}
+	}
+	if bodyReader != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for k, v := range t.cfg.headers {
+		req.Header.Set(k, v)
+	}
+	if t.cfg.auth != nil {
+		t.cfg.auth(req)
+	}
+
+	if bodyReader != nil {
+		body, _ := req.GetBody()
+		raw, _ := io.ReadAll(body)
+		slog.DebugContext(ctx, "httptool request", "tool", t.name, "method", method, "url", resolvedURL, "body", string(redact(t.cfg, raw)))
+	} else {
+		slog.DebugContext(ctx, "httptool request", "tool", t.name, "method", method, "url", resolvedURL)
+	}
+
+	resp, err := t.cfg.client.Do(req)
+	if err != nil {
+		return nil, &af.ToolError{ToolName: t.name, Message: "request failed: " + err.Error(), Err: af.ErrToolExecution}
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, &af.ToolError{ToolName: t.name, Message: "read response: " + err.Error(), Err: af.ErrToolExecution}
+	}
+	slog.DebugContext(ctx, "httptool response", "tool", t.name, "status", resp.StatusCode, "body", string(redact(t.cfg, respBody)))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, &af.ToolError{
+			ToolName: t.name,
+			Message:  fmt.Sprintf("http %d: %s", resp.StatusCode, truncate(respBody, maxBodyLog)),
+			Err:      af.ErrToolExecution,
+		}
+	}
+
+	var decoded any
+	if err := json.Unmarshal(respBody, &decoded); err != nil {
+		return string(respBody), nil
+	}
+	return decoded, nil
+}
+
+// substitutePlaceholders replaces every "{name}" in tmpl with the
+// URL-escaped value of values[name], and reports which names were
+// consumed this way.
+func substitutePlaceholders(tmpl string, values map[string]any) (string, map[string]bool) {
+	consumed := make(map[string]bool)
+	result := tmpl
+	for name, val := range values {
+		placeholder := "{" + name + "}"
+		if !strings.Contains(result, placeholder) {
+			continue
+		}
+		result = strings.ReplaceAll(result, placeholder, url.PathEscape(fmt.Sprint(val)))
+		consumed[name] = true
+	}
+	return result, consumed
+}
+
+// truncate returns s capped to n bytes, appending an elision marker if it
+// was cut.
+func truncate(b []byte, n int) string {
+	if len(b) <= n {
+		return string(b)
+	}
+	return string(b[:n]) + "...(truncated)"
+}
+
+// redact applies cfg's redactor, if any, for use in debug logging only.
+func redact(cfg *config, body []byte) []byte {
+	if cfg.redactFn == nil {
+		return body
+	}
+	return cfg.redactFn(body)
+}
@@ -0,0 +1,156 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package httptool_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+	"github.com/microsoft/agent-framework/go/agentframework/httptool"
+)
+
+func TestNewHTTPTool_GetSubstitutesPathAndAuth(t *testing.T) {
+	var gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		if r.URL.Path != "/weather/paris" {
+			t.Errorf("path = %q, want /weather/paris", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(map[string]any{"temp_c": 18})
+	}))
+	defer server.Close()
+
+	tool := httptool.NewHTTPTool("get_weather", "look up weather", server.URL+"/weather/{city}",
+		json.RawMessage(`{"type":"object","properties":{"city":{"type":"string"}}}`),
+		httptool.WithAuth(httptool.BearerAuth("secret-token")),
+	)
+
+	result, err := tool.Invoke(context.Background(), json.RawMessage(`{"city":"paris"}`))
+	if err != nil {
+		t.Fatalf("Invoke: %v", err)
+	}
+	if gotAuth != "Bearer secret-token" {
+		t.Errorf("Authorization = %q", gotAuth)
+	}
+	m, ok := result.(map[string]any)
+	if !ok || m["temp_c"] != float64(18) {
+		t.Errorf("result = %#v", result)
+	}
+}
+
+func TestNewHTTPTool_PostSendsUnconsumedArgsAsBody(t *testing.T) {
+	var gotBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&gotBody)
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]any{"id": "order-1"})
+	}))
+	defer server.Close()
+
+	tool := httptool.NewHTTPTool("create_order", "create an order", server.URL+"/orders",
+		json.RawMessage(`{"type":"object","properties":{"item":{"type":"string"},"qty":{"type":"integer"}}}`),
+		httptool.WithMethod(http.MethodPost),
+	)
+
+	_, err := tool.Invoke(context.Background(), json.RawMessage(`{"item":"widget","qty":3}`))
+	if err != nil {
+		t.Fatalf("Invoke: %v", err)
+	}
+	if gotBody["item"] != "widget" || gotBody["qty"] != float64(3) {
+		t.Errorf("body = %#v", gotBody)
+	}
+}
+
+func TestNewHTTPTool_NonSuccessStatusSurfacesToolError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("no such resource"))
+	}))
+	defer server.Close()
+
+	tool := httptool.NewHTTPTool("get_thing", "get a thing", server.URL+"/thing", nil)
+
+	_, err := tool.Invoke(context.Background(), nil)
+	if !errors.Is(err, af.ErrToolExecution) {
+		t.Fatalf("err = %v, want ErrToolExecution", err)
+	}
+	var toolErr *af.ToolError
+	if !errors.As(err, &toolErr) || toolErr.ToolName != "get_thing" {
+		t.Fatalf("err = %#v", err)
+	}
+}
+
+func TestNewOpenAPITool_DerivesOneToolPerOperation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/users/42" {
+			t.Errorf("path = %q, want /users/42", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(map[string]any{"id": "42", "name": "ada"})
+	}))
+	defer server.Close()
+
+	doc := httptool.OpenAPIDocument{
+		Paths: map[string]map[string]httptool.OpenAPIOperation{
+			"/users/{id}": {
+				"get": {
+					OperationID: "get_user",
+					Description: "fetch a user by id",
+					Parameters: []httptool.OpenAPIParameter{
+						{Name: "id", In: "path", Required: true, Schema: map[string]any{"type": "string"}},
+					},
+				},
+			},
+		},
+	}
+
+	tools, err := httptool.NewOpenAPITool(server.URL, doc)
+	if err != nil {
+		t.Fatalf("NewOpenAPITool: %v", err)
+	}
+	if len(tools) != 1 || tools[0].Name() != "get_user" {
+		t.Fatalf("tools = %#v", tools)
+	}
+
+	result, err := tools[0].Invoke(context.Background(), json.RawMessage(`{"id":"42"}`))
+	if err != nil {
+		t.Fatalf("Invoke: %v", err)
+	}
+	m, ok := result.(map[string]any)
+	if !ok || m["id"] != "42" {
+		t.Errorf("result = %#v", result)
+	}
+}
+
+func TestNewOpenAPITool_NoOperationsReturnsError(t *testing.T) {
+	_, err := httptool.NewOpenAPITool("https://api.example.com", httptool.OpenAPIDocument{})
+	if !errors.Is(err, httptool.ErrNoOperations) {
+		t.Fatalf("err = %v, want ErrNoOperations", err)
+	}
+}
+
+func TestNewOpenAPITool_UnmarshalableParameterSchemaReturnsError(t *testing.T) {
+	doc := httptool.OpenAPIDocument{
+		Paths: map[string]map[string]httptool.OpenAPIOperation{
+			"/users/{id}": {
+				"get": {
+					OperationID: "get_user",
+					Parameters: []httptool.OpenAPIParameter{
+						// A func value can't be json.Marshal'd, so the
+						// derived schema for this operation can't be built.
+						{Name: "id", In: "path", Required: true, Schema: map[string]any{"type": func() {}}},
+					},
+				},
+			},
+		},
+	}
+
+	_, err := httptool.NewOpenAPITool("https://api.example.com", doc)
+	if err == nil {
+		t.Fatal("NewOpenAPITool: want error, got nil")
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package httptool
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+// ErrNoOperations is returned by [NewOpenAPITool] when doc declares no
+// operations at all.
+var ErrNoOperations = errors.New("httptool: OpenAPI document has no operations")
+
+// OpenAPIDocument is the minimal subset of an OpenAPI 3 document
+// [NewOpenAPITool] needs: the set of paths and, per path, the operations
+// keyed by lowercase HTTP method ("get", "post", ...).
+type OpenAPIDocument struct {
+	Paths map[string]map[string]OpenAPIOperation `json:"paths"`
+}
+
+// OpenAPIOperation describes a single OpenAPI operation.
+type OpenAPIOperation struct {
+	OperationID string              `json:"operationId"`
+	Summary     string              `json:"summary"`
+	Description string              `json:"description"`
+	Parameters  []OpenAPIParameter  `json:"parameters"`
+	RequestBody *OpenAPIRequestBody `json:"requestBody"`
+}
+
+// OpenAPIParameter describes a path, query, or header parameter.
+type OpenAPIParameter struct {
+	Name        string         `json:"name"`
+	In          string         `json:"in"` // "path", "query", or "header"
+	Description string         `json:"description"`
+	Required    bool           `json:"required"`
+	Schema      map[string]any `json:"schema"`
+}
+
+// OpenAPIRequestBody describes an operation's request body, keyed by media
+// type (only "application/json" is used).
+type OpenAPIRequestBody struct {
+	Content map[string]OpenAPIMediaType `json:"content"`
+}
+
+// OpenAPIMediaType carries the JSON Schema for one request body media type.
+type OpenAPIMediaType struct {
+	Schema map[string]any `json:"schema"`
+}
+
+// NewOpenAPITool derives one [af.Tool] per operation in doc, each making
+// its call against baseURL+path. A tool's name is its operationId, or
+// "<method>_<path>" if unset; its JSON Schema arguments are assembled from
+// the operation's parameters and, for a JSON request body, its schema
+// properties. opts apply to every derived tool (shared auth, headers,
+// timeout, and so on); a [WithMethod] passed here is ignored, since each
+// tool's method comes from doc instead.
+func NewOpenAPITool(baseURL string, doc OpenAPIDocument, opts ...Option) ([]af.Tool, error) {
+	var tools []af.Tool
+	for path, methods := range doc.Paths {
+		for method, op := range methods {
+			name := op.OperationID
+			if name == "" {
+				name = strings.ToLower(method) + "_" + sanitizePath(path)
+			}
+
+			parameters, err := buildOperationSchema(op)
+			if err != nil {
+				return nil, fmt.Errorf("httptool: operation %q: %w", name, err)
+			}
+			urlTemplate := baseURL + path
+
+			description := op.Description
+			if description == "" {
+				description = op.Summary
+			}
+
+			toolOpts := append(append([]Option{}, opts...), WithMethod(strings.ToUpper(method)))
+			tools = append(tools, NewHTTPTool(name, description, urlTemplate, parameters, toolOpts...))
+		}
+	}
+	if len(tools) == 0 {
+		return nil, ErrNoOperations
+	}
+	return tools, nil
+}
+
+// buildOperationSchema assembles a JSON Schema object for op's parameters
+// and JSON request body. Path parameters need no special handling here:
+// the OpenAPI spec already writes them as "{name}" in the path, matching
+// [NewHTTPTool]'s own placeholder syntax.
+func buildOperationSchema(op OpenAPIOperation) (json.RawMessage, error) {
+	properties := make(map[string]any)
+	var required []string
+
+	for _, p := range op.Parameters {
+		schema := p.Schema
+		if schema == nil {
+			schema = map[string]any{"type": "string"}
+		}
+		if p.Description != "" {
+			schema = withDescription(schema, p.Description)
+		}
+		properties[p.Name] = schema
+		if p.Required {
+			required = append(required, p.Name)
+		}
+	}
+
+	if op.RequestBody != nil {
+		if media, ok := op.RequestBody.Content["application/json"]; ok {
+			if bodyProps, ok := media.Schema["properties"].(map[string]any); ok {
+				for name, schema := range bodyProps {
+					properties[name] = schema
+				}
+				if bodyRequired, ok := media.Schema["required"].([]any); ok {
+					for _, r := range bodyRequired {
+						if name, ok := r.(string); ok {
+							required = append(required, name)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	schema := map[string]any{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+
+	raw, err := json.Marshal(schema)
+	if err != nil {
+		// properties/required are built from plain maps and slices above,
+		// so this can only fail if a caller's Schema contains an
+		// unmarshalable value (e.g. a channel or func).
+		return nil, fmt.Errorf("marshal derived schema: %w", err)
+	}
+	return raw, nil
+}
+
+func withDescription(schema map[string]any, description string) map[string]any {
+	if _, ok := schema["description"]; ok {
+		return schema
+	}
+	withDesc := make(map[string]any, len(schema)+1)
+	for k, v := range schema {
+		withDesc[k] = v
+	}
+	withDesc["description"] = description
+	return withDesc
+}
+
+// sanitizePath turns an OpenAPI path template into a name-safe fragment,
+// e.g. "/users/{id}" -> "users_id".
+func sanitizePath(path string) string {
+	replacer := strings.NewReplacer("/", "_", "{", "", "}", "")
+	return strings.Trim(replacer.Replace(path), "_")
+}
@@ -0,0 +1,238 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package agentframework
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// IdempotencyStore persists [ChatResponse]s keyed by an idempotency key, so
+// [IdempotencyMiddleware] can serve a cached response instead of re-issuing
+// an identical request to the model.
+type IdempotencyStore interface {
+	// Get returns the cached response for key, if present and unexpired.
+	Get(ctx context.Context, key string) (*ChatResponse, bool, error)
+
+	// Put stores resp under key, to expire after ttl from now. A zero ttl
+	// means the entry never expires.
+	Put(ctx context.Context, key string, resp *ChatResponse, ttl time.Duration) error
+}
+
+// idempotencyConfig holds [IdempotencyMiddleware]'s resolved options.
+type idempotencyConfig struct {
+	ttl  time.Duration
+	skip bool
+}
+
+// IdempotencyOption configures [IdempotencyMiddleware].
+type IdempotencyOption func(*idempotencyConfig)
+
+// WithIdempotencyTTL overrides how long a cached response remains valid.
+// The default is 24 hours.
+func WithIdempotencyTTL(ttl time.Duration) IdempotencyOption {
+	return func(c *idempotencyConfig) { c.ttl = ttl }
+}
+
+// SkipIdempotency disables the middleware entirely. Use it when building a
+// shared middleware chain that should only cache some call sites — e.g. one
+// used for both retry-safe batch calls and interactive streaming requests
+// that must never serve a stale cached answer.
+func SkipIdempotency() IdempotencyOption {
+	return func(c *idempotencyConfig) { c.skip = true }
+}
+
+// IdempotencyMiddleware returns a [ChatMiddleware] that caches [ChatResponse]s
+// in store. Each request is keyed by an explicit key set via
+// [WithIdempotencyKey] on ctx, or failing that by a stable hash of the
+// messages, [ChatOptions], and tool schemas. A repeated call with the same
+// key returns the cached response instead of re-issuing an identical (and
+// possibly billed) request to the model.
+//
+// If the key can't be derived (hashing fails) or the store errors, the
+// middleware falls through to calling next rather than failing the request.
+func IdempotencyMiddleware(store IdempotencyStore, opts ...IdempotencyOption) ChatMiddleware {
+	cfg := idempotencyConfig{ttl: 24 * time.Hour}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.skip {
+		return func(next ChatHandler) ChatHandler { return next }
+	}
+
+	return func(next ChatHandler) ChatHandler {
+		return func(ctx context.Context, messages []Message, opts *ChatOptions) (*ChatResponse, error) {
+			key := idempotencyKeyFromContext(ctx)
+			if key == "" {
+				var err error
+				key, err = hashIdempotencyRequest(messages, opts)
+				if err != nil {
+					return next(ctx, messages, opts)
+				}
+			}
+
+			if resp, ok, err := store.Get(ctx, key); err == nil && ok {
+				return resp, nil
+			}
+
+			resp, err := next(ctx, messages, opts)
+			if err != nil || resp == nil {
+				return resp, err
+			}
+			_ = store.Put(ctx, key, resp, cfg.ttl)
+			return resp, nil
+		}
+	}
+}
+
+// idempotencyKeyCtxKey is the context key for an explicit idempotency key
+// set via [WithIdempotencyKey]. Unexported so only this package can set it.
+type idempotencyKeyCtxKey struct{}
+
+// WithIdempotencyKey attaches an explicit idempotency key to ctx, so
+// [IdempotencyMiddleware] dedupes by key instead of hashing the request.
+// Use this for calls that must dedupe by a caller-assigned identity (e.g. a
+// billing operation ID) even if their content later changes.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyCtxKey{}, key)
+}
+
+func idempotencyKeyFromContext(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKeyCtxKey{}).(string)
+	return key
+}
+
+// toolSignature captures the part of a [Tool] that affects what's sent to
+// the model, so tools can be hashed without relying on json.Marshal of the
+// (typically unexported) concrete implementation.
+type toolSignature struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Parameters  json.RawMessage `json:"parameters,omitempty"`
+}
+
+// idempotencyOptionsSignature mirrors the fields of [ChatOptions] that
+// affect what's sent to the model. It deliberately omits OnUsage (a func,
+// which json.Marshal can't encode at all) and Extra (provider-specific,
+// may itself hold incomparable values).
+type idempotencyOptionsSignature struct {
+	ModelID           string                `json:"modelId,omitempty"`
+	Temperature       *float64              `json:"temperature,omitempty"`
+	TopP              *float64              `json:"topP,omitempty"`
+	MaxTokens         *int                  `json:"maxTokens,omitempty"`
+	Stop              []string              `json:"stop,omitempty"`
+	Seed              *int                  `json:"seed,omitempty"`
+	FrequencyPenalty  *float64              `json:"frequencyPenalty,omitempty"`
+	PresencePenalty   *float64              `json:"presencePenalty,omitempty"`
+	ToolChoice        ToolChoice            `json:"toolChoice,omitempty"`
+	ResponseFormat    ResponseFormat        `json:"responseFormat,omitempty"`
+	Metadata          map[string]string     `json:"metadata,omitempty"`
+	User              string                `json:"user,omitempty"`
+	Instructions      string                `json:"instructions,omitempty"`
+	ConversationID    string                `json:"conversationId,omitempty"`
+	Store             *bool                 `json:"store,omitempty"`
+	DataSources       []DataSourceExtension `json:"dataSources,omitempty"`
+	EnablePromptCache bool                  `json:"enablePromptCache,omitempty"`
+	CacheKey          string                `json:"cacheKey,omitempty"`
+	Tools             []toolSignature       `json:"tools,omitempty"`
+}
+
+// hashIdempotencyRequest derives a stable cache key from messages, options,
+// and tool schemas, ignoring fields that don't affect what's sent to the
+// model (e.g. [ChatOptions.OnUsage]).
+func hashIdempotencyRequest(messages []Message, opts *ChatOptions) (string, error) {
+	normalized := struct {
+		Messages []Message                   `json:"messages"`
+		Options  idempotencyOptionsSignature `json:"options"`
+	}{
+		Messages: messages,
+	}
+
+	if opts != nil {
+		normalized.Options = idempotencyOptionsSignature{
+			ModelID:           opts.ModelID,
+			Temperature:       opts.Temperature,
+			TopP:              opts.TopP,
+			MaxTokens:         opts.MaxTokens,
+			Stop:              opts.Stop,
+			Seed:              opts.Seed,
+			FrequencyPenalty:  opts.FrequencyPenalty,
+			PresencePenalty:   opts.PresencePenalty,
+			ToolChoice:        opts.ToolChoice,
+			ResponseFormat:    opts.ResponseFormat,
+			Metadata:          opts.Metadata,
+			User:              opts.User,
+			Instructions:      opts.Instructions,
+			ConversationID:    opts.ConversationID,
+			Store:             opts.Store,
+			DataSources:       opts.DataSources,
+			EnablePromptCache: opts.EnablePromptCache,
+			CacheKey:          opts.CacheKey,
+		}
+		for _, tool := range opts.Tools {
+			normalized.Options.Tools = append(normalized.Options.Tools, toolSignature{
+				Name:        tool.Name(),
+				Description: tool.Description(),
+				Parameters:  tool.Parameters(),
+			})
+		}
+	}
+
+	data, err := json.Marshal(normalized)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// InMemoryIdempotencyStore is an [IdempotencyStore] backed by a process-local
+// map. Entries are lazily evicted: an expired entry is dropped the next time
+// it's looked up rather than on a background timer. Safe for concurrent use.
+type InMemoryIdempotencyStore struct {
+	mu      sync.Mutex
+	entries map[string]idempotencyEntry
+}
+
+type idempotencyEntry struct {
+	resp      *ChatResponse
+	expiresAt time.Time // zero means never
+}
+
+var _ IdempotencyStore = (*InMemoryIdempotencyStore)(nil)
+
+// NewInMemoryIdempotencyStore creates an empty [InMemoryIdempotencyStore].
+func NewInMemoryIdempotencyStore() *InMemoryIdempotencyStore {
+	return &InMemoryIdempotencyStore{entries: make(map[string]idempotencyEntry)}
+}
+
+func (s *InMemoryIdempotencyStore) Get(_ context.Context, key string) (*ChatResponse, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		delete(s.entries, key)
+		return nil, false, nil
+	}
+	return entry.resp, true, nil
+}
+
+func (s *InMemoryIdempotencyStore) Put(_ context.Context, key string, resp *ChatResponse, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var expiresAt time.Time
+	if ttl != 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+	s.entries[key] = idempotencyEntry{resp: resp, expiresAt: expiresAt}
+	return nil
+}
@@ -0,0 +1,119 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package agentframework_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+func TestIdempotencyMiddleware_CachesByRequestHash(t *testing.T) {
+	var calls int
+	client := &mockClient{
+		responseFn: func(ctx context.Context, msgs []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error) {
+			calls++
+			return &af.ChatResponse{Messages: []af.Message{af.NewAssistantMessage("hi")}}, nil
+		},
+	}
+
+	store := af.NewInMemoryIdempotencyStore()
+	agent := af.NewAgent(client, af.WithChatMiddleware(af.IdempotencyMiddleware(store)))
+
+	for i := 0; i < 3; i++ {
+		if _, err := agent.Run(context.Background(), []af.Message{af.NewUserMessage("hi")}); err != nil {
+			t.Fatalf("run %d: %v", i, err)
+		}
+	}
+
+	if calls != 1 {
+		t.Errorf("underlying client called %d times, want 1", calls)
+	}
+}
+
+func TestIdempotencyMiddleware_DifferentRequestsDontShareCache(t *testing.T) {
+	var calls int
+	client := &mockClient{
+		responseFn: func(ctx context.Context, msgs []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error) {
+			calls++
+			return &af.ChatResponse{Messages: []af.Message{af.NewAssistantMessage("hi")}}, nil
+		},
+	}
+
+	store := af.NewInMemoryIdempotencyStore()
+	agent := af.NewAgent(client, af.WithChatMiddleware(af.IdempotencyMiddleware(store)))
+
+	if _, err := agent.Run(context.Background(), []af.Message{af.NewUserMessage("hi")}); err != nil {
+		t.Fatalf("run 1: %v", err)
+	}
+	if _, err := agent.Run(context.Background(), []af.Message{af.NewUserMessage("bye")}); err != nil {
+		t.Fatalf("run 2: %v", err)
+	}
+
+	if calls != 2 {
+		t.Errorf("underlying client called %d times, want 2", calls)
+	}
+}
+
+func TestIdempotencyMiddleware_WithIdempotencyKeyForcesSharedCache(t *testing.T) {
+	var calls int
+	client := &mockClient{
+		responseFn: func(ctx context.Context, msgs []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error) {
+			calls++
+			return &af.ChatResponse{Messages: []af.Message{af.NewAssistantMessage("hi")}}, nil
+		},
+	}
+
+	store := af.NewInMemoryIdempotencyStore()
+	agent := af.NewAgent(client, af.WithChatMiddleware(af.IdempotencyMiddleware(store)))
+
+	ctx := af.WithIdempotencyKey(context.Background(), "billing-op-1")
+	if _, err := agent.Run(ctx, []af.Message{af.NewUserMessage("hi")}); err != nil {
+		t.Fatalf("run 1: %v", err)
+	}
+	if _, err := agent.Run(ctx, []af.Message{af.NewUserMessage("a completely different message")}); err != nil {
+		t.Fatalf("run 2: %v", err)
+	}
+
+	if calls != 1 {
+		t.Errorf("underlying client called %d times, want 1 (same explicit key)", calls)
+	}
+}
+
+func TestSkipIdempotency_DisablesCaching(t *testing.T) {
+	var calls int
+	client := &mockClient{
+		responseFn: func(ctx context.Context, msgs []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error) {
+			calls++
+			return &af.ChatResponse{Messages: []af.Message{af.NewAssistantMessage("hi")}}, nil
+		},
+	}
+
+	store := af.NewInMemoryIdempotencyStore()
+	agent := af.NewAgent(client, af.WithChatMiddleware(af.IdempotencyMiddleware(store, af.SkipIdempotency())))
+
+	for i := 0; i < 2; i++ {
+		if _, err := agent.Run(context.Background(), []af.Message{af.NewUserMessage("hi")}); err != nil {
+			t.Fatalf("run %d: %v", i, err)
+		}
+	}
+
+	if calls != 2 {
+		t.Errorf("underlying client called %d times, want 2 (idempotency skipped)", calls)
+	}
+}
+
+func TestInMemoryIdempotencyStore_ExpiresEntries(t *testing.T) {
+	store := af.NewInMemoryIdempotencyStore()
+	ctx := context.Background()
+	resp := &af.ChatResponse{Messages: []af.Message{af.NewAssistantMessage("cached")}}
+
+	if err := store.Put(ctx, "k", resp, -time.Second); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if _, ok, err := store.Get(ctx, "k"); err != nil || ok {
+		t.Errorf("Get after negative TTL: ok=%v err=%v, want miss", ok, err)
+	}
+}
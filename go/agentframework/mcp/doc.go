@@ -0,0 +1,24 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+// Package mcp connects to a remote Model Context Protocol (MCP) server and
+// exposes its tools as [agentframework.Tool] values, so they can sit
+// alongside native Go tools declared with [agentframework.NewTypedTool] on
+// the same [agentframework.Agent].
+//
+// Use [NewMCPToolset] with a stdio-launched server:
+//
+//	ts, err := mcp.NewMCPToolset(ctx, "stdio:/usr/local/bin/my-mcp-server",
+//	    mcp.WithArgs("--flag"),
+//	)
+//
+// or an HTTP(+SSE) server:
+//
+//	ts, err := mcp.NewMCPToolset(ctx, "https://mcp.example.com/mcp")
+//
+// Then pass ts.Tools() to [agentframework.WithTools]. A tool whose MCP
+// `annotations.destructiveHint` is set is given [agentframework.ApprovalAlways],
+// so it pauses for human approval like any other tool configured with
+// [agentframework.WithApprovalRequired]. The toolset refreshes its tool list
+// when the server sends a `notifications/tools/list_changed` notification.
+// Call Close when done with it.
+package mcp
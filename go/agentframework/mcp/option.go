@@ -0,0 +1,44 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package mcp
+
+import "net/http"
+
+// config holds the resolved options for [NewMCPToolset].
+type config struct {
+	httpClient *http.Client
+	command    string
+	args       []string
+	env        []string
+}
+
+// Option configures [NewMCPToolset].
+type Option func(*config)
+
+// WithCommand overrides the command run for a stdio-transport server. If
+// not set, the command is parsed from the endpoint passed to
+// [NewMCPToolset] (everything after the "stdio:" scheme).
+func WithCommand(command string, args ...string) Option {
+	return func(c *config) {
+		c.command = command
+		c.args = args
+	}
+}
+
+// WithArgs sets additional arguments for a stdio-transport server's command.
+func WithArgs(args ...string) Option {
+	return func(c *config) { c.args = args }
+}
+
+// WithEnv sets additional environment variables ("KEY=VALUE") for a
+// stdio-transport server's process, appended to the current process's
+// environment.
+func WithEnv(env ...string) Option {
+	return func(c *config) { c.env = env }
+}
+
+// WithHTTPClient overrides the [http.Client] used for an HTTP(+SSE)
+// transport server. Defaults to [http.DefaultClient].
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *config) { c.httpClient = client }
+}
@@ -0,0 +1,66 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// rpcRequest is a JSON-RPC 2.0 request, the wire format MCP uses for both
+// transports.
+type rpcRequest struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      int64  `json:"id"`
+	Method  string `json:"method"`
+	Params  any    `json:"params,omitempty"`
+}
+
+// rpcResponse is a JSON-RPC 2.0 response.
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      int64           `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// rpcError is a JSON-RPC 2.0 error object.
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return fmt.Sprintf("mcp: server error %d: %s", e.Code, e.Message)
+}
+
+// notification is a JSON-RPC 2.0 notification (a request with no id, sent
+// server -> client outside of any call/response).
+type notification struct {
+	Method string
+	Params json.RawMessage
+}
+
+// toolsListResult is the result of an MCP `tools/list` call.
+type toolsListResult struct {
+	Tools []toolDef `json:"tools"`
+}
+
+// toolDef describes one tool as returned by `tools/list`.
+type toolDef struct {
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	InputSchema json.RawMessage  `json:"inputSchema"`
+	Annotations *toolAnnotations `json:"annotations,omitempty"`
+}
+
+// toolAnnotations carries MCP's optional tool behavior hints.
+type toolAnnotations struct {
+	DestructiveHint bool `json:"destructiveHint,omitempty"`
+}
+
+// toolCallResult is the result of an MCP `tools/call` call.
+type toolCallResult struct {
+	Content []json.RawMessage `json:"content"`
+	IsError bool              `json:"isError,omitempty"`
+}
@@ -0,0 +1,50 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+// remoteTool adapts one MCP server tool to [af.Tool].
+type remoteTool struct {
+	toolset     *Toolset
+	name        string
+	description string
+	parameters  json.RawMessage
+	approval    af.ApprovalMode
+}
+
+func (t *remoteTool) Name() string                    { return t.name }
+func (t *remoteTool) Description() string             { return t.description }
+func (t *remoteTool) Parameters() json.RawMessage     { return t.parameters }
+func (t *remoteTool) DeclarationOnly() bool           { return false }
+func (t *remoteTool) Approval() af.ApprovalMode       { return t.approval }
+func (t *remoteTool) Concurrency() af.ConcurrencyMode { return af.ConcurrencyParallel }
+
+// Invoke calls the tool via the MCP server's `tools/call` method.
+func (t *remoteTool) Invoke(ctx context.Context, args json.RawMessage) (any, error) {
+	var params any
+	if len(args) > 0 {
+		if err := json.Unmarshal(args, &params); err != nil {
+			return nil, &af.ToolError{ToolName: t.name, Message: "invalid arguments: " + err.Error(), Err: af.ErrToolExecution}
+		}
+	}
+
+	raw, err := t.toolset.tp.call(ctx, "tools/call", map[string]any{"name": t.name, "arguments": params})
+	if err != nil {
+		return nil, &af.ToolError{ToolName: t.name, Message: err.Error(), Err: af.ErrToolExecution}
+	}
+
+	var result toolCallResult
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil, &af.ToolError{ToolName: t.name, Message: "invalid tools/call result: " + err.Error(), Err: af.ErrToolExecution}
+	}
+	if result.IsError {
+		return nil, &af.ToolError{ToolName: t.name, Message: "tool reported an error", Err: af.ErrToolExecution}
+	}
+	return result.Content, nil
+}
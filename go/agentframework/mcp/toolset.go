@@ -0,0 +1,139 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+// refreshTimeout bounds a toolset refresh triggered by a
+// notifications/tools/list_changed notification.
+const refreshTimeout = 10 * time.Second
+
+// Toolset is a live view of the tools exposed by one MCP server. Use
+// [NewMCPToolset] to connect to a server and [Toolset.Tools] to get the
+// current tool list for [af.WithTools]. Call [Toolset.Close] when done.
+type Toolset struct {
+	tp transport
+
+	mu    sync.RWMutex
+	tools map[string]*remoteTool
+	order []string
+
+	stopWatch chan struct{}
+	closeOnce sync.Once
+}
+
+// NewMCPToolset connects to an MCP server and lists its tools.
+//
+// endpoint is either "stdio:<command>" to launch a local server subprocess,
+// or an HTTP(S) URL to talk to a remote server. Use [WithCommand] to pass
+// arguments to a stdio server without embedding them in endpoint.
+func NewMCPToolset(ctx context.Context, endpoint string, opts ...Option) (*Toolset, error) {
+	cfg := &config{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	tp, err := newTransport(endpoint, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("mcp: connect: %w", err)
+	}
+
+	ts := &Toolset{tp: tp, stopWatch: make(chan struct{})}
+	if err := ts.refresh(ctx); err != nil {
+		tp.Close()
+		return nil, fmt.Errorf("mcp: initial tools/list: %w", err)
+	}
+
+	go ts.watch()
+	return ts, nil
+}
+
+// Tools returns the current tool list, in the order the server returned
+// them in.
+func (ts *Toolset) Tools() []af.Tool {
+	ts.mu.RLock()
+	defer ts.mu.RUnlock()
+
+	tools := make([]af.Tool, 0, len(ts.order))
+	for _, name := range ts.order {
+		tools = append(tools, ts.tools[name])
+	}
+	return tools
+}
+
+// Close stops watching for server notifications and closes the underlying
+// transport.
+func (ts *Toolset) Close() error {
+	var err error
+	ts.closeOnce.Do(func() {
+		close(ts.stopWatch)
+		err = ts.tp.Close()
+	})
+	return err
+}
+
+// refresh calls tools/list and replaces the toolset's contents.
+func (ts *Toolset) refresh(ctx context.Context) error {
+	raw, err := ts.tp.call(ctx, "tools/list", nil)
+	if err != nil {
+		return err
+	}
+
+	var result toolsListResult
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return fmt.Errorf("mcp: parse tools/list result: %w", err)
+	}
+
+	tools := make(map[string]*remoteTool, len(result.Tools))
+	order := make([]string, 0, len(result.Tools))
+	for _, def := range result.Tools {
+		approval := af.ApprovalNever
+		if def.Annotations != nil && def.Annotations.DestructiveHint {
+			approval = af.ApprovalAlways
+		}
+		tools[def.Name] = &remoteTool{
+			toolset:     ts,
+			name:        def.Name,
+			description: def.Description,
+			parameters:  def.InputSchema,
+			approval:    approval,
+		}
+		order = append(order, def.Name)
+	}
+
+	ts.mu.Lock()
+	ts.tools = tools
+	ts.order = order
+	ts.mu.Unlock()
+	return nil
+}
+
+// watch re-runs refresh whenever the server announces its tool list
+// changed, so long-running agents pick up additions/removals without a
+// restart.
+func (ts *Toolset) watch() {
+	for {
+		select {
+		case <-ts.stopWatch:
+			return
+		case n, ok := <-ts.tp.notifications():
+			if !ok {
+				return
+			}
+			if n.Method != "notifications/tools/list_changed" {
+				continue
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), refreshTimeout)
+			ts.refresh(ctx)
+			cancel()
+		}
+	}
+}
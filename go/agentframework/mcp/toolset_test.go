@@ -0,0 +1,153 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package mcp_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/microsoft/agent-framework/go/agentframework/mcp"
+)
+
+type rpcEnvelope struct {
+	ID     int64           `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+func writeResult(w http.ResponseWriter, id int64, result any) {
+	data, _ := json.Marshal(result)
+	fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,"result":%s}`, id, data)
+}
+
+func TestNewMCPToolset_HTTP_ListsAndInvokesTools(t *testing.T) {
+	var calls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var req rpcEnvelope
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+
+		switch req.Method {
+		case "tools/list":
+			writeResult(w, req.ID, map[string]any{
+				"tools": []map[string]any{
+					{
+						"name":        "get_weather",
+						"description": "Gets the weather",
+						"inputSchema": map[string]any{"type": "object"},
+					},
+					{
+						"name":        "delete_file",
+						"description": "Deletes a file",
+						"inputSchema": map[string]any{"type": "object"},
+						"annotations": map[string]any{"destructiveHint": true},
+					},
+				},
+			})
+		case "tools/call":
+			calls.Add(1)
+			writeResult(w, req.ID, map[string]any{
+				"content": []string{"sunny"},
+			})
+		default:
+			t.Fatalf("unexpected method %q", req.Method)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	ts, err := mcp.NewMCPToolset(ctx, server.URL)
+	if err != nil {
+		t.Fatalf("NewMCPToolset: %v", err)
+	}
+	defer ts.Close()
+
+	tools := ts.Tools()
+	if len(tools) != 2 {
+		t.Fatalf("expected 2 tools, got %d", len(tools))
+	}
+	if tools[0].Name() != "get_weather" || tools[0].Approval() != "never" {
+		t.Errorf("get_weather: unexpected tool %+v", tools[0])
+	}
+	if tools[1].Name() != "delete_file" || tools[1].Approval() != "always" {
+		t.Errorf("delete_file: expected ApprovalAlways from destructiveHint, got %+v", tools[1])
+	}
+
+	if _, err := tools[0].Invoke(ctx, json.RawMessage(`{"location":"nyc"}`)); err != nil {
+		t.Fatalf("Invoke: %v", err)
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("expected 1 tools/call, got %d", calls.Load())
+	}
+}
+
+func TestNewMCPToolset_HTTP_HotReloadOnListChanged(t *testing.T) {
+	var toolCount atomic.Int32
+	toolCount.Store(1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.WriteHeader(http.StatusOK)
+			time.Sleep(100 * time.Millisecond)
+			fmt.Fprint(w, "data: {\"method\":\"notifications/tools/list_changed\"}\n\n")
+			if f, ok := w.(http.Flusher); ok {
+				f.Flush()
+			}
+			return
+		}
+
+		var req rpcEnvelope
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+
+		if req.Method != "tools/list" {
+			t.Fatalf("unexpected method %q", req.Method)
+		}
+		tools := []map[string]any{
+			{"name": "tool_a", "description": "a", "inputSchema": map[string]any{"type": "object"}},
+		}
+		if toolCount.Load() == 2 {
+			tools = append(tools, map[string]any{"name": "tool_b", "description": "b", "inputSchema": map[string]any{"type": "object"}})
+		}
+		writeResult(w, req.ID, map[string]any{"tools": tools})
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	ts, err := mcp.NewMCPToolset(ctx, server.URL)
+	if err != nil {
+		t.Fatalf("NewMCPToolset: %v", err)
+	}
+	defer ts.Close()
+
+	if len(ts.Tools()) != 1 {
+		t.Fatalf("expected 1 tool initially, got %d", len(ts.Tools()))
+	}
+
+	toolCount.Store(2)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(ts.Tools()) != 2 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := len(ts.Tools()); got != 2 {
+		t.Fatalf("expected toolset to hot-reload to 2 tools, got %d", got)
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package mcp
+
+import (
+	"context"
+	"strings"
+)
+
+// transport performs JSON-RPC calls against an MCP server and surfaces any
+// notifications it sends outside of a call/response exchange.
+type transport interface {
+	// call sends method/params and returns the raw `result` field, or an
+	// error (typically an *rpcError or *af.ServiceError) on failure.
+	call(ctx context.Context, method string, params any) (result []byte, err error)
+
+	// notifications returns the channel notifications are delivered on.
+	// It is closed when the transport can no longer receive them.
+	notifications() <-chan notification
+
+	// Close releases the transport's resources (the subprocess, for
+	// stdio; in-flight connections, for HTTP).
+	Close() error
+}
+
+// newTransport selects a transport based on endpoint's scheme: "stdio:"
+// launches a subprocess; anything else is treated as an HTTP(+SSE) server
+// URL.
+func newTransport(endpoint string, cfg *config) (transport, error) {
+	if cfg.command != "" || strings.HasPrefix(endpoint, "stdio:") {
+		command := cfg.command
+		args := cfg.args
+		if command == "" {
+			command = strings.TrimPrefix(endpoint, "stdio:")
+		}
+		return newStdioTransport(command, args, cfg.env)
+	}
+	return newSSETransport(endpoint, cfg.httpClient), nil
+}
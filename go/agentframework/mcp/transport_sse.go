@@ -0,0 +1,153 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+// sseTransport speaks JSON-RPC over HTTP: each call is a POST that gets a
+// direct JSON response, and a background GET with `Accept:
+// text/event-stream` carries server-initiated notifications, when the
+// server supports it. A server that doesn't support the GET stream simply
+// never sends notifications — the toolset just won't hot-reload.
+type sseTransport struct {
+	endpoint string
+	client   *http.Client
+
+	nextID int64 // atomic
+
+	notifyCh chan notification
+	stop     chan struct{}
+
+	closeOnce sync.Once
+}
+
+func newSSETransport(endpoint string, client *http.Client) *sseTransport {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	t := &sseTransport{
+		endpoint: endpoint,
+		client:   client,
+		notifyCh: make(chan notification, 16),
+		stop:     make(chan struct{}),
+	}
+	go t.watchNotifications()
+	return t
+}
+
+func (t *sseTransport) watchNotifications() {
+	defer close(t.notifyCh)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-t.stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, t.endpoint, nil)
+	if err != nil {
+		return
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		return
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		select {
+		case <-t.stop:
+			return
+		default:
+		}
+
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "data: ") {
+			continue
+		}
+		data := strings.TrimPrefix(line, "data: ")
+
+		var msg struct {
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+		if err := json.Unmarshal([]byte(data), &msg); err != nil || msg.Method == "" {
+			continue
+		}
+
+		select {
+		case t.notifyCh <- notification{Method: msg.Method, Params: msg.Params}:
+		case <-t.stop:
+			return
+		}
+	}
+}
+
+func (t *sseTransport) call(ctx context.Context, method string, params any) ([]byte, error) {
+	id := atomic.AddInt64(&t.nextID, 1)
+
+	body, err := json.Marshal(rpcRequest{JSONRPC: "2.0", ID: id, Method: method, Params: params})
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, t.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json")
+
+	resp, err := t.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("mcp: request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("mcp: read response: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		return nil, &af.ServiceError{StatusCode: resp.StatusCode, Message: string(data), Err: af.ErrService}
+	}
+
+	var rpcResp rpcResponse
+	if err := json.Unmarshal(data, &rpcResp); err != nil {
+		return nil, fmt.Errorf("mcp: parse response: %w", err)
+	}
+	if rpcResp.Error != nil {
+		return nil, rpcResp.Error
+	}
+	return rpcResp.Result, nil
+}
+
+func (t *sseTransport) notifications() <-chan notification { return t.notifyCh }
+
+func (t *sseTransport) Close() error {
+	t.closeOnce.Do(func() { close(t.stop) })
+	return nil
+}
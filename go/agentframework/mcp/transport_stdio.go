@@ -0,0 +1,168 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+)
+
+// stdioTransport speaks newline-delimited JSON-RPC over a subprocess's
+// stdin/stdout, the framing MCP's stdio transport uses.
+type stdioTransport struct {
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+
+	mu      sync.Mutex
+	nextID  int64
+	pending map[int64]chan rpcResponse
+
+	notifyCh chan notification
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+func newStdioTransport(command string, args, env []string) (*stdioTransport, error) {
+	if command == "" {
+		return nil, fmt.Errorf("mcp: stdio transport requires a command")
+	}
+
+	cmd := exec.Command(command, args...)
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	cmd.Stderr = os.Stderr
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("mcp: stdin pipe: %w", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("mcp: stdout pipe: %w", err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("mcp: start %q: %w", command, err)
+	}
+
+	t := &stdioTransport{
+		cmd:      cmd,
+		stdin:    stdin,
+		pending:  make(map[int64]chan rpcResponse),
+		notifyCh: make(chan notification, 16),
+	}
+	go t.readLoop(stdout)
+	return t, nil
+}
+
+// readLoop dispatches each line as either a response to a pending call (has
+// an id) or a notification (no id), until stdout closes.
+func (t *stdioTransport) readLoop(stdout io.Reader) {
+	defer close(t.notifyCh)
+
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var probe struct {
+			ID     *int64 `json:"id"`
+			Method string `json:"method"`
+		}
+		if err := json.Unmarshal(line, &probe); err != nil {
+			continue
+		}
+
+		if probe.ID != nil {
+			var resp rpcResponse
+			if err := json.Unmarshal(line, &resp); err != nil {
+				continue
+			}
+			t.mu.Lock()
+			ch, ok := t.pending[resp.ID]
+			delete(t.pending, resp.ID)
+			t.mu.Unlock()
+			if ok {
+				ch <- resp
+			}
+			continue
+		}
+
+		if probe.Method != "" {
+			var n struct {
+				Method string          `json:"method"`
+				Params json.RawMessage `json:"params"`
+			}
+			if err := json.Unmarshal(line, &n); err != nil {
+				continue
+			}
+			select {
+			case t.notifyCh <- notification{Method: n.Method, Params: n.Params}:
+			default:
+				// Drop if nobody's listening fast enough; notifications
+				// are a hint, not a guaranteed delivery channel.
+			}
+		}
+	}
+}
+
+func (t *stdioTransport) call(ctx context.Context, method string, params any) ([]byte, error) {
+	t.mu.Lock()
+	t.nextID++
+	id := t.nextID
+	ch := make(chan rpcResponse, 1)
+	t.pending[id] = ch
+	t.mu.Unlock()
+
+	data, err := json.Marshal(rpcRequest{JSONRPC: "2.0", ID: id, Method: method, Params: params})
+	if err != nil {
+		t.mu.Lock()
+		delete(t.pending, id)
+		t.mu.Unlock()
+		return nil, err
+	}
+	data = append(data, '\n')
+
+	if _, err := t.stdin.Write(data); err != nil {
+		t.mu.Lock()
+		delete(t.pending, id)
+		t.mu.Unlock()
+		return nil, fmt.Errorf("mcp: write request: %w", err)
+	}
+
+	select {
+	case <-ctx.Done():
+		t.mu.Lock()
+		delete(t.pending, id)
+		t.mu.Unlock()
+		return nil, ctx.Err()
+	case resp := <-ch:
+		if resp.Error != nil {
+			return nil, resp.Error
+		}
+		return resp.Result, nil
+	}
+}
+
+func (t *stdioTransport) notifications() <-chan notification { return t.notifyCh }
+
+func (t *stdioTransport) Close() error {
+	t.closeOnce.Do(func() {
+		t.stdin.Close()
+		t.closeErr = t.cmd.Wait()
+	})
+	return t.closeErr
+}
@@ -2,7 +2,10 @@
 
 package agentframework
 
-import "strings"
+import (
+	"encoding/json"
+	"strings"
+)
 
 // Role identifies the author of a [Message].
 type Role string
@@ -18,10 +21,11 @@ const (
 type FinishReason string
 
 const (
-	FinishReasonStop          FinishReason = "stop"
-	FinishReasonLength        FinishReason = "length"
-	FinishReasonToolCalls     FinishReason = "tool_calls"
-	FinishReasonContentFilter FinishReason = "content_filter"
+	FinishReasonStop             FinishReason = "stop"
+	FinishReasonLength           FinishReason = "length"
+	FinishReasonToolCalls        FinishReason = "tool_calls"
+	FinishReasonContentFilter    FinishReason = "content_filter"
+	FinishReasonApprovalRequired FinishReason = "approval_required"
 )
 
 // Message represents a single chat message exchanged with an agent or model.
@@ -49,6 +53,44 @@ func (m *Message) Text() string {
 	return b.String()
 }
 
+// messageJSON is the wire format used by [Message.MarshalJSON] and
+// [Message.UnmarshalJSON]. It mirrors Message's exported, tagged fields;
+// Extra and Raw are provider-specific and intentionally not round-tripped.
+type messageJSON struct {
+	Role       Role     `json:"role"`
+	Contents   Contents `json:"contents,omitempty"`
+	AuthorName string   `json:"authorName,omitempty"`
+	MessageID  string   `json:"messageId,omitempty"`
+}
+
+// MarshalJSON implements [json.Marshaler]. Each item of Contents is encoded
+// through [MarshalContentJSON] via [Contents.MarshalJSON], so a Message
+// round-trips through JSON (e.g. for a persistent [MessageStore] or
+// cross-process transport) without callers hand-rolling a type switch over
+// the sealed [Content] types.
+func (m Message) MarshalJSON() ([]byte, error) {
+	return json.Marshal(messageJSON{
+		Role:       m.Role,
+		Contents:   m.Contents,
+		AuthorName: m.AuthorName,
+		MessageID:  m.MessageID,
+	})
+}
+
+// UnmarshalJSON implements [json.Unmarshaler], restoring a [Message]
+// previously encoded with [Message.MarshalJSON].
+func (m *Message) UnmarshalJSON(data []byte) error {
+	var mj messageJSON
+	if err := json.Unmarshal(data, &mj); err != nil {
+		return err
+	}
+	m.Role = mj.Role
+	m.Contents = mj.Contents
+	m.AuthorName = mj.AuthorName
+	m.MessageID = mj.MessageID
+	return nil
+}
+
 // NewUserMessage creates a user-role [Message] from a text string.
 func NewUserMessage(text string) Message {
 	return Message{
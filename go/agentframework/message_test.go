@@ -3,6 +3,7 @@
 package agentframework_test
 
 import (
+	"encoding/json"
 	"testing"
 
 	af "github.com/microsoft/agent-framework/go/agentframework"
@@ -45,6 +46,51 @@ func TestNewToolMessage(t *testing.T) {
 	}
 }
 
+func TestMessageJSONRoundTrip(t *testing.T) {
+	original := af.Message{
+		Role:       af.RoleAssistant,
+		AuthorName: "bot",
+		MessageID:  "m1",
+		Contents: af.Contents{
+			&af.TextContent{Text: "hi"},
+			&af.FunctionCallContent{CallID: "c1", Name: "fn", Arguments: "{}"},
+		},
+		Raw: "provider-specific, not round-tripped",
+	}
+
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	var restored af.Message
+	if err := json.Unmarshal(data, &restored); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	if restored.Role != original.Role {
+		t.Errorf("Role = %q, want %q", restored.Role, original.Role)
+	}
+	if restored.AuthorName != original.AuthorName {
+		t.Errorf("AuthorName = %q, want %q", restored.AuthorName, original.AuthorName)
+	}
+	if restored.MessageID != original.MessageID {
+		t.Errorf("MessageID = %q, want %q", restored.MessageID, original.MessageID)
+	}
+	if len(restored.Contents) != 2 {
+		t.Fatalf("len(Contents) = %d, want 2", len(restored.Contents))
+	}
+	if restored.Contents[0].Type() != af.ContentTypeText {
+		t.Errorf("Contents[0].Type() = %q", restored.Contents[0].Type())
+	}
+	if restored.Contents[1].Type() != af.ContentTypeFunctionCall {
+		t.Errorf("Contents[1].Type() = %q", restored.Contents[1].Type())
+	}
+	if restored.Raw != nil {
+		t.Errorf("Raw = %v, want nil (not round-tripped)", restored.Raw)
+	}
+}
+
 func TestMessageText_MultipleContents(t *testing.T) {
 	m := af.Message{
 		Role: af.RoleAssistant,
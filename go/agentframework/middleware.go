@@ -27,6 +27,15 @@ type ChatHandler func(ctx context.Context, messages []Message, opts *ChatOptions
 // ChatMiddleware wraps a [ChatHandler] to add cross-cutting behavior.
 type ChatMiddleware func(next ChatHandler) ChatHandler
 
+// StreamingChatHandler is the function signature for processing a streaming
+// chat request, mirroring [ChatHandler] for [Agent.RunStream].
+type StreamingChatHandler func(ctx context.Context, messages []Message, opts *ChatOptions) (*ResponseStream[ChatResponseUpdate], error)
+
+// StreamingChatMiddleware wraps a [StreamingChatHandler] to add cross-cutting
+// behavior around a whole streamed turn (including any tool-calling
+// iterations within it), mirroring [ChatMiddleware] for [Agent.RunStream].
+type StreamingChatMiddleware func(next StreamingChatHandler) StreamingChatHandler
+
 // FunctionHandler is the function signature for invoking a tool.
 type FunctionHandler func(ctx context.Context, tool Tool, args json.RawMessage) (any, error)
 
@@ -49,6 +58,14 @@ func chainChatMiddleware(handler ChatHandler, mws ...ChatMiddleware) ChatHandler
 	return handler
 }
 
+// chainStreamingChatMiddleware applies middleware in order (first in list = outermost wrapper).
+func chainStreamingChatMiddleware(handler StreamingChatHandler, mws ...StreamingChatMiddleware) StreamingChatHandler {
+	for i := len(mws) - 1; i >= 0; i-- {
+		handler = mws[i](handler)
+	}
+	return handler
+}
+
 // chainFunctionMiddleware applies middleware in order.
 func chainFunctionMiddleware(handler FunctionHandler, mws ...FunctionMiddleware) FunctionHandler {
 	for i := len(mws) - 1; i >= 0; i-- {
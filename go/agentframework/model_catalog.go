@@ -0,0 +1,98 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package agentframework
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Modality is a kind of content a model can consume or produce.
+type Modality string
+
+const (
+	ModalityText  Modality = "text"
+	ModalityImage Modality = "image"
+	ModalityAudio Modality = "audio"
+)
+
+// ModelInfo describes a model's capabilities and limits, used to validate
+// a [ChatOptions.ModelID] and to clamp request parameters to what the
+// model actually supports. Register custom entries (fine-tunes, Azure
+// deployment aliases pointing at a base model) with [RegisterModel].
+type ModelInfo struct {
+	ID                string
+	ContextWindow     int
+	MaxOutputTokens   int
+	InputModalities   []Modality
+	OutputModalities  []Modality
+	SupportsTools     bool
+	SupportsStreaming bool
+
+	// CostPerMillionInputTokens and CostPerMillionOutputTokens are in USD;
+	// zero means unknown rather than free. For usage-based cost tracking
+	// beyond a flat per-token rate, see the pricing subpackage.
+	CostPerMillionInputTokens  float64
+	CostPerMillionOutputTokens float64
+}
+
+// ModelCatalog looks up capability metadata for a model ID.
+// Implementations should report ok=false for a model they don't recognize
+// rather than erroring, so an unknown or custom model ID can still be used
+// without a catalog entry.
+type ModelCatalog interface {
+	Lookup(modelID string) (ModelInfo, bool)
+}
+
+var (
+	modelRegistryMu sync.RWMutex
+	modelRegistry   = map[string]ModelInfo{}
+)
+
+// RegisterModel adds info to the default model catalog, keyed by info.ID.
+// Registering under an ID already in use replaces it. Use this to add
+// provider models (see the openai package's Models map), fine-tunes, or
+// Azure deployment aliases that should be treated as a known base model.
+func RegisterModel(info ModelInfo) {
+	modelRegistryMu.Lock()
+	defer modelRegistryMu.Unlock()
+	modelRegistry[info.ID] = info
+}
+
+// LookupModel reports the registered [ModelInfo] for modelID, if any.
+func LookupModel(modelID string) (ModelInfo, bool) {
+	modelRegistryMu.RLock()
+	defer modelRegistryMu.RUnlock()
+	info, ok := modelRegistry[modelID]
+	return info, ok
+}
+
+// defaultCatalog adapts the package-level model registry to [ModelCatalog].
+type defaultCatalog struct{}
+
+func (defaultCatalog) Lookup(modelID string) (ModelInfo, bool) { return LookupModel(modelID) }
+
+// DefaultCatalog is a [ModelCatalog] backed by the models registered with
+// [RegisterModel].
+var DefaultCatalog ModelCatalog = defaultCatalog{}
+
+// ValidateModelID checks opts.ModelID against catalog, returning
+// [ErrModelNotFound] if the model isn't registered, or [ErrModelCapability]
+// if it's registered but doesn't support tool calls requested via
+// opts.Tools. A caller opts into this check explicitly (e.g. before
+// [Agent.Run]) rather than having it enforced automatically, since an
+// unregistered model ID is often legitimate (a new release, a deployment
+// alias) rather than a mistake.
+func ValidateModelID(catalog ModelCatalog, opts *ChatOptions) error {
+	if opts == nil || opts.ModelID == "" {
+		return nil
+	}
+	info, ok := catalog.Lookup(opts.ModelID)
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrModelNotFound, opts.ModelID)
+	}
+	if len(opts.Tools) > 0 && !info.SupportsTools {
+		return fmt.Errorf("%w: %q does not support tool calls", ErrModelCapability, opts.ModelID)
+	}
+	return nil
+}
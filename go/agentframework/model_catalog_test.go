@@ -0,0 +1,66 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package agentframework_test
+
+import (
+	"errors"
+	"testing"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+func TestRegisterModel_LookupModel(t *testing.T) {
+	af.RegisterModel(af.ModelInfo{ID: "catalog-test-model", ContextWindow: 8192, SupportsTools: true})
+
+	info, ok := af.LookupModel("catalog-test-model")
+	if !ok {
+		t.Fatal("expected model to be registered")
+	}
+	if info.ContextWindow != 8192 || !info.SupportsTools {
+		t.Errorf("info = %+v", info)
+	}
+
+	if _, ok := af.LookupModel("never-registered-model"); ok {
+		t.Error("expected unregistered model to be absent")
+	}
+}
+
+func TestValidateModelID_UnknownModel(t *testing.T) {
+	err := af.ValidateModelID(af.DefaultCatalog, &af.ChatOptions{ModelID: "definitely-not-registered"})
+	if !errors.Is(err, af.ErrModelNotFound) {
+		t.Fatalf("err = %v, want ErrModelNotFound", err)
+	}
+}
+
+func TestValidateModelID_ToolsUnsupported(t *testing.T) {
+	af.RegisterModel(af.ModelInfo{ID: "no-tools-model", SupportsTools: false})
+
+	err := af.ValidateModelID(af.DefaultCatalog, &af.ChatOptions{
+		ModelID: "no-tools-model",
+		Tools:   []af.Tool{af.NewTool("get_weather", "", nil, nil)},
+	})
+	if !errors.Is(err, af.ErrModelCapability) {
+		t.Fatalf("err = %v, want ErrModelCapability", err)
+	}
+}
+
+func TestValidateModelID_KnownModelOK(t *testing.T) {
+	af.RegisterModel(af.ModelInfo{ID: "known-good-model", SupportsTools: true})
+
+	err := af.ValidateModelID(af.DefaultCatalog, &af.ChatOptions{
+		ModelID: "known-good-model",
+		Tools:   []af.Tool{af.NewTool("get_weather", "", nil, nil)},
+	})
+	if err != nil {
+		t.Errorf("err = %v, want nil", err)
+	}
+}
+
+func TestValidateModelID_EmptyModelIDNoop(t *testing.T) {
+	if err := af.ValidateModelID(af.DefaultCatalog, &af.ChatOptions{}); err != nil {
+		t.Errorf("err = %v, want nil", err)
+	}
+	if err := af.ValidateModelID(af.DefaultCatalog, nil); err != nil {
+		t.Errorf("err = %v, want nil", err)
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package agentframework
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MultiClient is a [ChatClient] that routes each request to one of several
+// underlying clients based on a prefix match against
+// [ChatOptions.ModelID] — e.g. "claude-" to an anthropic client, "gemini-"
+// to a google client, everything else to an openai default. Unlike
+// [router.Router], which fails over across interchangeable backends serving
+// the same model, MultiClient routes deterministically so an [Agent] can
+// transparently target any configured provider by model name alone.
+type MultiClient struct {
+	routes   []multiRoute
+	fallback ChatClient
+}
+
+type multiRoute struct {
+	prefix string
+	client ChatClient
+}
+
+// MultiClientOption configures a [MultiClient] via [NewMultiClient].
+type MultiClientOption func(*MultiClient)
+
+// WithRoute registers client for model IDs starting with prefix. Longer
+// prefixes are preferred over shorter ones when more than one matches.
+func WithRoute(prefix string, client ChatClient) MultiClientOption {
+	return func(m *MultiClient) { m.routes = append(m.routes, multiRoute{prefix: prefix, client: client}) }
+}
+
+// WithFallback sets the client used when no route's prefix matches the
+// request's ModelID. Without a fallback, an unmatched ModelID is an error.
+func WithFallback(client ChatClient) MultiClientOption {
+	return func(m *MultiClient) { m.fallback = client }
+}
+
+// NewMultiClient creates a [MultiClient] with the given routes.
+func NewMultiClient(opts ...MultiClientOption) *MultiClient {
+	m := &MultiClient{}
+	for _, o := range opts {
+		o(m)
+	}
+	sort.SliceStable(m.routes, func(i, j int) bool {
+		return len(m.routes[i].prefix) > len(m.routes[j].prefix)
+	})
+	return m
+}
+
+var _ ChatClient = (*MultiClient)(nil)
+
+// resolve returns the client whose prefix matches opts.ModelID, or the
+// fallback if none match.
+func (m *MultiClient) resolve(opts *ChatOptions) (ChatClient, error) {
+	modelID := ""
+	if opts != nil {
+		modelID = opts.ModelID
+	}
+	for _, r := range m.routes {
+		if strings.HasPrefix(modelID, r.prefix) {
+			return r.client, nil
+		}
+	}
+	if m.fallback != nil {
+		return m.fallback, nil
+	}
+	return nil, fmt.Errorf("%w: no route matches model %q and no fallback configured", ErrChatClient, modelID)
+}
+
+// Response routes to the client matching opts.ModelID and returns its response.
+func (m *MultiClient) Response(ctx context.Context, messages []Message, opts *ChatOptions) (*ChatResponse, error) {
+	client, err := m.resolve(opts)
+	if err != nil {
+		return nil, err
+	}
+	return client.Response(ctx, messages, opts)
+}
+
+// StreamResponse routes to the client matching opts.ModelID and returns its stream.
+func (m *MultiClient) StreamResponse(ctx context.Context, messages []Message, opts *ChatOptions) (*ResponseStream[ChatResponseUpdate], error) {
+	client, err := m.resolve(opts)
+	if err != nil {
+		return nil, err
+	}
+	return client.StreamResponse(ctx, messages, opts)
+}
@@ -0,0 +1,88 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package agentframework_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+func echoModelClient(name string) *mockClient {
+	return &mockClient{
+		responseFn: func(ctx context.Context, msgs []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error) {
+			return &af.ChatResponse{Messages: []af.Message{af.NewAssistantMessage(name)}}, nil
+		},
+	}
+}
+
+func TestMultiClient_RoutesByModelPrefix(t *testing.T) {
+	claude := echoModelClient("claude")
+	gemini := echoModelClient("gemini")
+
+	client := af.NewMultiClient(
+		af.WithRoute("claude-", claude),
+		af.WithRoute("gemini-", gemini),
+	)
+
+	resp, err := client.Response(context.Background(), nil, &af.ChatOptions{ModelID: "gemini-2.0-flash"})
+	if err != nil {
+		t.Fatalf("Response: %v", err)
+	}
+	if resp.Text() != "gemini" {
+		t.Errorf("Text() = %q, want gemini", resp.Text())
+	}
+
+	resp, err = client.Response(context.Background(), nil, &af.ChatOptions{ModelID: "claude-sonnet-4-5"})
+	if err != nil {
+		t.Fatalf("Response: %v", err)
+	}
+	if resp.Text() != "claude" {
+		t.Errorf("Text() = %q, want claude", resp.Text())
+	}
+}
+
+func TestMultiClient_UnmatchedModelUsesFallback(t *testing.T) {
+	fallback := echoModelClient("fallback")
+	client := af.NewMultiClient(af.WithFallback(fallback))
+
+	resp, err := client.Response(context.Background(), nil, &af.ChatOptions{ModelID: "gpt-5"})
+	if err != nil {
+		t.Fatalf("Response: %v", err)
+	}
+	if resp.Text() != "fallback" {
+		t.Errorf("Text() = %q, want fallback", resp.Text())
+	}
+}
+
+func TestMultiClient_UnmatchedModelWithoutFallbackErrors(t *testing.T) {
+	client := af.NewMultiClient(af.WithRoute("claude-", echoModelClient("claude")))
+
+	_, err := client.Response(context.Background(), nil, &af.ChatOptions{ModelID: "gpt-5"})
+	if !errors.Is(err, af.ErrChatClient) {
+		t.Errorf("error = %v, want wrapping ErrChatClient", err)
+	}
+}
+
+func TestMultiClient_StreamResponse_Routes(t *testing.T) {
+	gemini := echoModelClient("gemini")
+	client := af.NewMultiClient(af.WithRoute("gemini-", gemini))
+
+	stream, err := client.StreamResponse(context.Background(), nil, &af.ChatOptions{ModelID: "gemini-2.0-flash"})
+	if err != nil {
+		t.Fatalf("StreamResponse: %v", err)
+	}
+	updates, err := stream.Collect(context.Background())
+	if err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+	var text string
+	for _, u := range updates {
+		text += u.Text()
+	}
+	if text != "gemini" {
+		t.Errorf("text = %q, want gemini", text)
+	}
+}
@@ -0,0 +1,225 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package agentframework
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// OperationPoller checks a long-running operation's status and fetches its
+// terminal result, backing [Operation.Poll]. A [ChatClient] whose provider
+// exposes 202-style image generation or code interpreter calls implements
+// this (typically with T fixed to the concrete result content type, e.g.
+// *ImageGenResultContent) to let callers resume an [Operation] started in
+// another process.
+type OperationPoller[T Content] interface {
+	// PollOperation checks operationLocation's current status, returning
+	// the terminal result once status is [OperationStatusSucceeded].
+	// Result is only meaningful when status is terminal.
+	PollOperation(ctx context.Context, operationLocation string) (status OperationStatus, result T, err error)
+}
+
+// Operation tracks a long-running, provider-side operation (image
+// generation, code interpreter) modeled on Azure's long-running-operation
+// pattern: Poll once, PollUntilDone to block until completion, or persist
+// via ResumeToken and recreate with [NewOperationFromResumeToken] in a
+// different process.
+type Operation[T Content] struct {
+	operationLocation string
+	status            OperationStatus
+	lastPolledAt      time.Time
+	poller            OperationPoller[T]
+	result            T
+}
+
+// NewOperation creates an [Operation] for an in-flight operation at
+// operationLocation, in the initial [OperationStatusQueued] state.
+func NewOperation[T Content](operationLocation string, poller OperationPoller[T]) *Operation[T] {
+	return &Operation[T]{operationLocation: operationLocation, status: OperationStatusQueued, poller: poller}
+}
+
+// OperationLocation returns the opaque provider-specific handle used to poll this operation.
+func (o *Operation[T]) OperationLocation() string { return o.operationLocation }
+
+// Status returns the operation's status as of the last [Operation.Poll] call.
+func (o *Operation[T]) Status() OperationStatus { return o.status }
+
+// Poll checks the operation's current status once. done is true once
+// status has reached a terminal state ([OperationStatusSucceeded],
+// [OperationStatusFailed], or [OperationStatusCanceled]); the result is
+// then available as the return value of [Operation.PollUntilDone].
+func (o *Operation[T]) Poll(ctx context.Context) (done bool, err error) {
+	status, result, err := o.poller.PollOperation(ctx, o.operationLocation)
+	if err != nil {
+		return false, fmt.Errorf("%w: poll %s: %w", ErrOperation, o.operationLocation, err)
+	}
+	o.status = status
+	o.lastPolledAt = time.Now()
+	if status.terminal() {
+		o.result = result
+	}
+	return status.terminal(), nil
+}
+
+// PollUntilDone polls at frequency until the operation reaches a terminal
+// state, then returns its result. It returns a non-nil error, wrapping
+// [ErrOperation], if the operation finishes as failed or canceled.
+func (o *Operation[T]) PollUntilDone(ctx context.Context, frequency time.Duration) (T, error) {
+	for {
+		done, err := o.Poll(ctx)
+		if err != nil {
+			var zero T
+			return zero, err
+		}
+		if done {
+			if o.status != OperationStatusSucceeded {
+				var zero T
+				return zero, fmt.Errorf("%w: %s finished as %s", ErrOperation, o.operationLocation, o.status)
+			}
+			return o.result, nil
+		}
+		select {
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		case <-time.After(frequency):
+		}
+	}
+}
+
+// operationResumeToken is the JSON shape of a token returned by
+// [Operation.ResumeToken].
+type operationResumeToken struct {
+	OperationLocation string          `json:"operationLocation"`
+	Status            OperationStatus `json:"status"`
+	LastPolledAt      time.Time       `json:"lastPolledAt"`
+}
+
+// ResumeToken serializes the operation's current location and status so it
+// can be persisted (e.g. to a [Session]) and later restored with
+// [NewOperationFromResumeToken] in a different process.
+func (o *Operation[T]) ResumeToken() (string, error) {
+	data, err := json.Marshal(operationResumeToken{
+		OperationLocation: o.operationLocation,
+		Status:            o.status,
+		LastPolledAt:      o.lastPolledAt,
+	})
+	if err != nil {
+		return "", fmt.Errorf("%w: encode resume token: %w", ErrOperation, err)
+	}
+	return string(data), nil
+}
+
+// NewOperationFromResumeToken recreates an [Operation] from a token
+// produced by [Operation.ResumeToken], wired to poller for subsequent polls.
+func NewOperationFromResumeToken[T Content](token string, poller OperationPoller[T]) (*Operation[T], error) {
+	var rt operationResumeToken
+	if err := json.Unmarshal([]byte(token), &rt); err != nil {
+		return nil, fmt.Errorf("%w: decode resume token: %w", ErrOperation, err)
+	}
+	return &Operation[T]{
+		operationLocation: rt.OperationLocation,
+		status:            rt.Status,
+		lastPolledAt:      rt.LastPolledAt,
+		poller:            poller,
+	}, nil
+}
+
+// OperationMiddleware wraps a [StreamingChatHandler] so that a provider's
+// 202-style response — surfaced as an [ImageGenOperationContent] or
+// [CodeInterpreterOperationContent] with a non-terminal status — doesn't
+// block the caller. Instead it polls poller at frequency, emitting an
+// updated operation-content update after each poll, and substitutes the
+// operation content with its terminal result once the operation completes.
+func OperationMiddleware(poller OperationPoller[Content], frequency time.Duration) StreamingChatMiddleware {
+	return func(next StreamingChatHandler) StreamingChatHandler {
+		return func(ctx context.Context, messages []Message, opts *ChatOptions) (*ResponseStream[ChatResponseUpdate], error) {
+			src, err := next(ctx, messages, opts)
+			if err != nil {
+				return nil, err
+			}
+			return NewResponseStream(ctx, func(ctx context.Context, ch chan<- ChatResponseUpdate) error {
+				defer src.Close()
+				for {
+					update, ok, err := src.Next(ctx)
+					if err != nil {
+						return err
+					}
+					if !ok {
+						return nil
+					}
+					resolved, err := resolveOperationContents(ctx, update, poller, frequency, ch)
+					if err != nil {
+						return err
+					}
+					select {
+					case ch <- resolved:
+					case <-ctx.Done():
+						return ctx.Err()
+					}
+				}
+			}), nil
+		}
+	}
+}
+
+// resolveOperationContents replaces every non-terminal [operationContent]
+// item in update.Contents with its terminal result, polling poller at
+// frequency and emitting an interim update to ch after each poll.
+func resolveOperationContents(ctx context.Context, update ChatResponseUpdate, poller OperationPoller[Content], frequency time.Duration, ch chan<- ChatResponseUpdate) (ChatResponseUpdate, error) {
+	for i, c := range update.Contents {
+		oc, ok := c.(operationContent)
+		if !ok || oc.operationStatus().terminal() {
+			continue
+		}
+
+		op := NewOperation[Content](oc.operationLocation(), poller)
+		for {
+			done, err := op.Poll(ctx)
+			if err != nil {
+				return ChatResponseUpdate{}, err
+			}
+			if done {
+				break
+			}
+			interim := update
+			interim.Contents = Contents{withOperationStatus(c, op.status)}
+			select {
+			case ch <- interim:
+			case <-ctx.Done():
+				return ChatResponseUpdate{}, ctx.Err()
+			}
+			select {
+			case <-ctx.Done():
+				return ChatResponseUpdate{}, ctx.Err()
+			case <-time.After(frequency):
+			}
+		}
+		if op.status != OperationStatusSucceeded {
+			return ChatResponseUpdate{}, fmt.Errorf("%w: %s finished as %s", ErrOperation, oc.operationLocation(), op.status)
+		}
+		update.Contents[i] = op.result
+	}
+	return update, nil
+}
+
+// withOperationStatus returns a copy of c — an [ImageGenOperationContent]
+// or [CodeInterpreterOperationContent] — with its Status and LastPolledAt
+// updated to reflect the most recent poll.
+func withOperationStatus(c Content, status OperationStatus) Content {
+	switch v := c.(type) {
+	case *ImageGenOperationContent:
+		cp := *v
+		cp.Status, cp.LastPolledAt = status, time.Now()
+		return &cp
+	case *CodeInterpreterOperationContent:
+		cp := *v
+		cp.Status, cp.LastPolledAt = status, time.Now()
+		return &cp
+	default:
+		return c
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package agentframework_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+// fakePoller resolves to result once it has been polled resolveAfter times,
+// incrementing its call count on every [OperationPoller.PollOperation] call.
+type fakePoller struct {
+	resolveAfter int
+	calls        int
+	result       af.Content
+	failWith     error
+}
+
+func (p *fakePoller) PollOperation(_ context.Context, _ string) (af.OperationStatus, af.Content, error) {
+	p.calls++
+	if p.failWith != nil {
+		return "", nil, p.failWith
+	}
+	if p.calls < p.resolveAfter {
+		return af.OperationStatusRunning, nil, nil
+	}
+	return af.OperationStatusSucceeded, p.result, nil
+}
+
+func TestOperation_PollUntilDone(t *testing.T) {
+	want := &af.ImageGenResultContent{CallID: "call-1", URI: "https://example.com/img.png"}
+	poller := &fakePoller{resolveAfter: 3, result: want}
+
+	op := af.NewOperation[af.Content]("op-location", poller)
+	got, err := op.PollUntilDone(context.Background(), time.Millisecond)
+	if err != nil {
+		t.Fatalf("PollUntilDone: %v", err)
+	}
+	if got != af.Content(want) {
+		t.Errorf("result = %+v, want %+v", got, want)
+	}
+	if poller.calls != 3 {
+		t.Errorf("calls = %d, want 3", poller.calls)
+	}
+	if op.Status() != af.OperationStatusSucceeded {
+		t.Errorf("status = %q, want succeeded", op.Status())
+	}
+}
+
+func TestOperation_PollUntilDone_PropagatesFailure(t *testing.T) {
+	poller := &fakePoller{failWith: errors.New("boom")}
+
+	op := af.NewOperation[af.Content]("op-location", poller)
+	_, err := op.PollUntilDone(context.Background(), time.Millisecond)
+	if !errors.Is(err, af.ErrOperation) {
+		t.Errorf("error = %v, want wrapping ErrOperation", err)
+	}
+}
+
+func TestOperation_ResumeToken_RoundTrips(t *testing.T) {
+	poller := &fakePoller{resolveAfter: 1}
+	op := af.NewOperation[af.Content]("op-location", poller)
+	if _, err := op.Poll(context.Background()); err != nil {
+		t.Fatalf("Poll: %v", err)
+	}
+
+	token, err := op.ResumeToken()
+	if err != nil {
+		t.Fatalf("ResumeToken: %v", err)
+	}
+
+	resumed, err := af.NewOperationFromResumeToken[af.Content](token, poller)
+	if err != nil {
+		t.Fatalf("NewOperationFromResumeToken: %v", err)
+	}
+	if resumed.OperationLocation() != op.OperationLocation() {
+		t.Errorf("resumed location = %q, want %q", resumed.OperationLocation(), op.OperationLocation())
+	}
+	if resumed.Status() != op.Status() {
+		t.Errorf("resumed status = %q, want %q", resumed.Status(), op.Status())
+	}
+}
+
+func TestOperationMiddleware_PollsUntilDoneAndSubstitutesResult(t *testing.T) {
+	want := &af.CodeInterpreterResultContent{CallID: "call-1", Output: "42"}
+	poller := &fakePoller{resolveAfter: 2, result: want}
+
+	next := func(_ context.Context, _ []af.Message, _ *af.ChatOptions) (*af.ResponseStream[af.ChatResponseUpdate], error) {
+		return af.NewResponseStream(context.Background(), func(_ context.Context, ch chan<- af.ChatResponseUpdate) error {
+			ch <- af.ChatResponseUpdate{
+				Contents: af.Contents{&af.CodeInterpreterOperationContent{
+					CallID:            "call-1",
+					OperationLocation: "op-location",
+					Status:            af.OperationStatusQueued,
+				}},
+			}
+			return nil
+		}), nil
+	}
+
+	mw := af.OperationMiddleware(poller, time.Millisecond)
+	stream, err := mw(next)(context.Background(), nil, nil)
+	if err != nil {
+		t.Fatalf("middleware handler: %v", err)
+	}
+
+	var final af.ChatResponseUpdate
+	seenInterim := false
+	for {
+		update, ok, err := stream.Next(context.Background())
+		if err != nil {
+			t.Fatalf("stream.Next: %v", err)
+		}
+		if !ok {
+			break
+		}
+		if oc, ok := update.Contents[0].(*af.CodeInterpreterOperationContent); ok && oc.Status == af.OperationStatusRunning {
+			seenInterim = true
+		}
+		final = update
+	}
+
+	if !seenInterim {
+		t.Error("expected an interim running-status update before the terminal one")
+	}
+	got, ok := final.Contents[0].(*af.CodeInterpreterResultContent)
+	if !ok {
+		t.Fatalf("final content type = %T, want *CodeInterpreterResultContent", final.Contents[0])
+	}
+	if got.Output != want.Output {
+		t.Errorf("output = %q, want %q", got.Output, want.Output)
+	}
+}
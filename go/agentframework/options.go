@@ -17,6 +17,16 @@ func ToolChoiceFunction(name string) ToolChoice {
 	return ToolChoice("function:" + name)
 }
 
+// DataSourceExtension describes a retrieval-augmentation data source (e.g.
+// Azure OpenAI "on your data") that providers translate into their native
+// request parameters. Type identifies the provider-specific extension kind;
+// providers that don't recognize it should ignore the extension rather
+// than error.
+type DataSourceExtension struct {
+	Type       string
+	Parameters map[string]any
+}
+
 // ChatOptions configures a single chat completion request.
 // Pointer fields use nil to represent "unset" (use provider default).
 type ChatOptions struct {
@@ -30,15 +40,45 @@ type ChatOptions struct {
 	PresencePenalty  *float64
 	Tools            []Tool
 	ToolChoice       ToolChoice
-	ResponseFormat   any // JSON Schema object or struct type descriptor
+	ResponseFormat   ResponseFormat
 	Metadata         map[string]string
 	User             string
 	Instructions     string
 	ConversationID   string
 	Store            *bool
+	DataSources      []DataSourceExtension
+
+	// EnablePromptCache opts into provider-side prompt caching. On
+	// Anthropic-compatible endpoints this adds `cache_control: {type:
+	// "ephemeral"}` breakpoints to the system prompt and tools array; on
+	// OpenAI it ensures the request's prefix (system, then tools, then
+	// history) stays stable across turns so the provider's automatic
+	// cache can match it. Has no effect on a [ChatClient] that doesn't
+	// implement caching.
+	EnablePromptCache bool
+
+	// CacheKey is an opaque partition key for provider-side prompt
+	// caching, forwarded as OpenAI's `user` field when EnablePromptCache
+	// is set and User is empty. Typically a session ID, so repeated turns
+	// of the same conversation land on the same cache partition.
+	CacheKey string
+
+	// OnUsage, if set, is called once the request's token usage is known:
+	// once for a non-streaming call (via [UsageMiddleware]), or once at
+	// stream end for a streaming call (via [UsageAggregator]). Use it to
+	// feed a cost dashboard or budget guard without threading usage
+	// tracking through every call site; see the pricing subpackage for a
+	// ready-made cost-event sink.
+	OnUsage func(UsageDetails)
 
 	// Extra holds provider-specific options not covered by standard fields.
 	Extra map[string]any
+
+	// ClampToModelLimits, when true, makes [MergeChatOptions] cap MaxTokens
+	// to the ModelID's registered [ModelInfo.MaxOutputTokens] (via
+	// [DefaultCatalog]), if lower. Has no effect when ModelID has no
+	// catalog entry.
+	ClampToModelLimits bool
 }
 
 // MergeChatOptions produces a new ChatOptions by overlaying override values
@@ -51,10 +91,12 @@ func MergeChatOptions(base, override *ChatOptions) *ChatOptions {
 			return &ChatOptions{}
 		}
 		cp := *override
+		clampToModelLimits(&cp)
 		return &cp
 	}
 	if override == nil {
 		cp := *base
+		clampToModelLimits(&cp)
 		return &cp
 	}
 
@@ -99,6 +141,21 @@ func MergeChatOptions(base, override *ChatOptions) *ChatOptions {
 	if override.Store != nil {
 		merged.Store = override.Store
 	}
+	if len(override.DataSources) > 0 {
+		merged.DataSources = override.DataSources
+	}
+	if override.EnablePromptCache {
+		merged.EnablePromptCache = true
+	}
+	if override.CacheKey != "" {
+		merged.CacheKey = override.CacheKey
+	}
+	if override.OnUsage != nil {
+		merged.OnUsage = override.OnUsage
+	}
+	if override.ClampToModelLimits {
+		merged.ClampToModelLimits = true
+	}
 
 	// Instructions: concatenate
 	if override.Instructions != "" {
@@ -155,5 +212,24 @@ func MergeChatOptions(base, override *ChatOptions) *ChatOptions {
 		}
 	}
 
+	clampToModelLimits(&merged)
+
 	return &merged
 }
+
+// clampToModelLimits caps opts.MaxTokens to the registered model's
+// MaxOutputTokens, when opts.ClampToModelLimits is set and the model has a
+// catalog entry declaring a lower limit.
+func clampToModelLimits(opts *ChatOptions) {
+	if !opts.ClampToModelLimits || opts.MaxTokens == nil {
+		return
+	}
+	info, ok := DefaultCatalog.Lookup(opts.ModelID)
+	if !ok || info.MaxOutputTokens <= 0 {
+		return
+	}
+	if *opts.MaxTokens > info.MaxOutputTokens {
+		clamped := info.MaxOutputTokens
+		opts.MaxTokens = &clamped
+	}
+}
@@ -93,6 +93,48 @@ func TestMergeChatOptions_MetadataMerge(t *testing.T) {
 	}
 }
 
+func TestMergeChatOptions_PromptCacheMerge(t *testing.T) {
+	base := &af.ChatOptions{CacheKey: "session-1"}
+	override := &af.ChatOptions{EnablePromptCache: true}
+	merged := af.MergeChatOptions(base, override)
+
+	if !merged.EnablePromptCache {
+		t.Error("EnablePromptCache = false, want true")
+	}
+	if merged.CacheKey != "session-1" {
+		t.Errorf("CacheKey = %q, want session-1 (preserved from base)", merged.CacheKey)
+	}
+
+	override2 := &af.ChatOptions{CacheKey: "session-2"}
+	merged2 := af.MergeChatOptions(merged, override2)
+	if merged2.CacheKey != "session-2" {
+		t.Errorf("CacheKey = %q, want session-2 (overridden)", merged2.CacheKey)
+	}
+}
+
+func TestMergeChatOptions_ClampToModelLimits(t *testing.T) {
+	t.Cleanup(func() { af.RegisterModel(af.ModelInfo{ID: "clamp-test-model", MaxOutputTokens: 4096}) })
+	af.RegisterModel(af.ModelInfo{ID: "clamp-test-model", MaxOutputTokens: 4096})
+
+	huge := 100_000
+	base := &af.ChatOptions{ModelID: "clamp-test-model", MaxTokens: &huge, ClampToModelLimits: true}
+	merged := af.MergeChatOptions(base, nil)
+
+	if merged.MaxTokens == nil || *merged.MaxTokens != 4096 {
+		t.Errorf("MaxTokens = %v, want 4096", merged.MaxTokens)
+	}
+}
+
+func TestMergeChatOptions_ClampToModelLimits_UnknownModelNoop(t *testing.T) {
+	huge := 100_000
+	base := &af.ChatOptions{ModelID: "no-such-model", MaxTokens: &huge, ClampToModelLimits: true}
+	merged := af.MergeChatOptions(base, nil)
+
+	if *merged.MaxTokens != huge {
+		t.Errorf("MaxTokens = %v, want unchanged %d", merged.MaxTokens, huge)
+	}
+}
+
 func TestToolChoiceFunction(t *testing.T) {
 	tc := af.ToolChoiceFunction("get_weather")
 	expected := af.ToolChoice("function:get_weather")
@@ -0,0 +1,46 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package otel
+
+import (
+	"context"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+// TraceAgentStream wraps src the same way [TraceStream] does, additionally
+// recording the final merged [af.UsageDetails] as agent.tokens.input and
+// agent.tokens.output counters once the stream ends — the same metric
+// names [af.Agent.Run] itself reports, so streamed and non-streamed runs
+// aggregate together.
+func TraceAgentStream(ctx context.Context, name string, src *af.ResponseStream[af.AgentResponseUpdate], opts ...Option) *af.ResponseStream[af.AgentResponseUpdate] {
+	cfg := defaultConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	traced := TraceStream(ctx, name, src, opts...)
+	return af.NewResponseStream(ctx, func(ctx context.Context, ch chan<- af.AgentResponseUpdate) error {
+		defer traced.Close()
+		var usage af.UsageDetails
+		for {
+			val, ok, err := traced.Next(ctx)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				cfg.meter.Counter("agent.tokens.input").Add(ctx, int64(usage.InputTokens))
+				cfg.meter.Counter("agent.tokens.output").Add(ctx, int64(usage.OutputTokens))
+				return nil
+			}
+			if val.Usage.TotalTokens > 0 {
+				usage = val.Usage
+			}
+			select {
+			case ch <- val:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	})
+}
@@ -0,0 +1,44 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package otel
+
+import (
+	"context"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+// TraceChatStream is the [af.ChatResponseUpdate] counterpart to
+// [TraceAgentStream], for [af.ChatClient] implementations traced directly
+// rather than through an [af.Agent].
+func TraceChatStream(ctx context.Context, name string, src *af.ResponseStream[af.ChatResponseUpdate], opts ...Option) *af.ResponseStream[af.ChatResponseUpdate] {
+	cfg := defaultConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	traced := TraceStream(ctx, name, src, opts...)
+	return af.NewResponseStream(ctx, func(ctx context.Context, ch chan<- af.ChatResponseUpdate) error {
+		defer traced.Close()
+		var usage af.UsageDetails
+		for {
+			val, ok, err := traced.Next(ctx)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				cfg.meter.Counter("agent.tokens.input").Add(ctx, int64(usage.InputTokens))
+				cfg.meter.Counter("agent.tokens.output").Add(ctx, int64(usage.OutputTokens))
+				return nil
+			}
+			if val.Usage.TotalTokens > 0 {
+				usage = val.Usage
+			}
+			select {
+			case ch <- val:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	})
+}
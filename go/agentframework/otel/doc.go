@@ -0,0 +1,21 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+// Package otel instruments [af.ResponseStream] and [af.MessageStore] with
+// the [af.Tracer]/[af.Meter] abstractions that the rest of the framework
+// already uses, so a real OpenTelemetry SDK adapted to those interfaces
+// lights up here too without a direct OTel dependency.
+//
+// [TraceStream] wraps a stream with a span that starts when the stream is
+// created and ends once the underlying producer returns, recording a
+// sampled attribute update per value. [TraceAgentStream] and
+// [TraceChatStream] additionally record the producer's token usage (for
+// streams of [af.AgentResponseUpdate] or [af.ChatResponseUpdate]) as
+// agent.tokens.input/agent.tokens.output counters — the same metric names
+// [af.Agent] itself reports, so streamed and non-streamed runs aggregate
+// together. [WrapMessageStore] wraps a [af.MessageStore], recording
+// store.messages.added and store.messages.listed histograms.
+package otel
+
+import af "github.com/microsoft/agent-framework/go/agentframework"
+
+var _ af.MessageStore = (*tracedMessageStore)(nil)
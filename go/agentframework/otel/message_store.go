@@ -0,0 +1,56 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package otel
+
+import (
+	"context"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+// tracedMessageStore wraps an [af.MessageStore], recording
+// store.messages.added and store.messages.listed histograms with
+// conversation.id as an attribute.
+type tracedMessageStore struct {
+	store af.MessageStore
+	cfg   *config
+}
+
+// WrapMessageStore instruments store, recording store.messages.added and
+// store.messages.listed histograms on every [af.MessageStore.AddMessages]
+// and [af.MessageStore.ListMessages] call. Use [WithConversationID] to tag
+// the recorded metrics with the conversation this store instance serves.
+func WrapMessageStore(store af.MessageStore, opts ...Option) af.MessageStore {
+	cfg := defaultConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return &tracedMessageStore{store: store, cfg: cfg}
+}
+
+func (s *tracedMessageStore) attrs() []af.KeyValue {
+	if s.cfg.conversationID == "" {
+		return nil
+	}
+	return []af.KeyValue{af.Attr("conversation.id", s.cfg.conversationID)}
+}
+
+func (s *tracedMessageStore) ListMessages(ctx context.Context) ([]af.Message, error) {
+	msgs, err := s.store.ListMessages(ctx)
+	s.cfg.meter.Histogram("store.messages.listed").Record(ctx, float64(len(msgs)), s.attrs()...)
+	return msgs, err
+}
+
+func (s *tracedMessageStore) AddMessages(ctx context.Context, msgs []af.Message) error {
+	err := s.store.AddMessages(ctx, msgs)
+	s.cfg.meter.Histogram("store.messages.added").Record(ctx, float64(len(msgs)), s.attrs()...)
+	return err
+}
+
+func (s *tracedMessageStore) Truncate(ctx context.Context, messageID string) error {
+	return s.store.Truncate(ctx, messageID)
+}
+
+func (s *tracedMessageStore) Serialize() (map[string]any, error) {
+	return s.store.Serialize()
+}
@@ -0,0 +1,55 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package otel
+
+import af "github.com/microsoft/agent-framework/go/agentframework"
+
+// config holds the tracer, meter, sampling rate, and conversation ID used
+// by [TraceStream] and [WrapMessageStore].
+type config struct {
+	tracer         af.Tracer
+	meter          af.Meter
+	sampleEvery    int
+	conversationID string
+}
+
+func defaultConfig() *config {
+	return &config{
+		tracer:      af.NoopTracer,
+		meter:       af.NoopMeter,
+		sampleEvery: 1,
+	}
+}
+
+// Option configures [TraceStream] and [WrapMessageStore].
+type Option func(*config)
+
+// WithTracer sets the [af.Tracer] used to emit spans. Defaults to
+// [af.NoopTracer].
+func WithTracer(t af.Tracer) Option {
+	return func(c *config) { c.tracer = t }
+}
+
+// WithMeter sets the [af.Meter] used to record counters and histograms.
+// Defaults to [af.NoopMeter].
+func WithMeter(m af.Meter) Option {
+	return func(c *config) { c.meter = m }
+}
+
+// WithSampleEvery attributes one out of every n values a [TraceStream]-wrapped
+// stream emits, rather than every value — cheap observability for
+// high-throughput token streams. n <= 1 attributes every value.
+func WithSampleEvery(n int) Option {
+	return func(c *config) {
+		if n < 1 {
+			n = 1
+		}
+		c.sampleEvery = n
+	}
+}
+
+// WithConversationID attaches a conversation.id attribute to every metric
+// [WrapMessageStore] records for this store instance.
+func WithConversationID(id string) Option {
+	return func(c *config) { c.conversationID = id }
+}
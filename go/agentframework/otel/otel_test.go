@@ -0,0 +1,209 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package otel_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+	"github.com/microsoft/agent-framework/go/agentframework/otel"
+)
+
+type fakeSpan struct {
+	mu    sync.Mutex
+	attrs []af.KeyValue
+	errs  []error
+	ended bool
+}
+
+func (s *fakeSpan) SetAttributes(attrs ...af.KeyValue) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attrs = append(s.attrs, attrs...)
+}
+func (s *fakeSpan) RecordError(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errs = append(s.errs, err)
+}
+func (s *fakeSpan) End() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ended = true
+}
+
+type fakeTracer struct {
+	mu    sync.Mutex
+	spans []*fakeSpan
+}
+
+func (t *fakeTracer) Start(ctx context.Context, _ string, _ ...af.KeyValue) (context.Context, af.Span) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	span := &fakeSpan{}
+	t.spans = append(t.spans, span)
+	return ctx, span
+}
+
+type fakeCounter struct {
+	mu    sync.Mutex
+	total int64
+}
+
+func (c *fakeCounter) Add(_ context.Context, delta int64, _ ...af.KeyValue) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.total += delta
+}
+
+type fakeHistogram struct {
+	mu      sync.Mutex
+	records []float64
+}
+
+func (h *fakeHistogram) Record(_ context.Context, value float64, _ ...af.KeyValue) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.records = append(h.records, value)
+}
+
+type fakeMeter struct {
+	mu         sync.Mutex
+	counters   map[string]*fakeCounter
+	histograms map[string]*fakeHistogram
+}
+
+func newFakeMeter() *fakeMeter {
+	return &fakeMeter{counters: map[string]*fakeCounter{}, histograms: map[string]*fakeHistogram{}}
+}
+
+func (m *fakeMeter) Counter(name string) af.Counter {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if c, ok := m.counters[name]; ok {
+		return c
+	}
+	c := &fakeCounter{}
+	m.counters[name] = c
+	return c
+}
+
+func (m *fakeMeter) Histogram(name string) af.Histogram {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if h, ok := m.histograms[name]; ok {
+		return h
+	}
+	h := &fakeHistogram{}
+	m.histograms[name] = h
+	return h
+}
+
+func TestTraceStream_EndsSpanAndRecordsItemCount(t *testing.T) {
+	ctx := context.Background()
+	tracer := &fakeTracer{}
+	src := af.NewResponseStream(ctx, func(ctx context.Context, ch chan<- int) error {
+		ch <- 1
+		ch <- 2
+		ch <- 3
+		return nil
+	})
+
+	traced := otel.TraceStream(ctx, "test.stream", src, otel.WithTracer(tracer))
+	defer traced.Close()
+
+	items, err := traced.Collect(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 3 {
+		t.Fatalf("len(items) = %d, want 3", len(items))
+	}
+
+	if len(tracer.spans) != 1 {
+		t.Fatalf("len(spans) = %d, want 1", len(tracer.spans))
+	}
+	span := tracer.spans[0]
+	span.mu.Lock()
+	defer span.mu.Unlock()
+	if !span.ended {
+		t.Error("span was never ended")
+	}
+	if len(span.attrs) == 0 {
+		t.Error("expected at least one attribute recorded")
+	}
+}
+
+func TestTraceStream_RecordsProducerError(t *testing.T) {
+	ctx := context.Background()
+	tracer := &fakeTracer{}
+	expectedErr := af.ErrService
+	src := af.NewResponseStream(ctx, func(ctx context.Context, ch chan<- int) error {
+		ch <- 1
+		return expectedErr
+	})
+
+	traced := otel.TraceStream(ctx, "test.stream", src, otel.WithTracer(tracer))
+	defer traced.Close()
+
+	_, err := traced.Collect(ctx)
+	if !errors.Is(err, expectedErr) {
+		t.Fatalf("err = %v, want %v", err, expectedErr)
+	}
+
+	span := tracer.spans[0]
+	span.mu.Lock()
+	defer span.mu.Unlock()
+	if len(span.errs) != 1 {
+		t.Fatalf("len(errs) = %d, want 1", len(span.errs))
+	}
+}
+
+func TestTraceAgentStream_RecordsTokenCounters(t *testing.T) {
+	ctx := context.Background()
+	meter := newFakeMeter()
+	src := af.NewResponseStream(ctx, func(ctx context.Context, ch chan<- af.AgentResponseUpdate) error {
+		ch <- af.AgentResponseUpdate{Contents: af.Contents{&af.TextContent{Text: "hi"}}}
+		ch <- af.AgentResponseUpdate{Usage: af.UsageDetails{InputTokens: 10, OutputTokens: 5, TotalTokens: 15}}
+		return nil
+	})
+
+	traced := otel.TraceAgentStream(ctx, "test.agent", src, otel.WithMeter(meter))
+	defer traced.Close()
+
+	if _, err := traced.Collect(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := meter.Counter("agent.tokens.input").(*fakeCounter).total; got != 10 {
+		t.Errorf("agent.tokens.input = %d, want 10", got)
+	}
+	if got := meter.Counter("agent.tokens.output").(*fakeCounter).total; got != 5 {
+		t.Errorf("agent.tokens.output = %d, want 5", got)
+	}
+}
+
+func TestWrapMessageStore_RecordsHistograms(t *testing.T) {
+	ctx := context.Background()
+	meter := newFakeMeter()
+	store := otel.WrapMessageStore(af.NewInMemoryStore(), otel.WithMeter(meter), otel.WithConversationID("conv-1"))
+
+	if err := store.AddMessages(ctx, []af.Message{af.NewUserMessage("hi"), af.NewUserMessage("there")}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.ListMessages(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	added := meter.Histogram("store.messages.added").(*fakeHistogram)
+	if len(added.records) != 1 || added.records[0] != 2 {
+		t.Errorf("store.messages.added = %v, want [2]", added.records)
+	}
+	listed := meter.Histogram("store.messages.listed").(*fakeHistogram)
+	if len(listed.records) != 1 || listed.records[0] != 2 {
+		t.Errorf("store.messages.listed = %v, want [2]", listed.records)
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package otel
+
+import (
+	"context"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+// TraceStream wraps src with a span named name that starts immediately and
+// ends once src is exhausted or errors. Every [WithSampleEvery] value
+// attributes the span with the running item count; a producer error is
+// recorded on the span before it ends.
+func TraceStream[T any](ctx context.Context, name string, src *af.ResponseStream[T], opts ...Option) *af.ResponseStream[T] {
+	cfg := defaultConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	spanCtx, span := cfg.tracer.Start(ctx, name)
+	return af.NewResponseStream[T](spanCtx, func(ctx context.Context, ch chan<- T) error {
+		defer src.Close()
+		defer span.End()
+
+		count := 0
+		for {
+			val, ok, err := src.Next(ctx)
+			if err != nil {
+				span.RecordError(err)
+				return err
+			}
+			if !ok {
+				span.SetAttributes(af.Attr("stream.items", count))
+				return nil
+			}
+			count++
+			if count%cfg.sampleEvery == 0 {
+				span.SetAttributes(af.Attr("stream.items", count))
+			}
+			select {
+			case ch <- val:
+			case <-ctx.Done():
+				span.RecordError(ctx.Err())
+				return ctx.Err()
+			}
+		}
+	})
+}
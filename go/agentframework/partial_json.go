@@ -0,0 +1,80 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package agentframework
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// PartialJSON best-effort repairs an incomplete JSON document — typically a
+// tool call's arguments string as it arrives chunk by chunk over a stream —
+// into something that parses. It closes any open string, drops a trailing
+// comma, fills in null for a key whose value hasn't arrived yet, and closes
+// any open objects or arrays. ok is false if s still doesn't parse after repair (e.g. s is
+// empty, or malformed in a way repair can't fix); callers should treat the
+// result as good enough for live display, not as a guarantee that the final
+// argument value will match it.
+func PartialJSON(s string) (result json.RawMessage, ok bool) {
+	if json.Valid([]byte(s)) {
+		return json.RawMessage(s), true
+	}
+
+	repaired := repairPartialJSON(s)
+	if json.Valid([]byte(repaired)) {
+		return json.RawMessage(repaired), true
+	}
+	return nil, false
+}
+
+// repairPartialJSON scans s tracking open containers and string state, then
+// appends whatever is needed to make it syntactically complete.
+func repairPartialJSON(s string) string {
+	var stack []byte
+	inString := false
+	escaped := false
+
+	for _, r := range s {
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case r == '\\':
+				escaped = true
+			case r == '"':
+				inString = false
+			}
+			continue
+		}
+		switch r {
+		case '"':
+			inString = true
+		case '{', '[':
+			stack = append(stack, byte(r))
+		case '}', ']':
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+
+	out := s
+	if inString {
+		out += `"`
+	}
+	out = strings.TrimRight(out, " \t\r\n")
+	out = strings.TrimSuffix(out, ",")
+	if strings.HasSuffix(out, ":") {
+		out += "null"
+	}
+
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch stack[i] {
+		case '{':
+			out += "}"
+		case '[':
+			out += "]"
+		}
+	}
+	return out
+}
@@ -0,0 +1,84 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package agentframework_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+func TestPartialJSON_AlreadyValid(t *testing.T) {
+	result, ok := af.PartialJSON(`{"city":"Seattle"}`)
+	if !ok {
+		t.Fatal("expected ok")
+	}
+	if string(result) != `{"city":"Seattle"}` {
+		t.Errorf("result = %s", result)
+	}
+}
+
+func TestPartialJSON_UnclosedString(t *testing.T) {
+	result, ok := af.PartialJSON(`{"city":"Seat`)
+	if !ok {
+		t.Fatal("expected ok")
+	}
+	var parsed map[string]any
+	if err := json.Unmarshal(result, &parsed); err != nil {
+		t.Fatalf("repaired result does not parse: %v (%s)", err, result)
+	}
+	if parsed["city"] != "Seat" {
+		t.Errorf("city = %v, want %q", parsed["city"], "Seat")
+	}
+}
+
+func TestPartialJSON_DanglingKey(t *testing.T) {
+	result, ok := af.PartialJSON(`{"city":`)
+	if !ok {
+		t.Fatal("expected ok")
+	}
+	var parsed map[string]any
+	if err := json.Unmarshal(result, &parsed); err != nil {
+		t.Fatalf("repaired result does not parse: %v (%s)", err, result)
+	}
+	if parsed["city"] != nil {
+		t.Errorf("city = %v, want nil", parsed["city"])
+	}
+}
+
+func TestPartialJSON_TrailingComma(t *testing.T) {
+	result, ok := af.PartialJSON(`{"city":"Seattle",`)
+	if !ok {
+		t.Fatal("expected ok")
+	}
+	var parsed map[string]any
+	if err := json.Unmarshal(result, &parsed); err != nil {
+		t.Fatalf("repaired result does not parse: %v (%s)", err, result)
+	}
+	if parsed["city"] != "Seattle" {
+		t.Errorf("city = %v", parsed["city"])
+	}
+}
+
+func TestPartialJSON_NestedArray(t *testing.T) {
+	result, ok := af.PartialJSON(`{"tags":["a","b"`)
+	if !ok {
+		t.Fatal("expected ok")
+	}
+	var parsed map[string]any
+	if err := json.Unmarshal(result, &parsed); err != nil {
+		t.Fatalf("repaired result does not parse: %v (%s)", err, result)
+	}
+	tags, ok := parsed["tags"].([]any)
+	if !ok || len(tags) != 2 {
+		t.Errorf("tags = %v", parsed["tags"])
+	}
+}
+
+func TestPartialJSON_Empty(t *testing.T) {
+	_, ok := af.PartialJSON("")
+	if ok {
+		t.Error("expected ok = false for empty input")
+	}
+}
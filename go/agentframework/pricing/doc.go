@@ -0,0 +1,14 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+// Package pricing turns token usage into an estimated monetary cost. A
+// [Pricer] maps a model ID and [af.UsageDetails] to a cost; [TablePricer]
+// is a static per-model rate table covering common OpenAI and Anthropic
+// models, and [Middleware] wraps a [af.ChatHandler] to emit a [CostEvent]
+// per completed request — useful for budget guards or cost dashboards
+// similar to what LLM gateways expose.
+//
+//	sink := func(e pricing.CostEvent) { log.Printf("%s: $%.4f", e.Model, e.Amount) }
+//	agent := agentframework.NewAgent(client,
+//	    agentframework.WithChatMiddleware(pricing.Middleware(pricing.NewDefaultPricer(), sink)),
+//	)
+package pricing
@@ -0,0 +1,98 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package pricing
+
+import (
+	"context"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+// Pricer computes the cost of a model's token usage. Implementations
+// should return an empty currency and zero amount for a model they don't
+// recognize, rather than erroring, so a caller can still see the request
+// happened even if it can't be priced.
+type Pricer interface {
+	Cost(model string, u af.UsageDetails) (currency string, amount float64)
+}
+
+// ModelRate is the USD cost per million input and output tokens for one
+// model, used by [TablePricer].
+type ModelRate struct {
+	InputPerMillion  float64
+	OutputPerMillion float64
+}
+
+// DefaultRates is built-in per-million-token USD pricing for common
+// OpenAI and Anthropic models. Prices drift over time — override or
+// extend via [NewTablePricer] rather than relying on this staying
+// current.
+var DefaultRates = map[string]ModelRate{
+	"gpt-4o":            {InputPerMillion: 2.50, OutputPerMillion: 10.00},
+	"gpt-4o-mini":       {InputPerMillion: 0.15, OutputPerMillion: 0.60},
+	"gpt-4.1":           {InputPerMillion: 2.00, OutputPerMillion: 8.00},
+	"gpt-4.1-mini":      {InputPerMillion: 0.40, OutputPerMillion: 1.60},
+	"o3":                {InputPerMillion: 2.00, OutputPerMillion: 8.00},
+	"claude-3-5-sonnet": {InputPerMillion: 3.00, OutputPerMillion: 15.00},
+	"claude-3-5-haiku":  {InputPerMillion: 0.80, OutputPerMillion: 4.00},
+	"claude-3-opus":     {InputPerMillion: 15.00, OutputPerMillion: 75.00},
+}
+
+// TablePricer is a [Pricer] backed by a static per-model rate table,
+// priced in USD.
+type TablePricer struct {
+	rates map[string]ModelRate
+}
+
+// NewTablePricer creates a [TablePricer] from rates, keyed by model ID.
+func NewTablePricer(rates map[string]ModelRate) *TablePricer {
+	return &TablePricer{rates: rates}
+}
+
+// NewDefaultPricer creates a [TablePricer] preloaded with [DefaultRates].
+func NewDefaultPricer() *TablePricer {
+	return NewTablePricer(DefaultRates)
+}
+
+// Cost implements [Pricer]. Returns ("", 0) for a model not present in
+// the table.
+func (p *TablePricer) Cost(model string, u af.UsageDetails) (currency string, amount float64) {
+	rate, ok := p.rates[model]
+	if !ok {
+		return "", 0
+	}
+	amount = float64(u.InputTokens)/1_000_000*rate.InputPerMillion +
+		float64(u.OutputTokens)/1_000_000*rate.OutputPerMillion
+	return "USD", amount
+}
+
+// CostEvent is emitted by [Middleware] once per completed chat request.
+type CostEvent struct {
+	Model    string
+	Usage    af.UsageDetails
+	Currency string
+	Amount   float64
+}
+
+// Middleware returns an [af.ChatMiddleware] that prices each completed
+// request's usage with p and passes the result to sink. sink is called
+// even for a model p doesn't recognize (Amount 0, Currency ""), so gaps
+// in the pricing table stay visible instead of silently dropping events.
+func Middleware(p Pricer, sink func(CostEvent)) af.ChatMiddleware {
+	return func(next af.ChatHandler) af.ChatHandler {
+		return func(ctx context.Context, messages []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error) {
+			resp, err := next(ctx, messages, opts)
+			if err != nil || resp == nil {
+				return resp, err
+			}
+			currency, amount := p.Cost(resp.ModelID, resp.Usage)
+			sink(CostEvent{
+				Model:    resp.ModelID,
+				Usage:    resp.Usage,
+				Currency: currency,
+				Amount:   amount,
+			})
+			return resp, nil
+		}
+	}
+}
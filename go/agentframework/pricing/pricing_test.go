@@ -0,0 +1,80 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package pricing_test
+
+import (
+	"context"
+	"testing"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+	"github.com/microsoft/agent-framework/go/agentframework/pricing"
+)
+
+func TestTablePricer_Cost(t *testing.T) {
+	p := pricing.NewTablePricer(map[string]pricing.ModelRate{
+		"test-model": {InputPerMillion: 1, OutputPerMillion: 2},
+	})
+
+	currency, amount := p.Cost("test-model", af.UsageDetails{InputTokens: 1_000_000, OutputTokens: 500_000})
+	if currency != "USD" {
+		t.Errorf("currency = %q, want USD", currency)
+	}
+	if amount != 2 {
+		t.Errorf("amount = %v, want 2", amount)
+	}
+}
+
+func TestTablePricer_Cost_UnknownModel(t *testing.T) {
+	p := pricing.NewDefaultPricer()
+	currency, amount := p.Cost("unknown-model", af.UsageDetails{InputTokens: 100})
+	if currency != "" || amount != 0 {
+		t.Errorf("cost = (%q, %v), want (\"\", 0)", currency, amount)
+	}
+}
+
+func TestMiddleware_EmitsCostEvent(t *testing.T) {
+	handler := func(ctx context.Context, messages []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error) {
+		return &af.ChatResponse{
+			ModelID: "test-model",
+			Usage:   af.UsageDetails{InputTokens: 1_000_000, OutputTokens: 1_000_000},
+		}, nil
+	}
+
+	p := pricing.NewTablePricer(map[string]pricing.ModelRate{
+		"test-model": {InputPerMillion: 1, OutputPerMillion: 3},
+	})
+
+	var events []pricing.CostEvent
+	wrapped := pricing.Middleware(p, func(e pricing.CostEvent) { events = append(events, e) })(handler)
+
+	if _, err := wrapped(context.Background(), nil, nil); err != nil {
+		t.Fatalf("handler: %v", err)
+	}
+
+	if len(events) != 1 {
+		t.Fatalf("events = %d, want 1", len(events))
+	}
+	if events[0].Amount != 4 {
+		t.Errorf("amount = %v, want 4", events[0].Amount)
+	}
+	if events[0].Model != "test-model" {
+		t.Errorf("model = %q", events[0].Model)
+	}
+}
+
+func TestMiddleware_PropagatesHandlerError(t *testing.T) {
+	wantErr := context.Canceled
+	handler := func(ctx context.Context, messages []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error) {
+		return nil, wantErr
+	}
+
+	var called bool
+	wrapped := pricing.Middleware(pricing.NewDefaultPricer(), func(pricing.CostEvent) { called = true })(handler)
+
+	if _, err := wrapped(context.Background(), nil, nil); err != wantErr {
+		t.Errorf("err = %v, want %v", err, wantErr)
+	}
+	if called {
+		t.Error("sink should not be called when the handler errors")
+	}
+}
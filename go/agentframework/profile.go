@@ -0,0 +1,209 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package agentframework
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// AgentProfile bundles everything that defines a named persona: system
+// instructions, a tool set, optional [ContextProvider]s (e.g. file-backed
+// RAG via [NewFileGlobContextProvider]), and default [ChatOptions]. Register
+// one with a [ProfileRegistry] and apply it to an [Agent] via [WithProfile]
+// or, per request, [WithProfileOverride].
+type AgentProfile struct {
+	// Name identifies the profile within a [ProfileRegistry].
+	Name string
+
+	// Instructions is the system prompt for this persona.
+	Instructions string
+
+	// Tools is the tool set available to this persona. Typically resolved
+	// from a [Toolbox] by name (see [Toolbox.Resolve] and
+	// [ProfileRegistry.LoadProfilesJSON]).
+	Tools []Tool
+
+	// ContextProviders are chained in order: each one's returned
+	// [InvocationContext] is merged into the request (instructions
+	// concatenated, messages and tools appended).
+	ContextProviders []ContextProvider
+
+	// DefaultOptions are merged over the agent's own defaults, and any
+	// per-call [WithRunOptions] are merged over them (see [MergeChatOptions]).
+	DefaultOptions *ChatOptions
+}
+
+// combinedContextProvider composes p.ContextProviders into a single
+// [ContextProvider], or returns nil if there are none.
+func (p *AgentProfile) combinedContextProvider() ContextProvider {
+	switch len(p.ContextProviders) {
+	case 0:
+		return nil
+	case 1:
+		return p.ContextProviders[0]
+	default:
+		return &compositeContextProvider{providers: p.ContextProviders}
+	}
+}
+
+// Toolbox is a named registry of [Tool]s that [AgentProfile] definitions
+// reference by string key, so profiles loaded declaratively (see
+// [ProfileRegistry.LoadProfilesJSON]) can assemble a tool set without
+// embedding Go closures in config.
+type Toolbox struct {
+	mu    sync.RWMutex
+	tools map[string]Tool
+}
+
+// NewToolbox creates a [Toolbox], optionally pre-registering tools.
+func NewToolbox(tools ...Tool) *Toolbox {
+	tb := &Toolbox{tools: make(map[string]Tool, len(tools))}
+	for _, t := range tools {
+		tb.Register(t)
+	}
+	return tb
+}
+
+// Register adds tool to the toolbox under its own [Tool.Name], replacing
+// any existing entry with the same name.
+func (tb *Toolbox) Register(tool Tool) {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	tb.tools[tool.Name()] = tool
+}
+
+// Get looks up a tool by name.
+func (tb *Toolbox) Get(name string) (Tool, bool) {
+	tb.mu.RLock()
+	defer tb.mu.RUnlock()
+	t, ok := tb.tools[name]
+	return t, ok
+}
+
+// Resolve looks up each of names, returning an [ErrProfile] error naming
+// the first one not found in the toolbox.
+func (tb *Toolbox) Resolve(names []string) ([]Tool, error) {
+	tb.mu.RLock()
+	defer tb.mu.RUnlock()
+	tools := make([]Tool, 0, len(names))
+	for _, name := range names {
+		t, ok := tb.tools[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: tool %q not registered in toolbox", ErrProfile, name)
+		}
+		tools = append(tools, t)
+	}
+	return tools, nil
+}
+
+// ProfileRegistry holds named [AgentProfile]s that an [Agent] can be
+// configured with via [WithProfile] or, per request, [WithProfileOverride].
+type ProfileRegistry struct {
+	mu       sync.RWMutex
+	profiles map[string]*AgentProfile
+	toolbox  *Toolbox
+}
+
+// ProfileRegistryOption configures a [ProfileRegistry] via [NewProfileRegistry].
+type ProfileRegistryOption func(*ProfileRegistry)
+
+// WithToolbox sets the [Toolbox] used to resolve tool names in profiles
+// loaded by [ProfileRegistry.LoadProfilesJSON].
+func WithToolbox(tb *Toolbox) ProfileRegistryOption {
+	return func(r *ProfileRegistry) { r.toolbox = tb }
+}
+
+// NewProfileRegistry creates an empty [ProfileRegistry].
+func NewProfileRegistry(opts ...ProfileRegistryOption) *ProfileRegistry {
+	r := &ProfileRegistry{profiles: make(map[string]*AgentProfile)}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Register adds profile to the registry, replacing any existing profile
+// with the same Name.
+func (r *ProfileRegistry) Register(profile AgentProfile) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	p := profile
+	r.profiles[p.Name] = &p
+}
+
+// Get looks up a profile by name.
+func (r *ProfileRegistry) Get(name string) (*AgentProfile, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	p, ok := r.profiles[name]
+	return p, ok
+}
+
+// Names returns the names of every registered profile, sorted.
+func (r *ProfileRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.profiles))
+	for name := range r.profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// profileDoc is the on-disk JSON shape for one profile definition loaded by
+// [ProfileRegistry.LoadProfilesJSON]. Tools are referenced by name against
+// the registry's [Toolbox] rather than embedded, since a Tool's Go handler
+// can't be expressed in JSON.
+type profileDoc struct {
+	Name           string       `json:"name"`
+	Instructions   string       `json:"instructions"`
+	Tools          []string     `json:"tools,omitempty"`
+	DefaultOptions *ChatOptions `json:"defaultOptions,omitempty"`
+}
+
+// LoadProfilesJSON registers one [AgentProfile] per entry in data, a JSON
+// array of declarative profile definitions. Each profile's Tools names are
+// resolved against the registry's [Toolbox] (see [WithToolbox]); an
+// unresolvable name is reported as an error naming both the profile and the
+// tool.
+//
+// Only JSON is supported for now — this module doesn't vendor a YAML
+// library. The on-disk shape is simple enough that a YAML-to-JSON
+// preprocessing step (e.g. sigs.k8s.io/yaml) can sit in front of this
+// function unchanged once that dependency is available.
+func (r *ProfileRegistry) LoadProfilesJSON(data []byte) error {
+	var docs []profileDoc
+	if err := json.Unmarshal(data, &docs); err != nil {
+		return fmt.Errorf("%w: decode profiles: %w", ErrProfile, err)
+	}
+
+	for _, doc := range docs {
+		if doc.Name == "" {
+			return fmt.Errorf("%w: profile missing name", ErrProfile)
+		}
+
+		var tools []Tool
+		if len(doc.Tools) > 0 {
+			if r.toolbox == nil {
+				return fmt.Errorf("%w: profile %q references tools but no Toolbox is configured (see WithToolbox)", ErrProfile, doc.Name)
+			}
+			resolved, err := r.toolbox.Resolve(doc.Tools)
+			if err != nil {
+				return fmt.Errorf("%w: profile %q: %w", ErrProfile, doc.Name, err)
+			}
+			tools = resolved
+		}
+
+		r.Register(AgentProfile{
+			Name:           doc.Name,
+			Instructions:   doc.Instructions,
+			Tools:          tools,
+			DefaultOptions: doc.DefaultOptions,
+		})
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package agentframework_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+func newEchoTool(name string) *af.FunctionTool {
+	return af.NewTool(name, "echoes its name", json.RawMessage(`{"type":"object"}`),
+		func(ctx context.Context, args json.RawMessage) (any, error) { return name, nil },
+	)
+}
+
+func TestProfileRegistry_RegisterAndGet(t *testing.T) {
+	registry := af.NewProfileRegistry()
+	registry.Register(af.AgentProfile{Name: "support", Instructions: "Be kind."})
+
+	profile, ok := registry.Get("support")
+	if !ok {
+		t.Fatal("expected profile to be found")
+	}
+	if profile.Instructions != "Be kind." {
+		t.Errorf("Instructions = %q", profile.Instructions)
+	}
+
+	if _, ok := registry.Get("missing"); ok {
+		t.Error("expected missing profile to not be found")
+	}
+
+	if names := registry.Names(); len(names) != 1 || names[0] != "support" {
+		t.Errorf("Names() = %v", names)
+	}
+}
+
+func TestToolbox_ResolveUnknownTool(t *testing.T) {
+	tb := af.NewToolbox(newEchoTool("a"))
+	if _, err := tb.Resolve([]string{"a", "b"}); err == nil {
+		t.Fatal("expected error resolving unknown tool")
+	} else if !errors.Is(err, af.ErrProfile) {
+		t.Errorf("error = %v, want wrapping ErrProfile", err)
+	}
+}
+
+func TestProfileRegistry_LoadProfilesJSON(t *testing.T) {
+	tb := af.NewToolbox(newEchoTool("get_weather"))
+	registry := af.NewProfileRegistry(af.WithToolbox(tb))
+
+	doc := `[
+		{"name": "weather-bot", "instructions": "Report the weather.", "tools": ["get_weather"]}
+	]`
+	if err := registry.LoadProfilesJSON([]byte(doc)); err != nil {
+		t.Fatalf("LoadProfilesJSON: %v", err)
+	}
+
+	profile, ok := registry.Get("weather-bot")
+	if !ok {
+		t.Fatal("expected weather-bot profile to be registered")
+	}
+	if profile.Instructions != "Report the weather." {
+		t.Errorf("Instructions = %q", profile.Instructions)
+	}
+	if len(profile.Tools) != 1 || profile.Tools[0].Name() != "get_weather" {
+		t.Errorf("Tools = %v", profile.Tools)
+	}
+}
+
+func TestProfileRegistry_LoadProfilesJSON_UnknownToolNamesTheProfile(t *testing.T) {
+	registry := af.NewProfileRegistry(af.WithToolbox(af.NewToolbox()))
+	err := registry.LoadProfilesJSON([]byte(`[{"name": "broken", "tools": ["nope"]}]`))
+	if err == nil {
+		t.Fatal("expected error for unresolvable tool")
+	}
+}
+
+func TestAgent_WithProfile_BakesInDefaults(t *testing.T) {
+	var receivedInstructions string
+	client := &mockClient{
+		responseFn: func(ctx context.Context, msgs []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error) {
+			receivedInstructions = opts.Instructions
+			return &af.ChatResponse{Messages: []af.Message{af.NewAssistantMessage("ok")}}, nil
+		},
+	}
+
+	registry := af.NewProfileRegistry()
+	registry.Register(af.AgentProfile{Name: "pirate", Instructions: "Talk like a pirate."})
+
+	agent := af.NewAgent(client, af.WithProfileRegistry(registry), af.WithProfile("pirate"))
+	if _, err := agent.Run(context.Background(), []af.Message{af.NewUserMessage("hi")}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if receivedInstructions != "Talk like a pirate." {
+		t.Errorf("Instructions = %q", receivedInstructions)
+	}
+}
+
+func TestAgent_WithProfile_UnknownName(t *testing.T) {
+	client := &mockClient{
+		responseFn: func(ctx context.Context, msgs []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error) {
+			return &af.ChatResponse{Messages: []af.Message{af.NewAssistantMessage("ok")}}, nil
+		},
+	}
+
+	registry := af.NewProfileRegistry()
+	agent := af.NewAgent(client, af.WithProfileRegistry(registry), af.WithProfile("missing"))
+
+	_, err := agent.Run(context.Background(), []af.Message{af.NewUserMessage("hi")})
+	if !errors.Is(err, af.ErrProfileNotFound) {
+		t.Errorf("error = %v, want wrapping ErrProfileNotFound", err)
+	}
+}
+
+func TestAgent_WithProfileOverride_SwitchesPersonaPerRequest(t *testing.T) {
+	var receivedInstructions string
+	client := &mockClient{
+		responseFn: func(ctx context.Context, msgs []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error) {
+			receivedInstructions = opts.Instructions
+			return &af.ChatResponse{Messages: []af.Message{af.NewAssistantMessage("ok")}}, nil
+		},
+	}
+
+	registry := af.NewProfileRegistry()
+	registry.Register(af.AgentProfile{Name: "default", Instructions: "Be neutral."})
+	registry.Register(af.AgentProfile{Name: "pirate", Instructions: "Talk like a pirate."})
+
+	agent := af.NewAgent(client, af.WithProfileRegistry(registry), af.WithProfile("default"))
+
+	if _, err := agent.Run(context.Background(), []af.Message{af.NewUserMessage("hi")}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if receivedInstructions != "Be neutral." {
+		t.Errorf("Instructions = %q, want baseline", receivedInstructions)
+	}
+
+	if _, err := agent.Run(context.Background(), []af.Message{af.NewUserMessage("hi")}, af.WithProfileOverride("pirate")); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if receivedInstructions != "Talk like a pirate." {
+		t.Errorf("Instructions = %q, want override", receivedInstructions)
+	}
+
+	// A further call without the override falls back to the baseline again.
+	if _, err := agent.Run(context.Background(), []af.Message{af.NewUserMessage("hi")}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if receivedInstructions != "Be neutral." {
+		t.Errorf("Instructions = %q, want baseline restored", receivedInstructions)
+	}
+}
+
+func TestAgent_AvailableProfiles(t *testing.T) {
+	client := &mockClient{
+		responseFn: func(ctx context.Context, msgs []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error) {
+			return &af.ChatResponse{Messages: []af.Message{af.NewAssistantMessage("ok")}}, nil
+		},
+	}
+
+	registry := af.NewProfileRegistry()
+	registry.Register(af.AgentProfile{Name: "b"})
+	registry.Register(af.AgentProfile{Name: "a"})
+
+	agent := af.NewAgent(client, af.WithProfileRegistry(registry))
+	if got := agent.AvailableProfiles(); len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Errorf("AvailableProfiles() = %v, want sorted [a b]", got)
+	}
+
+	if got := af.NewAgent(client).AvailableProfiles(); got != nil {
+		t.Errorf("AvailableProfiles() without a registry = %v, want nil", got)
+	}
+}
+
+func TestFileGlobContextProvider_InjectsMatchingFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := dir + "/doc.txt"
+	if err := os.WriteFile(path, []byte("the sky is blue"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	p := af.NewFileGlobContextProvider(dir + "/*.txt")
+	ic, err := p.Invoking(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Invoking: %v", err)
+	}
+	if !strings.Contains(ic.Instructions, "the sky is blue") {
+		t.Errorf("Instructions = %q, want it to contain file contents", ic.Instructions)
+	}
+}
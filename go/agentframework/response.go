@@ -2,19 +2,31 @@
 
 package agentframework
 
-import "strings"
+import (
+	"encoding/json"
+	"strings"
+)
 
 // ChatResponse is the complete (non-streaming) response from a [ChatClient].
 type ChatResponse struct {
-	Messages       []Message
-	ResponseID     string
-	ConversationID string
-	ModelID        string
-	CreatedAt      string
-	FinishReason   FinishReason
-	Usage          UsageDetails
-	Extra          map[string]any
-	Raw            any
+	Messages       []Message      `json:"messages,omitempty"`
+	ResponseID     string         `json:"responseId,omitempty"`
+	ConversationID string         `json:"conversationId,omitempty"`
+	ModelID        string         `json:"modelId,omitempty"`
+	CreatedAt      string         `json:"createdAt,omitempty"`
+	FinishReason   FinishReason   `json:"finishReason,omitempty"`
+	Usage          UsageDetails   `json:"usage,omitempty"`
+	Extra          map[string]any `json:"extra,omitempty"`
+
+	// Raw holds the original provider-specific response, if any. It is
+	// typically not JSON-serializable (may hold SDK types, live connections,
+	// etc.) and is deliberately omitted by [ChatResponse.MarshalJSON].
+	Raw any `json:"-"`
+
+	// PromptFilterResults carries per-prompt-position content-filter
+	// evaluations, for providers (e.g. Azure OpenAI) that evaluate the
+	// prompt itself rather than only the completion.
+	PromptFilterResults []PromptFilterResult `json:"promptFilterResults,omitempty"`
 }
 
 // Text returns the concatenated text of all messages in this response.
@@ -26,6 +38,37 @@ func (r *ChatResponse) Text() string {
 	return b.String()
 }
 
+// chatResponseJSON is the wire format used by [ChatResponse.MarshalJSON]. It
+// mirrors ChatResponse's tagged fields, minus Raw.
+type chatResponseJSON struct {
+	Messages            []Message            `json:"messages,omitempty"`
+	ResponseID          string               `json:"responseId,omitempty"`
+	ConversationID      string               `json:"conversationId,omitempty"`
+	ModelID             string               `json:"modelId,omitempty"`
+	CreatedAt           string               `json:"createdAt,omitempty"`
+	FinishReason        FinishReason         `json:"finishReason,omitempty"`
+	Usage               UsageDetails         `json:"usage,omitempty"`
+	Extra               map[string]any       `json:"extra,omitempty"`
+	PromptFilterResults []PromptFilterResult `json:"promptFilterResults,omitempty"`
+}
+
+// MarshalJSON implements [json.Marshaler], encoding every field of
+// ChatResponse except Raw, which holds a provider-specific value that is
+// typically not JSON-serializable and has no stable wire representation.
+func (r ChatResponse) MarshalJSON() ([]byte, error) {
+	return json.Marshal(chatResponseJSON{
+		Messages:            r.Messages,
+		ResponseID:          r.ResponseID,
+		ConversationID:      r.ConversationID,
+		ModelID:             r.ModelID,
+		CreatedAt:           r.CreatedAt,
+		FinishReason:        r.FinishReason,
+		Usage:               r.Usage,
+		Extra:               r.Extra,
+		PromptFilterResults: r.PromptFilterResults,
+	})
+}
+
 // ChatResponseUpdate is a single chunk received during streaming from a [ChatClient].
 type ChatResponseUpdate struct {
 	Contents       Contents
@@ -36,6 +79,11 @@ type ChatResponseUpdate struct {
 	FinishReason   FinishReason
 	Usage          UsageDetails
 	Raw            any
+
+	// Token is set on the terminal update of a stream suspended for human
+	// approval (FinishReason == [FinishReasonApprovalRequired]). Pass it to
+	// [Agent.Resume] once approval decisions are collected.
+	Token string
 }
 
 // Text returns the concatenated text of all [TextContent] items in this update.
@@ -83,12 +131,17 @@ func (r *AgentResponse) UserInputRequests() []Content {
 
 // AgentResponseUpdate is a single streaming chunk from an [Agent] run.
 type AgentResponseUpdate struct {
-	Contents   Contents
-	Role       Role
-	AgentID    string
-	ResponseID string
-	Usage      UsageDetails
-	Raw        any
+	Contents     Contents
+	Role         Role
+	AgentID      string
+	ResponseID   string
+	FinishReason FinishReason
+	Usage        UsageDetails
+	Raw          any
+
+	// Token is set on the terminal update of a stream suspended for human
+	// approval. Pass it to [Agent.Resume] once approval decisions are collected.
+	Token string
 }
 
 // Text returns the concatenated text of all [TextContent] items in this update.
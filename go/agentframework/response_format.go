@@ -0,0 +1,63 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package agentframework
+
+import "encoding/json"
+
+// ResponseFormat is a sealed interface constraining how a model must format
+// its output for a single chat completion request. Set it via
+// [ChatOptions.ResponseFormat]; providers translate the concrete type into
+// their native request shape and should treat an unrecognized variant as
+// unsupported rather than silently ignoring it.
+type ResponseFormat interface {
+	// responseFormatSealed prevents external implementations.
+	responseFormatSealed()
+}
+
+// formatBase is embedded by every concrete ResponseFormat type to satisfy
+// the sealed marker.
+type formatBase struct{}
+
+func (formatBase) responseFormatSealed() {}
+
+// ResponseFormatText requests plain, unstructured text (the provider default).
+type ResponseFormatText struct {
+	formatBase
+}
+
+// ResponseFormatJSONObject requests a syntactically valid JSON object, with
+// no schema constraint on its shape.
+type ResponseFormatJSONObject struct {
+	formatBase
+}
+
+// ResponseFormatJSONSchema requests output conforming to Schema. Name
+// identifies the schema to the provider (some require it to match a
+// registered function or tool name). When Strict is true, the provider
+// should reject outputs that don't validate rather than best-effort coerce
+// them.
+type ResponseFormatJSONSchema struct {
+	formatBase
+	Name   string
+	Schema json.RawMessage
+	Strict bool
+}
+
+// ResponseFormatGrammar requests output constrained by a GBNF grammar, for
+// llama.cpp and Foundry Local compatible endpoints.
+type ResponseFormatGrammar struct {
+	formatBase
+	GBNF string
+}
+
+// ResponseFormatForTool builds a [ResponseFormatJSONSchema] from tool's
+// generated parameter schema, pinning the output to the tool's shape. Pair
+// it with [ToolChoiceFunction] to guarantee a parseable typed result instead
+// of relying on text-based tool-call extraction.
+func ResponseFormatForTool(tool Tool, strict bool) ResponseFormat {
+	return &ResponseFormatJSONSchema{
+		Name:   tool.Name(),
+		Schema: tool.Parameters(),
+		Strict: strict,
+	}
+}
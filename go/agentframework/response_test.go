@@ -0,0 +1,35 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package agentframework_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+func TestChatResponseMarshalJSON_OmitsRaw(t *testing.T) {
+	resp := af.ChatResponse{
+		Messages:     []af.Message{af.NewAssistantMessage("hi")},
+		ResponseID:   "r1",
+		FinishReason: af.FinishReasonStop,
+		Raw:          make(chan int), // not JSON-serializable; must not reach MarshalJSON
+	}
+
+	data, err := json.Marshal(resp)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	var decoded map[string]any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if _, ok := decoded["raw"]; ok {
+		t.Errorf("encoded output has a %q field, want Raw omitted", "raw")
+	}
+	if decoded["responseId"] != "r1" {
+		t.Errorf("responseId = %v, want %q", decoded["responseId"], "r1")
+	}
+}
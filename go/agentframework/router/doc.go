@@ -0,0 +1,23 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+// Package router provides a [Router], a [agentframework.ChatClient] that
+// fans out across multiple backend clients (e.g. OpenAI, Azure, Foundry
+// Local) with health tracking and a choice of routing strategies.
+//
+// A failing backend is put into an exponentially-growing cooldown window
+// and skipped until it elapses, so a single unhealthy backend doesn't keep
+// absorbing traffic. Failover happens before a request is sent to the model
+// (or, for streaming, before the first byte is read back) — a backend is
+// never abandoned mid-response.
+//
+// [WithRoutingObserver] and [WithMeter] expose routing decisions to callers
+// for logging, tracing, or dashboards without influencing them.
+//
+//	r := router.New([]router.Backend{
+//	    {Name: "azure-primary", Client: azureClient},
+//	    {Name: "openai-fallback", Client: openaiClient},
+//	}, router.WithStrategy(router.Priority))
+//	defer r.Close()
+//
+//	agent := agentframework.NewAgent(r)
+package router
@@ -0,0 +1,56 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package router
+
+import (
+	"sync"
+	"time"
+)
+
+// healthTracker records consecutive failures for one backend and computes
+// an exponentially-growing cooldown window during which the backend is
+// skipped by [Router].
+type healthTracker struct {
+	mu                  sync.Mutex
+	consecutiveFailures int
+	cooldownUntil       time.Time
+}
+
+func newHealthTracker() *healthTracker {
+	return &healthTracker{}
+}
+
+// recordFailure extends the cooldown window, doubling it per consecutive
+// failure up to max.
+func (h *healthTracker) recordFailure(base, max time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.consecutiveFailures++
+	shift := h.consecutiveFailures - 1
+	if shift > 16 {
+		shift = 16 // avoid overflowing the duration shift
+	}
+	cooldown := base * time.Duration(uint64(1)<<uint(shift))
+	if cooldown <= 0 || cooldown > max {
+		cooldown = max
+	}
+	h.cooldownUntil = time.Now().Add(cooldown)
+}
+
+// recordSuccess clears the failure streak and any active cooldown.
+func (h *healthTracker) recordSuccess() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.consecutiveFailures = 0
+	h.cooldownUntil = time.Time{}
+}
+
+// available reports whether the backend's cooldown window has elapsed.
+func (h *healthTracker) available(now time.Time) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.cooldownUntil.IsZero() || now.After(h.cooldownUntil)
+}
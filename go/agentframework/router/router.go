@@ -0,0 +1,376 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package router
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+// Strategy selects the order in which [Router] tries healthy backends.
+type Strategy string
+
+const (
+	// Priority tries backends in the order they were configured.
+	Priority Strategy = "priority"
+	// RoundRobin cycles the starting backend on each call.
+	RoundRobin Strategy = "round_robin"
+	// WeightedRandom orders backends by a weighted random draw, using
+	// [Backend.Weight].
+	WeightedRandom Strategy = "weighted_random"
+	// LeastLatency tries the backend with the lowest recent p50 latency first.
+	LeastLatency Strategy = "least_latency"
+)
+
+// ErrNoBackends is returned when a [Router] is created or called with no
+// configured backends.
+var ErrNoBackends = errors.New("router: no backends configured")
+
+// Backend is one candidate [af.ChatClient] behind a [Router].
+type Backend struct {
+	// Name identifies the backend in [Router.Stats] and error messages.
+	Name string
+	// Client is the underlying chat client.
+	Client af.ChatClient
+	// Weight biases WeightedRandom selection; defaults to 1 if <= 0.
+	Weight int
+	// Options, if set, is merged over the caller's [af.ChatOptions] via
+	// [af.MergeChatOptions] before each call to this backend — e.g. to
+	// pin a backend-specific ModelID or Extra entries without the caller
+	// needing to know which backend will end up serving the request.
+	Options *af.ChatOptions
+}
+
+// backendState pairs a Backend with its health and metrics state.
+type backendState struct {
+	Backend
+	health *healthTracker
+	stats  *stats
+}
+
+// Option configures a [Router].
+type Option func(*Router)
+
+// WithStrategy sets the routing strategy. Defaults to [Priority].
+func WithStrategy(s Strategy) Option {
+	return func(r *Router) { r.strategy = s }
+}
+
+// WithCooldown sets the initial and maximum cooldown window applied to a
+// failing backend. The window doubles per consecutive failure, capped at
+// max. Defaults to 1s/2m.
+func WithCooldown(base, max time.Duration) Option {
+	return func(r *Router) {
+		r.baseCooldown = base
+		r.maxCooldown = max
+	}
+}
+
+// WithProbeInterval sets how often the background probe re-checks cooled
+// down backends for re-admission. Defaults to 5s.
+func WithProbeInterval(d time.Duration) Option {
+	return func(r *Router) { r.probeInterval = d }
+}
+
+// WithRoutingObserver registers obs to be called once per backend attempt —
+// success or failure — letting callers log or trace routing decisions
+// without influencing them. Multiple observers may be registered.
+func WithRoutingObserver(obs RoutingObserver) Option {
+	return func(r *Router) { r.observers = append(r.observers, obs) }
+}
+
+// WithMeter wires counters for routed, failed-over, and unhealthy-marked
+// requests (named "router.routed", "router.failed_over", and
+// "router.unhealthy", each tagged with a "backend" attribute) into m.
+// Defaults to [af.NoopMeter].
+func WithMeter(m af.Meter) Option {
+	return func(r *Router) { r.meter = m }
+}
+
+// RoutingEvent describes one backend attempt made while resolving a
+// Response or StreamResponse call, passed to a [RoutingObserver].
+type RoutingEvent struct {
+	Backend    string
+	Attempt    int // 1-based position in this call's candidate order
+	Err        error
+	Latency    time.Duration
+	FailedOver bool // true if an earlier candidate was tried and failed first
+}
+
+// RoutingObserver observes one [RoutingEvent] at a time; see
+// [WithRoutingObserver].
+type RoutingObserver func(RoutingEvent)
+
+// Router is an [af.ChatClient] that fans out across multiple backend
+// clients with health tracking and a pluggable [Strategy]. Use [New] to
+// create one.
+type Router struct {
+	backends []*backendState
+	strategy Strategy
+
+	baseCooldown  time.Duration
+	maxCooldown   time.Duration
+	probeInterval time.Duration
+
+	rrCounter uint64
+	rng       *rand.Rand
+	rngMu     sync.Mutex
+
+	observers  []RoutingObserver
+	meter      af.Meter
+	routed     af.Counter
+	failedOver af.Counter
+	unhealthy  af.Counter
+
+	stopProbe chan struct{}
+	closeOnce sync.Once
+}
+
+var _ af.ChatClient = (*Router)(nil)
+
+// New creates a [Router] over backends. A background goroutine periodically
+// re-checks cooled-down backends for re-admission; call [Router.Close] to
+// stop it.
+func New(backends []Backend, opts ...Option) *Router {
+	r := &Router{
+		strategy:      Priority,
+		baseCooldown:  time.Second,
+		maxCooldown:   2 * time.Minute,
+		probeInterval: 5 * time.Second,
+		rng:           rand.New(rand.NewSource(1)),
+		stopProbe:     make(chan struct{}),
+	}
+	for _, b := range backends {
+		weight := b.Weight
+		if weight <= 0 {
+			weight = 1
+		}
+		r.backends = append(r.backends, &backendState{
+			Backend: Backend{Name: b.Name, Client: b.Client, Weight: weight, Options: b.Options},
+			health:  newHealthTracker(),
+			stats:   newStats(),
+		})
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if r.meter == nil {
+		r.meter = af.NoopMeter
+	}
+	r.routed = r.meter.Counter("router.routed")
+	r.failedOver = r.meter.Counter("router.failed_over")
+	r.unhealthy = r.meter.Counter("router.unhealthy")
+
+	go r.probeLoop()
+	return r
+}
+
+// notify calls every registered [RoutingObserver] with ev.
+func (r *Router) notify(ev RoutingEvent) {
+	for _, obs := range r.observers {
+		obs(ev)
+	}
+}
+
+// Close stops the background re-admission probe. It does not close the
+// underlying backend clients.
+func (r *Router) Close() error {
+	r.closeOnce.Do(func() { close(r.stopProbe) })
+	return nil
+}
+
+// probeLoop queries every backend's availability each probeInterval. The
+// query changes no state: a cooled-down backend rejoins rotation when
+// [Router.order] next finds its window elapsed.
+func (r *Router) probeLoop() {
+	ticker := time.NewTicker(r.probeInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.stopProbe:
+			return
+		case now := <-ticker.C:
+			for _, b := range r.backends {
+				b.health.available(now)
+			}
+		}
+	}
+}
+
+// Response implements [af.ChatClient] by trying candidates, in strategy
+// order, until one succeeds.
+func (r *Router) Response(ctx context.Context, messages []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error) {
+	candidates := r.order()
+	if len(candidates) == 0 {
+		return nil, ErrNoBackends
+	}
+
+	var lastErr error
+	for i, b := range candidates {
+		callOpts := opts
+		if b.Options != nil {
+			callOpts = af.MergeChatOptions(opts, b.Options)
+		}
+		start := time.Now()
+		resp, err := b.Client.Response(ctx, messages, callOpts)
+		latency := time.Since(start)
+		if err != nil {
+			b.stats.recordError(latency)
+			if shouldTripHealth(err) {
+				b.health.recordFailure(r.baseCooldown, r.maxCooldown)
+				r.unhealthy.Add(ctx, 1, af.Attr("backend", b.Name))
+			}
+			r.notify(RoutingEvent{Backend: b.Name, Attempt: i + 1, Err: err, Latency: latency, FailedOver: i > 0})
+			lastErr = fmt.Errorf("backend %q: %w", b.Name, err)
+			continue
+		}
+		b.health.recordSuccess()
+		b.stats.recordSuccess(latency, resp.Usage.InputTokens, resp.Usage.OutputTokens)
+		r.routed.Add(ctx, 1, af.Attr("backend", b.Name))
+		if i > 0 {
+			r.failedOver.Add(ctx, 1, af.Attr("backend", b.Name))
+		}
+		r.notify(RoutingEvent{Backend: b.Name, Attempt: i + 1, Latency: latency, FailedOver: i > 0})
+		return resp, nil
+	}
+	return nil, fmt.Errorf("router: all backends failed: %w", lastErr)
+}
+
+// StreamResponse implements [af.ChatClient]. Failover only happens while
+// establishing the stream (the underlying client's StreamResponse call);
+// once a stream is returned to the caller, the router commits to it rather
+// than switching backends mid-stream.
+func (r *Router) StreamResponse(ctx context.Context, messages []af.Message, opts *af.ChatOptions) (*af.ResponseStream[af.ChatResponseUpdate], error) {
+	candidates := r.order()
+	if len(candidates) == 0 {
+		return nil, ErrNoBackends
+	}
+
+	var lastErr error
+	for i, b := range candidates {
+		callOpts := opts
+		if b.Options != nil {
+			callOpts = af.MergeChatOptions(opts, b.Options)
+		}
+		start := time.Now()
+		stream, err := b.Client.StreamResponse(ctx, messages, callOpts)
+		latency := time.Since(start)
+		if err != nil {
+			b.stats.recordError(latency)
+			if shouldTripHealth(err) {
+				b.health.recordFailure(r.baseCooldown, r.maxCooldown)
+				r.unhealthy.Add(ctx, 1, af.Attr("backend", b.Name))
+			}
+			r.notify(RoutingEvent{Backend: b.Name, Attempt: i + 1, Err: err, Latency: latency, FailedOver: i > 0})
+			lastErr = fmt.Errorf("backend %q: %w", b.Name, err)
+			continue
+		}
+		b.health.recordSuccess()
+		b.stats.recordSuccess(latency, 0, 0)
+		r.routed.Add(ctx, 1, af.Attr("backend", b.Name))
+		if i > 0 {
+			r.failedOver.Add(ctx, 1, af.Attr("backend", b.Name))
+		}
+		r.notify(RoutingEvent{Backend: b.Name, Attempt: i + 1, Latency: latency, FailedOver: i > 0})
+		return stream, nil
+	}
+	return nil, fmt.Errorf("router: all backends failed: %w", lastErr)
+}
+
+// Stats returns a snapshot of per-backend request metrics, keyed by
+// [Backend.Name].
+func (r *Router) Stats() map[string]BackendStats {
+	out := make(map[string]BackendStats, len(r.backends))
+	for _, b := range r.backends {
+		out[b.Name] = b.stats.snapshot()
+	}
+	return out
+}
+
+// order returns backends in the order [Router.strategy] should try them.
+// Healthy (not cooling down) backends come first; if all backends are
+// cooling down, every backend is returned anyway so a call still gets a
+// chance rather than failing outright.
+func (r *Router) order() []*backendState {
+	now := time.Now()
+	healthy := make([]*backendState, 0, len(r.backends))
+	for _, b := range r.backends {
+		if b.health.available(now) {
+			healthy = append(healthy, b)
+		}
+	}
+	if len(healthy) == 0 {
+		healthy = append(healthy, r.backends...)
+	}
+
+	switch r.strategy {
+	case RoundRobin:
+		n := atomic.AddUint64(&r.rrCounter, 1) - 1
+		return rotate(healthy, int(n%uint64(len(healthy))))
+	case WeightedRandom:
+		return r.weightedOrder(healthy)
+	case LeastLatency:
+		sorted := append([]*backendState(nil), healthy...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i].stats.p50() < sorted[j].stats.p50() })
+		return sorted
+	default: // Priority
+		return healthy
+	}
+}
+
+// shouldTripHealth reports whether err should count against a backend's
+// health: authentication failures, rate limiting, and server errors do;
+// malformed-request style errors are the caller's fault and shouldn't take
+// an otherwise-healthy backend out of rotation.
+func shouldTripHealth(err error) bool {
+	var svcErr *af.ServiceError
+	if errors.As(err, &svcErr) {
+		return svcErr.StatusCode == 401 || svcErr.StatusCode == 429 || svcErr.StatusCode >= 500
+	}
+	// No status code to classify (e.g. a transport-level failure) — treat
+	// as unhealthy rather than risk hammering a backend that's down.
+	return true
+}
+
+func rotate(backends []*backendState, start int) []*backendState {
+	out := make([]*backendState, len(backends))
+	for i := range backends {
+		out[i] = backends[(start+i)%len(backends)]
+	}
+	return out
+}
+
+func (r *Router) weightedOrder(backends []*backendState) []*backendState {
+	remaining := append([]*backendState(nil), backends...)
+	order := make([]*backendState, 0, len(remaining))
+
+	r.rngMu.Lock()
+	defer r.rngMu.Unlock()
+
+	for len(remaining) > 0 {
+		total := 0
+		for _, b := range remaining {
+			total += b.Weight
+		}
+		pick := r.rng.Intn(total)
+		idx := 0
+		for i, b := range remaining {
+			pick -= b.Weight
+			if pick < 0 {
+				idx = i
+				break
+			}
+		}
+		order = append(order, remaining[idx])
+		remaining = append(remaining[:idx], remaining[idx+1:]...)
+	}
+	return order
+}
@@ -0,0 +1,339 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package router_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+	"github.com/microsoft/agent-framework/go/agentframework/router"
+)
+
+// fakeClient implements af.ChatClient with canned responses/errors.
+type fakeClient struct {
+	responseFn func(ctx context.Context, msgs []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error)
+	calls      int
+}
+
+func (f *fakeClient) Response(ctx context.Context, msgs []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error) {
+	f.calls++
+	return f.responseFn(ctx, msgs, opts)
+}
+
+func (f *fakeClient) StreamResponse(ctx context.Context, msgs []af.Message, opts *af.ChatOptions) (*af.ResponseStream[af.ChatResponseUpdate], error) {
+	f.calls++
+	resp, err := f.responseFn(ctx, msgs, opts)
+	if err != nil {
+		return nil, err
+	}
+	return af.NewResponseStream(ctx, func(ctx context.Context, ch chan<- af.ChatResponseUpdate) error {
+		for _, msg := range resp.Messages {
+			ch <- af.ChatResponseUpdate{Contents: msg.Contents, Role: msg.Role}
+		}
+		return nil
+	}), nil
+}
+
+func okResponse(text string) func(context.Context, []af.Message, *af.ChatOptions) (*af.ChatResponse, error) {
+	return func(ctx context.Context, msgs []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error) {
+		return &af.ChatResponse{Messages: []af.Message{af.NewAssistantMessage(text)}}, nil
+	}
+}
+
+func failResponse(statusCode int) func(context.Context, []af.Message, *af.ChatOptions) (*af.ChatResponse, error) {
+	return func(ctx context.Context, msgs []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error) {
+		return nil, &af.ServiceError{StatusCode: statusCode, Message: "boom", Err: af.ErrService}
+	}
+}
+
+func TestRouter_Priority_FailsOverToFallback(t *testing.T) {
+	primary := &fakeClient{responseFn: failResponse(500)}
+	fallback := &fakeClient{responseFn: okResponse("from fallback")}
+
+	r := router.New([]router.Backend{
+		{Name: "primary", Client: primary},
+		{Name: "fallback", Client: fallback},
+	})
+	defer r.Close()
+
+	resp, err := r.Response(context.Background(), []af.Message{af.NewUserMessage("hi")}, nil)
+	if err != nil {
+		t.Fatalf("Response: %v", err)
+	}
+	if resp.Text() != "from fallback" {
+		t.Errorf("Text = %q", resp.Text())
+	}
+	if primary.calls != 1 || fallback.calls != 1 {
+		t.Errorf("calls = primary:%d fallback:%d", primary.calls, fallback.calls)
+	}
+}
+
+func TestRouter_Priority_CooldownSkipsFailingBackend(t *testing.T) {
+	primary := &fakeClient{responseFn: failResponse(500)}
+	fallback := &fakeClient{responseFn: okResponse("from fallback")}
+
+	r := router.New([]router.Backend{
+		{Name: "primary", Client: primary},
+		{Name: "fallback", Client: fallback},
+	}, router.WithCooldown(time.Minute, time.Minute))
+	defer r.Close()
+
+	for i := 0; i < 3; i++ {
+		if _, err := r.Response(context.Background(), []af.Message{af.NewUserMessage("hi")}, nil); err != nil {
+			t.Fatalf("Response %d: %v", i, err)
+		}
+	}
+
+	// primary should only have been tried once; once it's cooling down the
+	// router shouldn't keep retrying it ahead of the healthy fallback.
+	if primary.calls != 1 {
+		t.Errorf("primary.calls = %d, want 1", primary.calls)
+	}
+	if fallback.calls != 3 {
+		t.Errorf("fallback.calls = %d, want 3", fallback.calls)
+	}
+}
+
+func TestRouter_AllBackendsFail(t *testing.T) {
+	a := &fakeClient{responseFn: failResponse(500)}
+	b := &fakeClient{responseFn: failResponse(503)}
+
+	r := router.New([]router.Backend{
+		{Name: "a", Client: a},
+		{Name: "b", Client: b},
+	})
+	defer r.Close()
+
+	_, err := r.Response(context.Background(), []af.Message{af.NewUserMessage("hi")}, nil)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestRouter_Backend_OptionsMergedOverCallerOptions(t *testing.T) {
+	var gotModelID string
+	backend := &fakeClient{responseFn: func(ctx context.Context, msgs []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error) {
+		gotModelID = opts.ModelID
+		return &af.ChatResponse{Messages: []af.Message{af.NewAssistantMessage("ok")}}, nil
+	}}
+
+	r := router.New([]router.Backend{
+		{Name: "pinned", Client: backend, Options: &af.ChatOptions{ModelID: "backend-specific-model"}},
+	})
+	defer r.Close()
+
+	_, err := r.Response(context.Background(), []af.Message{af.NewUserMessage("hi")}, &af.ChatOptions{ModelID: "caller-model"})
+	if err != nil {
+		t.Fatalf("Response: %v", err)
+	}
+	if gotModelID != "backend-specific-model" {
+		t.Errorf("ModelID = %q, want backend-specific-model", gotModelID)
+	}
+}
+
+func TestRouter_ClientErrorDoesNotTripHealth(t *testing.T) {
+	primary := &fakeClient{responseFn: failResponse(400)}
+
+	r := router.New([]router.Backend{
+		{Name: "primary", Client: primary},
+	}, router.WithCooldown(time.Minute, time.Minute))
+	defer r.Close()
+
+	// A 400 is the caller's fault, not the backend's — it shouldn't be
+	// skipped on the next call even though it keeps failing.
+	for i := 0; i < 2; i++ {
+		if _, err := r.Response(context.Background(), []af.Message{af.NewUserMessage("hi")}, nil); err == nil {
+			t.Fatal("expected error")
+		}
+	}
+	if primary.calls != 2 {
+		t.Errorf("calls = %d, want 2 (backend should not have been skipped)", primary.calls)
+	}
+}
+
+func TestRouter_RoundRobin(t *testing.T) {
+	a := &fakeClient{responseFn: okResponse("a")}
+	b := &fakeClient{responseFn: okResponse("b")}
+
+	r := router.New([]router.Backend{
+		{Name: "a", Client: a},
+		{Name: "b", Client: b},
+	}, router.WithStrategy(router.RoundRobin))
+	defer r.Close()
+
+	var texts []string
+	for i := 0; i < 4; i++ {
+		resp, err := r.Response(context.Background(), []af.Message{af.NewUserMessage("hi")}, nil)
+		if err != nil {
+			t.Fatalf("Response %d: %v", i, err)
+		}
+		texts = append(texts, resp.Text())
+	}
+
+	if texts[0] == texts[1] {
+		t.Errorf("round robin did not alternate: %v", texts)
+	}
+}
+
+func TestRouter_StreamResponse_FailsOverBeforeFirstByte(t *testing.T) {
+	primary := &fakeClient{responseFn: failResponse(500)}
+	fallback := &fakeClient{responseFn: okResponse("streamed")}
+
+	r := router.New([]router.Backend{
+		{Name: "primary", Client: primary},
+		{Name: "fallback", Client: fallback},
+	})
+	defer r.Close()
+
+	stream, err := r.StreamResponse(context.Background(), []af.Message{af.NewUserMessage("hi")}, nil)
+	if err != nil {
+		t.Fatalf("StreamResponse: %v", err)
+	}
+	defer stream.Close()
+
+	updates, err := stream.Collect(context.Background())
+	if err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+	resp := af.ChatResponseFromUpdates(updates)
+	if resp.Text() != "streamed" {
+		t.Errorf("Text = %q", resp.Text())
+	}
+}
+
+func TestRouter_Stats(t *testing.T) {
+	primary := &fakeClient{responseFn: okResponse("ok")}
+
+	r := router.New([]router.Backend{{Name: "primary", Client: primary}})
+	defer r.Close()
+
+	if _, err := r.Response(context.Background(), []af.Message{af.NewUserMessage("hi")}, nil); err != nil {
+		t.Fatalf("Response: %v", err)
+	}
+
+	stats := r.Stats()
+	s, ok := stats["primary"]
+	if !ok {
+		t.Fatal("missing stats for primary")
+	}
+	if s.Requests != 1 {
+		t.Errorf("Requests = %d, want 1", s.Requests)
+	}
+	if s.Errors != 0 {
+		t.Errorf("Errors = %d, want 0", s.Errors)
+	}
+}
+
+func TestRouter_NoBackends(t *testing.T) {
+	r := router.New(nil)
+	defer r.Close()
+
+	_, err := r.Response(context.Background(), []af.Message{af.NewUserMessage("hi")}, nil)
+	if err != router.ErrNoBackends {
+		t.Errorf("err = %v, want ErrNoBackends", err)
+	}
+}
+
+// recordingCounter and recordingMeter capture Add calls for assertions.
+type recordingCounter struct {
+	name  string
+	adds  []af.KeyValue
+	meter *recordingMeter
+}
+
+func (c *recordingCounter) Add(ctx context.Context, delta int64, attrs ...af.KeyValue) {
+	c.meter.mu.Lock()
+	defer c.meter.mu.Unlock()
+	c.meter.adds = append(c.meter.adds, recordedAdd{counter: c.name, attrs: attrs})
+}
+
+type recordedAdd struct {
+	counter string
+	attrs   []af.KeyValue
+}
+
+type recordingMeter struct {
+	mu   sync.Mutex
+	adds []recordedAdd
+}
+
+func (m *recordingMeter) Counter(name string) af.Counter {
+	return &recordingCounter{name: name, meter: m}
+}
+
+func (m *recordingMeter) Histogram(string) af.Histogram { return nil }
+
+func (m *recordingMeter) counts(name string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n := 0
+	for _, a := range m.adds {
+		if a.counter == name {
+			n++
+		}
+	}
+	return n
+}
+
+func TestRouter_WithRoutingObserver_NotifiesEachAttempt(t *testing.T) {
+	primary := &fakeClient{responseFn: failResponse(500)}
+	fallback := &fakeClient{responseFn: okResponse("from fallback")}
+
+	var mu sync.Mutex
+	var events []router.RoutingEvent
+
+	r := router.New([]router.Backend{
+		{Name: "primary", Client: primary},
+		{Name: "fallback", Client: fallback},
+	}, router.WithRoutingObserver(func(ev router.RoutingEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, ev)
+	}))
+	defer r.Close()
+
+	if _, err := r.Response(context.Background(), []af.Message{af.NewUserMessage("hi")}, nil); err != nil {
+		t.Fatalf("Response: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(events) != 2 {
+		t.Fatalf("events = %d, want 2", len(events))
+	}
+	if events[0].Backend != "primary" || events[0].Err == nil || events[0].FailedOver {
+		t.Errorf("events[0] = %+v", events[0])
+	}
+	if events[1].Backend != "fallback" || events[1].Err != nil || !events[1].FailedOver {
+		t.Errorf("events[1] = %+v", events[1])
+	}
+}
+
+func TestRouter_WithMeter_RecordsCounters(t *testing.T) {
+	primary := &fakeClient{responseFn: failResponse(500)}
+	fallback := &fakeClient{responseFn: okResponse("from fallback")}
+
+	meter := &recordingMeter{}
+	r := router.New([]router.Backend{
+		{Name: "primary", Client: primary},
+		{Name: "fallback", Client: fallback},
+	}, router.WithMeter(meter))
+	defer r.Close()
+
+	if _, err := r.Response(context.Background(), []af.Message{af.NewUserMessage("hi")}, nil); err != nil {
+		t.Fatalf("Response: %v", err)
+	}
+
+	if n := meter.counts("router.unhealthy"); n != 1 {
+		t.Errorf("router.unhealthy count = %d, want 1", n)
+	}
+	if n := meter.counts("router.routed"); n != 1 {
+		t.Errorf("router.routed count = %d, want 1", n)
+	}
+	if n := meter.counts("router.failed_over"); n != 1 {
+		t.Errorf("router.failed_over count = %d, want 1", n)
+	}
+}
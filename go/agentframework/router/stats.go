@@ -0,0 +1,98 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package router
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// maxLatencySamples bounds the per-backend latency window used to compute
+// percentiles; older samples are dropped once the window is full.
+const maxLatencySamples = 256
+
+// BackendStats is a snapshot of one backend's request metrics, suitable for
+// wiring to Prometheus via [Router.Stats].
+type BackendStats struct {
+	Requests   uint64
+	Errors     uint64
+	P50Latency time.Duration
+	P95Latency time.Duration
+	TokensIn   uint64
+	TokensOut  uint64
+}
+
+// stats accumulates request counts, token usage, and a rolling latency
+// window for one backend.
+type stats struct {
+	mu        sync.Mutex
+	requests  uint64
+	errors    uint64
+	tokensIn  uint64
+	tokensOut uint64
+	latencies []time.Duration
+}
+
+func newStats() *stats {
+	return &stats{}
+}
+
+func (s *stats) recordSuccess(latency time.Duration, tokensIn, tokensOut int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests++
+	s.tokensIn += uint64(tokensIn)
+	s.tokensOut += uint64(tokensOut)
+	s.record(latency)
+}
+
+func (s *stats) recordError(latency time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests++
+	s.errors++
+	s.record(latency)
+}
+
+// record appends latency to the rolling window, must be called with mu held.
+func (s *stats) record(latency time.Duration) {
+	s.latencies = append(s.latencies, latency)
+	if len(s.latencies) > maxLatencySamples {
+		s.latencies = s.latencies[len(s.latencies)-maxLatencySamples:]
+	}
+}
+
+// p50 returns the current median latency, used by the LeastLatency strategy.
+func (s *stats) p50() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.percentileLocked(0.5)
+}
+
+func (s *stats) snapshot() BackendStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return BackendStats{
+		Requests:   s.requests,
+		Errors:     s.errors,
+		P50Latency: s.percentileLocked(0.5),
+		P95Latency: s.percentileLocked(0.95),
+		TokensIn:   s.tokensIn,
+		TokensOut:  s.tokensOut,
+	}
+}
+
+// percentileLocked returns the p-th percentile latency; mu must be held.
+func (s *stats) percentileLocked(p float64) time.Duration {
+	if len(s.latencies) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration(nil), s.latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	idx := int(p * float64(len(sorted)-1))
+	return sorted[idx]
+}
@@ -4,22 +4,126 @@ package agentframework
 
 import (
 	"encoding/json"
+	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
 )
 
+// SchemaDraft selects the JSON Schema draft [GenerateSchemaWithOptions]
+// targets, which only affects the "$schema" URI and whether shared
+// definitions are written under "$defs" (2020-12) or "definitions"
+// (draft-07).
+type SchemaDraft string
+
+const (
+	// SchemaDraft2020 writes "$schema": draft 2020-12, defs under "$defs".
+	SchemaDraft2020 SchemaDraft = "2020-12"
+	// SchemaDraft07 targets draft-07, with defs under "definitions", for
+	// tool callers that haven't moved to 2020-12 yet.
+	SchemaDraft07 SchemaDraft = "draft-07"
+)
+
+// SchemaOptions configures [GenerateSchemaWithOptions].
+type SchemaOptions struct {
+	// Draft selects the target JSON Schema draft. The zero value omits
+	// "$schema" entirely and writes defs under "$defs" as [SchemaDraft2020]
+	// does; it is what [GenerateSchema] uses to keep its output minimal.
+	Draft SchemaDraft
+
+	// InlineDefs writes every struct's schema inline at its point of use,
+	// the original behavior and what [GenerateSchema] uses. Set false to
+	// factor every named struct type out under "$defs"/"definitions" and
+	// reference it by "$ref" instead, so a type reused across several
+	// fields is described once.
+	//
+	// A struct that recurses into itself (directly or through another
+	// struct) always uses "$defs"/"$ref" for the cycle-closing occurrence
+	// regardless of this setting — it's the only way to terminate the
+	// recursion, so InlineDefs cannot inline past it.
+	InlineDefs bool
+}
+
 // generateSchemaFromType uses reflection to produce a JSON Schema for a struct.
-func generateSchemaFromType(v any) json.RawMessage {
+func generateSchemaFromType(v any, opts SchemaOptions) json.RawMessage {
 	t := reflect.TypeOf(v)
 	if t.Kind() == reflect.Ptr {
 		t = t.Elem()
 	}
-	schema := schemaForType(t)
-	b, _ := json.Marshal(schema)
+
+	gen := &schemaGen{opts: opts, defs: make(map[string]map[string]any)}
+	root := schemaForType(t, make(map[reflect.Type]string), gen)
+
+	doc := make(map[string]any, len(root)+2)
+	if opts.Draft != "" {
+		doc["$schema"] = schemaURI(opts.Draft)
+	}
+	for k, v := range root {
+		doc[k] = v
+	}
+	if len(gen.defs) > 0 {
+		defs := make(map[string]any, len(gen.defs))
+		for name, def := range gen.defs {
+			defs[name] = def
+		}
+		doc[defsKey(opts.Draft)] = defs
+	}
+
+	b, _ := json.Marshal(doc)
 	return b
 }
 
-func schemaForType(t reflect.Type) map[string]any {
+func schemaURI(draft SchemaDraft) string {
+	if draft == SchemaDraft07 {
+		return "http://json-schema.org/draft-07/schema#"
+	}
+	return "https://json-schema.org/draft/2020-12/schema"
+}
+
+func defsKey(draft SchemaDraft) string {
+	if draft == SchemaDraft07 {
+		return "definitions"
+	}
+	return "$defs"
+}
+
+func refPath(draft SchemaDraft, name string) string {
+	return "#/" + defsKey(draft) + "/" + name
+}
+
+// schemaGen carries generation-wide state across the recursive
+// schemaForType/schemaForStruct calls: the factored-out struct definitions
+// (populated for both recursive types and, with [SchemaOptions.InlineDefs]
+// false, every reused named struct), and a counter for naming anonymous
+// struct types that need a $defs entry.
+type schemaGen struct {
+	opts      SchemaOptions
+	defs      map[string]map[string]any // def name -> schema body
+	built     map[reflect.Type]string   // type -> def name, once fully built
+	requested map[string]bool           // def names referenced via $ref anywhere in the walk
+	anonN     int
+}
+
+func (g *schemaGen) nameFor(t reflect.Type) string {
+	if t.Name() != "" {
+		return t.Name()
+	}
+	g.anonN++
+	return fmt.Sprintf("anon%d", g.anonN)
+}
+
+// ref records that name was referenced (so schemaForStruct knows to hoist
+// it into $defs even when a self-cycle ref appears nested inside an array
+// or map rather than directly as a property) and returns the $ref node.
+func (g *schemaGen) ref(name string) map[string]any {
+	if g.requested == nil {
+		g.requested = make(map[string]bool)
+	}
+	g.requested[name] = true
+	return map[string]any{"$ref": refPath(g.opts.Draft, name)}
+}
+
+func schemaForType(t reflect.Type, visiting map[reflect.Type]string, gen *schemaGen) map[string]any {
 	switch t.Kind() {
 	case reflect.String:
 		return map[string]any{"type": "string"}
@@ -34,17 +138,17 @@ func schemaForType(t reflect.Type) map[string]any {
 	case reflect.Slice, reflect.Array:
 		return map[string]any{
 			"type":  "array",
-			"items": schemaForType(t.Elem()),
+			"items": schemaForType(t.Elem(), visiting, gen),
 		}
 	case reflect.Ptr:
-		return schemaForType(t.Elem())
+		return schemaForType(t.Elem(), visiting, gen)
 	case reflect.Struct:
-		return schemaForStruct(t)
+		return schemaForStruct(t, visiting, gen)
 	case reflect.Map:
 		if t.Key().Kind() == reflect.String {
 			return map[string]any{
 				"type":                 "object",
-				"additionalProperties": schemaForType(t.Elem()),
+				"additionalProperties": schemaForType(t.Elem(), visiting, gen),
 			}
 		}
 		return map[string]any{"type": "object"}
@@ -53,66 +157,277 @@ func schemaForType(t reflect.Type) map[string]any {
 	}
 }
 
-func schemaForStruct(t reflect.Type) map[string]any {
+// schemaForStruct builds t's schema, consulting gen to terminate cycles (t
+// appearing among its own ancestors in visiting) and, with
+// [SchemaOptions.InlineDefs] false, to reuse an already-built def for a
+// struct type seen anywhere earlier in the walk.
+func schemaForStruct(t reflect.Type, visiting map[reflect.Type]string, gen *schemaGen) map[string]any {
+	if name, ok := visiting[t]; ok {
+		return gen.ref(name) // cycle: t is its own ancestor
+	}
+	if !gen.opts.InlineDefs {
+		if gen.built == nil {
+			gen.built = make(map[reflect.Type]string)
+		}
+		if name, ok := gen.built[t]; ok {
+			return gen.ref(name)
+		}
+	}
+
+	name := gen.nameFor(t)
+	visiting[t] = name
+
 	properties := make(map[string]any)
 	var required []string
+	collectFields(t, visiting, gen, properties, &required)
+
+	delete(visiting, t)
 
+	schema := map[string]any{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+
+	if gen.requested[name] || !gen.opts.InlineDefs {
+		gen.defs[name] = schema
+		if gen.built == nil {
+			gen.built = make(map[reflect.Type]string)
+		}
+		gen.built[t] = name
+		return gen.ref(name)
+	}
+	return schema
+}
+
+// collectFields appends t's fields into properties/required, flattening
+// anonymous (embedded) struct fields instead of nesting them under their
+// own property name.
+func collectFields(t reflect.Type, visiting map[reflect.Type]string, gen *schemaGen, properties map[string]any, required *[]string) {
 	for i := 0; i < t.NumField(); i++ {
 		field := t.Field(i)
 		if !field.IsExported() {
 			continue
 		}
 
+		if field.Anonymous {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct && field.Tag.Get("json") == "" {
+				collectFields(embedded, visiting, gen, properties, required)
+				continue
+			}
+		}
+
 		// Determine JSON field name
 		jsonTag := field.Tag.Get("json")
 		if jsonTag == "-" {
 			continue
 		}
 		name := field.Name
+		omitEmpty := false
 		if jsonTag != "" {
-			parts := strings.SplitN(jsonTag, ",", 2)
+			parts := strings.Split(jsonTag, ",")
 			if parts[0] != "" {
 				name = parts[0]
 			}
+			for _, opt := range parts[1:] {
+				if opt == "omitempty" {
+					omitEmpty = true
+				}
+			}
 		}
 
-		prop := schemaForType(field.Type)
+		prop := schemaForType(field.Type, visiting, gen)
 
-		// Parse jsonschema tag
-		jsTag := field.Tag.Get("jsonschema")
-		if jsTag != "" {
-			for _, part := range strings.Split(jsTag, ",") {
-				kv := strings.SplitN(part, "=", 2)
-				key := strings.TrimSpace(kv[0])
-				val := ""
-				if len(kv) == 2 {
-					val = strings.TrimSpace(kv[1])
-				}
-				switch key {
-				case "description":
-					prop["description"] = val
-				case "required":
-					required = append(required, name)
-				case "enum":
-					enumVals := strings.Split(val, "|")
-					anyVals := make([]any, len(enumVals))
-					for j, ev := range enumVals {
-						anyVals[j] = strings.TrimSpace(ev)
-					}
-					prop["enum"] = anyVals
-				}
-			}
+		explicitlyRequired := applyJSONSchemaTag(field, prop)
+		if explicitlyRequired || !omitEmpty {
+			*required = append(*required, name)
 		}
 
 		properties[name] = prop
 	}
+}
 
-	schema := map[string]any{
-		"type":       "object",
-		"properties": properties,
+// applyJSONSchemaTag parses field's jsonschema struct tag into prop,
+// reporting whether the tag explicitly marked the field required.
+func applyJSONSchemaTag(field reflect.StructField, prop map[string]any) (explicitlyRequired bool) {
+	jsTag := field.Tag.Get("jsonschema")
+	if jsTag == "" {
+		return false
 	}
-	if len(required) > 0 {
-		schema["required"] = required
+	for _, part := range strings.Split(jsTag, ",") {
+		kv := strings.SplitN(part, "=", 2)
+		key := strings.TrimSpace(kv[0])
+		val := ""
+		if len(kv) == 2 {
+			val = strings.TrimSpace(kv[1])
+		}
+		switch key {
+		case "description":
+			prop["description"] = val
+		case "title":
+			prop["title"] = val
+		case "format":
+			prop["format"] = val
+		case "pattern":
+			prop["pattern"] = val
+		case "required":
+			explicitlyRequired = true
+		case "enum":
+			enumVals := strings.Split(val, "|")
+			anyVals := make([]any, len(enumVals))
+			for j, ev := range enumVals {
+				anyVals[j] = strings.TrimSpace(ev)
+			}
+			prop["enum"] = anyVals
+		case "minimum":
+			if n, err := strconv.ParseFloat(val, 64); err == nil {
+				prop["minimum"] = n
+			}
+		case "maximum":
+			if n, err := strconv.ParseFloat(val, 64); err == nil {
+				prop["maximum"] = n
+			}
+		case "minLength":
+			if n, err := strconv.Atoi(val); err == nil {
+				prop["minLength"] = n
+			}
+		case "maxLength":
+			if n, err := strconv.Atoi(val); err == nil {
+				prop["maxLength"] = n
+			}
+		case "default":
+			prop["default"] = parseTagScalar(val)
+		case "example":
+			prop["example"] = parseTagScalar(val)
+		}
 	}
-	return schema
+	return explicitlyRequired
+}
+
+// parseTagScalar parses a jsonschema tag value as JSON (so `default=0` or
+// `default=true` come through as a number/bool, not the string "0"), falling
+// back to the raw string for values like `default=Unknown` that aren't valid
+// JSON on their own.
+func parseTagScalar(val string) any {
+	var v any
+	if err := json.Unmarshal([]byte(val), &v); err == nil {
+		return v
+	}
+	return val
+}
+
+// parameterSchema is the subset of JSON Schema [GenerateSchema] emits,
+// parsed back out of a tool's Parameters() for validation by
+// [ValidateArguments].
+type parameterSchema struct {
+	Type       string                     `json:"type"`
+	Properties map[string]parameterSchema `json:"properties"`
+	Required   []string                   `json:"required"`
+	Items      *parameterSchema           `json:"items"`
+	Enum       []any                      `json:"enum"`
+}
+
+// ValidateArguments checks args against schema (as produced by
+// [GenerateSchema] or hand-written JSON Schema of the same shape),
+// reporting the first missing required field, type mismatch, or enum
+// violation it finds. A nil or unparseable schema is treated as
+// unconstrained and always validates.
+//
+// [invokeFunctions] calls this before dispatching a tool call so a
+// malformed call can be fed back to the model as a corrigible tool
+// error instead of failing deep inside the tool and burning a
+// consecutive-error slot.
+func ValidateArguments(schema json.RawMessage, args json.RawMessage) error {
+	if len(schema) == 0 {
+		return nil
+	}
+	var s parameterSchema
+	if err := json.Unmarshal(schema, &s); err != nil {
+		return nil
+	}
+
+	var value any
+	if len(args) == 0 {
+		value = map[string]any{}
+	} else if err := json.Unmarshal(args, &value); err != nil {
+		return fmt.Errorf("arguments are not valid JSON: %w", err)
+	}
+	return validateAgainstSchema(&s, value, "arguments")
+}
+
+func validateAgainstSchema(s *parameterSchema, value any, path string) error {
+	if len(s.Enum) > 0 && !enumContains(s.Enum, value) {
+		return fmt.Errorf("%s: value %v is not one of %v", path, value, s.Enum)
+	}
+
+	switch s.Type {
+	case "object":
+		obj, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s: expected object, got %T", path, value)
+		}
+		for _, name := range s.Required {
+			if _, ok := obj[name]; !ok {
+				return fmt.Errorf("%s: missing required field %q", path, name)
+			}
+		}
+		for name, propSchema := range s.Properties {
+			v, ok := obj[name]
+			if !ok {
+				continue
+			}
+			propSchema := propSchema
+			if err := validateAgainstSchema(&propSchema, v, path+"."+name); err != nil {
+				return err
+			}
+		}
+	case "array":
+		arr, ok := value.([]any)
+		if !ok {
+			return fmt.Errorf("%s: expected array, got %T", path, value)
+		}
+		if s.Items != nil {
+			for i, v := range arr {
+				if err := validateAgainstSchema(s.Items, v, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	case "string":
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("%s: expected string, got %T", path, value)
+		}
+	case "integer":
+		n, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("%s: expected integer, got %T", path, value)
+		}
+		if n != float64(int64(n)) {
+			return fmt.Errorf("%s: expected integer, got non-integral number %v", path, n)
+		}
+	case "number":
+		if _, ok := value.(float64); !ok {
+			return fmt.Errorf("%s: expected number, got %T", path, value)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s: expected boolean, got %T", path, value)
+		}
+	}
+	return nil
+}
+
+func enumContains(enum []any, value any) bool {
+	for _, e := range enum {
+		if fmt.Sprint(e) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
 }
@@ -121,3 +121,229 @@ func TestGenerateSchema_TypeMapping(t *testing.T) {
 		t.Errorf("score type = %v", score["type"])
 	}
 }
+
+func TestValidateArguments(t *testing.T) {
+	schema := af.GenerateSchema[weatherArgs]()
+
+	tests := []struct {
+		name    string
+		args    string
+		wantErr bool
+	}{
+		{"valid", `{"location":"Seattle","unit":"celsius"}`, false},
+		{"missing required field", `{"unit":"celsius"}`, true},
+		{"wrong type", `{"location":42}`, true},
+		{"bad enum value", `{"location":"Seattle","unit":"kelvin"}`, true},
+		{"not valid JSON", `{not json`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := af.ValidateArguments(schema, json.RawMessage(tt.args))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateArguments(%q) err = %v, wantErr %v", tt.args, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateArguments_NilSchemaAlwaysValid(t *testing.T) {
+	if err := af.ValidateArguments(nil, json.RawMessage(`{"anything":"goes"}`)); err != nil {
+		t.Errorf("ValidateArguments(nil schema) = %v, want nil", err)
+	}
+}
+
+type taggedArgs struct {
+	Age      int     `json:"age"      jsonschema:"title=Age,minimum=0,maximum=150"`
+	Name     string  `json:"name"     jsonschema:"minLength=1,maxLength=64,pattern=^[a-z]+$,format=hostname"`
+	Currency string  `json:"currency" jsonschema:"default=USD"`
+	Rate     float64 `json:"rate"     jsonschema:"example=0.5"`
+}
+
+func TestGenerateSchema_NewTags(t *testing.T) {
+	schema := af.GenerateSchema[taggedArgs]()
+
+	var parsed map[string]any
+	if err := json.Unmarshal(schema, &parsed); err != nil {
+		t.Fatal(err)
+	}
+	props := parsed["properties"].(map[string]any)
+
+	age := props["age"].(map[string]any)
+	if age["title"] != "Age" {
+		t.Errorf("age title = %v", age["title"])
+	}
+	if age["minimum"] != float64(0) || age["maximum"] != float64(150) {
+		t.Errorf("age minimum/maximum = %v/%v", age["minimum"], age["maximum"])
+	}
+
+	name := props["name"].(map[string]any)
+	if name["minLength"] != float64(1) || name["maxLength"] != float64(64) {
+		t.Errorf("name minLength/maxLength = %v/%v", name["minLength"], name["maxLength"])
+	}
+	if name["pattern"] != "^[a-z]+$" {
+		t.Errorf("name pattern = %v", name["pattern"])
+	}
+	if name["format"] != "hostname" {
+		t.Errorf("name format = %v", name["format"])
+	}
+
+	currency := props["currency"].(map[string]any)
+	if currency["default"] != "USD" {
+		t.Errorf("currency default = %v, want string USD", currency["default"])
+	}
+
+	rate := props["rate"].(map[string]any)
+	if rate["example"] != 0.5 {
+		t.Errorf("rate example = %v, want number 0.5", rate["example"])
+	}
+}
+
+type optionalArgs struct {
+	Required string `json:"required"`
+	Optional string `json:"optional,omitempty"`
+	Forced   string `json:"forced,omitempty" jsonschema:"required"`
+}
+
+func TestGenerateSchema_OmitEmptyDefaultRequired(t *testing.T) {
+	schema := af.GenerateSchema[optionalArgs]()
+
+	var parsed map[string]any
+	if err := json.Unmarshal(schema, &parsed); err != nil {
+		t.Fatal(err)
+	}
+	required := make(map[string]bool)
+	for _, r := range parsed["required"].([]any) {
+		required[r.(string)] = true
+	}
+
+	if !required["required"] {
+		t.Error("field without omitempty should be required by default")
+	}
+	if required["optional"] {
+		t.Error("omitempty field should not be required")
+	}
+	if !required["forced"] {
+		t.Error("omitempty field with explicit jsonschema:\"required\" should still be required")
+	}
+}
+
+type treeNode struct {
+	Value    string     `json:"value"`
+	Children []treeNode `json:"children,omitempty"`
+}
+
+func TestGenerateSchema_RecursiveType(t *testing.T) {
+	schema := af.GenerateSchema[treeNode]()
+
+	var parsed map[string]any
+	if err := json.Unmarshal(schema, &parsed); err != nil {
+		t.Fatal(err)
+	}
+
+	defs, ok := parsed["$defs"].(map[string]any)
+	if !ok {
+		t.Fatalf("$defs missing or wrong type, schema = %s", schema)
+	}
+	node, ok := defs["treeNode"].(map[string]any)
+	if !ok {
+		t.Fatalf("$defs.treeNode missing, defs = %v", defs)
+	}
+	props := node["properties"].(map[string]any)
+	children := props["children"].(map[string]any)
+	if children["type"] != "array" {
+		t.Errorf("children type = %v", children["type"])
+	}
+	items := children["items"].(map[string]any)
+	if items["$ref"] != "#/$defs/treeNode" {
+		t.Errorf("children items $ref = %v, want #/$defs/treeNode", items["$ref"])
+	}
+
+	// The root of the schema itself is the cycle-closing def, so it's
+	// referenced rather than inlined.
+	if parsed["$ref"] != "#/$defs/treeNode" {
+		t.Errorf("root $ref = %v, want #/$defs/treeNode", parsed["$ref"])
+	}
+}
+
+type EmbeddedBase struct {
+	ID string `json:"id"`
+}
+
+type withEmbedded struct {
+	EmbeddedBase
+	Name string `json:"name"`
+}
+
+func TestGenerateSchema_EmbeddedStructFlattened(t *testing.T) {
+	schema := af.GenerateSchema[withEmbedded]()
+
+	var parsed map[string]any
+	if err := json.Unmarshal(schema, &parsed); err != nil {
+		t.Fatal(err)
+	}
+	props := parsed["properties"].(map[string]any)
+
+	if _, ok := props["EmbeddedBase"]; ok {
+		t.Error("embedded field should be flattened, not nested under its type name")
+	}
+	if _, ok := props["id"]; !ok {
+		t.Error("embedded struct's field should be flattened into the parent's properties")
+	}
+	if _, ok := props["name"]; !ok {
+		t.Error("parent's own field should still be present")
+	}
+}
+
+func TestGenerateSchemaWithOptions_Draft07UsesDefinitions(t *testing.T) {
+	schema := af.GenerateSchemaWithOptions[treeNode](af.SchemaOptions{Draft: af.SchemaDraft07})
+
+	var parsed map[string]any
+	if err := json.Unmarshal(schema, &parsed); err != nil {
+		t.Fatal(err)
+	}
+	if parsed["$schema"] != "http://json-schema.org/draft-07/schema#" {
+		t.Errorf("$schema = %v", parsed["$schema"])
+	}
+	if _, ok := parsed["definitions"].(map[string]any); !ok {
+		t.Errorf("definitions missing for draft-07, schema = %s", schema)
+	}
+	if _, ok := parsed["$defs"]; ok {
+		t.Error("draft-07 schema should not have $defs")
+	}
+}
+
+func TestGenerateSchemaWithOptions_NoInlineDefsFactorsOutReusedStruct(t *testing.T) {
+	type addr struct {
+		City string `json:"city"`
+	}
+	type person struct {
+		Home addr `json:"home"`
+		Work addr `json:"work"`
+	}
+
+	schema := af.GenerateSchemaWithOptions[person](af.SchemaOptions{})
+
+	var parsed map[string]any
+	if err := json.Unmarshal(schema, &parsed); err != nil {
+		t.Fatal(err)
+	}
+	defs, ok := parsed["$defs"].(map[string]any)
+	if !ok {
+		t.Fatalf("$defs missing, schema = %s", schema)
+	}
+	if _, ok := defs["addr"]; !ok {
+		t.Errorf("addr not factored into $defs, defs = %v", defs)
+	}
+
+	// The root struct (person) is itself a named, non-inlined type with
+	// InlineDefs false, so it's also hoisted into $defs and referenced.
+	if parsed["$ref"] != "#/$defs/person" {
+		t.Errorf("root $ref = %v, want #/$defs/person", parsed["$ref"])
+	}
+	personDef := defs["person"].(map[string]any)
+	props := personDef["properties"].(map[string]any)
+	home := props["home"].(map[string]any)
+	if home["$ref"] != "#/$defs/addr" {
+		t.Errorf("home $ref = %v, want #/$defs/addr", home["$ref"])
+	}
+}
@@ -3,8 +3,11 @@
 package agentframework
 
 import (
+	"context"
 	"crypto/rand"
+	"encoding/json"
 	"fmt"
+	"sort"
 	"sync"
 )
 
@@ -15,12 +18,15 @@ import (
 //
 // Setting one mode locks out the other.
 type Session struct {
-	mu              sync.Mutex
-	id              string
-	serviceID       string
-	store           MessageStore
-	contextProvider ContextProvider
-	modeLocked      bool
+	mu                  sync.Mutex
+	id                  string
+	serviceID           string
+	store               MessageStore
+	contextProvider     ContextProvider
+	modeLocked          bool
+	cacheKey            string
+	alwaysApprovedTools map[string]bool
+	branch              string
 }
 
 // SessionOption configures a [Session].
@@ -40,6 +46,15 @@ func WithSessionContextProvider(cp ContextProvider) SessionOption {
 	}
 }
 
+// WithSessionCacheKey overrides the session's [Session.CacheKey], e.g. to
+// share one prompt-cache partition across several sessions for the same
+// user instead of the default one-partition-per-session behavior.
+func WithSessionCacheKey(key string) SessionOption {
+	return func(s *Session) {
+		s.cacheKey = key
+	}
+}
+
 // NewSession creates a new Session with a generated ID.
 func NewSession(opts ...SessionOption) *Session {
 	s := &Session{
@@ -97,6 +112,146 @@ func (s *Session) SetStore(store MessageStore) error {
 // ContextProvider returns the session's context provider, if any.
 func (s *Session) ContextProvider() ContextProvider { return s.contextProvider }
 
+// CacheKey returns the partition key to use for provider-side prompt
+// caching (see [ChatOptions.CacheKey]). Defaults to the session's ID, so
+// repeated turns of the same conversation land on the same cache
+// partition without any extra configuration; override with
+// [WithSessionCacheKey] to share a partition across sessions.
+func (s *Session) CacheKey() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cacheKey != "" {
+		return s.cacheKey
+	}
+	return s.id
+}
+
+// ApproveToolForSession records that name no longer requires approval for
+// the remainder of this session. [Agent.Resume] calls this when an
+// [ApprovalResponseContent] sets AlwaysApproveForSession, and consults it
+// (via [Session.IsToolAlwaysApproved]) before pausing later calls to the
+// same tool.
+func (s *Session) ApproveToolForSession(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.alwaysApprovedTools == nil {
+		s.alwaysApprovedTools = make(map[string]bool)
+	}
+	s.alwaysApprovedTools[name] = true
+}
+
+// IsToolAlwaysApproved reports whether name was previously pre-approved for
+// this session via [Session.ApproveToolForSession].
+func (s *Session) IsToolAlwaysApproved(name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.alwaysApprovedTools[name]
+}
+
+// Branch returns the active branch of the session's store, if it is a
+// [BranchingMessageStore]. Empty if the store doesn't support branching or
+// no fork has happened yet.
+func (s *Session) Branch() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.branch
+}
+
+// Fork rewinds the session to atMessageID, edits from there, and continues
+// as an independent branch while the original remains inspectable. Returns
+// [ErrSessionModeLocked] for a service-managed session, since branching is a
+// local-store concept, and [ErrSession] if the session's store doesn't
+// implement [BranchingMessageStore].
+func (s *Session) Fork(ctx context.Context, atMessageID string) (string, error) {
+	s.mu.Lock()
+	store := s.store
+	serviceManaged := s.serviceID != ""
+	s.mu.Unlock()
+
+	if serviceManaged {
+		return "", fmt.Errorf("%w: cannot fork a service-managed session", ErrSessionModeLocked)
+	}
+
+	bs, ok := store.(BranchingMessageStore)
+	if !ok {
+		return "", fmt.Errorf("%w: session store does not support branching", ErrSession)
+	}
+	branchID, err := bs.Fork(ctx, atMessageID)
+	if err != nil {
+		return "", err
+	}
+
+	s.mu.Lock()
+	s.branch = branchID
+	s.mu.Unlock()
+	return branchID, nil
+}
+
+// Branches returns every branch of the session's store, including its fork
+// ancestry, if the store implements [BranchingMessageStore]. Returns nil if
+// the store doesn't support branching.
+func (s *Session) Branches(ctx context.Context) ([]BranchInfo, error) {
+	s.mu.Lock()
+	store := s.store
+	s.mu.Unlock()
+
+	bs, ok := store.(BranchingMessageStore)
+	if !ok {
+		return nil, nil
+	}
+	return bs.Branches(ctx)
+}
+
+// EditAndReprompt forks the session at the message preceding messageID,
+// replaces messageID's text with newText, and leaves the session on the new
+// branch, ready for the next agent call to continue from the edited turn.
+// A convenience wrapper around [Session.Fork] for the common "edit a past
+// message and re-run" flow. Returns [ErrSession] if messageID is the first
+// message in the store (there is no earlier point to fork from) or isn't
+// found.
+func (s *Session) EditAndReprompt(ctx context.Context, messageID, newText string) error {
+	s.mu.Lock()
+	store := s.store
+	s.mu.Unlock()
+	if store == nil {
+		return fmt.Errorf("%w: session has no store", ErrSession)
+	}
+
+	msgs, err := store.ListMessages(ctx)
+	if err != nil {
+		return fmt.Errorf("list messages: %w", err)
+	}
+	idx := -1
+	for i, m := range msgs {
+		if m.MessageID == messageID {
+			idx = i
+			break
+		}
+	}
+	if idx == -1 {
+		return fmt.Errorf("%w: message %q not found", ErrSession, messageID)
+	}
+	if idx == 0 {
+		return fmt.Errorf("%w: cannot edit the first message of a session", ErrSession)
+	}
+
+	if _, err := s.Fork(ctx, msgs[idx-1].MessageID); err != nil {
+		return err
+	}
+
+	edited := msgs[idx]
+	edited.Contents = Contents{&TextContent{Text: newText}}
+	// Clear the original MessageID so AddMessages assigns a fresh one for
+	// the new branch's node, instead of colliding with the still-shared
+	// tree node the original branch keeps pointing at.
+	edited.MessageID = ""
+
+	s.mu.Lock()
+	store = s.store
+	s.mu.Unlock()
+	return store.AddMessages(ctx, []Message{edited})
+}
+
 // Serialize returns the session state as a serializable map.
 func (s *Session) Serialize() (map[string]any, error) {
 	s.mu.Lock()
@@ -114,10 +269,96 @@ func (s *Session) Serialize() (map[string]any, error) {
 			return nil, fmt.Errorf("serialize store: %w", err)
 		}
 		state["store"] = storeState
+
+		if bs, ok := s.store.(BranchingMessageStore); ok && s.branch != "" {
+			branches, err := bs.Branches(context.Background())
+			if err != nil {
+				return nil, fmt.Errorf("list branches: %w", err)
+			}
+			for _, b := range branches {
+				if b.ID == s.branch {
+					state["parentId"] = b.ParentID
+					state["forkPoint"] = b.ForkPoint
+					break
+				}
+			}
+		}
 	}
 	return state, nil
 }
 
+// sessionJSON is the wire format used by [Session.MarshalJSON] and
+// [Session.UnmarshalJSON].
+type sessionJSON struct {
+	ID                  string    `json:"id"`
+	ServiceID           string    `json:"serviceId,omitempty"`
+	CacheKey            string    `json:"cacheKey,omitempty"`
+	Messages            []Message `json:"messages,omitempty"`
+	AlwaysApprovedTools []string  `json:"alwaysApprovedTools,omitempty"`
+}
+
+// MarshalJSON implements [json.Marshaler], encoding the session's id,
+// service ID, cache key override, tools pre-approved via
+// [Session.ApproveToolForSession], and — for the default [InMemoryStore] —
+// its message history. A custom [MessageStore] is responsible for its own
+// persistence and is not round-tripped here; use [Session.Serialize] if you
+// need its state as well.
+func (s *Session) MarshalJSON() ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sj := sessionJSON{ID: s.id, ServiceID: s.serviceID, CacheKey: s.cacheKey}
+	if mem, ok := s.store.(*InMemoryStore); ok {
+		msgs, err := mem.ListMessages(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("list messages: %w", err)
+		}
+		sj.Messages = msgs
+	}
+	if len(s.alwaysApprovedTools) > 0 {
+		sj.AlwaysApprovedTools = make([]string, 0, len(s.alwaysApprovedTools))
+		for name := range s.alwaysApprovedTools {
+			sj.AlwaysApprovedTools = append(sj.AlwaysApprovedTools, name)
+		}
+		sort.Strings(sj.AlwaysApprovedTools)
+	}
+	return json.Marshal(sj)
+}
+
+// UnmarshalJSON implements [json.Unmarshaler], restoring a session
+// previously encoded with [Session.MarshalJSON]. If the encoded session had
+// message history, it is loaded into a new [InMemoryStore].
+func (s *Session) UnmarshalJSON(data []byte) error {
+	var sj sessionJSON
+	if err := json.Unmarshal(data, &sj); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.id = sj.ID
+	s.serviceID = sj.ServiceID
+	s.cacheKey = sj.CacheKey
+	if sj.ServiceID != "" {
+		s.modeLocked = true
+	}
+	if len(sj.Messages) > 0 {
+		store := NewInMemoryStore()
+		if err := store.AddMessages(context.Background(), sj.Messages); err != nil {
+			return fmt.Errorf("restore messages: %w", err)
+		}
+		s.store = store
+		s.modeLocked = true
+	}
+	if len(sj.AlwaysApprovedTools) > 0 {
+		s.alwaysApprovedTools = make(map[string]bool, len(sj.AlwaysApprovedTools))
+		for _, name := range sj.AlwaysApprovedTools {
+			s.alwaysApprovedTools[name] = true
+		}
+	}
+	return nil
+}
+
 func newUUID() string {
 	var b [16]byte
 	_, _ = rand.Read(b[:])
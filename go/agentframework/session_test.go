@@ -4,6 +4,7 @@ package agentframework_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
 
@@ -113,3 +114,200 @@ func TestSessionSerialize(t *testing.T) {
 		t.Errorf("serviceId = %v", state["serviceId"])
 	}
 }
+
+func TestSession_CacheKey_DefaultsToID(t *testing.T) {
+	s := af.NewSession()
+	if s.CacheKey() != s.ID() {
+		t.Errorf("CacheKey() = %q, want session ID %q", s.CacheKey(), s.ID())
+	}
+}
+
+func TestSession_Fork_ForbiddenForServiceManagedSession(t *testing.T) {
+	s := af.NewSession()
+	if err := s.SetServiceID("thread-abc"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.Fork(context.Background(), "anything"); !errors.Is(err, af.ErrSessionModeLocked) {
+		t.Errorf("Fork err = %v, want ErrSessionModeLocked", err)
+	}
+}
+
+func TestSession_Branches_RootAndFork(t *testing.T) {
+	ctx := context.Background()
+	store := af.NewInMemoryBranchingStore()
+	s := af.NewSession(af.WithSessionStore(store))
+
+	if err := store.AddMessages(ctx, []af.Message{af.NewUserMessage("hi")}); err != nil {
+		t.Fatalf("AddMessages: %v", err)
+	}
+	msgs, _ := store.ListMessages(ctx)
+
+	branchID, err := s.Fork(ctx, msgs[0].MessageID)
+	if err != nil {
+		t.Fatalf("Fork: %v", err)
+	}
+
+	branches, err := s.Branches(ctx)
+	if err != nil {
+		t.Fatalf("Branches: %v", err)
+	}
+	var forked *af.BranchInfo
+	for i := range branches {
+		if branches[i].ID == branchID {
+			forked = &branches[i]
+		}
+	}
+	if forked == nil {
+		t.Fatalf("Branches() = %v, missing %s", branches, branchID)
+	}
+	if forked.ParentID != "root" || forked.ForkPoint != msgs[0].MessageID {
+		t.Errorf("forked branch info = %+v", forked)
+	}
+}
+
+func TestSession_EditAndReprompt(t *testing.T) {
+	ctx := context.Background()
+	store := af.NewInMemoryBranchingStore()
+	s := af.NewSession(af.WithSessionStore(store))
+
+	if err := store.AddMessages(ctx, []af.Message{af.NewUserMessage("hi")}); err != nil {
+		t.Fatalf("AddMessages: %v", err)
+	}
+	if err := store.AddMessages(ctx, []af.Message{af.NewAssistantMessage("original reply")}); err != nil {
+		t.Fatalf("AddMessages: %v", err)
+	}
+	msgs, _ := store.ListMessages(ctx)
+	replyID := msgs[1].MessageID
+
+	if err := s.EditAndReprompt(ctx, replyID, "edited reply"); err != nil {
+		t.Fatalf("EditAndReprompt: %v", err)
+	}
+
+	edited, err := store.ListMessages(ctx)
+	if err != nil {
+		t.Fatalf("ListMessages: %v", err)
+	}
+	if len(edited) != 2 || edited[1].Text() != "edited reply" {
+		t.Errorf("edited branch = %v, want [hi, edited reply]", edited)
+	}
+
+	if err := store.SwitchBranch(ctx, "root"); err != nil {
+		t.Fatalf("SwitchBranch: %v", err)
+	}
+	original, err := store.ListMessages(ctx)
+	if err != nil {
+		t.Fatalf("ListMessages: %v", err)
+	}
+	if len(original) != 2 || original[1].Text() != "original reply" {
+		t.Errorf("original branch = %v, want [hi, original reply]", original)
+	}
+}
+
+func TestSession_EditAndReprompt_FirstMessageErrors(t *testing.T) {
+	ctx := context.Background()
+	store := af.NewInMemoryBranchingStore()
+	s := af.NewSession(af.WithSessionStore(store))
+
+	if err := store.AddMessages(ctx, []af.Message{af.NewUserMessage("hi")}); err != nil {
+		t.Fatalf("AddMessages: %v", err)
+	}
+	msgs, _ := store.ListMessages(ctx)
+
+	if err := s.EditAndReprompt(ctx, msgs[0].MessageID, "edited"); !errors.Is(err, af.ErrSession) {
+		t.Errorf("err = %v, want ErrSession", err)
+	}
+}
+
+func TestInMemoryStore_Truncate(t *testing.T) {
+	ctx := context.Background()
+	store := af.NewInMemoryStore()
+
+	if err := store.AddMessages(ctx, []af.Message{af.NewUserMessage("a"), af.NewUserMessage("b"), af.NewUserMessage("c")}); err != nil {
+		t.Fatalf("AddMessages: %v", err)
+	}
+	msgs, _ := store.ListMessages(ctx)
+
+	if err := store.Truncate(ctx, msgs[1].MessageID); err != nil {
+		t.Fatalf("Truncate: %v", err)
+	}
+
+	remaining, err := store.ListMessages(ctx)
+	if err != nil {
+		t.Fatalf("ListMessages: %v", err)
+	}
+	if len(remaining) != 2 || remaining[1].Text() != "b" {
+		t.Errorf("remaining = %v, want [a, b]", remaining)
+	}
+}
+
+func TestInMemoryStore_Truncate_NotFound(t *testing.T) {
+	store := af.NewInMemoryStore()
+	if err := store.Truncate(context.Background(), "missing"); !errors.Is(err, af.ErrSession) {
+		t.Errorf("err = %v, want ErrSession", err)
+	}
+}
+
+func TestSession_MarshalUnmarshalJSON_RoundTrips(t *testing.T) {
+	store := af.NewInMemoryStore()
+	if err := store.AddMessages(context.Background(), []af.Message{
+		af.NewUserMessage("hello"),
+		af.NewAssistantMessage("hi there"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	s := af.NewSession(af.WithSessionStore(store), af.WithSessionCacheKey("user-42"))
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	restored := &af.Session{}
+	if err := json.Unmarshal(data, restored); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	if restored.ID() != s.ID() {
+		t.Errorf("ID = %q, want %q", restored.ID(), s.ID())
+	}
+	if restored.CacheKey() != "user-42" {
+		t.Errorf("CacheKey() = %q, want user-42", restored.CacheKey())
+	}
+	msgs, err := restored.Store().ListMessages(context.Background())
+	if err != nil {
+		t.Fatalf("list messages: %v", err)
+	}
+	if len(msgs) != 2 || msgs[0].Text() != "hello" || msgs[1].Text() != "hi there" {
+		t.Errorf("restored messages = %+v", msgs)
+	}
+}
+
+func TestSession_AlwaysApprovedTools_PersistAcrossJSON(t *testing.T) {
+	s := af.NewSession()
+	s.ApproveToolForSession("charge_card")
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	restored := &af.Session{}
+	if err := json.Unmarshal(data, restored); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	if !restored.IsToolAlwaysApproved("charge_card") {
+		t.Error("charge_card should still be pre-approved after a round trip")
+	}
+	if restored.IsToolAlwaysApproved("other_tool") {
+		t.Error("other_tool should not be pre-approved")
+	}
+}
+
+func TestSession_CacheKey_Override(t *testing.T) {
+	s := af.NewSession(af.WithSessionCacheKey("user-42"))
+	if s.CacheKey() != "user-42" {
+		t.Errorf("CacheKey() = %q, want user-42", s.CacheKey())
+	}
+}
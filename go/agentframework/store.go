@@ -2,7 +2,10 @@
 
 package agentframework
 
-import "context"
+import (
+	"context"
+	"fmt"
+)
 
 // MessageStore persists conversation messages for a [Session].
 type MessageStore interface {
@@ -12,6 +15,11 @@ type MessageStore interface {
 	// AddMessages appends messages to the store.
 	AddMessages(ctx context.Context, msgs []Message) error
 
+	// Truncate discards every message after messageID, leaving it as the
+	// new last message. Returns [ErrSession] if messageID isn't in the
+	// store.
+	Truncate(ctx context.Context, messageID string) error
+
 	// Serialize returns the store's state as a serializable map.
 	Serialize() (map[string]any, error)
 }
@@ -19,6 +27,7 @@ type MessageStore interface {
 // InMemoryStore is a simple in-memory [MessageStore].
 type InMemoryStore struct {
 	messages []Message
+	nextID   int
 }
 
 // NewInMemoryStore creates an empty [InMemoryStore].
@@ -32,11 +41,30 @@ func (s *InMemoryStore) ListMessages(_ context.Context) ([]Message, error) {
 	return cp, nil
 }
 
+// AddMessages appends msgs, assigning each message's MessageID if it is
+// empty so a later [InMemoryStore.Truncate] can address it.
 func (s *InMemoryStore) AddMessages(_ context.Context, msgs []Message) error {
+	for i := range msgs {
+		if msgs[i].MessageID == "" {
+			s.nextID++
+			msgs[i].MessageID = fmt.Sprintf("msg-%d", s.nextID)
+		}
+	}
 	s.messages = append(s.messages, msgs...)
 	return nil
 }
 
+// Truncate drops every message after messageID.
+func (s *InMemoryStore) Truncate(_ context.Context, messageID string) error {
+	for i, m := range s.messages {
+		if m.MessageID == messageID {
+			s.messages = s.messages[:i+1]
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: message %q not found", ErrSession, messageID)
+}
+
 func (s *InMemoryStore) Serialize() (map[string]any, error) {
 	return map[string]any{
 		"messages": s.messages,
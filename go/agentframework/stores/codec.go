@@ -0,0 +1,67 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package stores
+
+import (
+	"encoding/json"
+	"fmt"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+// MessageCodec converts a [af.Message] to and from the byte representation
+// a store persists. Implement this to swap JSON for gob, protobuf, or
+// another format without changing the stores themselves.
+type MessageCodec interface {
+	Encode(msg af.Message) ([]byte, error)
+	Decode(data []byte) (af.Message, error)
+}
+
+// JSONCodec is the default [MessageCodec]. It encodes a [af.Message] with
+// the standard library's encoding/json, which already resolves the
+// [af.Content] sealed interface's $type discriminator via
+// [af.MarshalContentJSON] and [af.UnmarshalContentJSON] through
+// [af.Message]'s Contents field — no separate registry is needed.
+type JSONCodec struct{}
+
+// Encode implements [MessageCodec].
+func (JSONCodec) Encode(msg af.Message) ([]byte, error) {
+	return json.Marshal(msg)
+}
+
+// Decode implements [MessageCodec].
+func (JSONCodec) Decode(data []byte) (af.Message, error) {
+	var msg af.Message
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return af.Message{}, err
+	}
+	return msg, nil
+}
+
+// Deserializer is implemented by a store that can restore state previously
+// produced by its own Serialize, the symmetric counterpart
+// [af.MessageStore.Serialize] doesn't require of every implementation.
+type Deserializer interface {
+	Deserialize(state map[string]any) error
+}
+
+// decodeMessagesState extracts and decodes the "messages" entry of a state
+// map produced by [af.MessageStore.Serialize]. state["messages"] may be a
+// literal []af.Message (when state was never marshaled) or the generic
+// map[string]any shape json.Unmarshal produces (when state was persisted
+// and reloaded); round-tripping through JSON handles both the same way.
+func decodeMessagesState(state map[string]any) ([]af.Message, error) {
+	raw, ok := state["messages"]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("stores: re-marshal messages: %w", err)
+	}
+	var msgs []af.Message
+	if err := json.Unmarshal(data, &msgs); err != nil {
+		return nil, fmt.Errorf("stores: decode messages: %w", err)
+	}
+	return msgs, nil
+}
@@ -0,0 +1,22 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+// Package stores provides production [af.MessageStore] backends that
+// survive a process restart, unlike [af.InMemoryStore]: [SQLStore] (any
+// database/sql driver), [RedisStore] (a minimal client interface, so no
+// specific Redis library is required), and [FileStore] (append-only JSONL
+// with periodic compaction). Each adds a [af.MessageStore.Serialize]
+// counterpart, Deserialize, to restore state, and a TrimBefore method for
+// retention policies.
+//
+// All three encode messages with a [MessageCodec]; [JSONCodec] is the
+// default and handles the [af.Content] sealed interface's $type
+// discriminator via [af.MarshalContentJSON] and [af.UnmarshalContentJSON].
+// Supply a different [MessageCodec] to use gob, protobuf, or another wire
+// format instead.
+package stores
+
+import af "github.com/microsoft/agent-framework/go/agentframework"
+
+var _ af.MessageStore = (*FileStore)(nil)
+var _ af.MessageStore = (*SQLStore)(nil)
+var _ af.MessageStore = (*RedisStore)(nil)
@@ -0,0 +1,307 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package stores
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+// FileStore is a [af.MessageStore] backed by an append-only JSONL file: one
+// JSON record per line. AddMessages and Truncate only ever append, so
+// concurrent writers never corrupt a partially-written line; call Compact
+// periodically to reclaim the space truncated or trimmed-away records
+// still occupy.
+type FileStore struct {
+	mu    sync.Mutex
+	path  string
+	codec MessageCodec
+}
+
+var _ Deserializer = (*FileStore)(nil)
+
+// FileStoreOption configures a [FileStore].
+type FileStoreOption func(*FileStore)
+
+// WithFileStoreCodec overrides the [MessageCodec] used to encode each
+// message. The default is [JSONCodec].
+func WithFileStoreCodec(codec MessageCodec) FileStoreOption {
+	return func(s *FileStore) { s.codec = codec }
+}
+
+// NewFileStore creates a [FileStore] writing to path, creating it (and any
+// existing content is read back) on first use.
+func NewFileStore(path string, opts ...FileStoreOption) *FileStore {
+	s := &FileStore{path: path, codec: JSONCodec{}}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// fileRecord is one line of a [FileStore]'s JSONL file. A record is either
+// a message (Data set) or a truncate marker (Truncate set) recording that
+// every record after the one with MessageID == Truncate should be dropped.
+type fileRecord struct {
+	StoredAt int64  `json:"storedAt"`
+	Data     []byte `json:"data,omitempty"`
+	Truncate string `json:"truncate,omitempty"`
+}
+
+type storedMessage struct {
+	msg      af.Message
+	storedAt time.Time
+}
+
+func (s *FileStore) ListMessages(_ context.Context) ([]af.Message, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	records, err := s.readLive()
+	if err != nil {
+		return nil, err
+	}
+	msgs := make([]af.Message, len(records))
+	for i, r := range records {
+		msgs[i] = r.msg
+	}
+	return msgs, nil
+}
+
+// readLive replays the file, applying truncate markers as it goes, and
+// returns the resulting live records in order.
+func (s *FileStore) readLive() ([]storedMessage, error) {
+	f, err := os.Open(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("stores: open %s: %w", s.path, err)
+	}
+	defer f.Close()
+
+	var live []storedMessage
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16<<20)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var rec fileRecord
+		if err := json.Unmarshal(line, &rec); err != nil {
+			return nil, fmt.Errorf("stores: decode record: %w", err)
+		}
+		if rec.Truncate != "" {
+			for i, m := range live {
+				if m.msg.MessageID == rec.Truncate {
+					live = live[:i+1]
+					break
+				}
+			}
+			continue
+		}
+		msg, err := s.codec.Decode(rec.Data)
+		if err != nil {
+			return nil, fmt.Errorf("stores: decode message: %w", err)
+		}
+		live = append(live, storedMessage{msg: msg, storedAt: time.Unix(0, rec.StoredAt)})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("stores: scan %s: %w", s.path, err)
+	}
+	return live, nil
+}
+
+func (s *FileStore) AddMessages(_ context.Context, msgs []af.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("stores: open %s: %w", s.path, err)
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	for i := range msgs {
+		if msgs[i].MessageID == "" {
+			msgs[i].MessageID = newMessageID()
+		}
+		if err := s.appendRecord(w, fileRecord{}, msgs[i]); err != nil {
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("stores: write %s: %w", s.path, err)
+	}
+	return nil
+}
+
+func (s *FileStore) appendRecord(w *bufio.Writer, rec fileRecord, msg af.Message) error {
+	data, err := s.codec.Encode(msg)
+	if err != nil {
+		return fmt.Errorf("stores: encode message: %w", err)
+	}
+	rec.StoredAt = time.Now().UnixNano()
+	rec.Data = data
+	return writeRecordLine(w, rec)
+}
+
+func writeRecordLine(w *bufio.Writer, rec fileRecord) error {
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+	_, err = w.Write(line)
+	return err
+}
+
+// Truncate appends a marker dropping every record after messageID; it
+// doesn't shrink the file. Call [FileStore.Compact] periodically to reclaim
+// the space truncated-away records occupy.
+func (s *FileStore) Truncate(_ context.Context, messageID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	live, err := s.readLive()
+	if err != nil {
+		return err
+	}
+	found := false
+	for _, m := range live {
+		if m.msg.MessageID == messageID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("%w: message %q not found", af.ErrSession, messageID)
+	}
+
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("stores: open %s: %w", s.path, err)
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	if err := writeRecordLine(w, fileRecord{StoredAt: time.Now().UnixNano(), Truncate: messageID}); err != nil {
+		return fmt.Errorf("stores: write %s: %w", s.path, err)
+	}
+	return w.Flush()
+}
+
+// TrimBefore drops every message stored before cutoff, for a retention
+// policy. Unlike Truncate, this rewrites the file immediately.
+func (s *FileStore) TrimBefore(_ context.Context, cutoff time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	live, err := s.readLive()
+	if err != nil {
+		return err
+	}
+	kept := live[:0]
+	for _, m := range live {
+		if !m.storedAt.Before(cutoff) {
+			kept = append(kept, m)
+		}
+	}
+	return s.rewrite(kept)
+}
+
+// Compact rewrites the file to contain only its live records, dropping any
+// truncated-away or trimmed-away history.
+func (s *FileStore) Compact() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	live, err := s.readLive()
+	if err != nil {
+		return err
+	}
+	return s.rewrite(live)
+}
+
+// rewrite atomically replaces the file's contents with records, preserving
+// each one's original storedAt.
+func (s *FileStore) rewrite(records []storedMessage) error {
+	tmp := s.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return fmt.Errorf("stores: open %s: %w", tmp, err)
+	}
+
+	w := bufio.NewWriter(f)
+	for _, m := range records {
+		data, err := s.codec.Encode(m.msg)
+		if err != nil {
+			f.Close()
+			os.Remove(tmp)
+			return fmt.Errorf("stores: encode message: %w", err)
+		}
+		if err := writeRecordLine(w, fileRecord{StoredAt: m.storedAt.UnixNano(), Data: data}); err != nil {
+			f.Close()
+			os.Remove(tmp)
+			return fmt.Errorf("stores: write %s: %w", tmp, err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return fmt.Errorf("stores: flush %s: %w", tmp, err)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("stores: close %s: %w", tmp, err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("stores: rename %s: %w", tmp, err)
+	}
+	return nil
+}
+
+func (s *FileStore) Serialize() (map[string]any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	live, err := s.readLive()
+	if err != nil {
+		return nil, err
+	}
+	msgs := make([]af.Message, len(live))
+	for i, r := range live {
+		msgs[i] = r.msg
+	}
+	return map[string]any{"messages": msgs}, nil
+}
+
+// Deserialize replaces the store's contents with the messages in state,
+// restoring state previously produced by Serialize. It rewrites the file,
+// stamping each message with the current time as its storedAt.
+func (s *FileStore) Deserialize(state map[string]any) error {
+	msgs, err := decodeMessagesState(state)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	records := make([]storedMessage, len(msgs))
+	for i, m := range msgs {
+		records[i] = storedMessage{msg: m, storedAt: now}
+	}
+	return s.rewrite(records)
+}
@@ -0,0 +1,20 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package stores
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+var idCounter uint64
+
+// newMessageID returns a MessageID unique within this process and likely
+// unique across restarts, for a store to assign to a message that arrives
+// without one — mirroring [af.InMemoryStore.AddMessages], which needs no
+// such guarantee since its counter never survives a restart.
+func newMessageID() string {
+	n := atomic.AddUint64(&idCounter, 1)
+	return fmt.Sprintf("msg-%d-%d", time.Now().UnixNano(), n)
+}
@@ -0,0 +1,233 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package stores
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+// RedisClient is the minimal surface a Redis client needs to implement to
+// back a [RedisStore] — a thin wrapper around github.com/redis/go-redis/v9
+// or similar satisfies this easily.
+type RedisClient interface {
+	// RPush appends values to the end of the list at key.
+	RPush(ctx context.Context, key string, values ...string) error
+
+	// LRange returns list elements from start to stop, inclusive, using
+	// Redis's negative-index convention (-1 is the last element).
+	LRange(ctx context.Context, key string, start, stop int64) ([]string, error)
+
+	// LTrim keeps only elements from start to stop, inclusive, dropping the
+	// rest.
+	LTrim(ctx context.Context, key string, start, stop int64) error
+
+	// Expire sets key's time-to-live, replacing any previous one.
+	Expire(ctx context.Context, key string, ttl time.Duration) error
+
+	// Del removes key entirely.
+	Del(ctx context.Context, key string) error
+}
+
+// RedisStore is a [af.MessageStore] backed by a single Redis list (one
+// element per message) via [RedisClient], so any Redis client library can
+// back it by satisfying that narrow interface.
+type RedisStore struct {
+	client RedisClient
+	key    string
+	ttl    time.Duration
+	codec  MessageCodec
+}
+
+var _ Deserializer = (*RedisStore)(nil)
+
+// RedisStoreOption configures a [RedisStore].
+type RedisStoreOption func(*RedisStore)
+
+// WithRedisStoreCodec overrides the [MessageCodec] used to encode each
+// message. The default is [JSONCodec].
+func WithRedisStoreCodec(codec MessageCodec) RedisStoreOption {
+	return func(s *RedisStore) { s.codec = codec }
+}
+
+// WithRedisStoreTTL sets the list key's time-to-live, refreshed every time
+// [RedisStore.AddMessages] is called. A ttl of zero (the default) disables
+// expiry.
+func WithRedisStoreTTL(ttl time.Duration) RedisStoreOption {
+	return func(s *RedisStore) { s.ttl = ttl }
+}
+
+// NewRedisStore creates a [RedisStore] whose messages live in client's list
+// at key.
+func NewRedisStore(client RedisClient, key string, opts ...RedisStoreOption) *RedisStore {
+	s := &RedisStore{client: client, key: key, codec: JSONCodec{}}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// redisRecord is one element of a [RedisStore]'s list.
+type redisRecord struct {
+	StoredAt int64  `json:"storedAt"`
+	Data     []byte `json:"data"`
+}
+
+func (s *RedisStore) readAll(ctx context.Context) ([]redisRecord, error) {
+	raw, err := s.client.LRange(ctx, s.key, 0, -1)
+	if err != nil {
+		return nil, fmt.Errorf("stores: read %s: %w", s.key, err)
+	}
+	records := make([]redisRecord, len(raw))
+	for i, v := range raw {
+		if err := json.Unmarshal([]byte(v), &records[i]); err != nil {
+			return nil, fmt.Errorf("stores: decode record: %w", err)
+		}
+	}
+	return records, nil
+}
+
+func (s *RedisStore) ListMessages(ctx context.Context) ([]af.Message, error) {
+	records, err := s.readAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	msgs := make([]af.Message, len(records))
+	for i, r := range records {
+		msg, err := s.codec.Decode(r.Data)
+		if err != nil {
+			return nil, fmt.Errorf("stores: decode message: %w", err)
+		}
+		msgs[i] = msg
+	}
+	return msgs, nil
+}
+
+// AddMessages assigns each message's MessageID if it is empty, then
+// RPushes it onto the list and, if a TTL was configured, refreshes it.
+func (s *RedisStore) AddMessages(ctx context.Context, msgs []af.Message) error {
+	now := time.Now().UnixNano()
+	values := make([]string, len(msgs))
+	for i := range msgs {
+		if msgs[i].MessageID == "" {
+			msgs[i].MessageID = newMessageID()
+		}
+		data, err := s.codec.Encode(msgs[i])
+		if err != nil {
+			return fmt.Errorf("stores: encode message: %w", err)
+		}
+		line, err := json.Marshal(redisRecord{StoredAt: now + int64(i), Data: data})
+		if err != nil {
+			return err
+		}
+		values[i] = string(line)
+	}
+	if err := s.client.RPush(ctx, s.key, values...); err != nil {
+		return fmt.Errorf("stores: push to %s: %w", s.key, err)
+	}
+	if s.ttl > 0 {
+		if err := s.client.Expire(ctx, s.key, s.ttl); err != nil {
+			return fmt.Errorf("stores: expire %s: %w", s.key, err)
+		}
+	}
+	return nil
+}
+
+// Truncate drops every message after messageID.
+func (s *RedisStore) Truncate(ctx context.Context, messageID string) error {
+	msgs, err := s.ListMessages(ctx)
+	if err != nil {
+		return err
+	}
+	idx := -1
+	for i, m := range msgs {
+		if m.MessageID == messageID {
+			idx = i
+			break
+		}
+	}
+	if idx == -1 {
+		return fmt.Errorf("%w: message %q not found", af.ErrSession, messageID)
+	}
+	if err := s.client.LTrim(ctx, s.key, 0, int64(idx)); err != nil {
+		return fmt.Errorf("stores: truncate %s: %w", s.key, err)
+	}
+	return nil
+}
+
+// TrimBefore drops every message stored before cutoff, for a retention
+// policy. Since Redis lists can only be trimmed by index, this reads the
+// full list, then rewrites it with the kept elements.
+func (s *RedisStore) TrimBefore(ctx context.Context, cutoff time.Time) error {
+	records, err := s.readAll(ctx)
+	if err != nil {
+		return err
+	}
+	cutoffNanos := cutoff.UnixNano()
+	kept := records[:0]
+	for _, r := range records {
+		if r.StoredAt >= cutoffNanos {
+			kept = append(kept, r)
+		}
+	}
+	return s.rewrite(ctx, kept)
+}
+
+func (s *RedisStore) rewrite(ctx context.Context, records []redisRecord) error {
+	if err := s.client.Del(ctx, s.key); err != nil {
+		return fmt.Errorf("stores: clear %s: %w", s.key, err)
+	}
+	if len(records) == 0 {
+		return nil
+	}
+	values := make([]string, len(records))
+	for i, r := range records {
+		line, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+		values[i] = string(line)
+	}
+	if err := s.client.RPush(ctx, s.key, values...); err != nil {
+		return fmt.Errorf("stores: push to %s: %w", s.key, err)
+	}
+	if s.ttl > 0 {
+		if err := s.client.Expire(ctx, s.key, s.ttl); err != nil {
+			return fmt.Errorf("stores: expire %s: %w", s.key, err)
+		}
+	}
+	return nil
+}
+
+func (s *RedisStore) Serialize() (map[string]any, error) {
+	msgs, err := s.ListMessages(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"messages": msgs}, nil
+}
+
+// Deserialize replaces the list's contents with the messages in state,
+// restoring state previously produced by Serialize.
+func (s *RedisStore) Deserialize(state map[string]any) error {
+	msgs, err := decodeMessagesState(state)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	now := time.Now().UnixNano()
+	records := make([]redisRecord, len(msgs))
+	for i, m := range msgs {
+		data, err := s.codec.Encode(m)
+		if err != nil {
+			return fmt.Errorf("stores: encode message: %w", err)
+		}
+		records[i] = redisRecord{StoredAt: now + int64(i), Data: data}
+	}
+	return s.rewrite(ctx, records)
+}
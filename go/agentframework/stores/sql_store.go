@@ -0,0 +1,161 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package stores
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+// SQLExecutor is the subset of *sql.DB (or *sql.Tx) that [SQLStore] needs.
+// It extends [af.SQLExecutor] with QueryContext, for listing every stored
+// message. Use [WrapSQLDB] to adapt a *sql.DB.
+type SQLExecutor interface {
+	af.SQLExecutor
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// WrapSQLDB adapts a *sql.DB (or any driver-specific pool exposing the same
+// methods) to [SQLExecutor].
+func WrapSQLDB(db *sql.DB) SQLExecutor { return db }
+
+// SQLStoreSchema is a portable DDL statement creating the table expected by
+// [SQLStore], using the default table name "agent_messages". stored_at is
+// a Unix nanosecond timestamp used to order messages and to implement
+// [SQLStore.TrimBefore]; [SQLStore.AddMessages] keeps it strictly
+// increasing within a batch.
+const SQLStoreSchema = `CREATE TABLE IF NOT EXISTS agent_messages (
+	message_id TEXT NOT NULL,
+	stored_at  INTEGER NOT NULL,
+	data       BLOB NOT NULL
+)`
+
+// SQLStore is a [af.MessageStore] backed by a SQL table with columns
+// (message_id TEXT, stored_at INTEGER, data BLOB), ordered by stored_at.
+// Use [NewSQLStore] with a *sql.DB wrapped by [WrapSQLDB]; the table must
+// already exist (see [SQLStoreSchema]).
+type SQLStore struct {
+	db    SQLExecutor
+	table string
+	codec MessageCodec
+}
+
+var _ Deserializer = (*SQLStore)(nil)
+
+// SQLStoreOption configures a [SQLStore].
+type SQLStoreOption func(*SQLStore)
+
+// WithSQLStoreCodec overrides the [MessageCodec] used to encode each
+// message. The default is [JSONCodec].
+func WithSQLStoreCodec(codec MessageCodec) SQLStoreOption {
+	return func(s *SQLStore) { s.codec = codec }
+}
+
+// NewSQLStore creates a [SQLStore] using db to execute queries against
+// table (typically "agent_messages", matching [SQLStoreSchema]).
+func NewSQLStore(db SQLExecutor, table string, opts ...SQLStoreOption) *SQLStore {
+	s := &SQLStore{db: db, table: table, codec: JSONCodec{}}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *SQLStore) ListMessages(ctx context.Context) ([]af.Message, error) {
+	query := fmt.Sprintf(`SELECT data FROM %s ORDER BY stored_at ASC`, s.table)
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("stores: list messages: %w", err)
+	}
+	defer rows.Close()
+
+	var msgs []af.Message
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("stores: scan message: %w", err)
+		}
+		msg, err := s.codec.Decode(data)
+		if err != nil {
+			return nil, fmt.Errorf("stores: decode message: %w", err)
+		}
+		msgs = append(msgs, msg)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("stores: list messages: %w", err)
+	}
+	return msgs, nil
+}
+
+// AddMessages assigns each message's MessageID if it is empty, then inserts
+// it with a stored_at one nanosecond after the previous message in the
+// batch, so ORDER BY stored_at preserves insertion order even when
+// time.Now() doesn't advance between inserts.
+func (s *SQLStore) AddMessages(ctx context.Context, msgs []af.Message) error {
+	now := time.Now().UnixNano()
+	query := fmt.Sprintf(`INSERT INTO %s (message_id, stored_at, data) VALUES (?, ?, ?)`, s.table)
+	for i := range msgs {
+		if msgs[i].MessageID == "" {
+			msgs[i].MessageID = newMessageID()
+		}
+		data, err := s.codec.Encode(msgs[i])
+		if err != nil {
+			return fmt.Errorf("stores: encode message: %w", err)
+		}
+		if _, err := s.db.ExecContext(ctx, query, msgs[i].MessageID, now+int64(i), data); err != nil {
+			return fmt.Errorf("stores: insert message: %w", err)
+		}
+	}
+	return nil
+}
+
+// Truncate drops every message stored after messageID.
+func (s *SQLStore) Truncate(ctx context.Context, messageID string) error {
+	query := fmt.Sprintf(`SELECT stored_at FROM %s WHERE message_id = ?`, s.table)
+	var storedAt int64
+	if err := s.db.QueryRowContext(ctx, query, messageID).Scan(&storedAt); err != nil {
+		return fmt.Errorf("%w: message %q not found: %w", af.ErrSession, messageID, err)
+	}
+	del := fmt.Sprintf(`DELETE FROM %s WHERE stored_at > ?`, s.table)
+	if _, err := s.db.ExecContext(ctx, del, storedAt); err != nil {
+		return fmt.Errorf("stores: truncate: %w", err)
+	}
+	return nil
+}
+
+// TrimBefore deletes every message stored before cutoff, for a retention
+// policy.
+func (s *SQLStore) TrimBefore(ctx context.Context, cutoff time.Time) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE stored_at < ?`, s.table)
+	if _, err := s.db.ExecContext(ctx, query, cutoff.UnixNano()); err != nil {
+		return fmt.Errorf("stores: trim: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLStore) Serialize() (map[string]any, error) {
+	msgs, err := s.ListMessages(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"messages": msgs}, nil
+}
+
+// Deserialize replaces the table's contents with the messages in state,
+// restoring state previously produced by Serialize.
+func (s *SQLStore) Deserialize(state map[string]any) error {
+	msgs, err := decodeMessagesState(state)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	if _, err := s.db.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s`, s.table)); err != nil {
+		return fmt.Errorf("stores: clear before restore: %w", err)
+	}
+	return s.AddMessages(ctx, msgs)
+}
@@ -0,0 +1,366 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package stores_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+	"github.com/microsoft/agent-framework/go/agentframework/stores"
+)
+
+func fileSize(t *testing.T, path string) int64 {
+	t.Helper()
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("stat %s: %v", path, err)
+	}
+	return info.Size()
+}
+
+func TestJSONCodec_RoundTrips(t *testing.T) {
+	codec := stores.JSONCodec{}
+	msg := af.Message{Role: af.RoleUser, Contents: af.Contents{&af.TextContent{Text: "hi"}}, MessageID: "m1"}
+
+	data, err := codec.Encode(msg)
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	got, err := codec.Decode(data)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if got.MessageID != msg.MessageID || got.Text() != "hi" || got.Role != af.RoleUser {
+		t.Errorf("round-tripped message = %+v, want %+v", got, msg)
+	}
+}
+
+func TestFileStore_AddListTruncate(t *testing.T) {
+	ctx := context.Background()
+	store := stores.NewFileStore(filepath.Join(t.TempDir(), "messages.jsonl"))
+
+	if err := store.AddMessages(ctx, []af.Message{af.NewUserMessage("one"), af.NewUserMessage("two")}); err != nil {
+		t.Fatalf("AddMessages: %v", err)
+	}
+	if err := store.AddMessages(ctx, []af.Message{af.NewUserMessage("three")}); err != nil {
+		t.Fatalf("AddMessages: %v", err)
+	}
+
+	msgs, err := store.ListMessages(ctx)
+	if err != nil {
+		t.Fatalf("ListMessages: %v", err)
+	}
+	if len(msgs) != 3 || msgs[0].Text() != "one" || msgs[2].Text() != "three" {
+		t.Fatalf("ListMessages = %+v, want [one two three]", msgs)
+	}
+
+	if err := store.Truncate(ctx, msgs[1].MessageID); err != nil {
+		t.Fatalf("Truncate: %v", err)
+	}
+	msgs, err = store.ListMessages(ctx)
+	if err != nil {
+		t.Fatalf("ListMessages after truncate: %v", err)
+	}
+	if len(msgs) != 2 || msgs[1].Text() != "two" {
+		t.Fatalf("ListMessages after truncate = %+v, want [one two]", msgs)
+	}
+
+	if err := store.Truncate(ctx, "missing"); !errors.Is(err, af.ErrSession) {
+		t.Errorf("Truncate(missing) err = %v, want ErrSession", err)
+	}
+}
+
+func TestFileStore_CompactDropsTruncatedHistory(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "messages.jsonl")
+	store := stores.NewFileStore(path)
+
+	if err := store.AddMessages(ctx, []af.Message{af.NewUserMessage("one"), af.NewUserMessage("two")}); err != nil {
+		t.Fatalf("AddMessages: %v", err)
+	}
+	msgs, _ := store.ListMessages(ctx)
+	if err := store.Truncate(ctx, msgs[0].MessageID); err != nil {
+		t.Fatalf("Truncate: %v", err)
+	}
+
+	sizeBefore := fileSize(t, path)
+	if err := store.Compact(); err != nil {
+		t.Fatalf("Compact: %v", err)
+	}
+	if got := fileSize(t, path); got >= sizeBefore {
+		t.Errorf("file size after Compact = %d, want < %d", got, sizeBefore)
+	}
+
+	msgs, err := store.ListMessages(ctx)
+	if err != nil {
+		t.Fatalf("ListMessages after Compact: %v", err)
+	}
+	if len(msgs) != 1 || msgs[0].Text() != "one" {
+		t.Fatalf("ListMessages after Compact = %+v, want [one]", msgs)
+	}
+}
+
+func TestFileStore_SerializeDeserialize(t *testing.T) {
+	ctx := context.Background()
+	src := stores.NewFileStore(filepath.Join(t.TempDir(), "a.jsonl"))
+	if err := src.AddMessages(ctx, []af.Message{af.NewUserMessage("one"), af.NewUserMessage("two")}); err != nil {
+		t.Fatalf("AddMessages: %v", err)
+	}
+	state, err := src.Serialize()
+	if err != nil {
+		t.Fatalf("Serialize: %v", err)
+	}
+
+	dst := stores.NewFileStore(filepath.Join(t.TempDir(), "b.jsonl"))
+	if err := dst.AddMessages(ctx, []af.Message{af.NewUserMessage("stale")}); err != nil {
+		t.Fatalf("AddMessages: %v", err)
+	}
+	if err := dst.Deserialize(state); err != nil {
+		t.Fatalf("Deserialize: %v", err)
+	}
+
+	msgs, err := dst.ListMessages(ctx)
+	if err != nil {
+		t.Fatalf("ListMessages: %v", err)
+	}
+	if len(msgs) != 2 || msgs[0].Text() != "one" || msgs[1].Text() != "two" {
+		t.Fatalf("ListMessages after Deserialize = %+v, want [one two]", msgs)
+	}
+}
+
+func TestFileStore_TrimBefore(t *testing.T) {
+	ctx := context.Background()
+	store := stores.NewFileStore(filepath.Join(t.TempDir(), "messages.jsonl"))
+
+	if err := store.AddMessages(ctx, []af.Message{af.NewUserMessage("old")}); err != nil {
+		t.Fatalf("AddMessages: %v", err)
+	}
+	cutoff := time.Now()
+	if err := store.AddMessages(ctx, []af.Message{af.NewUserMessage("new")}); err != nil {
+		t.Fatalf("AddMessages: %v", err)
+	}
+
+	if err := store.TrimBefore(ctx, cutoff); err != nil {
+		t.Fatalf("TrimBefore: %v", err)
+	}
+	msgs, err := store.ListMessages(ctx)
+	if err != nil {
+		t.Fatalf("ListMessages: %v", err)
+	}
+	if len(msgs) != 1 || msgs[0].Text() != "new" {
+		t.Fatalf("ListMessages after TrimBefore = %+v, want [new]", msgs)
+	}
+}
+
+// fakeRedis is an in-memory [stores.RedisClient] for testing [stores.RedisStore]
+// without a real Redis server.
+type fakeRedis struct {
+	lists map[string][]string
+	ttl   map[string]time.Duration
+}
+
+func newFakeRedis() *fakeRedis {
+	return &fakeRedis{lists: map[string][]string{}, ttl: map[string]time.Duration{}}
+}
+
+func (f *fakeRedis) RPush(_ context.Context, key string, values ...string) error {
+	f.lists[key] = append(f.lists[key], values...)
+	return nil
+}
+
+func (f *fakeRedis) LRange(_ context.Context, key string, start, stop int64) ([]string, error) {
+	list := f.lists[key]
+	n := int64(len(list))
+	if stop < 0 {
+		stop = n + stop
+	}
+	if start < 0 || start >= n || stop < start {
+		return nil, nil
+	}
+	if stop >= n {
+		stop = n - 1
+	}
+	out := make([]string, stop-start+1)
+	copy(out, list[start:stop+1])
+	return out, nil
+}
+
+func (f *fakeRedis) LTrim(_ context.Context, key string, start, stop int64) error {
+	kept, err := f.LRange(context.Background(), key, start, stop)
+	if err != nil {
+		return err
+	}
+	f.lists[key] = kept
+	return nil
+}
+
+func (f *fakeRedis) Expire(_ context.Context, key string, ttl time.Duration) error {
+	f.ttl[key] = ttl
+	return nil
+}
+
+func (f *fakeRedis) Del(_ context.Context, key string) error {
+	delete(f.lists, key)
+	delete(f.ttl, key)
+	return nil
+}
+
+func TestRedisStore_AddListTruncateTTL(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeRedis()
+	store := stores.NewRedisStore(client, "session:1", stores.WithRedisStoreTTL(time.Hour))
+
+	if err := store.AddMessages(ctx, []af.Message{af.NewUserMessage("one"), af.NewUserMessage("two")}); err != nil {
+		t.Fatalf("AddMessages: %v", err)
+	}
+	if client.ttl["session:1"] != time.Hour {
+		t.Errorf("ttl = %v, want 1h", client.ttl["session:1"])
+	}
+
+	msgs, err := store.ListMessages(ctx)
+	if err != nil {
+		t.Fatalf("ListMessages: %v", err)
+	}
+	if len(msgs) != 2 {
+		t.Fatalf("ListMessages = %d messages, want 2", len(msgs))
+	}
+
+	if err := store.Truncate(ctx, msgs[0].MessageID); err != nil {
+		t.Fatalf("Truncate: %v", err)
+	}
+	msgs, err = store.ListMessages(ctx)
+	if err != nil {
+		t.Fatalf("ListMessages after truncate: %v", err)
+	}
+	if len(msgs) != 1 || msgs[0].Text() != "one" {
+		t.Fatalf("ListMessages after truncate = %+v, want [one]", msgs)
+	}
+
+	if err := store.Truncate(ctx, "missing"); !errors.Is(err, af.ErrSession) {
+		t.Errorf("Truncate(missing) err = %v, want ErrSession", err)
+	}
+}
+
+func TestRedisStore_SerializeDeserialize(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeRedis()
+	src := stores.NewRedisStore(client, "src")
+	if err := src.AddMessages(ctx, []af.Message{af.NewUserMessage("one"), af.NewUserMessage("two")}); err != nil {
+		t.Fatalf("AddMessages: %v", err)
+	}
+	state, err := src.Serialize()
+	if err != nil {
+		t.Fatalf("Serialize: %v", err)
+	}
+
+	dst := stores.NewRedisStore(client, "dst")
+	if err := dst.Deserialize(state); err != nil {
+		t.Fatalf("Deserialize: %v", err)
+	}
+	msgs, err := dst.ListMessages(ctx)
+	if err != nil {
+		t.Fatalf("ListMessages: %v", err)
+	}
+	if len(msgs) != 2 || msgs[0].Text() != "one" || msgs[1].Text() != "two" {
+		t.Fatalf("ListMessages after Deserialize = %+v, want [one two]", msgs)
+	}
+}
+
+// echoLastMessageClient is a minimal [af.ChatClient] that replies with the
+// text of the last message it was sent, so a test can confirm a reloaded
+// session's history was actually passed back into Agent.Run.
+type echoLastMessageClient struct {
+	lastMessages []af.Message
+}
+
+func (c *echoLastMessageClient) Response(ctx context.Context, msgs []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error) {
+	c.lastMessages = msgs
+	return &af.ChatResponse{Messages: []af.Message{af.NewAssistantMessage("got it: " + msgs[len(msgs)-1].Text())}}, nil
+}
+
+func (c *echoLastMessageClient) StreamResponse(ctx context.Context, msgs []af.Message, opts *af.ChatOptions) (*af.ResponseStream[af.ChatResponseUpdate], error) {
+	resp, err := c.Response(ctx, msgs, opts)
+	if err != nil {
+		return nil, err
+	}
+	return af.NewResponseStream(ctx, func(ctx context.Context, ch chan<- af.ChatResponseUpdate) error {
+		ch <- af.ChatResponseUpdate{Contents: resp.Messages[0].Contents, Role: resp.Messages[0].Role}
+		return nil
+	}), nil
+}
+
+// TestFileStore_SessionRoundTrip_PreservesToolCallState persists a session
+// mid-conversation — including a FunctionCallContent/FunctionResultContent
+// exchange — to a FileStore, reloads it into a brand new Session backed by
+// a fresh FileStore instance pointed at the same file, and confirms both
+// that the tool-call content round-trips byte-for-byte and that the
+// reloaded history is what gets passed back into the next Agent.Run call.
+func TestFileStore_SessionRoundTrip_PreservesToolCallState(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "session.jsonl")
+
+	history := []af.Message{
+		af.NewUserMessage("what is 3+4?"),
+		{
+			Role: af.RoleAssistant,
+			Contents: af.Contents{
+				&af.FunctionCallContent{CallID: "call-1", Name: "add", Arguments: `{"a":3,"b":4}`},
+			},
+		},
+		{
+			Role: af.RoleTool,
+			Contents: af.Contents{
+				&af.FunctionResultContent{CallID: "call-1", Result: 7},
+			},
+		},
+		af.NewAssistantMessage("The answer is 7."),
+	}
+
+	original := stores.NewFileStore(path)
+	if err := original.AddMessages(ctx, history); err != nil {
+		t.Fatalf("AddMessages: %v", err)
+	}
+
+	// Simulate a process restart: a fresh FileStore instance reading back
+	// the same file, wrapped in a brand new Session.
+	reloaded := stores.NewFileStore(path)
+	msgs, err := reloaded.ListMessages(ctx)
+	if err != nil {
+		t.Fatalf("ListMessages: %v", err)
+	}
+	if len(msgs) != len(history) {
+		t.Fatalf("reloaded %d messages, want %d", len(msgs), len(history))
+	}
+
+	call, ok := msgs[1].Contents[0].(*af.FunctionCallContent)
+	if !ok || call.CallID != "call-1" || call.Name != "add" || call.Arguments != `{"a":3,"b":4}` {
+		t.Fatalf("reloaded FunctionCallContent = %+v", msgs[1].Contents[0])
+	}
+	result, ok := msgs[2].Contents[0].(*af.FunctionResultContent)
+	if !ok || result.CallID != "call-1" {
+		t.Fatalf("reloaded FunctionResultContent = %+v", msgs[2].Contents[0])
+	}
+
+	session := af.NewSession(af.WithSessionStore(reloaded))
+	client := &echoLastMessageClient{}
+	agent := af.NewAgent(client)
+
+	resp, err := agent.Run(ctx, []af.Message{af.NewUserMessage("and what about 5+5?")}, af.WithSession(session))
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if resp.Text() != "got it: and what about 5+5?" {
+		t.Errorf("Run response = %q", resp.Text())
+	}
+	if len(client.lastMessages) != len(history)+1 {
+		t.Fatalf("client saw %d messages, want %d (reloaded history + new turn)", len(client.lastMessages), len(history)+1)
+	}
+	if call, ok := client.lastMessages[1].Contents[0].(*af.FunctionCallContent); !ok || call.Arguments != `{"a":3,"b":4}` {
+		t.Errorf("client's view of reloaded tool call = %+v", client.lastMessages[1].Contents[0])
+	}
+}
@@ -0,0 +1,374 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package agentframework
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// FilterStream transforms a [ResponseStream][A] into one that only emits
+// values for which pred returns true.
+func FilterStream[T any](ctx context.Context, src *ResponseStream[T], pred func(T) bool) *ResponseStream[T] {
+	return NewResponseStream[T](ctx, func(ctx context.Context, ch chan<- T) error {
+		defer src.Close()
+		for {
+			val, ok, err := src.Next(ctx)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return nil
+			}
+			if !pred(val) {
+				continue
+			}
+			select {
+			case ch <- val:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	})
+}
+
+// FlatMapStream transforms a [ResponseStream][A] into a [ResponseStream][B]
+// by applying fn to each value and emitting every element of the result in
+// order.
+func FlatMapStream[A, B any](ctx context.Context, src *ResponseStream[A], fn func(A) []B) *ResponseStream[B] {
+	return NewResponseStream[B](ctx, func(ctx context.Context, ch chan<- B) error {
+		defer src.Close()
+		for {
+			val, ok, err := src.Next(ctx)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return nil
+			}
+			for _, b := range fn(val) {
+				select {
+				case ch <- b:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+		}
+	})
+}
+
+// ThrottleStream passes values from src through unchanged, but waits at
+// least minInterval after emitting one value before emitting the next —
+// useful for pacing updates to a rate-limited downstream consumer (a UI
+// render loop, a log sink) without dropping any of them. Use [BufferStream]
+// instead when dropping is acceptable and unbounded delay is not.
+func ThrottleStream[T any](ctx context.Context, src *ResponseStream[T], minInterval time.Duration) *ResponseStream[T] {
+	return NewResponseStream[T](ctx, func(ctx context.Context, ch chan<- T) error {
+		defer src.Close()
+		var last time.Time
+		for {
+			val, ok, err := src.Next(ctx)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return nil
+			}
+			if !last.IsZero() {
+				if wait := minInterval - time.Since(last); wait > 0 {
+					select {
+					case <-time.After(wait):
+					case <-ctx.Done():
+						return ctx.Err()
+					}
+				}
+			}
+			last = time.Now()
+			select {
+			case ch <- val:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	})
+}
+
+// BatchStream groups values from src into slices of up to size elements,
+// emitting a batch as soon as it reaches size or maxWait has elapsed since
+// its first element, whichever comes first. A maxWait of zero disables the
+// time-based flush, so a batch is only emitted once full or when src is
+// exhausted.
+func BatchStream[T any](ctx context.Context, src *ResponseStream[T], size int, maxWait time.Duration) *ResponseStream[[]T] {
+	return NewResponseStream[[]T](ctx, func(ctx context.Context, ch chan<- []T) error {
+		defer src.Close()
+
+		type item struct {
+			val T
+			ok  bool
+			err error
+		}
+		items := make(chan item)
+		go func() {
+			for {
+				val, ok, err := src.Next(ctx)
+				select {
+				case items <- item{val: val, ok: ok, err: err}:
+				case <-ctx.Done():
+					return
+				}
+				if !ok {
+					return
+				}
+			}
+		}()
+
+		var batch []T
+		var timer *time.Timer
+		var timerC <-chan time.Time
+
+		flush := func() error {
+			if len(batch) == 0 {
+				return nil
+			}
+			if timer != nil {
+				timer.Stop()
+				timerC = nil
+			}
+			select {
+			case ch <- batch:
+				batch = nil
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		for {
+			select {
+			case it := <-items:
+				if it.err != nil {
+					return it.err
+				}
+				if !it.ok {
+					return flush()
+				}
+				batch = append(batch, it.val)
+				if len(batch) == 1 && maxWait > 0 {
+					timer = time.NewTimer(maxWait)
+					timerC = timer.C
+				}
+				if len(batch) >= size {
+					if err := flush(); err != nil {
+						return err
+					}
+				}
+			case <-timerC:
+				timerC = nil
+				if err := flush(); err != nil {
+					return err
+				}
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	})
+}
+
+// MergeStreams fans multiple streams of the same type into one, relaying
+// values as they arrive from whichever source produces next (fair in the
+// sense that no source is starved — each runs its own goroutine competing
+// to send on the merged channel). The merged stream ends once every source
+// is exhausted, or as soon as any source errors.
+func MergeStreams[T any](ctx context.Context, srcs ...*ResponseStream[T]) *ResponseStream[T] {
+	return NewResponseStream[T](ctx, func(ctx context.Context, ch chan<- T) error {
+		// srcCtx is canceled on the first source error, so the other
+		// sources stop instead of running on to exhaustion.
+		srcCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		var wg sync.WaitGroup
+		errCh := make(chan error, len(srcs))
+
+		for _, src := range srcs {
+			wg.Add(1)
+			go func(src *ResponseStream[T]) {
+				defer wg.Done()
+				defer src.Close()
+				for {
+					val, ok, err := src.Next(srcCtx)
+					if err != nil {
+						errCh <- err
+						cancel()
+						return
+					}
+					if !ok {
+						return
+					}
+					select {
+					case ch <- val:
+					case <-srcCtx.Done():
+						return
+					}
+				}
+			}(src)
+		}
+
+		wg.Wait()
+		close(errCh)
+		for err := range errCh {
+			if err != nil {
+				return err
+			}
+		}
+		return ctx.Err()
+	})
+}
+
+// OverflowPolicy controls what [BufferStream] does when its buffer is full
+// and another value arrives.
+type OverflowPolicy int
+
+const (
+	// DropOldest discards the buffer's oldest value to make room.
+	DropOldest OverflowPolicy = iota
+	// DropNewest discards the arriving value, keeping the buffer unchanged.
+	DropNewest
+	// Block waits for the consumer to make room before accepting the value.
+	Block
+	// Error fails the stream with [ErrStreamOverflow].
+	Error
+)
+
+// ringBuffer is a fixed-capacity FIFO queue with configurable behavior on
+// overflow, used by [BufferStream] to decouple a slow consumer from src.
+type ringBuffer[T any] struct {
+	mu       sync.Mutex
+	items    []T
+	capacity int
+	policy   OverflowPolicy
+
+	itemAdded  chan struct{} // signaled (best-effort) when an item is added
+	spaceFreed chan struct{} // signaled (best-effort) when an item is removed
+}
+
+func newRingBuffer[T any](capacity int, policy OverflowPolicy) *ringBuffer[T] {
+	return &ringBuffer[T]{
+		capacity:   capacity,
+		policy:     policy,
+		itemAdded:  make(chan struct{}, 1),
+		spaceFreed: make(chan struct{}, 1),
+	}
+}
+
+func notify(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
+
+// push adds v according to the buffer's [OverflowPolicy]. It only blocks
+// (honoring ctx) under [Block]; it only returns an error under [Error].
+func (b *ringBuffer[T]) push(ctx context.Context, v T) error {
+	for {
+		b.mu.Lock()
+		switch {
+		case len(b.items) < b.capacity:
+			b.items = append(b.items, v)
+			b.mu.Unlock()
+			notify(b.itemAdded)
+			return nil
+		case b.policy == DropOldest:
+			b.items = append(b.items[1:], v)
+			b.mu.Unlock()
+			notify(b.itemAdded)
+			return nil
+		case b.policy == DropNewest:
+			b.mu.Unlock()
+			return nil
+		case b.policy == Error:
+			b.mu.Unlock()
+			return ErrStreamOverflow
+		default: // Block
+			b.mu.Unlock()
+			select {
+			case <-b.spaceFreed:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+// pop removes and returns the oldest item, if any.
+func (b *ringBuffer[T]) pop() (T, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	v := b.items[0]
+	b.items = b.items[1:]
+	notify(b.spaceFreed)
+	return v, true
+}
+
+// BufferStream decouples src from a slow consumer by draining it into an
+// internal buffer of capacity as fast as it produces, applying onOverflow
+// when the buffer fills — useful for a long-lived agent run whose
+// downstream consumer (a UI, a log sink) may pause without slowing or
+// failing the run itself (depending on the policy chosen).
+func BufferStream[T any](ctx context.Context, src *ResponseStream[T], capacity int, onOverflow OverflowPolicy) *ResponseStream[T] {
+	return NewResponseStream[T](ctx, func(ctx context.Context, ch chan<- T) error {
+		buf := newRingBuffer[T](capacity, onOverflow)
+		feederErr := make(chan error, 1)
+		feederDone := make(chan struct{})
+
+		go func() {
+			defer close(feederDone)
+			defer src.Close()
+			for {
+				val, ok, err := src.Next(ctx)
+				if err != nil {
+					feederErr <- err
+					return
+				}
+				if !ok {
+					return
+				}
+				if err := buf.push(ctx, val); err != nil {
+					feederErr <- err
+					return
+				}
+			}
+		}()
+
+		done := false
+		for {
+			if v, ok := buf.pop(); ok {
+				select {
+				case ch <- v:
+					continue
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+			if done {
+				select {
+				case err := <-feederErr:
+					return err
+				default:
+					return nil
+				}
+			}
+			select {
+			case <-feederDone:
+				done = true
+			case <-buf.itemAdded:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	})
+}
@@ -0,0 +1,276 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package agentframework_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+func TestFilterStream(t *testing.T) {
+	ctx := context.Background()
+	src := af.NewResponseStream(ctx, func(ctx context.Context, ch chan<- int) error {
+		for i := 1; i <= 5; i++ {
+			ch <- i
+		}
+		return nil
+	})
+
+	even := af.FilterStream(ctx, src, func(i int) bool { return i%2 == 0 })
+	defer even.Close()
+
+	items, err := even.Collect(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{2, 4}; !equalInts(items, want) {
+		t.Errorf("items = %v, want %v", items, want)
+	}
+}
+
+func TestFlatMapStream(t *testing.T) {
+	ctx := context.Background()
+	src := af.NewResponseStream(ctx, func(ctx context.Context, ch chan<- int) error {
+		ch <- 1
+		ch <- 2
+		return nil
+	})
+
+	out := af.FlatMapStream(ctx, src, func(i int) []string {
+		return []string{"a", "b"}
+	})
+	defer out.Close()
+
+	items, err := out.Collect(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"a", "b", "a", "b"}; !equalStrings(items, want) {
+		t.Errorf("items = %v, want %v", items, want)
+	}
+}
+
+func TestThrottleStream(t *testing.T) {
+	ctx := context.Background()
+	src := af.NewResponseStream(ctx, func(ctx context.Context, ch chan<- int) error {
+		ch <- 1
+		ch <- 2
+		ch <- 3
+		return nil
+	})
+
+	throttled := af.ThrottleStream(ctx, src, 20*time.Millisecond)
+	defer throttled.Close()
+
+	start := time.Now()
+	items, err := throttled.Collect(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{1, 2, 3}; !equalInts(items, want) {
+		t.Errorf("items = %v, want %v", items, want)
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("elapsed = %v, want at least 40ms", elapsed)
+	}
+}
+
+func TestBatchStream_FlushesOnSize(t *testing.T) {
+	ctx := context.Background()
+	src := af.NewResponseStream(ctx, func(ctx context.Context, ch chan<- int) error {
+		for i := 1; i <= 5; i++ {
+			ch <- i
+		}
+		return nil
+	})
+
+	batched := af.BatchStream(ctx, src, 2, 0)
+	defer batched.Close()
+
+	batches, err := batched.Collect(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(batches) != 3 {
+		t.Fatalf("len(batches) = %d, want 3", len(batches))
+	}
+	if !equalInts(batches[0], []int{1, 2}) || !equalInts(batches[1], []int{3, 4}) || !equalInts(batches[2], []int{5}) {
+		t.Errorf("batches = %v", batches)
+	}
+}
+
+func TestBatchStream_FlushesOnMaxWait(t *testing.T) {
+	ctx := context.Background()
+	src := af.NewResponseStream(ctx, func(ctx context.Context, ch chan<- int) error {
+		ch <- 1
+		time.Sleep(30 * time.Millisecond)
+		ch <- 2
+		return nil
+	})
+
+	batched := af.BatchStream(ctx, src, 10, 10*time.Millisecond)
+	defer batched.Close()
+
+	first, ok, err := batched.Next(ctx)
+	if err != nil || !ok {
+		t.Fatalf("first: ok=%v err=%v", ok, err)
+	}
+	if !equalInts(first, []int{1}) {
+		t.Errorf("first batch = %v, want [1]", first)
+	}
+}
+
+func TestMergeStreams(t *testing.T) {
+	ctx := context.Background()
+	a := af.NewResponseStream(ctx, func(ctx context.Context, ch chan<- int) error {
+		ch <- 1
+		ch <- 2
+		return nil
+	})
+	b := af.NewResponseStream(ctx, func(ctx context.Context, ch chan<- int) error {
+		ch <- 3
+		ch <- 4
+		return nil
+	})
+
+	merged := af.MergeStreams(ctx, a, b)
+	defer merged.Close()
+
+	items, err := merged.Collect(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 4 {
+		t.Fatalf("len(items) = %d, want 4", len(items))
+	}
+}
+
+func TestMergeStreams_PropagatesError(t *testing.T) {
+	ctx := context.Background()
+	expectedErr := af.ErrService
+	ok := af.NewResponseStream(ctx, func(ctx context.Context, ch chan<- int) error {
+		ch <- 1
+		return nil
+	})
+	bad := af.NewResponseStream(ctx, func(ctx context.Context, ch chan<- int) error {
+		return expectedErr
+	})
+
+	merged := af.MergeStreams(ctx, ok, bad)
+	defer merged.Close()
+
+	_, err := merged.Collect(ctx)
+	if !errors.Is(err, expectedErr) {
+		t.Errorf("err = %v, want %v", err, expectedErr)
+	}
+}
+
+func TestBufferStream_DropOldest(t *testing.T) {
+	ctx := context.Background()
+	release := make(chan struct{})
+	src := af.NewResponseStream(ctx, func(ctx context.Context, ch chan<- int) error {
+		for i := 1; i <= 5; i++ {
+			ch <- i
+		}
+		<-release
+		return nil
+	})
+
+	buffered := af.BufferStream(ctx, src, 2, af.DropOldest)
+	defer buffered.Close()
+
+	time.Sleep(20 * time.Millisecond) // let the feeder race ahead of the consumer
+	close(release)
+
+	items, err := buffered.Collect(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Exactly which early values got evicted depends on how far the feeder
+	// raced ahead of the main loop's drain before the consumer started
+	// reading (up to two values can already be in flight past the ring
+	// buffer itself), but the invariants below always hold: order is
+	// preserved and the newest value always makes it through since it's
+	// the last one pushed.
+	if len(items) == 0 || len(items) > 4 {
+		t.Fatalf("items = %v, want between 1 and 4 values", items)
+	}
+	if items[len(items)-1] != 5 {
+		t.Errorf("items = %v, want last value 5", items)
+	}
+	for i := 1; i < len(items); i++ {
+		if items[i] <= items[i-1] {
+			t.Errorf("items = %v, not strictly increasing", items)
+		}
+	}
+}
+
+func TestBufferStream_ErrorPolicy(t *testing.T) {
+	ctx := context.Background()
+	release := make(chan struct{})
+	src := af.NewResponseStream(ctx, func(ctx context.Context, ch chan<- int) error {
+		for i := 1; i <= 5; i++ {
+			ch <- i
+		}
+		<-release
+		return nil
+	})
+	buffered := af.BufferStream(ctx, src, 2, af.Error)
+	defer buffered.Close()
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	_, err := buffered.Collect(ctx)
+	if !errors.Is(err, af.ErrStreamOverflow) {
+		t.Errorf("err = %v, want ErrStreamOverflow", err)
+	}
+}
+
+func TestBufferStream_Close(t *testing.T) {
+	ctx := context.Background()
+	src := af.NewResponseStream(ctx, func(ctx context.Context, ch chan<- int) error {
+		for {
+			select {
+			case ch <- 1:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	})
+
+	buffered := af.BufferStream(ctx, src, 1, af.Block)
+	v, ok, err := buffered.Next(ctx)
+	if err != nil || !ok || v != 1 {
+		t.Fatalf("next: val=%d ok=%v err=%v", v, ok, err)
+	}
+	buffered.Close()
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,77 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package agentframework_test
+
+import (
+	"context"
+	"testing"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+func TestAgent_RunStream_StreamingChatMiddleware(t *testing.T) {
+	var order []string
+
+	mw := af.StreamingChatMiddleware(func(next af.StreamingChatHandler) af.StreamingChatHandler {
+		return func(ctx context.Context, msgs []af.Message, opts *af.ChatOptions) (*af.ResponseStream[af.ChatResponseUpdate], error) {
+			order = append(order, "before")
+			stream, err := next(ctx, msgs, opts)
+			order = append(order, "after")
+			return stream, err
+		}
+	})
+
+	client := &mockClient{
+		responseFn: func(ctx context.Context, msgs []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error) {
+			return &af.ChatResponse{Messages: []af.Message{af.NewAssistantMessage("ok")}}, nil
+		},
+	}
+
+	agent := af.NewAgent(client, af.WithStreamingChatMiddleware(mw))
+	stream, err := agent.RunStream(context.Background(), []af.Message{af.NewUserMessage("hi")})
+	if err != nil {
+		t.Fatalf("RunStream: %v", err)
+	}
+	if _, err := stream.FinalResponse(context.Background()); err != nil {
+		t.Fatalf("FinalResponse: %v", err)
+	}
+
+	// "after" runs once the handler constructs the stream, before it's drained.
+	if want := []string{"before", "after"}; len(order) != len(want) || order[0] != want[0] || order[1] != want[1] {
+		t.Errorf("order = %v, want %v", order, want)
+	}
+}
+
+func TestAgent_RunStream_PersistsToSessionOnCompletion(t *testing.T) {
+	client := &mockClient{
+		responseFn: func(ctx context.Context, msgs []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error) {
+			return &af.ChatResponse{Messages: []af.Message{af.NewAssistantMessage("streamed reply")}}, nil
+		},
+	}
+
+	agent := af.NewAgent(client)
+	session := agent.NewSession()
+
+	stream, err := agent.RunStream(context.Background(), []af.Message{af.NewUserMessage("hi")}, af.WithSession(session))
+	if err != nil {
+		t.Fatalf("RunStream: %v", err)
+	}
+	if _, err := stream.FinalResponse(context.Background()); err != nil {
+		t.Fatalf("FinalResponse: %v", err)
+	}
+
+	store := session.Store()
+	if store == nil {
+		t.Fatal("session store should be initialized after stream completes")
+	}
+	msgs, err := store.ListMessages(context.Background())
+	if err != nil {
+		t.Fatalf("ListMessages: %v", err)
+	}
+	if len(msgs) != 2 {
+		t.Fatalf("session has %d messages, want 2 (request + response)", len(msgs))
+	}
+	if msgs[1].Text() != "streamed reply" {
+		t.Errorf("persisted response = %q, want %q", msgs[1].Text(), "streamed reply")
+	}
+}
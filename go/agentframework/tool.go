@@ -15,6 +15,24 @@ const (
 	ApprovalAlways ApprovalMode = "always"
 )
 
+// ConcurrencyMode controls how a tool participates in [InvocationConfig]'s
+// concurrent dispatch (see [InvocationConfig.Concurrency]).
+type ConcurrencyMode string
+
+const (
+	// ConcurrencyParallel lets the tool run alongside other calls in the
+	// same batch, up to [InvocationConfig.Concurrency] at once.
+	ConcurrencyParallel ConcurrencyMode = "parallel"
+
+	// ConcurrencySerial still runs within the bounded worker pool, but
+	// calls to this tool never overlap each other within a batch.
+	ConcurrencySerial ConcurrencyMode = "serial"
+
+	// ConcurrencyExclusive drains all other in-flight calls in the batch
+	// before running, and blocks every other call until it completes.
+	ConcurrencyExclusive ConcurrencyMode = "exclusive"
+)
+
 // Tool defines a callable function that can be exposed to an LLM.
 type Tool interface {
 	// Name returns the function name as exposed to the model.
@@ -34,6 +52,10 @@ type Tool interface {
 
 	// Approval returns the approval mode for this tool.
 	Approval() ApprovalMode
+
+	// Concurrency returns how this tool participates in concurrent batch
+	// dispatch when [InvocationConfig.Concurrency] is greater than 1.
+	Concurrency() ConcurrencyMode
 }
 
 // FunctionTool is a concrete [Tool] backed by a Go function.
@@ -45,6 +67,7 @@ type FunctionTool struct {
 	declarationOnly bool
 	approvalMode    ApprovalMode
 	maxInvocations  int
+	concurrencyMode ConcurrencyMode
 }
 
 // ToolOption configures a [FunctionTool].
@@ -65,6 +88,23 @@ func WithMaxInvocations(n int) ToolOption {
 	return func(t *FunctionTool) { t.maxInvocations = n }
 }
 
+// WithSerialExecution marks the tool so that, under concurrent batch
+// dispatch (see [InvocationConfig.Concurrency]), its calls never overlap
+// each other within the same batch. Use this for tools that share
+// non-thread-safe state, such as a single-writer cache.
+func WithSerialExecution() ToolOption {
+	return func(t *FunctionTool) { t.concurrencyMode = ConcurrencySerial }
+}
+
+// WithExclusiveExecution marks the tool so that, under concurrent batch
+// dispatch, it waits for every other in-flight call in the batch to finish,
+// runs alone, and blocks the rest of the batch until it completes. Use this
+// for tools that must not race with anything else, such as one that mutates
+// shared on-disk state.
+func WithExclusiveExecution() ToolOption {
+	return func(t *FunctionTool) { t.concurrencyMode = ConcurrencyExclusive }
+}
+
 // NewTool creates a [FunctionTool] with raw JSON schema and handler.
 func NewTool(name, description string, parameters json.RawMessage, fn func(ctx context.Context, args json.RawMessage) (any, error), opts ...ToolOption) *FunctionTool {
 	t := &FunctionTool{
@@ -107,12 +147,38 @@ func NewTypedTool[Args any](name, description string, fn func(ctx context.Contex
 	return NewTool(name, description, schema, wrapped, opts...)
 }
 
-func (t *FunctionTool) Name() string              { return t.name }
-func (t *FunctionTool) Description() string        { return t.description }
+// NewStructuredTool is a [NewTypedTool] variant for forcing a parseable
+// typed result. It returns the tool alongside a [ResponseFormatJSONSchema]
+// built from the tool's generated schema; set it as [ChatOptions.ResponseFormat]
+// together with [ToolChoiceFunction] pinned to the tool's name to guarantee
+// the model's output matches Args, instead of relying on the text-based
+// tool-call regex workaround some non-tool-native providers need:
+//
+//	tool, format := agentframework.NewStructuredTool("extract_invoice", "...", extract)
+//	agent.Run(ctx, messages, agentframework.WithRunTools(tool), agentframework.WithRunOptions(&agentframework.ChatOptions{
+//	    ToolChoice:     agentframework.ToolChoiceFunction(tool.Name()),
+//	    ResponseFormat: format,
+//	}))
+func NewStructuredTool[Args any](name, description string, fn func(ctx context.Context, args Args) (any, error), opts ...ToolOption) (*FunctionTool, ResponseFormat) {
+	tool := NewTypedTool(name, description, fn, opts...)
+	return tool, ResponseFormatForTool(tool, true)
+}
+
+func (t *FunctionTool) Name() string                { return t.name }
+func (t *FunctionTool) Description() string         { return t.description }
 func (t *FunctionTool) Parameters() json.RawMessage { return t.parameters }
 func (t *FunctionTool) DeclarationOnly() bool       { return t.declarationOnly }
 func (t *FunctionTool) Approval() ApprovalMode      { return t.approvalMode }
 
+// Concurrency returns [ConcurrencyParallel] unless the tool was built with
+// [WithSerialExecution] or [WithExclusiveExecution].
+func (t *FunctionTool) Concurrency() ConcurrencyMode {
+	if t.concurrencyMode == "" {
+		return ConcurrencyParallel
+	}
+	return t.concurrencyMode
+}
+
 // Invoke calls the tool's backing function.
 func (t *FunctionTool) Invoke(ctx context.Context, args json.RawMessage) (any, error) {
 	if t.fn == nil {
@@ -125,9 +191,27 @@ func (t *FunctionTool) Invoke(ctx context.Context, args json.RawMessage) (any, e
 	return t.fn(ctx, args)
 }
 
-// GenerateSchema builds a JSON Schema from a Go struct type using reflection.
-// Supports struct tags: json (field name), jsonschema (description, required, enum).
+// GenerateSchema builds a JSON Schema from a Go struct type using
+// reflection. Supports the json tag (field name, omitempty — a field
+// without omitempty is required by default) and the jsonschema tag
+// (description, title, required, enum, format, pattern, minimum, maximum,
+// minLength, maxLength, default, example). Embedded struct fields are
+// flattened into their parent's properties. A struct that recurses into
+// itself emits "$ref"/"$defs" for the cycle-closing occurrence instead of
+// recursing forever.
+//
+// This is [GenerateSchemaWithOptions] with [SchemaOptions.InlineDefs] set
+// and no draft selected: no "$schema" URI, and every non-recursive struct
+// inlined at its point of use. Use [GenerateSchemaWithOptions] to target a
+// specific draft or to factor reused struct types out under "$defs".
 func GenerateSchema[T any]() json.RawMessage {
+	return GenerateSchemaWithOptions[T](SchemaOptions{InlineDefs: true})
+}
+
+// GenerateSchemaWithOptions is [GenerateSchema] with control over the
+// target JSON Schema draft and whether reused struct types are inlined or
+// factored out under "$defs"/"definitions"; see [SchemaOptions].
+func GenerateSchemaWithOptions[T any](opts SchemaOptions) json.RawMessage {
 	var zero T
-	return generateSchemaFromType(zero)
+	return generateSchemaFromType(zero, opts)
 }
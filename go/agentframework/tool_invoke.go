@@ -7,6 +7,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"sync"
+	"sync/atomic"
 )
 
 // InvocationConfig controls the function invocation loop behavior.
@@ -25,6 +27,15 @@ type InvocationConfig struct {
 	// IncludeDetailedErrors includes full error text in tool results sent
 	// back to the model. When false, a generic error message is used.
 	IncludeDetailedErrors bool
+
+	// Concurrency is the maximum number of tool calls from a single model
+	// turn dispatched at once. Default: 1 (sequential, the historical
+	// behavior). Values greater than 1 run calls through a bounded worker
+	// pool, honoring each [Tool.Concurrency] opt-out; original call order
+	// is preserved in the resulting tool messages regardless of completion
+	// order, and consecutiveErrors is aggregated deterministically once the
+	// whole batch completes.
+	Concurrency int
 }
 
 // DefaultInvocationConfig returns the default configuration.
@@ -32,6 +43,7 @@ func DefaultInvocationConfig() InvocationConfig {
 	return InvocationConfig{
 		MaxIterations:        40,
 		MaxConsecutiveErrors: 3,
+		Concurrency:          1,
 	}
 }
 
@@ -39,6 +51,17 @@ func DefaultInvocationConfig() InvocationConfig {
 // from the response, invoke matched tools, append results, and re-call the LLM.
 //
 // It returns the final ChatResponse after all tool calls are resolved (or limits hit).
+//
+// isPreApproved, if non-nil, is consulted for each call to a tool requiring
+// approval; a true result lets the call through without pausing, the same
+// as if the tool itself didn't require approval (see
+// [Session.IsToolAlwaysApproved]). startConsecutiveErrors seeds the
+// consecutive-error counter, so [Agent.Resume] can continue a suspended
+// run's budget instead of resetting it.
+//
+// approver, if non-nil, is consulted synchronously in place of the
+// suspend/resume pendingCalls path: it decides approval-required calls
+// immediately instead of pausing the run (see [WithToolApprover]).
 func invokeFunctions(
 	ctx context.Context,
 	client ChatClient,
@@ -46,6 +69,10 @@ func invokeFunctions(
 	opts *ChatOptions,
 	config InvocationConfig,
 	fnMiddleware []FunctionMiddleware,
+	tracer Tracer,
+	isPreApproved func(name string) bool,
+	approver ToolApprover,
+	startConsecutiveErrors int,
 ) (*ChatResponse, error) {
 	if config.MaxIterations <= 0 {
 		config.MaxIterations = 40
@@ -53,6 +80,9 @@ func invokeFunctions(
 	if config.MaxConsecutiveErrors <= 0 {
 		config.MaxConsecutiveErrors = 3
 	}
+	if config.Concurrency <= 0 {
+		config.Concurrency = 1
+	}
 
 	// Build tool lookup
 	toolMap := make(map[string]Tool, len(opts.Tools))
@@ -60,7 +90,7 @@ func invokeFunctions(
 		toolMap[t.Name()] = t
 	}
 
-	consecutiveErrors := 0
+	consecutiveErrors := startConsecutiveErrors
 
 	for iteration := 0; iteration < config.MaxIterations; iteration++ {
 		resp, err := client.Response(ctx, messages, opts)
@@ -74,71 +104,484 @@ func invokeFunctions(
 			return resp, nil
 		}
 
-		// Process each function call
+		// Process each function call. Calls requiring approval are deferred
+		// (added to pendingCalls) rather than invoked; everything else in the
+		// same turn still executes normally.
 		var resultMessages []Message
-		for _, call := range calls {
-			tool, ok := toolMap[call.Name]
+		var pendingCalls []FunctionCallContent
+		var declarationOnlyHit bool
+
+		if config.Concurrency > 1 {
+			var batchErr error
+			resultMessages, pendingCalls, consecutiveErrors, declarationOnlyHit, batchErr = invokeCallsConcurrently(
+				ctx, calls, toolMap, config, fnMiddleware, tracer, isPreApproved, approver, consecutiveErrors,
+			)
+			if batchErr != nil {
+				return nil, batchErr
+			}
+			if consecutiveErrors >= config.MaxConsecutiveErrors {
+				return nil, fmt.Errorf("%w: max consecutive errors reached (%d)", ErrToolExecution, consecutiveErrors)
+			}
+		} else {
+			for _, call := range calls {
+				tool, ok := toolMap[call.Name]
+				if !ok {
+					if config.TerminateOnUnknown {
+						return nil, fmt.Errorf("%w: unknown tool %q", ErrToolExecution, call.Name)
+					}
+					slog.WarnContext(ctx, "unknown tool called", "tool", call.Name)
+					resultMessages = append(resultMessages, NewToolMessage(call.CallID, "error: unknown tool"))
+					consecutiveErrors++
+					continue
+				}
+
+				// Check approval
+				if tool.Approval() == ApprovalAlways && !isCallPreApproved(isPreApproved, call.Name) {
+					if approver == nil {
+						pendingCalls = append(pendingCalls, FunctionCallContent{
+							CallID:    call.CallID,
+							Name:      call.Name,
+							Arguments: call.Arguments,
+						})
+						continue
+					}
+					resolvedCall, denyMsg, denied, approveErr := resolveApproval(ctx, approver, call)
+					if approveErr != nil {
+						return nil, approveErr
+					}
+					if denied {
+						resultMessages = append(resultMessages, denyMsg)
+						continue
+					}
+					call = resolvedCall
+				}
+
+				// Check declaration-only
+				if tool.DeclarationOnly() {
+					declarationOnlyHit = true
+					break
+				}
+
+				// Validate arguments against the tool's schema before dispatch.
+				// A validation failure is fed back to the model to self-correct
+				// without counting against consecutiveErrors, since it isn't a
+				// tool execution failure.
+				if err := ValidateArguments(tool.Parameters(), json.RawMessage(call.Arguments)); err != nil {
+					slog.WarnContext(ctx, "tool call failed argument validation", "tool", call.Name, "error", err)
+					resultMessages = append(resultMessages, NewToolMessage(call.CallID, "invalid arguments: "+err.Error()))
+					continue
+				}
+
+				// Invoke the tool (through middleware chain if any)
+				result, invokeErr := invokeToolWithMiddleware(ctx, tool, json.RawMessage(call.Arguments), fnMiddleware, tracer, call.CallID)
+				if invokeErr != nil {
+					consecutiveErrors++
+					slog.WarnContext(ctx, "tool invocation error",
+						"tool", call.Name,
+						"error", invokeErr,
+						"consecutive_errors", consecutiveErrors,
+					)
+					if consecutiveErrors >= config.MaxConsecutiveErrors {
+						return nil, fmt.Errorf("%w: max consecutive errors reached (%d)", ErrToolExecution, consecutiveErrors)
+					}
+					errMsg := "error invoking tool"
+					if config.IncludeDetailedErrors {
+						errMsg = invokeErr.Error()
+					}
+					resultMessages = append(resultMessages, NewToolMessage(call.CallID, errMsg))
+					continue
+				}
+
+				consecutiveErrors = 0
+				resultMessages = append(resultMessages, NewToolMessage(call.CallID, result))
+			}
+		}
+
+		if declarationOnlyHit {
+			return resp, nil
+		}
+
+		// Append assistant message with tool calls and tool results
+		for _, m := range resp.Messages {
+			messages = append(messages, m)
+		}
+		messages = append(messages, resultMessages...)
+
+		if len(pendingCalls) > 0 {
+			return nil, &pendingApproval{
+				messages:          messages,
+				pending:           pendingCalls,
+				iteration:         iteration,
+				consecutiveErrors: consecutiveErrors,
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("%w: max iterations reached (%d)", ErrExecution, config.MaxIterations)
+}
+
+// invokeFunctionsStream is the streaming analogue of invokeFunctions. It
+// forwards each [ChatResponseUpdate] from the client as it arrives, and once
+// a turn's updates are fully drained, resolves any tool calls the same way
+// invokeFunctions does before issuing another streaming call. If a tool
+// requiring approval is encountered, onPending is called to persist a
+// resumable snapshot; its token is delivered on a terminal update (with
+// FinishReason [FinishReasonApprovalRequired]) before the loop stops.
+func invokeFunctionsStream(
+	ctx context.Context,
+	client ChatClient,
+	messages []Message,
+	opts *ChatOptions,
+	config InvocationConfig,
+	fnMiddleware []FunctionMiddleware,
+	tracer Tracer,
+	isPreApproved func(name string) bool,
+	approver ToolApprover,
+	startConsecutiveErrors int,
+	ch chan<- ChatResponseUpdate,
+	onPending func(ctx context.Context, messages []Message, pending []FunctionCallContent, iteration, consecutiveErrors int) (string, error),
+) error {
+	if config.MaxIterations <= 0 {
+		config.MaxIterations = 40
+	}
+	if config.MaxConsecutiveErrors <= 0 {
+		config.MaxConsecutiveErrors = 3
+	}
+	if config.Concurrency <= 0 {
+		config.Concurrency = 1
+	}
+
+	toolMap := make(map[string]Tool, len(opts.Tools))
+	for _, t := range opts.Tools {
+		toolMap[t.Name()] = t
+	}
+
+	consecutiveErrors := startConsecutiveErrors
+
+	for iteration := 0; iteration < config.MaxIterations; iteration++ {
+		stream, err := client.StreamResponse(ctx, messages, opts)
+		if err != nil {
+			return err
+		}
+
+		var updates []ChatResponseUpdate
+		for {
+			u, ok, nextErr := stream.Next(ctx)
+			if nextErr != nil {
+				stream.Close()
+				return nextErr
+			}
 			if !ok {
-				if config.TerminateOnUnknown {
-					return nil, fmt.Errorf("%w: unknown tool %q", ErrToolExecution, call.Name)
+				break
+			}
+			updates = append(updates, u)
+			select {
+			case ch <- u:
+			case <-ctx.Done():
+				stream.Close()
+				return ctx.Err()
+			}
+		}
+		stream.Close()
+
+		resp := ChatResponseFromUpdates(updates)
+		calls := extractFunctionCalls(resp)
+		if len(calls) == 0 {
+			return nil
+		}
+
+		var resultMessages []Message
+		var pendingCalls []FunctionCallContent
+		var declarationOnlyHit bool
+
+		if config.Concurrency > 1 {
+			var batchErr error
+			resultMessages, pendingCalls, consecutiveErrors, declarationOnlyHit, batchErr = invokeCallsConcurrently(
+				ctx, calls, toolMap, config, fnMiddleware, tracer, isPreApproved, approver, consecutiveErrors,
+			)
+			if batchErr != nil {
+				return batchErr
+			}
+			if consecutiveErrors >= config.MaxConsecutiveErrors {
+				return fmt.Errorf("%w: max consecutive errors reached (%d)", ErrToolExecution, consecutiveErrors)
+			}
+		} else {
+			for _, call := range calls {
+				tool, ok := toolMap[call.Name]
+				if !ok {
+					if config.TerminateOnUnknown {
+						return fmt.Errorf("%w: unknown tool %q", ErrToolExecution, call.Name)
+					}
+					slog.WarnContext(ctx, "unknown tool called", "tool", call.Name)
+					resultMessages = append(resultMessages, NewToolMessage(call.CallID, "error: unknown tool"))
+					consecutiveErrors++
+					continue
 				}
-				slog.WarnContext(ctx, "unknown tool called", "tool", call.Name)
-				resultMessages = append(resultMessages, NewToolMessage(call.CallID, "error: unknown tool"))
-				consecutiveErrors++
-				continue
+
+				if tool.Approval() == ApprovalAlways && !isCallPreApproved(isPreApproved, call.Name) {
+					if approver == nil {
+						pendingCalls = append(pendingCalls, FunctionCallContent{
+							CallID:    call.CallID,
+							Name:      call.Name,
+							Arguments: call.Arguments,
+						})
+						continue
+					}
+					resolvedCall, denyMsg, denied, approveErr := resolveApproval(ctx, approver, call)
+					if approveErr != nil {
+						return approveErr
+					}
+					if denied {
+						resultMessages = append(resultMessages, denyMsg)
+						continue
+					}
+					call = resolvedCall
+				}
+
+				if tool.DeclarationOnly() {
+					declarationOnlyHit = true
+					break
+				}
+
+				if err := ValidateArguments(tool.Parameters(), json.RawMessage(call.Arguments)); err != nil {
+					slog.WarnContext(ctx, "tool call failed argument validation", "tool", call.Name, "error", err)
+					resultMessages = append(resultMessages, NewToolMessage(call.CallID, "invalid arguments: "+err.Error()))
+					continue
+				}
+
+				result, invokeErr := invokeToolWithMiddleware(ctx, tool, json.RawMessage(call.Arguments), fnMiddleware, tracer, call.CallID)
+				if invokeErr != nil {
+					consecutiveErrors++
+					slog.WarnContext(ctx, "tool invocation error",
+						"tool", call.Name,
+						"error", invokeErr,
+						"consecutive_errors", consecutiveErrors,
+					)
+					if consecutiveErrors >= config.MaxConsecutiveErrors {
+						return fmt.Errorf("%w: max consecutive errors reached (%d)", ErrToolExecution, consecutiveErrors)
+					}
+					errMsg := "error invoking tool"
+					if config.IncludeDetailedErrors {
+						errMsg = invokeErr.Error()
+					}
+					resultMessages = append(resultMessages, NewToolMessage(call.CallID, errMsg))
+					continue
+				}
+
+				consecutiveErrors = 0
+				resultMessages = append(resultMessages, NewToolMessage(call.CallID, result))
 			}
+		}
+
+		if declarationOnlyHit {
+			return nil
+		}
+
+		messages = append(messages, resp.Messages...)
+		messages = append(messages, resultMessages...)
+
+		if len(pendingCalls) > 0 {
+			token, err := onPending(ctx, messages, pendingCalls, iteration, consecutiveErrors)
+			if err != nil {
+				return err
+			}
+			select {
+			case ch <- ChatResponseUpdate{FinishReason: FinishReasonApprovalRequired, Token: token}:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: max iterations reached (%d)", ErrExecution, config.MaxIterations)
+}
+
+// batchOutcome classifies a [batchSlot] for consecutiveErrors aggregation.
+// A call that neither succeeded nor failed to execute — a denied approval,
+// or a schema validation failure fed back to the model to self-correct —
+// is batchNeutral and leaves the streak wherever it was, exactly as the
+// sequential loop in [invokeFunctions] treats those two cases.
+type batchOutcome int
 
-			// Check approval
-			if tool.Approval() == ApprovalAlways {
-				// Return response with approval request â€” caller handles approval flow
-				resp.Messages = append(resp.Messages, Message{
-					Role: RoleAssistant,
-					Contents: Contents{&ApprovalRequestContent{
-						CallID:    call.CallID,
-						Name:      call.Name,
-						Arguments: call.Arguments,
-					}},
+const (
+	batchNeutral batchOutcome = iota
+	batchSuccess
+	batchError
+)
+
+// batchSlot holds the outcome of one call dispatched by
+// [invokeCallsConcurrently], indexed by the call's position in the original
+// batch so results can be reassembled in order regardless of completion order.
+type batchSlot struct {
+	message Message
+	outcome batchOutcome
+}
+
+// invokeCallsConcurrently dispatches calls through a worker pool bounded by
+// config.Concurrency, using errgroup-like semantics hand-rolled on top of
+// sync.WaitGroup and a buffered channel as a semaphore (the repo carries no
+// errgroup dependency). Approval-required and unknown-tool calls are
+// resolved up front, sequentially, since neither involves a tool
+// invocation; only the remaining calls are actually dispatched concurrently.
+//
+// Call order is preserved in the returned messages by writing into a
+// pre-sized slice by index. consecutiveErrors is aggregated deterministically
+// by walking that slice in original order once the whole batch has
+// completed, exactly as the sequential loop would count it — including
+// leaving the streak untouched for a [batchNeutral] slot (denied approval,
+// validation failure) rather than treating it as a success; a live error
+// streak is also tracked to cancel the shared context early once
+// config.MaxConsecutiveErrors is reached mid-batch, so sibling in-flight
+// calls are given a chance to stop.
+//
+// Tools report their [ConcurrencyMode] via Concurrency(): Exclusive tools
+// take a write lock on a shared drainLock, which waits for every in-flight
+// Parallel/Serial call to release its read lock first, then blocks new ones
+// until it's done; Serial tools additionally take a per-tool-name mutex so
+// repeated calls to the same tool never overlap each other.
+//
+// approver, if non-nil, is consulted synchronously and up front (alongside
+// the unknown-tool and declaration-only checks) for calls requiring
+// approval, instead of deferring them to pendingCalls.
+func invokeCallsConcurrently(
+	ctx context.Context,
+	calls []functionCall,
+	toolMap map[string]Tool,
+	config InvocationConfig,
+	fnMiddleware []FunctionMiddleware,
+	tracer Tracer,
+	isPreApproved func(name string) bool,
+	approver ToolApprover,
+	consecutiveErrors int,
+) (resultMessages []Message, pendingCalls []FunctionCallContent, newConsecutiveErrors int, declarationOnlyHit bool, err error) {
+	slots := make([]*batchSlot, len(calls))
+	var invocable []int
+
+	for i, call := range calls {
+		tool, ok := toolMap[call.Name]
+		if !ok {
+			if config.TerminateOnUnknown {
+				return nil, nil, consecutiveErrors, false, fmt.Errorf("%w: unknown tool %q", ErrToolExecution, call.Name)
+			}
+			slog.WarnContext(ctx, "unknown tool called", "tool", call.Name)
+			slots[i] = &batchSlot{message: NewToolMessage(call.CallID, "error: unknown tool"), outcome: batchError}
+			continue
+		}
+		if tool.Approval() == ApprovalAlways && !isCallPreApproved(isPreApproved, call.Name) {
+			if approver == nil {
+				pendingCalls = append(pendingCalls, FunctionCallContent{
+					CallID:    call.CallID,
+					Name:      call.Name,
+					Arguments: call.Arguments,
 				})
-				return resp, nil
+				continue
+			}
+			resolvedCall, denyMsg, denied, approveErr := resolveApproval(ctx, approver, call)
+			if approveErr != nil {
+				return nil, nil, consecutiveErrors, false, approveErr
+			}
+			if denied {
+				slots[i] = &batchSlot{message: denyMsg, outcome: batchNeutral}
+				continue
+			}
+			calls[i] = resolvedCall
+		}
+		if tool.DeclarationOnly() {
+			return nil, nil, consecutiveErrors, true, nil
+		}
+		invocable = append(invocable, i)
+	}
+
+	groupCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg        sync.WaitGroup
+		drainLock sync.RWMutex
+		toolLocks sync.Map // tool name -> *sync.Mutex, for ConcurrencySerial
+		sem       = make(chan struct{}, config.Concurrency)
+		errStreak int32
+	)
+
+	for _, idx := range invocable {
+		if groupCtx.Err() != nil {
+			break
+		}
+		idx, call, tool := idx, calls[idx], toolMap[calls[idx].Name]
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			switch tool.Concurrency() {
+			case ConcurrencyExclusive:
+				drainLock.Lock()
+				defer drainLock.Unlock()
+			default:
+				drainLock.RLock()
+				defer drainLock.RUnlock()
+				if tool.Concurrency() == ConcurrencySerial {
+					lockAny, _ := toolLocks.LoadOrStore(call.Name, &sync.Mutex{})
+					lock := lockAny.(*sync.Mutex)
+					lock.Lock()
+					defer lock.Unlock()
+				}
 			}
 
-			// Check declaration-only
-			if tool.DeclarationOnly() {
-				return resp, nil
+			if groupCtx.Err() != nil {
+				slots[idx] = &batchSlot{message: NewToolMessage(call.CallID, "error: cancelled after prior tool errors"), outcome: batchError}
+				return
 			}
 
-			// Invoke the tool (through middleware chain if any)
-			result, invokeErr := invokeToolWithMiddleware(ctx, tool, json.RawMessage(call.Arguments), fnMiddleware)
+			// A validation failure is fed back to the model to self-correct
+			// without counting against consecutiveErrors, since it isn't a
+			// tool execution failure — mirrors the sequential loop.
+			if verr := ValidateArguments(tool.Parameters(), json.RawMessage(call.Arguments)); verr != nil {
+				slog.WarnContext(ctx, "tool call failed argument validation", "tool", call.Name, "error", verr)
+				slots[idx] = &batchSlot{message: NewToolMessage(call.CallID, "invalid arguments: "+verr.Error()), outcome: batchNeutral}
+				return
+			}
+
+			result, invokeErr := invokeToolWithMiddleware(groupCtx, tool, json.RawMessage(call.Arguments), fnMiddleware, tracer, call.CallID)
 			if invokeErr != nil {
-				consecutiveErrors++
-				slog.WarnContext(ctx, "tool invocation error",
-					"tool", call.Name,
-					"error", invokeErr,
-					"consecutive_errors", consecutiveErrors,
-				)
-				if consecutiveErrors >= config.MaxConsecutiveErrors {
-					return nil, fmt.Errorf("%w: max consecutive errors reached (%d)", ErrToolExecution, consecutiveErrors)
-				}
+				slog.WarnContext(ctx, "tool invocation error", "tool", call.Name, "error", invokeErr)
 				errMsg := "error invoking tool"
 				if config.IncludeDetailedErrors {
 					errMsg = invokeErr.Error()
 				}
-				resultMessages = append(resultMessages, NewToolMessage(call.CallID, errMsg))
-				continue
+				slots[idx] = &batchSlot{message: NewToolMessage(call.CallID, errMsg), outcome: batchError}
+				if atomic.AddInt32(&errStreak, 1) >= int32(config.MaxConsecutiveErrors) {
+					cancel()
+				}
+				return
 			}
 
-			consecutiveErrors = 0
-			resultMessages = append(resultMessages, NewToolMessage(call.CallID, result))
-		}
+			atomic.StoreInt32(&errStreak, 0)
+			slots[idx] = &batchSlot{message: NewToolMessage(call.CallID, result), outcome: batchSuccess}
+		}()
+	}
+	wg.Wait()
 
-		// Append assistant message with tool calls and tool results
-		for _, m := range resp.Messages {
-			messages = append(messages, m)
+	for _, s := range slots {
+		if s == nil {
+			continue
+		}
+		resultMessages = append(resultMessages, s.message)
+		switch s.outcome {
+		case batchError:
+			consecutiveErrors++
+		case batchSuccess:
+			consecutiveErrors = 0
+		case batchNeutral:
+			// leave the streak unchanged, as the sequential loop does for a
+			// denied approval or a validation failure.
 		}
-		messages = append(messages, resultMessages...)
 	}
 
-	return nil, fmt.Errorf("%w: max iterations reached (%d)", ErrExecution, config.MaxIterations)
+	return resultMessages, pendingCalls, consecutiveErrors, false, nil
 }
 
 // functionCall is an extracted function call from a response.
@@ -165,11 +608,68 @@ func extractFunctionCalls(resp *ChatResponse) []functionCall {
 	return calls
 }
 
-// invokeToolWithMiddleware runs the tool through the function middleware chain.
-func invokeToolWithMiddleware(ctx context.Context, tool Tool, args json.RawMessage, mws []FunctionMiddleware) (any, error) {
+// pendingApproval is an internal signal returned by invokeFunctions when one
+// or more tool calls require approval. The agent-level caller turns it into
+// a durable [RunSnapshot] and a [PendingApprovalError].
+type pendingApproval struct {
+	// messages is the conversation so far, including the assistant turn that
+	// requested the pending calls and any tool results already produced by
+	// other calls in the same turn.
+	messages []Message
+	pending  []FunctionCallContent
+
+	// iteration and consecutiveErrors are the loop counters at the point of
+	// suspension, carried into the [RunSnapshot] so [Agent.Resume] can
+	// continue the same budget rather than starting over.
+	iteration         int
+	consecutiveErrors int
+}
+
+func (p *pendingApproval) Error() string { return "approval required" }
+
+// isCallPreApproved reports whether isPreApproved is set and approves name,
+// letting an otherwise approval-required call through without pausing.
+func isCallPreApproved(isPreApproved func(name string) bool, name string) bool {
+	return isPreApproved != nil && isPreApproved(name)
+}
+
+// resolveApproval consults approver synchronously for call, which must
+// require approval. It returns the (possibly argument-edited) call to
+// invoke; or denied=true and a tool result message to use in its place when
+// the approver denies the call.
+func resolveApproval(ctx context.Context, approver ToolApprover, call functionCall) (resolved functionCall, denyMsg Message, denied bool, err error) {
+	decision, approveErr := approver.Approve(ctx, ToolCall{CallID: call.CallID, Name: call.Name, Arguments: call.Arguments})
+	if approveErr != nil {
+		return functionCall{}, Message{}, false, fmt.Errorf("%w: %w", ErrApproval, approveErr)
+	}
+
+	switch decision.Outcome {
+	case DenyCall:
+		reason := decision.Reason
+		if reason == "" {
+			reason = "denied by approver"
+		}
+		return functionCall{}, NewToolMessage(call.CallID, "tool call denied: "+reason), true, nil
+	case EditArgsCall:
+		call.Arguments = decision.Arguments
+	}
+	return call, Message{}, false, nil
+}
+
+// invokeToolWithMiddleware runs the tool through the function middleware
+// chain, wrapped in a [Span] so each invocation shows up as a child of the
+// enclosing agent.run span.
+func invokeToolWithMiddleware(ctx context.Context, tool Tool, args json.RawMessage, mws []FunctionMiddleware, tracer Tracer, callID string) (any, error) {
+	ctx, span := tracer.Start(ctx, "tool.invoke", Attr("tool", tool.Name()), Attr("call_id", callID))
+	defer span.End()
+
 	handler := func(ctx context.Context, t Tool, a json.RawMessage) (any, error) {
 		return t.Invoke(ctx, a)
 	}
 	final := chainFunctionMiddleware(handler, mws...)
-	return final(ctx, tool, args)
+	result, err := final(ctx, tool, args)
+	if err != nil {
+		span.RecordError(err)
+	}
+	return result, err
 }
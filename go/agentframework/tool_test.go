@@ -70,6 +70,32 @@ func TestNewTypedTool(t *testing.T) {
 	}
 }
 
+func TestNewStructuredTool(t *testing.T) {
+	type args struct {
+		Name string `json:"name" jsonschema:"description=Person name,required"`
+	}
+
+	tool, format := af.NewStructuredTool("greet", "Greet someone",
+		func(ctx context.Context, a args) (any, error) {
+			return "Hello, " + a.Name + "!", nil
+		},
+	)
+
+	schema, ok := format.(*af.ResponseFormatJSONSchema)
+	if !ok {
+		t.Fatalf("format type = %T", format)
+	}
+	if schema.Name != "greet" {
+		t.Errorf("Name = %q", schema.Name)
+	}
+	if !schema.Strict {
+		t.Error("Strict = false, want true")
+	}
+	if string(schema.Schema) != string(tool.Parameters()) {
+		t.Errorf("Schema = %s, want tool's generated parameters", schema.Schema)
+	}
+}
+
 func TestNewTypedTool_InvalidArgs(t *testing.T) {
 	type args struct {
 		Count int `json:"count"`
@@ -97,6 +123,31 @@ func TestToolOption_ApprovalRequired(t *testing.T) {
 	}
 }
 
+func TestToolOption_Concurrency(t *testing.T) {
+	parallel := af.NewTool("fetch", "Fetches things", nil,
+		func(ctx context.Context, args json.RawMessage) (any, error) { return nil, nil },
+	)
+	if parallel.Concurrency() != af.ConcurrencyParallel {
+		t.Errorf("default Concurrency = %q, want %q", parallel.Concurrency(), af.ConcurrencyParallel)
+	}
+
+	serial := af.NewTool("append_log", "Appends to a log", nil,
+		func(ctx context.Context, args json.RawMessage) (any, error) { return nil, nil },
+		af.WithSerialExecution(),
+	)
+	if serial.Concurrency() != af.ConcurrencySerial {
+		t.Errorf("Concurrency = %q, want %q", serial.Concurrency(), af.ConcurrencySerial)
+	}
+
+	exclusive := af.NewTool("migrate_db", "Runs a migration", nil,
+		func(ctx context.Context, args json.RawMessage) (any, error) { return nil, nil },
+		af.WithExclusiveExecution(),
+	)
+	if exclusive.Concurrency() != af.ConcurrencyExclusive {
+		t.Errorf("Concurrency = %q, want %q", exclusive.Concurrency(), af.ConcurrencyExclusive)
+	}
+}
+
 func TestToolOption_DeclarationOnly(t *testing.T) {
 	tool := af.NewTool("decl", "Declaration only", nil, nil,
 		af.WithDeclarationOnly(),
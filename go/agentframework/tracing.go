@@ -0,0 +1,79 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package agentframework
+
+import "context"
+
+// KeyValue is a single span or metric attribute, shaped after OpenTelemetry's
+// attribute.KeyValue so a real OTel SDK can be adapted into a [Tracer] or
+// [Meter] without changing any call site.
+type KeyValue struct {
+	Key   string
+	Value any
+}
+
+// Attr builds a [KeyValue] attribute.
+func Attr(key string, value any) KeyValue { return KeyValue{Key: key, Value: value} }
+
+// Span represents one traced operation, started by [Tracer.Start] and ended
+// by the caller once the operation completes.
+type Span interface {
+	SetAttributes(attrs ...KeyValue)
+	RecordError(err error)
+	End()
+}
+
+// Tracer starts [Span]s around traced operations such as an agent run or a
+// tool invocation. [NewAgent] defaults to [NoopTracer]; pass a real
+// OpenTelemetry tracer adapted to this interface via [WithTracer].
+type Tracer interface {
+	Start(ctx context.Context, spanName string, attrs ...KeyValue) (context.Context, Span)
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(...KeyValue) {}
+func (noopSpan) RecordError(error)         {}
+func (noopSpan) End()                      {}
+
+type noopTracer struct{}
+
+func (noopTracer) Start(ctx context.Context, _ string, _ ...KeyValue) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+// NoopTracer is a [Tracer] that records nothing.
+var NoopTracer Tracer = noopTracer{}
+
+// Counter accumulates a monotonic count, e.g. agent runs or tool
+// invocations, shaped after OpenTelemetry's metric.Int64Counter.
+type Counter interface {
+	Add(ctx context.Context, delta int64, attrs ...KeyValue)
+}
+
+// Histogram records a distribution of values, e.g. run duration, shaped
+// after OpenTelemetry's metric.Float64Histogram.
+type Histogram interface {
+	Record(ctx context.Context, value float64, attrs ...KeyValue)
+}
+
+// Meter creates [Counter]s and [Histogram]s. [NewAgent] defaults to
+// [NoopMeter]; pass a real OpenTelemetry meter adapted to this interface via
+// [WithMeter].
+type Meter interface {
+	Counter(name string) Counter
+	Histogram(name string) Histogram
+}
+
+type noopInstrument struct{}
+
+func (noopInstrument) Add(context.Context, int64, ...KeyValue)      {}
+func (noopInstrument) Record(context.Context, float64, ...KeyValue) {}
+
+type noopMeter struct{}
+
+func (noopMeter) Counter(string) Counter     { return noopInstrument{} }
+func (noopMeter) Histogram(string) Histogram { return noopInstrument{} }
+
+// NoopMeter is a [Meter] that records nothing.
+var NoopMeter Meter = noopMeter{}
@@ -0,0 +1,72 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package agentframework_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+// recordingTracer captures the names of every span started, for asserting
+// that [af.Agent] traces runs and tool calls without depending on a real
+// OpenTelemetry SDK.
+type recordingTracer struct {
+	mu    sync.Mutex
+	spans []string
+}
+
+func (t *recordingTracer) Start(ctx context.Context, spanName string, _ ...af.KeyValue) (context.Context, af.Span) {
+	t.mu.Lock()
+	t.spans = append(t.spans, spanName)
+	t.mu.Unlock()
+	return af.NoopTracer.Start(ctx, spanName)
+}
+
+func TestAgent_TracesRunAndToolInvocation(t *testing.T) {
+	tool := af.NewTypedTool("add", "Adds two numbers",
+		func(ctx context.Context, args struct {
+			A int `json:"a"`
+			B int `json:"b"`
+		}) (any, error) {
+			return args.A + args.B, nil
+		},
+	)
+
+	calls := 0
+	client := &mockClient{
+		responseFn: func(ctx context.Context, msgs []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error) {
+			calls++
+			if calls == 1 {
+				return &af.ChatResponse{
+					Messages: []af.Message{{
+						Role: af.RoleAssistant,
+						Contents: af.Contents{
+							&af.FunctionCallContent{CallID: "c1", Name: "add", Arguments: `{"a":1,"b":2}`},
+						},
+					}},
+				}, nil
+			}
+			return &af.ChatResponse{Messages: []af.Message{af.NewAssistantMessage("3")}}, nil
+		},
+	}
+
+	tracer := &recordingTracer{}
+	agent := af.NewAgent(client, af.WithTools(tool), af.WithTracer(tracer))
+
+	_, err := agent.Run(context.Background(), []af.Message{af.NewUserMessage("add 1 and 2")})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	tracer.mu.Lock()
+	defer tracer.mu.Unlock()
+	if len(tracer.spans) != 2 {
+		t.Fatalf("spans = %v, want [agent.run tool.invoke]", tracer.spans)
+	}
+	if tracer.spans[0] != "agent.run" || tracer.spans[1] != "tool.invoke" {
+		t.Errorf("spans = %v", tracer.spans)
+	}
+}
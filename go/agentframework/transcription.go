@@ -0,0 +1,33 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package agentframework
+
+import "context"
+
+// TranscriptionOptions configures a transcription or translation request.
+// An empty field is "unset" and is not sent (the provider default applies).
+type TranscriptionOptions struct {
+	// Language is a BCP-47 hint for the audio's source language. Ignored
+	// by [TranscriptionClient.Translate], which always targets English.
+	Language string
+
+	// Prompt biases transcription toward expected vocabulary (names,
+	// acronyms) or continues a previous segment's style.
+	Prompt string
+}
+
+// TranscriptionClient converts between audio and text, and text and
+// speech. Provider packages (e.g., openai) implement this interface
+// alongside [ChatClient] so tools and middleware can consume the audio
+// parts of a [Message] end-to-end.
+type TranscriptionClient interface {
+	// Transcribe converts audio to text in its original language.
+	Transcribe(ctx context.Context, audio *AudioContent, opts *TranscriptionOptions) (*AudioTranscriptionContent, error)
+
+	// Translate converts audio to an English text transcription,
+	// regardless of the audio's source language.
+	Translate(ctx context.Context, audio *AudioContent, opts *TranscriptionOptions) (*AudioTranscriptionContent, error)
+
+	// Synthesize renders call's text as speech.
+	Synthesize(ctx context.Context, call *SpeechCallContent) (*SpeechResultContent, error)
+}
@@ -0,0 +1,143 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package sse
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+// Backoff bounds used by [NewSSEStream] when reconnecting after a
+// transient network failure.
+const (
+	minReconnectBackoff = 200 * time.Millisecond
+	maxReconnectBackoff = 10 * time.Second
+)
+
+// NewSSEStream connects to url (an [SSEHandler] endpoint) with headers
+// attached to every request, and returns a [af.ResponseStream] of
+// [af.AgentResponseUpdate]. On a transient network failure it reconnects,
+// sending the last event ID it saw via Last-Event-ID, with bounded
+// exponential backoff between attempts. The stream ends when the server
+// sends a "done" or "error" event.
+func NewSSEStream(ctx context.Context, url string, headers http.Header) *af.ResponseStream[af.AgentResponseUpdate] {
+	return af.NewResponseStream(ctx, func(ctx context.Context, ch chan<- af.AgentResponseUpdate) error {
+		var lastEventID string
+		backoff := minReconnectBackoff
+		for {
+			done, err := streamOnce(ctx, url, headers, &lastEventID, ch)
+			if done {
+				return err
+			}
+
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			backoff *= 2
+			if backoff > maxReconnectBackoff {
+				backoff = maxReconnectBackoff
+			}
+		}
+	})
+}
+
+// streamOnce makes one connection attempt and relays updates to ch until
+// the connection drops or a terminal event arrives. done is true when the
+// caller should stop (a terminal event or a malformed event was seen, or
+// the request could not be built); a false done with a non-nil err means
+// the attempt failed without one, including when ctx was canceled, and the
+// caller decides whether to reconnect.
+func streamOnce(ctx context.Context, url string, headers http.Header, lastEventID *string, ch chan<- af.AgentResponseUpdate) (done bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return true, err
+	}
+	for k, vs := range headers {
+		for _, v := range vs {
+			req.Header.Add(k, v)
+		}
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if *lastEventID != "" {
+		req.Header.Set("Last-Event-ID", *lastEventID)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("sse: connect: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("sse: unexpected status %d", resp.StatusCode)
+	}
+
+	var event string
+	var data []string
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if event == "" && len(data) == 0 {
+				continue
+			}
+			done, err := dispatch(event, strings.Join(data, "\n"), ch)
+			event, data = "", nil
+			if done {
+				return true, err
+			}
+		case strings.HasPrefix(line, "id: "):
+			*lastEventID = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			data = append(data, strings.TrimPrefix(line, "data: "))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return false, fmt.Errorf("sse: read stream: %w", err)
+	}
+	// The connection closed without a terminal event — treat it as
+	// transient so the caller reconnects rather than silently truncating
+	// the run.
+	return false, errors.New("sse: stream closed unexpectedly")
+}
+
+// dispatch handles one decoded SSE event, sending an "update" event's
+// payload to ch. done is true for "done"/"error" events (terminal) or a
+// decode failure.
+func dispatch(event, data string, ch chan<- af.AgentResponseUpdate) (done bool, err error) {
+	switch event {
+	case "update":
+		var update af.AgentResponseUpdate
+		if err := json.Unmarshal([]byte(data), &update); err != nil {
+			return true, fmt.Errorf("sse: decode update: %w", err)
+		}
+		ch <- update
+		return false, nil
+
+	case "done":
+		return true, nil
+
+	case "error":
+		var payload errorPayload
+		if err := json.Unmarshal([]byte(data), &payload); err != nil {
+			return true, fmt.Errorf("sse: decode error event: %w", err)
+		}
+		return true, errors.New(payload.Error)
+
+	default:
+		return false, nil
+	}
+}
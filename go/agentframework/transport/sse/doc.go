@@ -0,0 +1,10 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+// Package sse exposes an [af.Agent]'s streaming responses over HTTP using
+// the Server-Sent Events wire format, so browsers and non-Go clients can
+// consume an agent built with this module without inventing an ad-hoc
+// protocol. [SSEHandler] serves the server side, one "update" event per
+// [af.AgentResponseUpdate] followed by a terminal "done" or "error" event;
+// [NewSSEStream] consumes it, reconnecting with Last-Event-ID on a
+// transient network failure using bounded exponential backoff.
+package sse
@@ -0,0 +1,162 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package sse
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+	"github.com/microsoft/agent-framework/go/agentframework/auth"
+)
+
+// AgentRunner is the subset of [*af.Agent] that [SSEHandler] needs.
+type AgentRunner interface {
+	RunStream(ctx context.Context, messages []af.Message, opts ...af.RunOption) (*af.AgentResponseStream, error)
+}
+
+// Option configures [SSEHandler].
+type Option func(*config)
+
+type config struct {
+	verifier auth.RequestVerifier
+}
+
+// WithVerifier requires every request to pass verifier before the agent run
+// starts. On success, the resulting [auth.Principal] is attached to the
+// request context (retrievable via [auth.PrincipalFromContext]) for the
+// duration of the run, so tools and approval handlers can read it. On
+// failure, the request is rejected with 401 and the run never starts.
+func WithVerifier(verifier auth.RequestVerifier) Option {
+	return func(c *config) { c.verifier = verifier }
+}
+
+// runRequest is the JSON shape [SSEHandler] expects: the messages to send
+// to the agent for this turn. A GET request carries it JSON-encoded in the
+// "messages" query parameter; any other method carries it as the request
+// body.
+type runRequest struct {
+	Messages []af.Message `json:"messages"`
+}
+
+// SSEHandler serves agent.RunStream over HTTP as Server-Sent Events. Each
+// request is decoded as a [runRequest]; the response streams one "update"
+// event per [af.AgentResponseUpdate] encoded as JSON, then either a "done"
+// event carrying the merged final [af.AgentResponse] (via
+// [af.AgentResponseStream.FinalResponse]) or an "error" event if the
+// stream fails partway through.
+func SSEHandler(agent AgentRunner, opts ...Option) http.Handler {
+	cfg := &config{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if cfg.verifier != nil {
+			principal, err := cfg.verifier.Verify(ctx, r)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("sse: %v", err), http.StatusUnauthorized)
+				return
+			}
+			ctx = auth.WithPrincipal(ctx, principal)
+		}
+
+		messages, err := readRunRequest(r)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("sse: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "sse: streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+
+		stream, err := agent.RunStream(ctx, messages)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("sse: run stream: %v", err), http.StatusInternalServerError)
+			return
+		}
+		defer stream.Close()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.WriteHeader(http.StatusOK)
+
+		id := 0
+		for {
+			update, ok, err := stream.Next(ctx)
+			if err != nil {
+				id++
+				writeEvent(w, "error", id, errorPayload{Error: err.Error()})
+				flusher.Flush()
+				return
+			}
+			if !ok {
+				break
+			}
+			id++
+			writeEvent(w, "update", id, update)
+			flusher.Flush()
+		}
+
+		final, err := stream.FinalResponse(ctx)
+		id++
+		if err != nil {
+			writeEvent(w, "error", id, errorPayload{Error: err.Error()})
+		} else {
+			writeEvent(w, "done", id, final)
+		}
+		flusher.Flush()
+	})
+}
+
+func readRunRequest(r *http.Request) ([]af.Message, error) {
+	var raw []byte
+	if r.Method == http.MethodGet {
+		q := r.URL.Query().Get("messages")
+		if q == "" {
+			return nil, fmt.Errorf("missing messages query parameter")
+		}
+		raw = []byte(q)
+	} else {
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, fmt.Errorf("read request body: %w", err)
+		}
+		raw = data
+	}
+
+	var req runRequest
+	if err := json.Unmarshal(raw, &req); err != nil {
+		return nil, fmt.Errorf("decode messages: %w", err)
+	}
+	return req.Messages, nil
+}
+
+type errorPayload struct {
+	Error string `json:"error"`
+}
+
+// writeEvent writes one SSE event: an id line, an event line, one or more
+// data lines (data is JSON-encoded and split on newlines per the SSE wire
+// format), and the blank line that terminates it.
+func writeEvent(w io.Writer, event string, id int, data any) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		payload, _ = json.Marshal(errorPayload{Error: err.Error()})
+	}
+	fmt.Fprintf(w, "id: %d\n", id)
+	fmt.Fprintf(w, "event: %s\n", event)
+	for _, line := range bytes.Split(payload, []byte("\n")) {
+		fmt.Fprintf(w, "data: %s\n", line)
+	}
+	fmt.Fprint(w, "\n")
+}
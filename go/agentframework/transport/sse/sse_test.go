@@ -0,0 +1,95 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package sse_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+	"github.com/microsoft/agent-framework/go/agentframework/auth"
+	"github.com/microsoft/agent-framework/go/agentframework/transport/sse"
+)
+
+// streamingClient is a minimal [af.ChatClient] that streams back the text
+// of the last user message, one word per update.
+type streamingClient struct{}
+
+func (streamingClient) Response(ctx context.Context, msgs []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error) {
+	return &af.ChatResponse{Messages: []af.Message{af.NewAssistantMessage(msgs[len(msgs)-1].Text())}}, nil
+}
+
+func (c streamingClient) StreamResponse(ctx context.Context, msgs []af.Message, opts *af.ChatOptions) (*af.ResponseStream[af.ChatResponseUpdate], error) {
+	words := []string{"hello", "from", "the", "agent"}
+	return af.NewResponseStream(ctx, func(ctx context.Context, ch chan<- af.ChatResponseUpdate) error {
+		for _, w := range words {
+			ch <- af.ChatResponseUpdate{
+				Role:     af.RoleAssistant,
+				Contents: af.Contents{&af.TextContent{Text: w + " "}},
+			}
+		}
+		return nil
+	}), nil
+}
+
+func TestSSEHandlerAndStream_RoundTrip(t *testing.T) {
+	agent := af.NewAgent(streamingClient{})
+	server := httptest.NewServer(sse.SSEHandler(agent))
+	defer server.Close()
+
+	body, err := json.Marshal(struct {
+		Messages []af.Message `json:"messages"`
+	}{Messages: []af.Message{af.NewUserMessage("hi")}})
+	if err != nil {
+		t.Fatalf("marshal request: %v", err)
+	}
+	reqURL := server.URL + "?messages=" + url.QueryEscape(string(body))
+
+	stream := sse.NewSSEStream(context.Background(), reqURL, nil)
+	defer stream.Close()
+
+	var got string
+	for {
+		update, ok, err := stream.Next(context.Background())
+		if err != nil {
+			t.Fatalf("Next: %v", err)
+		}
+		if !ok {
+			break
+		}
+		got += update.Text()
+	}
+
+	if want := "hello from the agent "; got != want {
+		t.Errorf("streamed text = %q, want %q", got, want)
+	}
+}
+
+func TestSSEHandler_RejectsFailedVerification(t *testing.T) {
+	agent := af.NewAgent(streamingClient{})
+	denied := auth.RequestVerifierFunc(func(context.Context, *http.Request) (auth.Principal, error) {
+		return auth.Principal{}, errors.New("invalid token")
+	})
+	server := httptest.NewServer(sse.SSEHandler(agent, sse.WithVerifier(denied)))
+	defer server.Close()
+
+	body, err := json.Marshal(struct {
+		Messages []af.Message `json:"messages"`
+	}{Messages: []af.Message{af.NewUserMessage("hi")}})
+	if err != nil {
+		t.Fatalf("marshal request: %v", err)
+	}
+	resp, err := http.Get(server.URL + "?messages=" + url.QueryEscape(string(body)))
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusUnauthorized)
+	}
+}
@@ -2,9 +2,108 @@
 
 package agentframework
 
+import "context"
+
 // UsageDetails holds token consumption statistics for a model response.
 type UsageDetails struct {
 	InputTokens  int `json:"inputTokenCount,omitempty"`
 	OutputTokens int `json:"outputTokenCount,omitempty"`
 	TotalTokens  int `json:"totalTokenCount,omitempty"`
+
+	// CachedInputTokens is the portion of InputTokens served from a
+	// provider-side prompt cache (a cache hit), populated when
+	// [ChatOptions.EnablePromptCache] is set and the provider reports it.
+	CachedInputTokens int `json:"cachedInputTokenCount,omitempty"`
+
+	// CacheCreationTokens is the portion of InputTokens written to a
+	// provider-side prompt cache for reuse on a later turn (a cache
+	// miss that primed the cache). Anthropic-specific; always 0 for
+	// providers without a separate cache-write charge.
+	CacheCreationTokens int `json:"cacheCreationTokenCount,omitempty"`
+}
+
+// UsageMiddleware returns a [ChatMiddleware] that calls
+// [ChatOptions.OnUsage] with the completed response's usage, once per
+// request. A no-op if opts.OnUsage is unset. It wraps only non-streaming
+// calls; for streaming calls, use [UsageAggregator] instead.
+func UsageMiddleware() ChatMiddleware {
+	return func(next ChatHandler) ChatHandler {
+		return func(ctx context.Context, messages []Message, opts *ChatOptions) (*ChatResponse, error) {
+			resp, err := next(ctx, messages, opts)
+			if err == nil && resp != nil && opts != nil && opts.OnUsage != nil {
+				opts.OnUsage(resp.Usage)
+			}
+			return resp, err
+		}
+	}
+}
+
+// UsageAggregator wraps a [ResponseStream] of [ChatResponseUpdate],
+// keeping the usage of the latest update that reports a nonzero
+// TotalTokens (the same rule [ChatResponseFromUpdates] applies; values are
+// not summed across updates), and calls [ChatOptions.OnUsage] once with it
+// when the stream ends
+// (exhausted or closed). Use it in place of consuming the stream directly
+// when a caller needs usage accounting without buffering every update
+// itself:
+//
+//	agg := agentframework.NewUsageAggregator(stream, opts)
+//	for {
+//	    update, ok, err := agg.Next(ctx)
+//	    if !ok { break }
+//	    ...
+//	}
+type UsageAggregator struct {
+	stream  *ResponseStream[ChatResponseUpdate]
+	onUsage func(UsageDetails)
+	usage   UsageDetails
+	fired   bool
+}
+
+// NewUsageAggregator wraps stream, reading opts.OnUsage (nil-safe) as the
+// callback to fire once the stream ends.
+func NewUsageAggregator(stream *ResponseStream[ChatResponseUpdate], opts *ChatOptions) *UsageAggregator {
+	a := &UsageAggregator{stream: stream}
+	if opts != nil {
+		a.onUsage = opts.OnUsage
+	}
+	return a
+}
+
+// Next returns the next update, passing it through unchanged and keeping
+// its usage if TotalTokens is nonzero. Mirrors [ResponseStream.Next].
+func (a *UsageAggregator) Next(ctx context.Context) (ChatResponseUpdate, bool, error) {
+	update, ok, err := a.stream.Next(ctx)
+	if ok {
+		if update.Usage.TotalTokens > 0 {
+			a.usage = update.Usage
+		}
+		return update, true, nil
+	}
+	a.fire()
+	return update, false, err
+}
+
+// Usage returns the usage kept so far, final once the stream has been
+// fully consumed.
+func (a *UsageAggregator) Usage() UsageDetails {
+	return a.usage
+}
+
+// Close releases the underlying stream and fires OnUsage with whatever
+// usage was kept before closing, if it hasn't fired already.
+func (a *UsageAggregator) Close() error {
+	err := a.stream.Close()
+	a.fire()
+	return err
+}
+
+func (a *UsageAggregator) fire() {
+	if a.fired {
+		return
+	}
+	a.fired = true
+	if a.onUsage != nil {
+		a.onUsage(a.usage)
+	}
 }
@@ -0,0 +1,121 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package agentframework_test
+
+import (
+	"context"
+	"testing"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+func TestUsageMiddleware_FiresOnceWithFinalUsage(t *testing.T) {
+	client := &mockClient{
+		responseFn: func(ctx context.Context, msgs []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error) {
+			return &af.ChatResponse{
+				Messages: []af.Message{af.NewAssistantMessage("hi")},
+				Usage:    af.UsageDetails{InputTokens: 10, OutputTokens: 5, TotalTokens: 15},
+			}, nil
+		},
+	}
+
+	var calls int
+	var got af.UsageDetails
+	agent := af.NewAgent(client, af.WithChatMiddleware(af.UsageMiddleware()))
+	_, err := agent.Run(context.Background(), []af.Message{af.NewUserMessage("hi")},
+		af.WithRunOptions(&af.ChatOptions{OnUsage: func(u af.UsageDetails) {
+			calls++
+			got = u
+		}}))
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	if calls != 1 {
+		t.Fatalf("OnUsage called %d times, want 1", calls)
+	}
+	if got.TotalTokens != 15 {
+		t.Errorf("usage = %+v, want TotalTokens 15", got)
+	}
+}
+
+func TestUsageMiddleware_NoopWithoutOnUsage(t *testing.T) {
+	client := &mockClient{
+		responseFn: func(ctx context.Context, msgs []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error) {
+			return &af.ChatResponse{Messages: []af.Message{af.NewAssistantMessage("hi")}}, nil
+		},
+	}
+
+	agent := af.NewAgent(client, af.WithChatMiddleware(af.UsageMiddleware()))
+	if _, err := agent.Run(context.Background(), []af.Message{af.NewUserMessage("hi")}); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+}
+
+func TestUsageAggregator_AccumulatesAndFiresOnStreamEnd(t *testing.T) {
+	ctx := context.Background()
+	stream := af.NewResponseStream(ctx, func(ctx context.Context, ch chan<- af.ChatResponseUpdate) error {
+		ch <- af.ChatResponseUpdate{Contents: af.Contents{&af.TextContent{Text: "hel"}}}
+		ch <- af.ChatResponseUpdate{Contents: af.Contents{&af.TextContent{Text: "lo"}}}
+		ch <- af.ChatResponseUpdate{Usage: af.UsageDetails{InputTokens: 3, OutputTokens: 2, TotalTokens: 5}}
+		return nil
+	})
+
+	var calls int
+	var got af.UsageDetails
+	agg := af.NewUsageAggregator(stream, &af.ChatOptions{OnUsage: func(u af.UsageDetails) {
+		calls++
+		got = u
+	}})
+
+	var text string
+	for {
+		update, ok, err := agg.Next(ctx)
+		if err != nil {
+			t.Fatalf("Next: %v", err)
+		}
+		if !ok {
+			break
+		}
+		text += update.Text()
+	}
+
+	if text != "hello" {
+		t.Errorf("text = %q, want hello", text)
+	}
+	if calls != 1 {
+		t.Fatalf("OnUsage called %d times, want 1", calls)
+	}
+	if got.TotalTokens != 5 {
+		t.Errorf("usage = %+v, want TotalTokens 5", got)
+	}
+	if agg.Usage().TotalTokens != 5 {
+		t.Errorf("Usage() = %+v, want TotalTokens 5", agg.Usage())
+	}
+}
+
+func TestUsageAggregator_CloseFiresOnceWithPartialUsage(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := af.NewResponseStream(ctx, func(ctx context.Context, ch chan<- af.ChatResponseUpdate) error {
+		ch <- af.ChatResponseUpdate{Usage: af.UsageDetails{TotalTokens: 1}}
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	var calls int
+	agg := af.NewUsageAggregator(stream, &af.ChatOptions{OnUsage: func(af.UsageDetails) { calls++ }})
+	if _, _, err := agg.Next(ctx); err != nil {
+		t.Fatalf("Next: %v", err)
+	}
+
+	cancel()
+	if err := agg.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if err := agg.Close(); err != nil {
+		t.Fatalf("second Close: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("OnUsage called %d times, want 1", calls)
+	}
+}
@@ -0,0 +1,243 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package anthropic
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+// Client implements [agentframework.ChatClient] using the Anthropic Messages
+// API. Use [New] to create one.
+type Client struct {
+	tp      transport
+	cfg     *clientConfig
+	handler af.ChatHandler
+}
+
+// Verify interface compliance at compile time.
+var _ af.ChatClient = (*Client)(nil)
+
+// New creates an Anthropic [Client] with the given API key and options.
+//
+//	client := anthropic.New(os.Getenv("ANTHROPIC_API_KEY"),
+//	    anthropic.WithModel("claude-sonnet-4-5"),
+//	)
+func New(apiKey string, opts ...Option) *Client {
+	cfg := &clientConfig{}
+	for _, o := range opts {
+		o(cfg)
+	}
+	c := &Client{
+		tp:  newHTTPTransport(apiKey, cfg),
+		cfg: cfg,
+	}
+	c.handler = c.coreResponse
+	for i := len(cfg.chatMiddleware) - 1; i >= 0; i-- {
+		c.handler = cfg.chatMiddleware[i](c.handler)
+	}
+	return c
+}
+
+// newWithTransport creates a Client with a custom transport (for testing).
+func newWithTransport(tp transport, cfg *clientConfig) *Client {
+	c := &Client{tp: tp, cfg: cfg}
+	c.handler = c.coreResponse
+	return c
+}
+
+// Response sends a non-streaming Messages API request and returns the
+// complete response.
+func (c *Client) Response(ctx context.Context, messages []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error) {
+	return c.handler(ctx, messages, opts)
+}
+
+// coreResponse is the base implementation called by the middleware chain.
+func (c *Client) coreResponse(ctx context.Context, messages []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error) {
+	req := buildRequest(messages, opts, c.cfg)
+	req.Stream = false
+
+	resp, err := c.tp.do(ctx, "POST", "/messages", req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: read response body: %v", af.ErrService, err)
+	}
+
+	var raw messagesResponse
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("%w: parse response: %v", af.ErrService, err)
+	}
+
+	result := parseResponse(&raw)
+	if c.cfg.xmlToolProtocol {
+		applyXMLToolCalls(result)
+	}
+	result.Raw = &raw
+	return result, nil
+}
+
+// StreamResponse sends a streaming Messages API request and returns a
+// [af.ResponseStream] that yields incremental updates via server-sent events.
+func (c *Client) StreamResponse(ctx context.Context, messages []af.Message, opts *af.ChatOptions) (*af.ResponseStream[af.ChatResponseUpdate], error) {
+	req := buildRequest(messages, opts, c.cfg)
+	req.Stream = true
+
+	resp, err := c.tp.do(ctx, "POST", "/messages", req)
+	if err != nil {
+		return nil, err
+	}
+
+	parse := parseSSEStream
+	if c.cfg.xmlToolProtocol {
+		parse = parseSSEStreamXML
+	}
+
+	stream := af.NewResponseStream[af.ChatResponseUpdate](ctx, func(ctx context.Context, ch chan<- af.ChatResponseUpdate) error {
+		defer resp.Body.Close()
+		return parse(ctx, resp.Body, ch)
+	})
+
+	return stream, nil
+}
+
+// parseSSEStream reads Anthropic server-sent events from r and sends parsed
+// updates to ch. It returns when the stream reports message_stop, the
+// context is cancelled, or an error occurs.
+func parseSSEStream(ctx context.Context, r io.Reader, ch chan<- af.ChatResponseUpdate) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	blocks := make(map[int]*streamState)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if !strings.HasPrefix(line, "data: ") {
+			continue
+		}
+		data := strings.TrimSpace(strings.TrimPrefix(line, "data: "))
+		if data == "" {
+			continue
+		}
+
+		var evt streamEvent
+		if err := json.Unmarshal([]byte(data), &evt); err != nil {
+			// Skip malformed events rather than aborting.
+			continue
+		}
+
+		if evt.Type == "message_stop" {
+			return nil
+		}
+
+		update := parseStreamEvent(&evt, blocks)
+		if update == nil {
+			continue
+		}
+		update.Raw = &evt
+
+		select {
+		case ch <- *update:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("%w: read SSE stream: %v", af.ErrService, err)
+	}
+
+	return nil
+}
+
+// parseSSEStreamXML is parseSSEStream's analogue for [WithXMLToolProtocol]:
+// since a <function_calls> block can only be recognized once it's fully
+// arrived, text deltas are buffered rather than forwarded as they stream in,
+// and parsed into content once at message_stop.
+func parseSSEStreamXML(ctx context.Context, r io.Reader, ch chan<- af.ChatResponseUpdate) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	blocks := make(map[int]*streamState)
+	var textBuf strings.Builder
+
+	flush := func() error {
+		cleaned, calls := parseXMLFunctionCalls(textBuf.String())
+		var contents af.Contents
+		if cleaned != "" {
+			contents = append(contents, &af.TextContent{Text: cleaned})
+		}
+		for i := range calls {
+			contents = append(contents, &calls[i])
+		}
+		if len(contents) == 0 {
+			return nil
+		}
+		update := af.ChatResponseUpdate{Contents: contents, Role: af.RoleAssistant}
+		if len(calls) > 0 {
+			update.FinishReason = af.FinishReasonToolCalls
+		}
+		select {
+		case ch <- update:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		return nil
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if !strings.HasPrefix(line, "data: ") {
+			continue
+		}
+		data := strings.TrimSpace(strings.TrimPrefix(line, "data: "))
+		if data == "" {
+			continue
+		}
+
+		var evt streamEvent
+		if err := json.Unmarshal([]byte(data), &evt); err != nil {
+			continue
+		}
+
+		if evt.Type == "message_stop" {
+			return flush()
+		}
+
+		update := parseStreamEvent(&evt, blocks)
+		if update == nil {
+			continue
+		}
+		if len(update.Contents) == 1 {
+			if tc, ok := update.Contents[0].(*af.TextContent); ok {
+				textBuf.WriteString(tc.Text)
+				continue
+			}
+		}
+		update.Raw = &evt
+
+		select {
+		case ch <- *update:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("%w: read SSE stream: %v", af.ErrService, err)
+	}
+
+	return flush()
+}
@@ -0,0 +1,32 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+// Package anthropic provides a [agentframework.ChatClient] implementation for
+// the Anthropic Messages API, with first-class support for tool_use /
+// tool_result content blocks.
+//
+// Create a client and pass it to [agentframework.NewAgent]:
+//
+//	client := anthropic.New(os.Getenv("ANTHROPIC_API_KEY"),
+//	    anthropic.WithModel("claude-sonnet-4-5"),
+//	)
+//
+//	agent := agentframework.NewAgent(client)
+//
+// The client supports both synchronous and streaming responses, tool calling,
+// and the standard ChatOptions fields. Unlike the JSON-in-text workaround
+// used for models without native tool support, this provider maps
+// [agentframework.FunctionCallContent] and [agentframework.FunctionResultContent]
+// directly onto Anthropic's structured tool_use / tool_result blocks.
+//
+// # Configuration
+//
+// Use functional options to configure the client:
+//
+//   - [WithModel]: set the default model
+//   - [WithBaseURL]: override the API endpoint
+//   - [WithHTTPClient]: provide a custom http.Client
+//   - [WithHeaders]: add custom headers to every request
+//   - [WithMaxTokens]: set the default max_tokens (required by the Messages API)
+//   - [WithXMLToolProtocol]: fall back to the legacy XML-in-system-prompt
+//     tool-call convention for older model versions without native tool_use
+package anthropic
@@ -0,0 +1,273 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package anthropic
+
+import (
+	"encoding/json"
+	"strings"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+// messagesRequest is the Anthropic Messages API request body.
+type messagesRequest struct {
+	Model         string          `json:"model"`
+	MaxTokens     int             `json:"max_tokens"`
+	System        any             `json:"system,omitempty"` // string or []anthropicBlock
+	Messages      []anthropicMsg  `json:"messages"`
+	Temperature   *float64        `json:"temperature,omitempty"`
+	TopP          *float64        `json:"top_p,omitempty"`
+	StopSequences []string        `json:"stop_sequences,omitempty"`
+	Tools         []anthropicTool `json:"tools,omitempty"`
+	ToolChoice    any             `json:"tool_choice,omitempty"`
+	Stream        bool            `json:"stream,omitempty"`
+	Metadata      *anthropicMeta  `json:"metadata,omitempty"`
+}
+
+type anthropicMeta struct {
+	UserID string `json:"user_id,omitempty"`
+}
+
+type anthropicMsg struct {
+	Role    string           `json:"role"`
+	Content []anthropicBlock `json:"content"`
+}
+
+// anthropicBlock is a single content block within a message. Only the fields
+// relevant to the block's Type are populated.
+type anthropicBlock struct {
+	Type string `json:"type"`
+
+	// text
+	Text string `json:"text,omitempty"`
+
+	// image (DataContent)
+	Source *anthropicImageSource `json:"source,omitempty"`
+
+	// tool_use
+	ID    string `json:"id,omitempty"`
+	Name  string `json:"name,omitempty"`
+	Input any    `json:"input,omitempty"`
+
+	// tool_result
+	ToolUseID string `json:"tool_use_id,omitempty"`
+	Content   any    `json:"content,omitempty"`
+	IsError   bool   `json:"is_error,omitempty"`
+
+	CacheControl *cacheControl `json:"cache_control,omitempty"`
+}
+
+// cacheControl marks a block as a prompt-cache breakpoint. Anthropic caches
+// everything up to and including the marked block, so only the last block of
+// a cacheable prefix (the system prompt, the tools array) needs one.
+type cacheControl struct {
+	Type string `json:"type"`
+}
+
+type anthropicImageSource struct {
+	Type      string `json:"type"`
+	MediaType string `json:"media_type,omitempty"`
+	Data      string `json:"data,omitempty"`
+	URL       string `json:"url,omitempty"`
+}
+
+type anthropicTool struct {
+	Name         string          `json:"name"`
+	Description  string          `json:"description,omitempty"`
+	InputSchema  json.RawMessage `json:"input_schema,omitempty"`
+	CacheControl *cacheControl   `json:"cache_control,omitempty"`
+}
+
+// buildRequest converts framework types into an Anthropic Messages API request.
+func buildRequest(messages []af.Message, opts *af.ChatOptions, cfg *clientConfig) *messagesRequest {
+	req := &messagesRequest{
+		Model:     cfg.model,
+		MaxTokens: cfg.maxTokens,
+	}
+	if req.MaxTokens == 0 {
+		req.MaxTokens = defaultMaxTokens
+	}
+
+	var system []string
+	var rest []af.Message
+	for _, m := range messages {
+		if m.Role == af.RoleSystem {
+			if t := m.Text(); t != "" {
+				system = append(system, t)
+			}
+			continue
+		}
+		rest = append(rest, m)
+	}
+	systemText := strings.Join(system, "\n")
+
+	if opts != nil {
+		if opts.ModelID != "" {
+			req.Model = opts.ModelID
+		}
+		if opts.MaxTokens != nil {
+			req.MaxTokens = *opts.MaxTokens
+		}
+		req.Temperature = opts.Temperature
+		req.TopP = opts.TopP
+		req.StopSequences = opts.Stop
+		if opts.User != "" {
+			req.Metadata = &anthropicMeta{UserID: opts.User}
+		}
+
+		if cfg.xmlToolProtocol && len(opts.Tools) > 0 {
+			systemText = strings.TrimSpace(systemText + "\n\n" + xmlToolsSystemPrompt(opts.Tools))
+			req.StopSequences = append(req.StopSequences, functionStopSequence)
+		} else {
+			for _, t := range opts.Tools {
+				req.Tools = append(req.Tools, anthropicTool{
+					Name:        t.Name(),
+					Description: t.Description(),
+					InputSchema: t.Parameters(),
+				})
+			}
+			req.ToolChoice = convertToolChoice(opts.ToolChoice)
+		}
+
+		if opts.EnablePromptCache {
+			// Anthropic caches a prefix up to each marked breakpoint, so
+			// marking the last block of the system prompt and the last tool
+			// covers both as a stable, reusable prefix across turns.
+			if systemText != "" {
+				req.System = []anthropicBlock{{Type: "text", Text: systemText, CacheControl: &cacheControl{Type: "ephemeral"}}}
+			}
+			if n := len(req.Tools); n > 0 {
+				req.Tools[n-1].CacheControl = &cacheControl{Type: "ephemeral"}
+			}
+		}
+	}
+	if req.System == nil && systemText != "" {
+		req.System = systemText
+	}
+
+	req.Messages = convertMessages(rest)
+	return req
+}
+
+// convertMessages translates framework Messages into Anthropic messages,
+// merging consecutive tool-result messages into the preceding user turn
+// since Anthropic expects all tool_result blocks for a round of tool calls
+// in a single user message.
+func convertMessages(messages []af.Message) []anthropicMsg {
+	var result []anthropicMsg
+
+	for _, msg := range messages {
+		blocks := convertContentBlocks(msg.Contents)
+		if len(blocks) == 0 {
+			continue
+		}
+
+		role := "user"
+		if msg.Role == af.RoleAssistant {
+			role = "assistant"
+		}
+
+		if msg.Role == af.RoleTool && len(result) > 0 && result[len(result)-1].Role == "user" {
+			last := &result[len(result)-1]
+			last.Content = append(last.Content, blocks...)
+			continue
+		}
+
+		result = append(result, anthropicMsg{Role: role, Content: blocks})
+	}
+
+	return result
+}
+
+// convertContentBlocks converts framework Content items into Anthropic blocks.
+func convertContentBlocks(contents af.Contents) []anthropicBlock {
+	var blocks []anthropicBlock
+	for _, c := range contents {
+		switch v := c.(type) {
+		case *af.TextContent:
+			if v.Text != "" {
+				blocks = append(blocks, anthropicBlock{Type: "text", Text: v.Text})
+			}
+		case *af.TextReasoningContent:
+			if v.Text != "" {
+				blocks = append(blocks, anthropicBlock{Type: "text", Text: v.Text})
+			}
+		case *af.DataContent:
+			blocks = append(blocks, anthropicBlock{Type: "image", Source: dataURIToSource(v.URI, v.MediaType)})
+		case *af.FunctionCallContent:
+			// The framework's Arguments field is a JSON-encoded string; the
+			// Anthropic API wants the parsed object as `input`.
+			var input any
+			if v.Arguments != "" {
+				_ = json.Unmarshal([]byte(v.Arguments), &input)
+			} else {
+				input = map[string]any{}
+			}
+			blocks = append(blocks, anthropicBlock{
+				Type:  "tool_use",
+				ID:    v.CallID,
+				Name:  v.Name,
+				Input: input,
+			})
+		case *af.FunctionResultContent:
+			blocks = append(blocks, anthropicBlock{
+				Type:      "tool_result",
+				ToolUseID: v.CallID,
+				Content:   resultToString(v.Result),
+			})
+		}
+	}
+	return blocks
+}
+
+// dataURIToSource converts a `data:<mediaType>;base64,<data>` URI into an
+// Anthropic image source block. Non-data URIs are passed through as a URL
+// source (supported by newer API versions).
+func dataURIToSource(uri, mediaType string) *anthropicImageSource {
+	const prefix = "data:"
+	if !strings.HasPrefix(uri, prefix) {
+		return &anthropicImageSource{Type: "url", URL: uri}
+	}
+	rest := strings.TrimPrefix(uri, prefix)
+	parts := strings.SplitN(rest, ",", 2)
+	if len(parts) != 2 {
+		return &anthropicImageSource{Type: "url", URL: uri}
+	}
+	meta, data := parts[0], parts[1]
+	meta = strings.TrimSuffix(meta, ";base64")
+	if mediaType == "" {
+		mediaType = meta
+	}
+	return &anthropicImageSource{Type: "base64", MediaType: mediaType, Data: data}
+}
+
+func resultToString(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+func convertToolChoice(tc af.ToolChoice) any {
+	if tc == "" {
+		return nil
+	}
+	switch tc {
+	case af.ToolChoiceAuto:
+		return map[string]string{"type": "auto"}
+	case af.ToolChoiceRequired:
+		return map[string]string{"type": "any"}
+	case af.ToolChoiceNone:
+		return nil
+	default:
+		s := string(tc)
+		if strings.HasPrefix(s, "function:") {
+			return map[string]string{"type": "tool", "name": strings.TrimPrefix(s, "function:")}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package anthropic
+
+import (
+	"net/http"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+// clientConfig holds resolved configuration for the Anthropic client.
+type clientConfig struct {
+	baseURL         string
+	httpClient      *http.Client
+	headers         map[string]string
+	model           string
+	maxTokens       int
+	chatMiddleware  []af.ChatMiddleware
+	xmlToolProtocol bool
+}
+
+// Option configures an Anthropic [Client].
+type Option func(*clientConfig)
+
+// WithBaseURL overrides the API base URL (e.g., for a proxy or gateway).
+func WithBaseURL(url string) Option {
+	return func(c *clientConfig) { c.baseURL = url }
+}
+
+// WithHTTPClient provides a custom http.Client for requests.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *clientConfig) { c.httpClient = client }
+}
+
+// WithHeaders adds custom headers to every request.
+func WithHeaders(headers map[string]string) Option {
+	return func(c *clientConfig) { c.headers = headers }
+}
+
+// WithModel sets the default model for requests.
+func WithModel(model string) Option {
+	return func(c *clientConfig) { c.model = model }
+}
+
+// WithMaxTokens sets the default max_tokens sent when a request does not
+// specify [agentframework.ChatOptions.MaxTokens]. The Messages API requires
+// max_tokens on every request; if neither is set, [defaultMaxTokens] is used.
+func WithMaxTokens(n int) Option {
+	return func(c *clientConfig) { c.maxTokens = n }
+}
+
+// WithChatMiddleware adds middleware to the chat pipeline.
+// Middleware is applied in the order provided (first = outermost).
+func WithChatMiddleware(mw ...af.ChatMiddleware) Option {
+	return func(c *clientConfig) { c.chatMiddleware = append(c.chatMiddleware, mw...) }
+}
+
+// WithXMLToolProtocol switches the client to the legacy tool-calling
+// convention used by Anthropic model versions that predate native tool_use:
+// tools are described as XML in the system prompt, a
+// [functionStopSequence] stop sequence tells the model to stop generating
+// once it has listed its function calls, and the client parses
+// <function_calls> blocks back out of the assistant's text into
+// [agentframework.FunctionCallContent]. Leave unset for current models,
+// which should use the native tools field.
+func WithXMLToolProtocol() Option {
+	return func(c *clientConfig) { c.xmlToolProtocol = true }
+}
@@ -0,0 +1,182 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package anthropic
+
+import (
+	"encoding/json"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+// messagesResponse is the Anthropic Messages API response.
+type messagesResponse struct {
+	ID         string           `json:"id"`
+	Type       string           `json:"type"`
+	Role       string           `json:"role"`
+	Model      string           `json:"model"`
+	Content    []anthropicBlock `json:"content"`
+	StopReason string           `json:"stop_reason"`
+	Usage      anthropicUsage   `json:"usage"`
+}
+
+type anthropicUsage struct {
+	InputTokens              int `json:"input_tokens"`
+	OutputTokens             int `json:"output_tokens"`
+	CacheCreationInputTokens int `json:"cache_creation_input_tokens,omitempty"`
+	CacheReadInputTokens     int `json:"cache_read_input_tokens,omitempty"`
+}
+
+// parseResponse converts an Anthropic response into framework types.
+func parseResponse(raw *messagesResponse) *af.ChatResponse {
+	resp := &af.ChatResponse{
+		ResponseID:   raw.ID,
+		ModelID:      raw.Model,
+		FinishReason: mapStopReason(raw.StopReason),
+		Usage: af.UsageDetails{
+			InputTokens:         raw.Usage.InputTokens,
+			OutputTokens:        raw.Usage.OutputTokens,
+			TotalTokens:         raw.Usage.InputTokens + raw.Usage.OutputTokens,
+			CachedInputTokens:   raw.Usage.CacheReadInputTokens,
+			CacheCreationTokens: raw.Usage.CacheCreationInputTokens,
+		},
+	}
+
+	msg := af.Message{Role: af.RoleAssistant}
+	for _, b := range raw.Content {
+		switch b.Type {
+		case "text":
+			msg.Contents = append(msg.Contents, &af.TextContent{Text: b.Text})
+		case "tool_use":
+			args, _ := json.Marshal(b.Input)
+			msg.Contents = append(msg.Contents, &af.FunctionCallContent{
+				CallID:    b.ID,
+				Name:      b.Name,
+				Arguments: string(args),
+			})
+		}
+	}
+	resp.Messages = []af.Message{msg}
+	return resp
+}
+
+func mapStopReason(s string) af.FinishReason {
+	switch s {
+	case "end_turn", "stop_sequence":
+		return af.FinishReasonStop
+	case "max_tokens":
+		return af.FinishReasonLength
+	case "tool_use":
+		return af.FinishReasonToolCalls
+	default:
+		return af.FinishReason(s)
+	}
+}
+
+// --- Streaming ---
+
+// streamEvent is the envelope for Anthropic's SSE events; only the fields
+// relevant to the event's Type are populated.
+type streamEvent struct {
+	Type         string            `json:"type"`
+	Message      *messagesResponse `json:"message,omitempty"`
+	Index        int               `json:"index"`
+	ContentBlock *anthropicBlock   `json:"content_block,omitempty"`
+	Delta        *streamDelta      `json:"delta,omitempty"`
+	Usage        *anthropicUsage   `json:"usage,omitempty"`
+}
+
+type streamDelta struct {
+	Type        string `json:"type"`
+	Text        string `json:"text,omitempty"`
+	PartialJSON string `json:"partial_json,omitempty"`
+	StopReason  string `json:"stop_reason,omitempty"`
+}
+
+// streamState accumulates per-index content-block state across SSE events so
+// tool-call arguments (delivered as incremental `input_json_delta` fragments)
+// can be reassembled into a single JSON string.
+type streamState struct {
+	blockType string
+	toolID    string
+	toolName  string
+	argsBuf   string
+}
+
+// parseStreamEvent updates per-block accumulator state and returns the
+// ChatResponseUpdate (if any) that should be emitted for this event.
+func parseStreamEvent(evt *streamEvent, blocks map[int]*streamState) *af.ChatResponseUpdate {
+	switch evt.Type {
+	case "message_start":
+		if evt.Message == nil {
+			return nil
+		}
+		return &af.ChatResponseUpdate{
+			ResponseID: evt.Message.ID,
+			ModelID:    evt.Message.Model,
+			Role:       af.RoleAssistant,
+			Usage: af.UsageDetails{
+				InputTokens:         evt.Message.Usage.InputTokens,
+				CachedInputTokens:   evt.Message.Usage.CacheReadInputTokens,
+				CacheCreationTokens: evt.Message.Usage.CacheCreationInputTokens,
+			},
+		}
+
+	case "content_block_start":
+		if evt.ContentBlock == nil {
+			return nil
+		}
+		st := &streamState{blockType: evt.ContentBlock.Type}
+		if evt.ContentBlock.Type == "tool_use" {
+			st.toolID = evt.ContentBlock.ID
+			st.toolName = evt.ContentBlock.Name
+		}
+		blocks[evt.Index] = st
+		return nil
+
+	case "content_block_delta":
+		st := blocks[evt.Index]
+		if st == nil || evt.Delta == nil {
+			return nil
+		}
+		switch evt.Delta.Type {
+		case "text_delta":
+			return &af.ChatResponseUpdate{
+				Contents: af.Contents{&af.TextContent{Text: evt.Delta.Text}},
+				Role:     af.RoleAssistant,
+			}
+		case "input_json_delta":
+			st.argsBuf += evt.Delta.PartialJSON
+		}
+		return nil
+
+	case "content_block_stop":
+		st := blocks[evt.Index]
+		if st == nil || st.blockType != "tool_use" {
+			return nil
+		}
+		return &af.ChatResponseUpdate{
+			Contents: af.Contents{&af.FunctionCallContent{
+				CallID:    st.toolID,
+				Name:      st.toolName,
+				Arguments: st.argsBuf,
+			}},
+			Role: af.RoleAssistant,
+		}
+
+	case "message_delta":
+		update := &af.ChatResponseUpdate{}
+		if evt.Delta != nil && evt.Delta.StopReason != "" {
+			update.FinishReason = mapStopReason(evt.Delta.StopReason)
+		}
+		if evt.Usage != nil {
+			update.Usage = af.UsageDetails{
+				OutputTokens: evt.Usage.OutputTokens,
+				TotalTokens:  evt.Usage.OutputTokens,
+			}
+		}
+		return update
+
+	default:
+		return nil
+	}
+}
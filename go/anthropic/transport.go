@@ -0,0 +1,121 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package anthropic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+const (
+	defaultBaseURL   = "https://api.anthropic.com/v1"
+	defaultVersion   = "2023-06-01"
+	defaultMaxTokens = 4096
+)
+
+// transport is an unexported interface for HTTP communication.
+// The default implementation uses net/http; tests inject a mock.
+type transport interface {
+	do(ctx context.Context, method, path string, body any) (*http.Response, error)
+}
+
+// httpTransport is the default transport using net/http.
+type httpTransport struct {
+	client  *http.Client
+	baseURL string
+	apiKey  string
+	headers map[string]string
+}
+
+func newHTTPTransport(apiKey string, opts *clientConfig) *httpTransport {
+	t := &httpTransport{
+		client:  opts.httpClient,
+		baseURL: opts.baseURL,
+		apiKey:  apiKey,
+		headers: opts.headers,
+	}
+	if t.client == nil {
+		t.client = http.DefaultClient
+	}
+	if t.baseURL == "" {
+		t.baseURL = defaultBaseURL
+	}
+	return t
+}
+
+func (t *httpTransport) do(ctx context.Context, method, path string, body any) (*http.Response, error) {
+	var bodyReader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("marshal request: %w", err)
+		}
+		bodyReader = bytes.NewReader(b)
+	}
+
+	url := t.baseURL + path
+	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-api-key", t.apiKey)
+	req.Header.Set("anthropic-version", defaultVersion)
+	for k, v := range t.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("http request: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		return nil, parseErrorResponse(resp)
+	}
+
+	return resp, nil
+}
+
+// parseErrorResponse reads an error response body and returns a typed error.
+func parseErrorResponse(resp *http.Response) error {
+	body, _ := io.ReadAll(resp.Body)
+
+	var apiErr struct {
+		Error struct {
+			Type    string `json:"type"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	_ = json.Unmarshal(body, &apiErr)
+
+	msg := apiErr.Error.Message
+	if msg == "" {
+		msg = string(body)
+	}
+
+	svcErr := &af.ServiceError{
+		StatusCode: resp.StatusCode,
+		Message:    msg,
+		Code:       apiErr.Error.Type,
+	}
+
+	switch {
+	case resp.StatusCode == 401 || resp.StatusCode == 403:
+		svcErr.Err = af.ErrAuth
+	case resp.StatusCode == 400:
+		svcErr.Err = af.ErrInvalidRequest
+	default:
+		svcErr.Err = af.ErrService
+	}
+
+	return svcErr
+}
@@ -0,0 +1,108 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package anthropic
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+// functionStopSequence is the stop sequence legacy Anthropic models (see
+// [WithXMLToolProtocol]) are instructed to emit once they've finished
+// listing function calls.
+const functionStopSequence = "FUNCTION_STOP_SEQUENCE"
+
+// xmlToolsSystemPrompt renders tools as the XML tool-description block
+// legacy, pre-tool_use Anthropic models expect in the system prompt.
+func xmlToolsSystemPrompt(tools []af.Tool) string {
+	var b strings.Builder
+	b.WriteString("In this environment you have access to a set of tools you can use to answer the user's question.\n\n")
+	b.WriteString("You may call them like this:\n<function_calls>\n<invoke name=\"$FUNCTION_NAME\">\n<parameter name=\"$PARAMETER_NAME\">$PARAMETER_VALUE</parameter>\n...\n</invoke>\n</function_calls>\n\n")
+	b.WriteString("Here are the tools available:\n<tools>\n")
+	for _, t := range tools {
+		fmt.Fprintf(&b, "<tool_description>\n<tool_name>%s</tool_name>\n<description>%s</description>\n<parameters>%s</parameters>\n</tool_description>\n",
+			t.Name(), t.Description(), string(t.Parameters()))
+	}
+	b.WriteString("</tools>\n\n")
+	fmt.Fprintf(&b, "When you have finished issuing function calls, stop generating with %q.\n", functionStopSequence)
+	return b.String()
+}
+
+var (
+	functionCallsBlockRe = regexp.MustCompile(`(?s)<function_calls>(.*?)</function_calls>`)
+	invokeRe             = regexp.MustCompile(`(?s)<invoke name="([^"]+)">(.*?)</invoke>`)
+	parameterRe          = regexp.MustCompile(`(?s)<parameter name="([^"]+)">(.*?)</parameter>`)
+)
+
+// parseXMLFunctionCalls extracts <function_calls> blocks from text (the
+// legacy protocol enabled by [WithXMLToolProtocol]) and returns the
+// remaining text alongside the calls they contained. CallIDs are synthesized
+// since the legacy protocol has no call-ID concept of its own.
+func parseXMLFunctionCalls(text string) (cleaned string, calls []af.FunctionCallContent) {
+	matches := functionCallsBlockRe.FindAllStringSubmatchIndex(text, -1)
+	if len(matches) == 0 {
+		return text, nil
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		b.WriteString(text[last:m[0]])
+		last = m[1]
+
+		for _, im := range invokeRe.FindAllStringSubmatch(text[m[2]:m[3]], -1) {
+			args := map[string]string{}
+			for _, pm := range parameterRe.FindAllStringSubmatch(im[2], -1) {
+				args[pm[1]] = strings.TrimSpace(pm[2])
+			}
+			argJSON, _ := json.Marshal(args)
+			calls = append(calls, af.FunctionCallContent{
+				CallID:    fmt.Sprintf("xmlcall-%d", len(calls)+1),
+				Name:      im[1],
+				Arguments: string(argJSON),
+			})
+		}
+	}
+	b.WriteString(text[last:])
+	return strings.TrimSpace(b.String()), calls
+}
+
+// applyXMLToolCalls rewrites resp's assistant text, extracting any
+// <function_calls> blocks into [af.FunctionCallContent] and marking the
+// response as requiring tool calls when any were found.
+func applyXMLToolCalls(resp *af.ChatResponse) {
+	for i := range resp.Messages {
+		msg := &resp.Messages[i]
+		var contents af.Contents
+		var found []af.FunctionCallContent
+
+		for _, c := range msg.Contents {
+			tc, ok := c.(*af.TextContent)
+			if !ok {
+				contents = append(contents, c)
+				continue
+			}
+			cleaned, calls := parseXMLFunctionCalls(tc.Text)
+			if len(calls) == 0 {
+				contents = append(contents, c)
+				continue
+			}
+			if cleaned != "" {
+				contents = append(contents, &af.TextContent{Text: cleaned})
+			}
+			found = append(found, calls...)
+		}
+		if len(found) == 0 {
+			continue
+		}
+		for j := range found {
+			contents = append(contents, &found[j])
+		}
+		msg.Contents = contents
+		resp.FinishReason = af.FinishReasonToolCalls
+	}
+}
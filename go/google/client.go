@@ -0,0 +1,152 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package google
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+// Client implements [agentframework.ChatClient] using the Gemini
+// generateContent API. Use [New] to create one.
+type Client struct {
+	tp      transport
+	cfg     *clientConfig
+	handler af.ChatHandler
+}
+
+// Verify interface compliance at compile time.
+var _ af.ChatClient = (*Client)(nil)
+
+// New creates a Gemini [Client] with the given API key and options.
+//
+//	client := google.New(os.Getenv("GOOGLE_API_KEY"),
+//	    google.WithModel("gemini-2.0-flash"),
+//	)
+func New(apiKey string, opts ...Option) *Client {
+	cfg := &clientConfig{}
+	for _, o := range opts {
+		o(cfg)
+	}
+	c := &Client{
+		tp:  newHTTPTransport(apiKey, cfg),
+		cfg: cfg,
+	}
+	c.handler = c.coreResponse
+	for i := len(cfg.chatMiddleware) - 1; i >= 0; i-- {
+		c.handler = cfg.chatMiddleware[i](c.handler)
+	}
+	return c
+}
+
+// newWithTransport creates a Client with a custom transport (for testing).
+func newWithTransport(tp transport, cfg *clientConfig) *Client {
+	c := &Client{tp: tp, cfg: cfg}
+	c.handler = c.coreResponse
+	return c
+}
+
+func (c *Client) model(opts *af.ChatOptions) string {
+	if opts != nil && opts.ModelID != "" {
+		return opts.ModelID
+	}
+	return c.cfg.model
+}
+
+// Response sends a non-streaming generateContent request and returns the
+// complete response.
+func (c *Client) Response(ctx context.Context, messages []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error) {
+	return c.handler(ctx, messages, opts)
+}
+
+// coreResponse is the base implementation called by the middleware chain.
+func (c *Client) coreResponse(ctx context.Context, messages []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error) {
+	req := buildRequest(messages, opts, c.cfg)
+	path := fmt.Sprintf("/models/%s:generateContent", c.model(opts))
+
+	resp, err := c.tp.do(ctx, "POST", path, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: read response body: %v", af.ErrService, err)
+	}
+
+	var raw generateContentResponse
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("%w: parse response: %v", af.ErrService, err)
+	}
+
+	result := parseResponse(&raw)
+	result.Raw = &raw
+	return result, nil
+}
+
+// StreamResponse sends a streaming generateContent request and returns a
+// [af.ResponseStream] that yields incremental updates via server-sent events.
+func (c *Client) StreamResponse(ctx context.Context, messages []af.Message, opts *af.ChatOptions) (*af.ResponseStream[af.ChatResponseUpdate], error) {
+	req := buildRequest(messages, opts, c.cfg)
+	path := fmt.Sprintf("/models/%s:streamGenerateContent?alt=sse", c.model(opts))
+
+	resp, err := c.tp.do(ctx, "POST", path, req)
+	if err != nil {
+		return nil, err
+	}
+
+	stream := af.NewResponseStream[af.ChatResponseUpdate](ctx, func(ctx context.Context, ch chan<- af.ChatResponseUpdate) error {
+		defer resp.Body.Close()
+		return parseSSEStream(ctx, resp.Body, ch)
+	})
+
+	return stream, nil
+}
+
+// parseSSEStream reads Gemini server-sent events from r and sends parsed
+// updates to ch. Each `data:` line is a complete generateContentResponse
+// JSON object (see [parseStreamChunk]); the stream ends when r is exhausted.
+func parseSSEStream(ctx context.Context, r io.Reader, ch chan<- af.ChatResponseUpdate) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if !strings.HasPrefix(line, "data: ") {
+			continue
+		}
+		data := strings.TrimSpace(strings.TrimPrefix(line, "data: "))
+		if data == "" {
+			continue
+		}
+
+		var raw generateContentResponse
+		if err := json.Unmarshal([]byte(data), &raw); err != nil {
+			// Skip malformed chunks rather than aborting.
+			continue
+		}
+
+		update := parseStreamChunk(&raw)
+		update.Raw = &raw
+
+		select {
+		case ch <- *update:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("%w: read SSE stream: %v", af.ErrService, err)
+	}
+
+	return nil
+}
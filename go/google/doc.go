@@ -0,0 +1,30 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+// Package google provides a [agentframework.ChatClient] implementation for
+// the Gemini generateContent API, with first-class support for
+// functionCall / functionResponse content parts.
+//
+// Create a client and pass it to [agentframework.NewAgent]:
+//
+//	client := google.New(os.Getenv("GOOGLE_API_KEY"),
+//	    google.WithModel("gemini-2.0-flash"),
+//	)
+//
+//	agent := agentframework.NewAgent(client)
+//
+// The client supports both synchronous and streaming responses, tool
+// calling, and the standard ChatOptions fields. Like the anthropic package,
+// it maps [agentframework.FunctionCallContent] and
+// [agentframework.FunctionResultContent] directly onto Gemini's structured
+// functionCall / functionResponse parts, rather than relying on the
+// JSON-in-text workaround used for models without native tool support.
+//
+// # Configuration
+//
+// Use functional options to configure the client:
+//
+//   - [WithModel]: set the default model
+//   - [WithBaseURL]: override the API endpoint
+//   - [WithHTTPClient]: provide a custom http.Client
+//   - [WithHeaders]: add custom headers to every request
+package google
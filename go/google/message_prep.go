@@ -0,0 +1,266 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package google
+
+import (
+	"encoding/json"
+	"strings"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+// generateContentRequest is the Gemini generateContent API request body.
+type generateContentRequest struct {
+	Contents          []geminiContent   `json:"contents"`
+	SystemInstruction *geminiContent    `json:"systemInstruction,omitempty"`
+	Tools             []geminiTool      `json:"tools,omitempty"`
+	ToolConfig        *geminiToolConfig `json:"toolConfig,omitempty"`
+	GenerationConfig  *generationConfig `json:"generationConfig,omitempty"`
+}
+
+type generationConfig struct {
+	Temperature      *float64 `json:"temperature,omitempty"`
+	TopP             *float64 `json:"topP,omitempty"`
+	MaxOutputTokens  *int     `json:"maxOutputTokens,omitempty"`
+	StopSequences    []string `json:"stopSequences,omitempty"`
+	Seed             *int     `json:"seed,omitempty"`
+	FrequencyPenalty *float64 `json:"frequencyPenalty,omitempty"`
+	PresencePenalty  *float64 `json:"presencePenalty,omitempty"`
+}
+
+type geminiContent struct {
+	Role  string       `json:"role,omitempty"`
+	Parts []geminiPart `json:"parts"`
+}
+
+// geminiPart is a single part within a Content. Only the field relevant to
+// the part's kind is populated, matching Gemini's oneof `part` shape.
+type geminiPart struct {
+	Text             string              `json:"text,omitempty"`
+	InlineData       *geminiBlob         `json:"inlineData,omitempty"`
+	FunctionCall     *geminiFunctionCall `json:"functionCall,omitempty"`
+	FunctionResponse *geminiFuncResponse `json:"functionResponse,omitempty"`
+}
+
+type geminiBlob struct {
+	MimeType string `json:"mimeType"`
+	Data     string `json:"data"`
+}
+
+type geminiFunctionCall struct {
+	Name string `json:"name"`
+	Args any    `json:"args,omitempty"`
+}
+
+type geminiFuncResponse struct {
+	Name     string `json:"name"`
+	Response any    `json:"response"`
+}
+
+type geminiTool struct {
+	FunctionDeclarations []geminiFunctionDecl `json:"functionDeclarations,omitempty"`
+}
+
+type geminiFunctionDecl struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Parameters  json.RawMessage `json:"parameters,omitempty"`
+}
+
+type geminiToolConfig struct {
+	FunctionCallingConfig *functionCallingConfig `json:"functionCallingConfig,omitempty"`
+}
+
+type functionCallingConfig struct {
+	Mode                 string   `json:"mode,omitempty"`
+	AllowedFunctionNames []string `json:"allowedFunctionNames,omitempty"`
+}
+
+// buildRequest converts framework types into a Gemini generateContent request.
+func buildRequest(messages []af.Message, opts *af.ChatOptions, cfg *clientConfig) *generateContentRequest {
+	req := &generateContentRequest{}
+
+	var system []string
+	var rest []af.Message
+	for _, m := range messages {
+		if m.Role == af.RoleSystem {
+			if t := m.Text(); t != "" {
+				system = append(system, t)
+			}
+			continue
+		}
+		rest = append(rest, m)
+	}
+	if systemText := strings.Join(system, "\n"); systemText != "" {
+		req.SystemInstruction = &geminiContent{Parts: []geminiPart{{Text: systemText}}}
+	}
+
+	if opts != nil {
+		if opts.Instructions != "" {
+			instr := opts.Instructions
+			if req.SystemInstruction != nil {
+				instr = req.SystemInstruction.Parts[0].Text + "\n" + instr
+			}
+			req.SystemInstruction = &geminiContent{Parts: []geminiPart{{Text: instr}}}
+		}
+
+		gc := &generationConfig{
+			Temperature:      opts.Temperature,
+			TopP:             opts.TopP,
+			StopSequences:    opts.Stop,
+			Seed:             opts.Seed,
+			FrequencyPenalty: opts.FrequencyPenalty,
+			PresencePenalty:  opts.PresencePenalty,
+		}
+		if opts.MaxTokens != nil {
+			gc.MaxOutputTokens = opts.MaxTokens
+		}
+		req.GenerationConfig = gc
+
+		for _, t := range opts.Tools {
+			req.Tools = append(req.Tools, geminiTool{FunctionDeclarations: []geminiFunctionDecl{{
+				Name:        t.Name(),
+				Description: t.Description(),
+				Parameters:  t.Parameters(),
+			}}})
+		}
+		if tc := convertToolChoice(opts.ToolChoice); tc != nil {
+			req.ToolConfig = tc
+		}
+	}
+
+	req.Contents = convertMessages(rest)
+	return req
+}
+
+// convertMessages translates framework Messages into Gemini contents,
+// merging consecutive same-role messages into a single Content — Gemini
+// requires contents to strictly alternate user/model turns, the analogue of
+// the assistant-continuation handling in the lmcli api package's
+// IsAssistantContinuation helper — and routing tool results onto a
+// dedicated "function" role content rather than folding them into "user"
+// as Anthropic does, since Gemini's API reserves a separate role for them.
+func convertMessages(messages []af.Message) []geminiContent {
+	var result []geminiContent
+	callNames := make(map[string]string) // call ID -> function name, for functionResponse parts
+
+	for _, msg := range messages {
+		parts := convertContentParts(msg.Contents, callNames)
+		if len(parts) == 0 {
+			continue
+		}
+
+		role := geminiRole(msg.Role)
+
+		if len(result) > 0 && result[len(result)-1].Role == role {
+			last := &result[len(result)-1]
+			last.Parts = append(last.Parts, parts...)
+			continue
+		}
+
+		result = append(result, geminiContent{Role: role, Parts: parts})
+	}
+
+	return result
+}
+
+func geminiRole(role af.Role) string {
+	switch role {
+	case af.RoleAssistant:
+		return "model"
+	case af.RoleTool:
+		return "function"
+	default:
+		return "user"
+	}
+}
+
+// convertContentParts converts framework Content items into Gemini parts.
+// callNames records each [af.FunctionCallContent]'s CallID -> Name as calls
+// are seen, since [af.FunctionResultContent] carries only a CallID but
+// Gemini's functionResponse part is keyed by function name.
+func convertContentParts(contents af.Contents, callNames map[string]string) []geminiPart {
+	var parts []geminiPart
+	for _, c := range contents {
+		switch v := c.(type) {
+		case *af.TextContent:
+			if v.Text != "" {
+				parts = append(parts, geminiPart{Text: v.Text})
+			}
+		case *af.TextReasoningContent:
+			if v.Text != "" {
+				parts = append(parts, geminiPart{Text: v.Text})
+			}
+		case *af.DataContent:
+			parts = append(parts, geminiPart{InlineData: dataURIToBlob(v.URI, v.MediaType)})
+		case *af.FunctionCallContent:
+			// The framework's Arguments field is a JSON-encoded string; the
+			// Gemini API wants the parsed object as `args`.
+			var args any
+			if v.Arguments != "" {
+				_ = json.Unmarshal([]byte(v.Arguments), &args)
+			} else {
+				args = map[string]any{}
+			}
+			callNames[v.CallID] = v.Name
+			parts = append(parts, geminiPart{FunctionCall: &geminiFunctionCall{Name: v.Name, Args: args}})
+		case *af.FunctionResultContent:
+			parts = append(parts, geminiPart{FunctionResponse: &geminiFuncResponse{
+				Name:     callNames[v.CallID],
+				Response: resultToResponse(v.Result),
+			}})
+		}
+	}
+	return parts
+}
+
+// dataURIToBlob converts a `data:<mediaType>;base64,<data>` URI into a
+// Gemini inline-data blob.
+func dataURIToBlob(uri, mediaType string) *geminiBlob {
+	const prefix = "data:"
+	if !strings.HasPrefix(uri, prefix) {
+		return &geminiBlob{MimeType: mediaType, Data: uri}
+	}
+	rest := strings.TrimPrefix(uri, prefix)
+	parts := strings.SplitN(rest, ",", 2)
+	if len(parts) != 2 {
+		return &geminiBlob{MimeType: mediaType, Data: uri}
+	}
+	meta, data := parts[0], parts[1]
+	meta = strings.TrimSuffix(meta, ";base64")
+	if mediaType == "" {
+		mediaType = meta
+	}
+	return &geminiBlob{MimeType: mediaType, Data: data}
+}
+
+// resultToResponse wraps a tool result in the object shape Gemini's
+// functionResponse part requires; non-object results are wrapped under a
+// "result" key.
+func resultToResponse(v any) any {
+	if m, ok := v.(map[string]any); ok {
+		return m
+	}
+	return map[string]any{"result": v}
+}
+
+func convertToolChoice(tc af.ToolChoice) *geminiToolConfig {
+	if tc == "" {
+		return nil
+	}
+	switch tc {
+	case af.ToolChoiceAuto:
+		return &geminiToolConfig{FunctionCallingConfig: &functionCallingConfig{Mode: "AUTO"}}
+	case af.ToolChoiceRequired:
+		return &geminiToolConfig{FunctionCallingConfig: &functionCallingConfig{Mode: "ANY"}}
+	case af.ToolChoiceNone:
+		return &geminiToolConfig{FunctionCallingConfig: &functionCallingConfig{Mode: "NONE"}}
+	default:
+		s := string(tc)
+		if strings.HasPrefix(s, "function:") {
+			name := strings.TrimPrefix(s, "function:")
+			return &geminiToolConfig{FunctionCallingConfig: &functionCallingConfig{Mode: "ANY", AllowedFunctionNames: []string{name}}}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package google
+
+import (
+	"net/http"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+// clientConfig holds resolved configuration for the Gemini client.
+type clientConfig struct {
+	baseURL        string
+	httpClient     *http.Client
+	headers        map[string]string
+	model          string
+	chatMiddleware []af.ChatMiddleware
+}
+
+// Option configures a Gemini [Client].
+type Option func(*clientConfig)
+
+// WithBaseURL overrides the API base URL (e.g., for a proxy or gateway).
+func WithBaseURL(url string) Option {
+	return func(c *clientConfig) { c.baseURL = url }
+}
+
+// WithHTTPClient provides a custom http.Client for requests.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *clientConfig) { c.httpClient = client }
+}
+
+// WithHeaders adds custom headers to every request.
+func WithHeaders(headers map[string]string) Option {
+	return func(c *clientConfig) { c.headers = headers }
+}
+
+// WithModel sets the default model for requests.
+func WithModel(model string) Option {
+	return func(c *clientConfig) { c.model = model }
+}
+
+// WithChatMiddleware adds middleware to the chat pipeline.
+// Middleware is applied in the order provided (first = outermost).
+func WithChatMiddleware(mw ...af.ChatMiddleware) Option {
+	return func(c *clientConfig) { c.chatMiddleware = append(c.chatMiddleware, mw...) }
+}
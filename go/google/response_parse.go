@@ -0,0 +1,122 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package google
+
+import (
+	"encoding/json"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+// generateContentResponse is the Gemini generateContent API response.
+type generateContentResponse struct {
+	Candidates    []geminiCandidate `json:"candidates"`
+	ModelVersion  string            `json:"modelVersion"`
+	ResponseID    string            `json:"responseId"`
+	UsageMetadata geminiUsage       `json:"usageMetadata"`
+}
+
+type geminiCandidate struct {
+	Content      geminiContent `json:"content"`
+	FinishReason string        `json:"finishReason"`
+}
+
+type geminiUsage struct {
+	PromptTokenCount        int `json:"promptTokenCount"`
+	CandidatesTokenCount    int `json:"candidatesTokenCount"`
+	TotalTokenCount         int `json:"totalTokenCount"`
+	CachedContentTokenCount int `json:"cachedContentTokenCount,omitempty"`
+}
+
+// parseResponse converts a Gemini response into framework types.
+func parseResponse(raw *generateContentResponse) *af.ChatResponse {
+	resp := &af.ChatResponse{
+		ResponseID: raw.ResponseID,
+		ModelID:    raw.ModelVersion,
+		Usage: af.UsageDetails{
+			InputTokens:       raw.UsageMetadata.PromptTokenCount,
+			OutputTokens:      raw.UsageMetadata.CandidatesTokenCount,
+			TotalTokens:       raw.UsageMetadata.TotalTokenCount,
+			CachedInputTokens: raw.UsageMetadata.CachedContentTokenCount,
+		},
+	}
+
+	if len(raw.Candidates) == 0 {
+		return resp
+	}
+	cand := raw.Candidates[0]
+	resp.FinishReason = mapFinishReason(cand.FinishReason)
+
+	msg := af.Message{Role: af.RoleAssistant}
+	for _, p := range cand.Content.Parts {
+		switch {
+		case p.Text != "":
+			msg.Contents = append(msg.Contents, &af.TextContent{Text: p.Text})
+		case p.FunctionCall != nil:
+			args, _ := json.Marshal(p.FunctionCall.Args)
+			msg.Contents = append(msg.Contents, &af.FunctionCallContent{
+				CallID:    p.FunctionCall.Name,
+				Name:      p.FunctionCall.Name,
+				Arguments: string(args),
+			})
+		}
+	}
+	resp.Messages = []af.Message{msg}
+	return resp
+}
+
+func mapFinishReason(s string) af.FinishReason {
+	switch s {
+	case "STOP":
+		return af.FinishReasonStop
+	case "MAX_TOKENS":
+		return af.FinishReasonLength
+	case "":
+		return ""
+	default:
+		return af.FinishReason(s)
+	}
+}
+
+// --- Streaming ---
+//
+// Gemini's streamGenerateContent?alt=sse endpoint emits one complete
+// generateContentResponse JSON object per SSE `data:` line — each carries
+// the candidate parts generated since the previous line, rather than
+// Anthropic's typed block events. parseStreamChunk treats each line as a
+// self-contained update, whose text [af.ChatResponseFromUpdates] appends
+// to the text of the updates before it.
+
+func parseStreamChunk(raw *generateContentResponse) *af.ChatResponseUpdate {
+	update := &af.ChatResponseUpdate{
+		ResponseID: raw.ResponseID,
+		ModelID:    raw.ModelVersion,
+		Role:       af.RoleAssistant,
+		Usage: af.UsageDetails{
+			InputTokens:  raw.UsageMetadata.PromptTokenCount,
+			OutputTokens: raw.UsageMetadata.CandidatesTokenCount,
+			TotalTokens:  raw.UsageMetadata.TotalTokenCount,
+		},
+	}
+
+	if len(raw.Candidates) == 0 {
+		return update
+	}
+	cand := raw.Candidates[0]
+	update.FinishReason = mapFinishReason(cand.FinishReason)
+
+	for _, p := range cand.Content.Parts {
+		switch {
+		case p.Text != "":
+			update.Contents = append(update.Contents, &af.TextContent{Text: p.Text})
+		case p.FunctionCall != nil:
+			args, _ := json.Marshal(p.FunctionCall.Args)
+			update.Contents = append(update.Contents, &af.FunctionCallContent{
+				CallID:    p.FunctionCall.Name,
+				Name:      p.FunctionCall.Name,
+				Arguments: string(args),
+			})
+		}
+	}
+	return update
+}
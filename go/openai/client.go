@@ -10,12 +10,9 @@
 package openai
 
 import (
-	"bufio"
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
-	"strings"
 
 	af "github.com/microsoft/agent-framework/go/agentframework"
 )
@@ -23,9 +20,10 @@ import (
 // Client implements [agentframework.ChatClient] using the OpenAI Chat
 // Completions API. Use [New] to create one.
 type Client struct {
-	tp      transport
-	model   string
-	handler af.ChatHandler
+	tp                 transport
+	model              string
+	handler            af.ChatHandler
+	functionCallParser FunctionCallParser
 }
 
 // Verify interface compliance at compile time.
@@ -42,8 +40,9 @@ func New(apiKey string, opts ...Option) *Client {
 		o(cfg)
 	}
 	c := &Client{
-		tp:    newHTTPTransport(apiKey, cfg),
-		model: cfg.model,
+		tp:                 newHTTPTransport(apiKey, cfg),
+		model:              cfg.model,
+		functionCallParser: cfg.functionCallParser,
 	}
 	// Set up core handler
 	c.handler = c.coreResponse
@@ -69,7 +68,7 @@ func (c *Client) Response(ctx context.Context, messages []af.Message, opts *af.C
 
 // coreResponse is the base implementation called by the middleware chain.
 func (c *Client) coreResponse(ctx context.Context, messages []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error) {
-	req := buildRequest(messages, opts, c.model)
+	req := buildRequest(messages, opts, c.model, c.functionCallParser)
 	req.Stream = false
 
 	resp, err := c.tp.do(ctx, "POST", "/chat/completions", req)
@@ -90,72 +89,85 @@ func (c *Client) coreResponse(ctx context.Context, messages []af.Message, opts *
 
 	result := parseChatResponse(raw)
 	result.Raw = raw
-	return result, nil
-}
-
-// StreamResponse sends a streaming chat completion request and returns
-// a [ResponseStream] that yields incremental updates via server-sent events.
-func (c *Client) StreamResponse(ctx context.Context, messages []af.Message, opts *af.ChatOptions) (*af.ResponseStream[af.ChatResponseUpdate], error) {
-	req := buildRequest(messages, opts, c.model)
-	req.Stream = true
-	req.StreamOptions = &streamOptions{IncludeUsage: true}
-
-	resp, err := c.tp.do(ctx, "POST", "/chat/completions", req)
-	if err != nil {
-		return nil, err
+	if c.functionCallParser != nil {
+		applyFunctionCallParser(result, c.functionCallParser)
 	}
-
-	stream := af.NewResponseStream[af.ChatResponseUpdate](ctx, func(ctx context.Context, ch chan<- af.ChatResponseUpdate) error {
-		defer resp.Body.Close()
-		return parseSSEStream(ctx, resp.Body, ch)
-	})
-
-	return stream, nil
+	return result, nil
 }
 
-// parseSSEStream reads OpenAI server-sent events from r and sends parsed
-// updates to ch. It returns when the stream is exhausted ([DONE]),
-// the context is cancelled, or an error occurs.
-func parseSSEStream(ctx context.Context, r io.Reader, ch chan<- af.ChatResponseUpdate) error {
-	scanner := bufio.NewScanner(r)
-	// Allow large SSE lines (some responses can be substantial).
-	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
-
-	for scanner.Scan() {
-		line := scanner.Text()
-
-		// SSE format: lines starting with "data: "
-		if !strings.HasPrefix(line, "data: ") {
+// applyFunctionCallParser scans result's assistant messages for a
+// text-based function-call encoding and converts any it finds into
+// [af.FunctionCallContent], for providers that don't emit OpenAI's
+// `tool_calls` field natively.
+func applyFunctionCallParser(result *af.ChatResponse, parser FunctionCallParser) {
+	for i := range result.Messages {
+		msg := &result.Messages[i]
+		if msg.Role != af.RoleAssistant || len(msg.Contents) != 1 {
 			continue
 		}
-
-		data := strings.TrimPrefix(line, "data: ")
-		data = strings.TrimSpace(data)
-
-		// Stream terminator.
-		if data == "[DONE]" {
-			return nil
+		text, ok := msg.Contents[0].(*af.TextContent)
+		if !ok {
+			continue
 		}
 
-		var chunk chatCompletionChunk
-		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
-			// Skip malformed chunks rather than aborting.
+		calls, remaining, ok := parser.ParseCalls(text.Text)
+		if !ok {
 			continue
 		}
 
-		update := parseChunk(&chunk)
-		update.Raw = &chunk
-
-		select {
-		case ch <- *update:
-		case <-ctx.Done():
-			return ctx.Err()
+		contents := make(af.Contents, 0, len(calls)+1)
+		if remaining != "" {
+			contents = append(contents, &af.TextContent{Text: remaining})
+		}
+		for _, call := range calls {
+			contents = append(contents, call)
 		}
+		msg.Contents = contents
+		result.FinishReason = af.FinishReasonToolCalls
+	}
+}
+
+// StreamResponse sends a streaming chat completion request and returns
+// a [ResponseStream] that yields incremental updates via server-sent events.
+func (c *Client) StreamResponse(ctx context.Context, messages []af.Message, opts *af.ChatOptions) (*af.ResponseStream[af.ChatResponseUpdate], error) {
+	reader, err := c.StreamChat(ctx, messages, opts)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := scanner.Err(); err != nil {
-		return fmt.Errorf("%w: read SSE stream: %v", af.ErrService, err)
+	return af.NewResponseStream[af.ChatResponseUpdate](ctx, func(ctx context.Context, ch chan<- af.ChatResponseUpdate) error {
+		defer reader.Close()
+		for {
+			update, ok, err := reader.Next()
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return nil
+			}
+			select {
+			case ch <- update:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}), nil
+}
+
+// StreamChat sends a streaming chat completion request and returns a
+// [StreamReader] over the raw server-sent event stream. Most callers should
+// use [Client.StreamResponse] (which satisfies [af.ChatClient]); StreamChat
+// is useful when a caller wants to pull updates or call [StreamReader.Aggregate]
+// without going through a [af.ResponseStream].
+func (c *Client) StreamChat(ctx context.Context, messages []af.Message, opts *af.ChatOptions) (*StreamReader, error) {
+	req := buildRequest(messages, opts, c.model, c.functionCallParser)
+	req.Stream = true
+	req.StreamOptions = &streamOptions{IncludeUsage: true}
+
+	resp, err := c.tp.do(ctx, "POST", "/chat/completions", req)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	return newStreamReader(resp.Body), nil
 }
@@ -39,9 +39,9 @@ func jsonResponse(status int, body any) *http.Response {
 func TestClient_Response_Basic(t *testing.T) {
 	content := "Hello, I'm an AI assistant!"
 	apiResp := map[string]any{
-		"id":      "chatcmpl-123",
-		"object":  "chat.completion",
-		"model":   "gpt-4o",
+		"id":     "chatcmpl-123",
+		"object": "chat.completion",
+		"model":  "gpt-4o",
 		"choices": []map[string]any{{
 			"index":         0,
 			"finish_reason": "stop",
@@ -211,12 +211,34 @@ func TestClient_Response_ErrorMapping(t *testing.T) {
 				"error": map[string]any{
 					"message": "content filtered",
 					"code":    "content_filter",
+					"innererror": map[string]any{
+						"content_filter_result": map[string]any{
+							"violence": map[string]any{"filtered": true, "severity": "high", "detected": true},
+						},
+					},
 				},
 			},
 			checkErr: func(t *testing.T, err error) {
 				if err == nil {
 					t.Fatal("expected error")
 				}
+				if !errors.Is(err, af.ErrContentFilter) {
+					t.Fatal("expected ErrContentFilter")
+				}
+				var svcErr *af.ServiceError
+				if !errors.As(err, &svcErr) {
+					t.Fatal("expected ServiceError")
+				}
+				cf, ok := svcErr.Details.(*af.ContentFilterResultContent)
+				if !ok {
+					t.Fatalf("Details = %T, want *af.ContentFilterResultContent", svcErr.Details)
+				}
+				if !cf.Violence.Filtered || !cf.Violence.Detected || cf.Violence.Severity != "high" {
+					t.Errorf("Violence = %+v", cf.Violence)
+				}
+				if cf.Hate.Filtered {
+					t.Errorf("Hate should be unfiltered by default, got %+v", cf.Hate)
+				}
 			},
 		},
 	}
@@ -312,6 +334,238 @@ func TestClient_StreamResponse(t *testing.T) {
 	}
 }
 
+func TestClient_Response_ResponseFormatJSONSchema(t *testing.T) {
+	var reqBody map[string]any
+	httpClient := newMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		body, _ := io.ReadAll(req.Body)
+		json.Unmarshal(body, &reqBody)
+		return jsonResponse(200, map[string]any{
+			"id": "chatcmpl-1", "model": "gpt-4o",
+			"choices": []map[string]any{{
+				"index": 0, "finish_reason": "stop",
+				"message": map[string]any{"role": "assistant", "content": `{"city":"Seattle"}`},
+			}},
+		}), nil
+	})
+
+	client := openai.New("test-key",
+		openai.WithModel("gpt-4o"),
+		openai.WithHTTPClient(httpClient),
+	)
+
+	_, err := client.Response(context.Background(),
+		[]af.Message{af.NewUserMessage("where?")},
+		&af.ChatOptions{
+			ResponseFormat: &af.ResponseFormatJSONSchema{
+				Name:   "location",
+				Schema: json.RawMessage(`{"type":"object"}`),
+				Strict: true,
+			},
+		},
+	)
+	if err != nil {
+		t.Fatalf("Response: %v", err)
+	}
+
+	rf, ok := reqBody["response_format"].(map[string]any)
+	if !ok {
+		t.Fatalf("response_format = %v", reqBody["response_format"])
+	}
+	if rf["type"] != "json_schema" {
+		t.Errorf("response_format.type = %v", rf["type"])
+	}
+	schema, ok := rf["json_schema"].(map[string]any)
+	if !ok {
+		t.Fatalf("json_schema = %v", rf["json_schema"])
+	}
+	if schema["name"] != "location" {
+		t.Errorf("json_schema.name = %v", schema["name"])
+	}
+	if schema["strict"] != true {
+		t.Errorf("json_schema.strict = %v", schema["strict"])
+	}
+}
+
+func TestClient_Response_ResponseFormatGrammar(t *testing.T) {
+	var reqBody map[string]any
+	httpClient := newMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		body, _ := io.ReadAll(req.Body)
+		json.Unmarshal(body, &reqBody)
+		return jsonResponse(200, map[string]any{
+			"id": "chatcmpl-1", "model": "gpt-4o",
+			"choices": []map[string]any{{
+				"index": 0, "finish_reason": "stop",
+				"message": map[string]any{"role": "assistant", "content": "ok"},
+			}},
+		}), nil
+	})
+
+	client := openai.New("test-key",
+		openai.WithModel("gpt-4o"),
+		openai.WithHTTPClient(httpClient),
+	)
+
+	_, err := client.Response(context.Background(),
+		[]af.Message{af.NewUserMessage("hi")},
+		&af.ChatOptions{
+			ResponseFormat: &af.ResponseFormatGrammar{GBNF: `root ::= "yes" | "no"`},
+		},
+	)
+	if err != nil {
+		t.Fatalf("Response: %v", err)
+	}
+
+	if reqBody["grammar"] != `root ::= "yes" | "no"` {
+		t.Errorf("grammar = %v", reqBody["grammar"])
+	}
+	if _, ok := reqBody["response_format"]; ok {
+		t.Errorf("response_format should be absent when grammar is set, got %v", reqBody["response_format"])
+	}
+}
+
+func TestClient_StreamResponse_ToolCallDeltas(t *testing.T) {
+	sseData := strings.Join([]string{
+		`data: {"id":"chatcmpl-2","model":"gpt-4o","choices":[{"index":0,"delta":{"role":"assistant","tool_calls":[{"index":0,"id":"call_1","type":"function","function":{"name":"get_weather","arguments":""}}]},"finish_reason":null}]}`,
+		``,
+		`data: {"id":"chatcmpl-2","model":"gpt-4o","choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"function":{"arguments":"{\"city\":"}}]},"finish_reason":null}]}`,
+		``,
+		`data: {"id":"chatcmpl-2","model":"gpt-4o","choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"function":{"arguments":"\"Seattle\"}"}}]},"finish_reason":null}]}`,
+		``,
+		`data: {"id":"chatcmpl-2","model":"gpt-4o","choices":[{"index":0,"delta":{},"finish_reason":"tool_calls"}]}`,
+		``,
+		`data: [DONE]`,
+		``,
+	}, "\n")
+
+	httpClient := newMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: 200,
+			Header:     http.Header{"Content-Type": []string{"text/event-stream"}},
+			Body:       io.NopCloser(strings.NewReader(sseData)),
+		}, nil
+	})
+
+	client := openai.New("test-key",
+		openai.WithModel("gpt-4o"),
+		openai.WithHTTPClient(httpClient),
+	)
+
+	stream, err := client.StreamResponse(context.Background(),
+		[]af.Message{af.NewUserMessage("weather?")},
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("StreamResponse: %v", err)
+	}
+	defer stream.Close()
+
+	updates, err := stream.Collect(context.Background())
+	if err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+
+	// The two argument-only deltas carry no role, text, finish reason, or
+	// usage, so they shouldn't surface as updates on their own; the call
+	// should only appear once, assembled, on the finish_reason update.
+	resp := af.ChatResponseFromUpdates(updates)
+	if len(resp.Messages) != 1 {
+		t.Fatalf("messages = %d", len(resp.Messages))
+	}
+
+	msg := resp.Messages[0]
+	if len(msg.Contents) != 1 {
+		t.Fatalf("contents = %d", len(msg.Contents))
+	}
+
+	fc, ok := msg.Contents[0].(*af.FunctionCallContent)
+	if !ok {
+		t.Fatalf("content type = %T", msg.Contents[0])
+	}
+	if fc.CallID != "call_1" {
+		t.Errorf("CallID = %q", fc.CallID)
+	}
+	if fc.Name != "get_weather" {
+		t.Errorf("Name = %q", fc.Name)
+	}
+	if fc.Arguments != `{"city":"Seattle"}` {
+		t.Errorf("Arguments = %q", fc.Arguments)
+	}
+}
+
+func TestClient_StreamResponse_ToolCallTransitionFlushesEarly(t *testing.T) {
+	sseData := strings.Join([]string{
+		`data: {"id":"chatcmpl-3","model":"gpt-4o","choices":[{"index":0,"delta":{"role":"assistant","tool_calls":[{"index":0,"id":"call_1","type":"function","function":{"name":"get_weather","arguments":"{\"city\":\"Seattle\"}"}}]},"finish_reason":null}]}`,
+		``,
+		`data: {"id":"chatcmpl-3","model":"gpt-4o","choices":[{"index":0,"delta":{"tool_calls":[{"index":1,"id":"call_2","type":"function","function":{"name":"get_time","arguments":"{}"}}]},"finish_reason":null}]}`,
+		``,
+		`data: {"id":"chatcmpl-3","model":"gpt-4o","choices":[{"index":0,"delta":{},"finish_reason":"tool_calls"}]}`,
+		``,
+		`data: [DONE]`,
+		``,
+	}, "\n")
+
+	httpClient := newMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: 200,
+			Header:     http.Header{"Content-Type": []string{"text/event-stream"}},
+			Body:       io.NopCloser(strings.NewReader(sseData)),
+		}, nil
+	})
+
+	client := openai.New("test-key",
+		openai.WithModel("gpt-4o"),
+		openai.WithHTTPClient(httpClient),
+	)
+
+	stream, err := client.StreamChat(context.Background(),
+		[]af.Message{af.NewUserMessage("weather and time?")},
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("StreamChat: %v", err)
+	}
+	defer stream.Close()
+
+	// call_1 should be emitted as soon as the delta for call_2 arrives,
+	// rather than waiting for finish_reason.
+	update, ok, err := stream.Next()
+	if err != nil {
+		t.Fatalf("Next: %v", err)
+	}
+	if !ok {
+		t.Fatal("expected an update")
+	}
+
+	update, ok, err = stream.Next()
+	if err != nil {
+		t.Fatalf("Next: %v", err)
+	}
+	if !ok {
+		t.Fatal("expected an update")
+	}
+	if len(update.Contents) != 1 {
+		t.Fatalf("contents = %d, want 1 (call_1 flushed on transition)", len(update.Contents))
+	}
+	fc, ok := update.Contents[0].(*af.FunctionCallContent)
+	if !ok {
+		t.Fatalf("content type = %T", update.Contents[0])
+	}
+	if fc.CallID != "call_1" {
+		t.Errorf("CallID = %q, want call_1", fc.CallID)
+	}
+	if fc.Arguments != `{"city":"Seattle"}` {
+		t.Errorf("Arguments = %q", fc.Arguments)
+	}
+
+	resp, err := stream.Aggregate()
+	if err != nil {
+		t.Fatalf("Aggregate: %v", err)
+	}
+	if len(resp.Messages) != 1 || len(resp.Messages[0].Contents) != 2 {
+		t.Fatalf("resp = %+v", resp)
+	}
+}
+
 func TestClient_WithOptions(t *testing.T) {
 	var sentOrg string
 	httpClient := newMockHTTPClient(func(req *http.Request) (*http.Response, error) {
@@ -0,0 +1,94 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+// FileStore implements [af.FileStore] against the OpenAI Files API. The
+// API has no notion of independently-addressable chunks or byte-range
+// reads, so FileStore doesn't implement [af.ChunkedFileStore] or
+// [af.RangedFileStore]; [af.UploadInParallel] and [af.DownloadInParallel]
+// fall back to a single Upload/Download call for it.
+type FileStore struct {
+	tp      transport
+	purpose string
+}
+
+// NewFileStore creates a [FileStore] with the given API key and options.
+// Purpose sets the Files API "purpose" field attached to every upload
+// (e.g. "assistants", "vision", "batch"); it defaults to "assistants",
+// the purpose expected by code interpreter and vector store tools.
+func NewFileStore(apiKey string, purpose string, opts ...Option) *FileStore {
+	cfg := &clientConfig{}
+	for _, o := range opts {
+		o(cfg)
+	}
+	if purpose == "" {
+		purpose = "assistants"
+	}
+	return &FileStore{tp: newHTTPTransport(apiKey, cfg), purpose: purpose}
+}
+
+var _ af.FileStore = (*FileStore)(nil)
+
+// Upload uploads r as a new file and returns its file ID.
+func (s *FileStore) Upload(ctx context.Context, r io.Reader, opts af.UploadOptions) (string, error) {
+	filename := opts.Filename
+	if filename == "" {
+		filename = "upload"
+	}
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	if err := w.WriteField("purpose", s.purpose); err != nil {
+		return "", fmt.Errorf("%w: write purpose field: %v", af.ErrFileStore, err)
+	}
+	part, err := w.CreateFormFile("file", filename)
+	if err != nil {
+		return "", fmt.Errorf("%w: create form file: %v", af.ErrFileStore, err)
+	}
+	if _, err := io.Copy(part, r); err != nil {
+		return "", fmt.Errorf("%w: write file: %v", af.ErrFileStore, err)
+	}
+	if err := w.Close(); err != nil {
+		return "", fmt.Errorf("%w: close multipart writer: %v", af.ErrFileStore, err)
+	}
+
+	resp, err := s.tp.doMultipart(ctx, http.MethodPost, "/files", w.FormDataContentType(), &body)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var file struct {
+		ID string `json:"id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&file); err != nil {
+		return "", fmt.Errorf("%w: parse upload response: %v", af.ErrFileStore, err)
+	}
+	return file.ID, nil
+}
+
+// Download writes the named file's full contents into w.
+func (s *FileStore) Download(ctx context.Context, fileID string, w io.WriterAt) error {
+	resp, err := s.tp.do(ctx, http.MethodGet, "/files/"+fileID+"/content", nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if _, err := io.Copy(io.NewOffsetWriter(w, 0), resp.Body); err != nil {
+		return fmt.Errorf("%w: read file content: %v", af.ErrFileStore, err)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package openai_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+	"github.com/microsoft/agent-framework/go/openai"
+)
+
+func rawResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/octet-stream"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestFileStore_Upload(t *testing.T) {
+	var gotPurpose string
+	httpClient := newMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodPost || !strings.HasSuffix(req.URL.Path, "/files") {
+			t.Fatalf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+		if err := req.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("ParseMultipartForm: %v", err)
+		}
+		gotPurpose = req.FormValue("purpose")
+		return jsonResponse(200, map[string]any{"id": "file-123"}), nil
+	})
+
+	store := openai.NewFileStore("test-key", "", openai.WithHTTPClient(httpClient))
+
+	fileID, err := store.Upload(context.Background(), strings.NewReader("hello"), af.UploadOptions{Filename: "hello.txt"})
+	if err != nil {
+		t.Fatalf("Upload: %v", err)
+	}
+	if fileID != "file-123" {
+		t.Errorf("fileID = %q, want file-123", fileID)
+	}
+	if gotPurpose != "assistants" {
+		t.Errorf("purpose = %q, want default assistants", gotPurpose)
+	}
+}
+
+func TestFileStore_Download(t *testing.T) {
+	httpClient := newMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodGet || !strings.HasSuffix(req.URL.Path, "/files/file-123/content") {
+			t.Fatalf("unexpected request %s %s", req.Method, req.URL.Path)
+		}
+		return rawResponse(200, "file contents"), nil
+	})
+
+	store := openai.NewFileStore("test-key", "", openai.WithHTTPClient(httpClient))
+
+	var buf bytes.Buffer
+	w := &sizedWriterAt{buf: &buf}
+	if err := store.Download(context.Background(), "file-123", w); err != nil {
+		t.Fatalf("Download: %v", err)
+	}
+	if got := buf.String(); got != "file contents" {
+		t.Errorf("downloaded = %q, want %q", got, "file contents")
+	}
+}
+
+// sizedWriterAt adapts a bytes.Buffer to io.WriterAt for tests, growing the
+// buffer as needed. Real callers typically pass an *os.File.
+type sizedWriterAt struct {
+	buf *bytes.Buffer
+}
+
+func (w *sizedWriterAt) WriteAt(p []byte, off int64) (int, error) {
+	need := int(off) + len(p)
+	if w.buf.Len() < need {
+		w.buf.Write(make([]byte, need-w.buf.Len()))
+	}
+	copy(w.buf.Bytes()[off:], p)
+	return len(p), nil
+}
@@ -0,0 +1,207 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+// FineTuningClient manages fine-tuning jobs against the OpenAI fine-tuning
+// API. Create one with [NewFineTuningClient], which accepts the same
+// [Option] values as [New] (base URL, Azure credential, custom headers,
+// HTTP client).
+type FineTuningClient struct {
+	tp transport
+}
+
+// NewFineTuningClient creates a [FineTuningClient] with the given API key
+// and options.
+func NewFineTuningClient(apiKey string, opts ...Option) *FineTuningClient {
+	cfg := &clientConfig{}
+	for _, o := range opts {
+		o(cfg)
+	}
+	return &FineTuningClient{tp: newHTTPTransport(apiKey, cfg)}
+}
+
+// FineTuningJobRequest describes a fine-tuning job to create.
+type FineTuningJobRequest struct {
+	Model          string         `json:"model"`
+	TrainingFile   string         `json:"training_file"`
+	ValidationFile string         `json:"validation_file,omitempty"`
+	Suffix         string         `json:"suffix,omitempty"`
+	Hyperparams    map[string]any `json:"hyperparameters,omitempty"`
+}
+
+// FineTuningJob is the state of a fine-tuning job as returned by the
+// create, retrieve, list, and cancel endpoints.
+type FineTuningJob struct {
+	ID             string `json:"id"`
+	Object         string `json:"object"`
+	Model          string `json:"model"`
+	CreatedAt      int64  `json:"created_at"`
+	FinishedAt     int64  `json:"finished_at"`
+	Status         string `json:"status"`
+	TrainingFile   string `json:"training_file"`
+	ValidationFile string `json:"validation_file"`
+	FineTunedModel string `json:"fine_tuned_model"`
+	Error          *struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+		Param   string `json:"param"`
+	} `json:"error"`
+}
+
+// FineTuningJobEvent is a single status or metric event emitted during a
+// fine-tuning job's lifecycle.
+type FineTuningJobEvent struct {
+	ID        string `json:"id"`
+	Object    string `json:"object"`
+	CreatedAt int64  `json:"created_at"`
+	Level     string `json:"level"`
+	Message   string `json:"message"`
+}
+
+// FineTuningListOptions page a fine-tuning list endpoint. After is the ID
+// of the last object from a previous page; Limit bounds the page size
+// (the API default applies when zero).
+type FineTuningListOptions struct {
+	After string
+	Limit int
+}
+
+// FineTuningJobList is a page of fine-tuning jobs.
+type FineTuningJobList struct {
+	Object  string          `json:"object"`
+	Data    []FineTuningJob `json:"data"`
+	HasMore bool            `json:"has_more"`
+}
+
+// FineTuningJobEventList is a page of fine-tuning job events.
+type FineTuningJobEventList struct {
+	Object  string               `json:"object"`
+	Data    []FineTuningJobEvent `json:"data"`
+	HasMore bool                 `json:"has_more"`
+}
+
+// CreateFineTuningJob starts a new fine-tuning job.
+func (c *FineTuningClient) CreateFineTuningJob(ctx context.Context, req FineTuningJobRequest) (*FineTuningJob, error) {
+	var job FineTuningJob
+	if err := c.doJSON(ctx, http.MethodPost, "/fine_tuning/jobs", req, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// RetrieveFineTuningJob fetches a fine-tuning job's current state.
+func (c *FineTuningClient) RetrieveFineTuningJob(ctx context.Context, jobID string) (*FineTuningJob, error) {
+	var job FineTuningJob
+	if err := c.doJSON(ctx, http.MethodGet, "/fine_tuning/jobs/"+jobID, nil, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// CancelFineTuningJob cancels a running fine-tuning job.
+func (c *FineTuningClient) CancelFineTuningJob(ctx context.Context, jobID string) (*FineTuningJob, error) {
+	var job FineTuningJob
+	if err := c.doJSON(ctx, http.MethodPost, "/fine_tuning/jobs/"+jobID+"/cancel", nil, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// ListFineTuningJobs lists fine-tuning jobs, most recent first.
+func (c *FineTuningClient) ListFineTuningJobs(ctx context.Context, paging FineTuningListOptions) (*FineTuningJobList, error) {
+	var list FineTuningJobList
+	if err := c.doJSON(ctx, http.MethodGet, "/fine_tuning/jobs"+pagingQuery(paging), nil, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// ListFineTuningJobEvents lists the status and metric events emitted by a
+// fine-tuning job, oldest first.
+func (c *FineTuningClient) ListFineTuningJobEvents(ctx context.Context, jobID string, paging FineTuningListOptions) (*FineTuningJobEventList, error) {
+	var list FineTuningJobEventList
+	if err := c.doJSON(ctx, http.MethodGet, "/fine_tuning/jobs/"+jobID+"/events"+pagingQuery(paging), nil, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// UploadTrainingFile uploads a JSONL training (or validation) file and
+// returns its file ID, for use as [FineTuningJobRequest.TrainingFile] or
+// [FineTuningJobRequest.ValidationFile].
+func (c *FineTuningClient) UploadTrainingFile(ctx context.Context, filename string, jsonl io.Reader) (string, error) {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	if err := w.WriteField("purpose", "fine-tune"); err != nil {
+		return "", fmt.Errorf("%w: write purpose field: %v", af.ErrService, err)
+	}
+	part, err := w.CreateFormFile("file", filename)
+	if err != nil {
+		return "", fmt.Errorf("%w: create form file: %v", af.ErrService, err)
+	}
+	if _, err := io.Copy(part, jsonl); err != nil {
+		return "", fmt.Errorf("%w: write training file: %v", af.ErrService, err)
+	}
+	if err := w.Close(); err != nil {
+		return "", fmt.Errorf("%w: close multipart writer: %v", af.ErrService, err)
+	}
+
+	resp, err := c.tp.doMultipart(ctx, http.MethodPost, "/files", w.FormDataContentType(), &body)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var file struct {
+		ID string `json:"id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&file); err != nil {
+		return "", fmt.Errorf("%w: parse upload response: %v", af.ErrService, err)
+	}
+	return file.ID, nil
+}
+
+// doJSON makes a request against path with a JSON-encoded body (or no body,
+// when req is nil) and decodes the JSON response into out.
+func (c *FineTuningClient) doJSON(ctx context.Context, method, path string, req, out any) error {
+	resp, err := c.tp.do(ctx, method, path, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("%w: parse response: %v", af.ErrService, err)
+	}
+	return nil
+}
+
+// pagingQuery renders paging as a URL query string, or "" if both fields
+// are zero.
+func pagingQuery(paging FineTuningListOptions) string {
+	q := url.Values{}
+	if paging.After != "" {
+		q.Set("after", paging.After)
+	}
+	if paging.Limit > 0 {
+		q.Set("limit", strconv.Itoa(paging.Limit))
+	}
+	if len(q) == 0 {
+		return ""
+	}
+	return "?" + q.Encode()
+}
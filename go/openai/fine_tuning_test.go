@@ -0,0 +1,132 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package openai_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+	"github.com/microsoft/agent-framework/go/openai"
+)
+
+func TestFineTuningClient_CreateRetrieveCancel(t *testing.T) {
+	httpClient := newMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		switch {
+		case req.Method == http.MethodPost && strings.HasSuffix(req.URL.Path, "/fine_tuning/jobs"):
+			return jsonResponse(200, map[string]any{"id": "ft-1", "status": "validating_files"}), nil
+		case req.Method == http.MethodGet && strings.HasSuffix(req.URL.Path, "/fine_tuning/jobs/ft-1"):
+			return jsonResponse(200, map[string]any{"id": "ft-1", "status": "running"}), nil
+		case req.Method == http.MethodPost && strings.HasSuffix(req.URL.Path, "/fine_tuning/jobs/ft-1/cancel"):
+			return jsonResponse(200, map[string]any{"id": "ft-1", "status": "cancelled"}), nil
+		default:
+			t.Fatalf("unexpected request %s %s", req.Method, req.URL.Path)
+			return nil, nil
+		}
+	})
+
+	client := openai.NewFineTuningClient("test-key", openai.WithHTTPClient(httpClient))
+
+	job, err := client.CreateFineTuningJob(context.Background(), openai.FineTuningJobRequest{
+		Model:        "gpt-4o-mini",
+		TrainingFile: "file-abc",
+	})
+	if err != nil {
+		t.Fatalf("CreateFineTuningJob: %v", err)
+	}
+	if job.ID != "ft-1" || job.Status != "validating_files" {
+		t.Errorf("job = %+v", job)
+	}
+
+	job, err = client.RetrieveFineTuningJob(context.Background(), "ft-1")
+	if err != nil {
+		t.Fatalf("RetrieveFineTuningJob: %v", err)
+	}
+	if job.Status != "running" {
+		t.Errorf("status = %q", job.Status)
+	}
+
+	job, err = client.CancelFineTuningJob(context.Background(), "ft-1")
+	if err != nil {
+		t.Fatalf("CancelFineTuningJob: %v", err)
+	}
+	if job.Status != "cancelled" {
+		t.Errorf("status = %q", job.Status)
+	}
+}
+
+func TestFineTuningClient_ListJobsAppliesPaging(t *testing.T) {
+	var gotQuery string
+	httpClient := newMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		gotQuery = req.URL.RawQuery
+		return jsonResponse(200, map[string]any{"data": []any{}, "has_more": false}), nil
+	})
+
+	client := openai.NewFineTuningClient("test-key", openai.WithHTTPClient(httpClient))
+	_, err := client.ListFineTuningJobs(context.Background(), openai.FineTuningListOptions{After: "ft-1", Limit: 5})
+	if err != nil {
+		t.Fatalf("ListFineTuningJobs: %v", err)
+	}
+	if gotQuery != "after=ft-1&limit=5" {
+		t.Errorf("query = %q", gotQuery)
+	}
+}
+
+func TestFineTuningClient_ListJobEvents(t *testing.T) {
+	httpClient := newMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		if !strings.HasSuffix(req.URL.Path, "/fine_tuning/jobs/ft-1/events") {
+			t.Errorf("path = %q", req.URL.Path)
+		}
+		return jsonResponse(200, map[string]any{
+			"data": []map[string]any{{"id": "evt-1", "message": "created"}},
+		}), nil
+	})
+
+	client := openai.NewFineTuningClient("test-key", openai.WithHTTPClient(httpClient))
+	list, err := client.ListFineTuningJobEvents(context.Background(), "ft-1", openai.FineTuningListOptions{})
+	if err != nil {
+		t.Fatalf("ListFineTuningJobEvents: %v", err)
+	}
+	if len(list.Data) != 1 || list.Data[0].Message != "created" {
+		t.Errorf("events = %+v", list.Data)
+	}
+}
+
+func TestFineTuningClient_UploadTrainingFile(t *testing.T) {
+	var gotContentType string
+	httpClient := newMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		gotContentType = req.Header.Get("Content-Type")
+		return jsonResponse(200, map[string]any{"id": "file-xyz"}), nil
+	})
+
+	client := openai.NewFineTuningClient("test-key", openai.WithHTTPClient(httpClient))
+	fileID, err := client.UploadTrainingFile(context.Background(), "train.jsonl", strings.NewReader(`{"messages":[]}`))
+	if err != nil {
+		t.Fatalf("UploadTrainingFile: %v", err)
+	}
+	if fileID != "file-xyz" {
+		t.Errorf("fileID = %q", fileID)
+	}
+	if !strings.HasPrefix(gotContentType, "multipart/form-data") {
+		t.Errorf("Content-Type = %q", gotContentType)
+	}
+}
+
+func TestFineTuningClient_ErrorResponseIsServiceError(t *testing.T) {
+	httpClient := newMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(404, map[string]any{"error": map[string]any{"message": "no such job"}}), nil
+	})
+
+	client := openai.NewFineTuningClient("test-key", openai.WithHTTPClient(httpClient))
+	_, err := client.RetrieveFineTuningJob(context.Background(), "ft-missing")
+	if !errors.Is(err, af.ErrService) {
+		t.Fatalf("err = %v, want ErrService", err)
+	}
+	var svcErr *af.ServiceError
+	if !errors.As(err, &svcErr) || svcErr.StatusCode != 404 {
+		t.Fatalf("err = %#v", err)
+	}
+}
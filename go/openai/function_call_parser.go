@@ -0,0 +1,58 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package openai
+
+import (
+	"sync"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+// FunctionCallParser recognizes a model's text-based encoding of function
+// calls, for providers that don't emit the OpenAI `tool_calls` wire format.
+// Register implementations with [RegisterParser] and select one per client
+// with [WithFunctionCallParser].
+type FunctionCallParser interface {
+	// ParseCalls extracts function calls encoded in text, along with any
+	// leftover prose. ok is false if text contains no recognizable calls.
+	ParseCalls(text string) (calls []*af.FunctionCallContent, remaining string, ok bool)
+
+	// FormatCall renders call the way this format expects the model to
+	// have emitted it, so it can be echoed back into message history on
+	// the next turn instead of OpenAI's `tool_calls` field.
+	FormatCall(call *af.FunctionCallContent) string
+
+	// FormatResult renders a tool's result as plain text, for echoing an
+	// [af.RoleTool] message back into history on models that were never
+	// trained to understand that role.
+	FormatResult(call *af.FunctionCallContent, result string) string
+}
+
+var (
+	parserRegistryMu sync.RWMutex
+	parserRegistry   = map[string]FunctionCallParser{}
+)
+
+// RegisterParser makes parser available by name to [WithFunctionCallParser].
+// Registering under a name already in use replaces it. Built-in parsers are
+// pre-registered under "hermes", "llama3", "mistral", and "qwen".
+func RegisterParser(name string, parser FunctionCallParser) {
+	parserRegistryMu.Lock()
+	defer parserRegistryMu.Unlock()
+	parserRegistry[name] = parser
+}
+
+// lookupParser returns the parser registered under name, if any.
+func lookupParser(name string) (FunctionCallParser, bool) {
+	parserRegistryMu.RLock()
+	defer parserRegistryMu.RUnlock()
+	p, ok := parserRegistry[name]
+	return p, ok
+}
+
+func init() {
+	RegisterParser("hermes", hermesParser{})
+	RegisterParser("llama3", llama3Parser{})
+	RegisterParser("mistral", mistralParser{})
+	RegisterParser("qwen", qwenParser{})
+}
@@ -0,0 +1,119 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+	"github.com/microsoft/agent-framework/go/openai"
+)
+
+func TestClient_Response_FunctionCallParser_Hermes(t *testing.T) {
+	apiResp := map[string]any{
+		"id":     "chatcmpl-1",
+		"object": "chat.completion",
+		"model":  "local-model",
+		"choices": []map[string]any{{
+			"index":         0,
+			"finish_reason": "stop",
+			"message": map[string]any{
+				"role":    "assistant",
+				"content": "<tool_call>\n{\"name\": \"get_weather\", \"arguments\": {\"city\": \"Seattle\"}}\n</tool_call>",
+			},
+		}},
+	}
+
+	httpClient := newMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(http.StatusOK, apiResp), nil
+	})
+
+	client := openai.New("test-key",
+		openai.WithHTTPClient(httpClient),
+		openai.WithBaseURL("https://example.com/v1"),
+		openai.WithFunctionCallParser("hermes"),
+	)
+
+	resp, err := client.Response(context.Background(), []af.Message{af.NewUserMessage("weather in Seattle?")}, nil)
+	if err != nil {
+		t.Fatalf("Response: %v", err)
+	}
+	if resp.FinishReason != af.FinishReasonToolCalls {
+		t.Errorf("FinishReason = %q, want %q", resp.FinishReason, af.FinishReasonToolCalls)
+	}
+	if len(resp.Messages) != 1 || len(resp.Messages[0].Contents) != 1 {
+		t.Fatalf("unexpected message shape: %+v", resp.Messages)
+	}
+	call, ok := resp.Messages[0].Contents[0].(*af.FunctionCallContent)
+	if !ok {
+		t.Fatalf("content = %T, want *af.FunctionCallContent", resp.Messages[0].Contents[0])
+	}
+	if call.Name != "get_weather" {
+		t.Errorf("call.Name = %q, want %q", call.Name, "get_weather")
+	}
+	if call.Arguments != `{"city": "Seattle"}` {
+		t.Errorf("call.Arguments = %q", call.Arguments)
+	}
+}
+
+func TestClient_Response_FunctionCallParser_EchoesHistoryAsText(t *testing.T) {
+	var sentBody map[string]any
+
+	httpClient := newMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		body, _ := io.ReadAll(req.Body)
+		if err := json.Unmarshal(body, &sentBody); err != nil {
+			t.Fatalf("decode request body: %v", err)
+		}
+		return jsonResponse(http.StatusOK, map[string]any{
+			"id":     "chatcmpl-2",
+			"object": "chat.completion",
+			"model":  "local-model",
+			"choices": []map[string]any{{
+				"index":         0,
+				"finish_reason": "stop",
+				"message":       map[string]any{"role": "assistant", "content": "Seattle is sunny."},
+			}},
+		}), nil
+	})
+
+	client := openai.New("test-key",
+		openai.WithHTTPClient(httpClient),
+		openai.WithBaseURL("https://example.com/v1"),
+		openai.WithFunctionCallParser("hermes"),
+	)
+
+	messages := []af.Message{
+		af.NewUserMessage("weather in Seattle?"),
+		{Role: af.RoleAssistant, Contents: af.Contents{&af.FunctionCallContent{CallID: "call_1", Name: "get_weather", Arguments: `{"city":"Seattle"}`}}},
+		af.NewToolMessage("call_1", "sunny, 72F"),
+	}
+
+	if _, err := client.Response(context.Background(), messages, nil); err != nil {
+		t.Fatalf("Response: %v", err)
+	}
+
+	rawMessages, ok := sentBody["messages"].([]any)
+	if !ok || len(rawMessages) != 3 {
+		t.Fatalf("sent messages = %+v", sentBody["messages"])
+	}
+
+	assistantMsg := rawMessages[1].(map[string]any)
+	if _, hasToolCalls := assistantMsg["tool_calls"]; hasToolCalls {
+		t.Errorf("assistant message still has tool_calls: %+v", assistantMsg)
+	}
+	if content, _ := assistantMsg["content"].(string); content == "" {
+		t.Errorf("assistant message has no rendered tool-call text: %+v", assistantMsg)
+	}
+
+	toolMsg := rawMessages[2].(map[string]any)
+	if toolMsg["role"] != "user" {
+		t.Errorf("tool-result message role = %v, want %q", toolMsg["role"], "user")
+	}
+	if _, hasToolCallID := toolMsg["tool_call_id"]; hasToolCallID {
+		t.Errorf("tool-result message still has tool_call_id: %+v", toolMsg)
+	}
+}
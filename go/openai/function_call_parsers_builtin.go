@@ -0,0 +1,266 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package openai
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync/atomic"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+// parserCallSeq generates unique suffixes for synthetic call IDs, since none
+// of the text encodings below are guaranteed to carry one.
+var parserCallSeq atomic.Int64
+
+func nextParserCallID() string {
+	return fmt.Sprintf("call_local_%d", parserCallSeq.Add(1))
+}
+
+// nameArgs is the common `{"name": ..., "arguments"/"parameters": ...}`
+// shape every built-in format below wraps in its own delimiters.
+type nameArgs struct {
+	Name       string          `json:"name"`
+	Arguments  json.RawMessage `json:"arguments"`
+	Parameters json.RawMessage `json:"parameters"`
+}
+
+func (n nameArgs) args() json.RawMessage {
+	if len(n.Arguments) > 0 {
+		return n.Arguments
+	}
+	return n.Parameters
+}
+
+// parseNameArgsBlocks parses one or more JSON objects matched by pattern's
+// sole capture group into [af.FunctionCallContent], returning the input text
+// with all matches removed.
+func parseNameArgsBlocks(pattern *regexp.Regexp, text string) (calls []*af.FunctionCallContent, remaining string, ok bool) {
+	matches := pattern.FindAllStringSubmatchIndex(text, -1)
+	if len(matches) == 0 {
+		return nil, "", false
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		var parsed nameArgs
+		if err := json.Unmarshal([]byte(text[m[2]:m[3]]), &parsed); err != nil || parsed.Name == "" {
+			continue
+		}
+		args := parsed.args()
+		if len(args) == 0 {
+			args = json.RawMessage("{}")
+		}
+		calls = append(calls, &af.FunctionCallContent{
+			CallID:    nextParserCallID(),
+			Name:      parsed.Name,
+			Arguments: string(args),
+		})
+		b.WriteString(text[last:m[0]])
+		last = m[1]
+	}
+	if len(calls) == 0 {
+		return nil, "", false
+	}
+	b.WriteString(text[last:])
+	return calls, strings.TrimSpace(b.String()), true
+}
+
+// hermesParser implements the `<tool_call>{"name": ..., "arguments": ...}
+// </tool_call>` encoding used by Hermes/Nous fine-tunes (one block per call).
+type hermesParser struct{}
+
+var hermesPattern = regexp.MustCompile(`(?s)<tool_call>\s*(\{.*?\})\s*</tool_call>`)
+
+func (hermesParser) ParseCalls(text string) ([]*af.FunctionCallContent, string, bool) {
+	return parseNameArgsBlocks(hermesPattern, text)
+}
+
+func (hermesParser) FormatCall(call *af.FunctionCallContent) string {
+	return fmt.Sprintf(`<tool_call>
+{"name": %q, "arguments": %s}
+</tool_call>`, call.Name, argsOrEmptyObject(call.Arguments))
+}
+
+func (hermesParser) FormatResult(call *af.FunctionCallContent, result string) string {
+	encoded, _ := json.Marshal(result)
+	return fmt.Sprintf(`<tool_response>
+{"name": %q, "content": %s}
+</tool_response>`, call.Name, encoded)
+}
+
+// llama3Parser implements Llama-3.1's built-in tool calling encoding:
+// `<|python_tag|>{"name": ..., "parameters": ...}<|eom_id|>`.
+type llama3Parser struct{}
+
+var llama3Pattern = regexp.MustCompile(`(?s)<\|python_tag\|>(\{.*?\})(?:<\|eom_id\|>|<\|eot_id\|>|$)`)
+
+func (llama3Parser) ParseCalls(text string) ([]*af.FunctionCallContent, string, bool) {
+	return parseNameArgsBlocks(llama3Pattern, text)
+}
+
+func (llama3Parser) FormatCall(call *af.FunctionCallContent) string {
+	return fmt.Sprintf(`<|python_tag|>{"name": %q, "parameters": %s}<|eom_id|>`, call.Name, argsOrEmptyObject(call.Arguments))
+}
+
+func (llama3Parser) FormatResult(call *af.FunctionCallContent, result string) string {
+	return fmt.Sprintf("%s\n\n%s", call.Name, result)
+}
+
+// mistralParser implements Mistral's `[TOOL_CALLS] [{"name": ..., "arguments":
+// ..., "id": ...}]` encoding (a JSON array, even for a single call).
+type mistralParser struct{}
+
+var mistralPattern = regexp.MustCompile(`(?s)\[TOOL_CALLS\]\s*(\[.*?\])`)
+
+func (mistralParser) ParseCalls(text string) (calls []*af.FunctionCallContent, remaining string, ok bool) {
+	loc := mistralPattern.FindStringSubmatchIndex(text)
+	if loc == nil {
+		return nil, "", false
+	}
+
+	var parsed []nameArgs
+	if err := json.Unmarshal([]byte(text[loc[2]:loc[3]]), &parsed); err != nil {
+		return nil, "", false
+	}
+	for _, p := range parsed {
+		if p.Name == "" {
+			continue
+		}
+		args := p.args()
+		if len(args) == 0 {
+			args = json.RawMessage("{}")
+		}
+		calls = append(calls, &af.FunctionCallContent{
+			CallID:    nextParserCallID(),
+			Name:      p.Name,
+			Arguments: string(args),
+		})
+	}
+	if len(calls) == 0 {
+		return nil, "", false
+	}
+	remaining = strings.TrimSpace(text[:loc[0]] + text[loc[1]:])
+	return calls, remaining, true
+}
+
+func (mistralParser) FormatCall(call *af.FunctionCallContent) string {
+	return fmt.Sprintf(`[TOOL_CALLS] [{"name": %q, "arguments": %s}]`, call.Name, argsOrEmptyObject(call.Arguments))
+}
+
+func (mistralParser) FormatResult(call *af.FunctionCallContent, result string) string {
+	encoded, _ := json.Marshal(result)
+	return fmt.Sprintf(`[TOOL_RESULTS] {"name": %q, "content": %s}`, call.Name, encoded)
+}
+
+// qwenParser implements Qwen's fenced-code-block encoding: a ```` ```json ````
+// block containing `{"name": ..., "arguments": ...}`.
+type qwenParser struct{}
+
+var qwenPattern = regexp.MustCompile("(?s)```json\\s*(\\{.*?\\})\\s*```")
+
+func (qwenParser) ParseCalls(text string) ([]*af.FunctionCallContent, string, bool) {
+	return parseNameArgsBlocks(qwenPattern, text)
+}
+
+func (qwenParser) FormatCall(call *af.FunctionCallContent) string {
+	return fmt.Sprintf("```json\n{\"name\": %q, \"arguments\": %s}\n```", call.Name, argsOrEmptyObject(call.Arguments))
+}
+
+func (qwenParser) FormatResult(call *af.FunctionCallContent, result string) string {
+	encoded, _ := json.Marshal(result)
+	return fmt.Sprintf(`<tool_response>
+{"name": %q, "content": %s}
+</tool_response>`, call.Name, encoded)
+}
+
+// argsOrEmptyObject returns args unless empty, in which case it returns "{}"
+// so the rendered call is always valid JSON.
+func argsOrEmptyObject(args string) string {
+	if strings.TrimSpace(args) == "" {
+		return "{}"
+	}
+	return args
+}
+
+// RegexParserOptions configures [NewRegexParser].
+type RegexParserOptions struct {
+	// Pattern must declare the named capture groups "name" and either
+	// "arguments" or "parameters".
+	Pattern *regexp.Regexp
+
+	// CallFormat is a fmt-style template with two verbs: the call's name,
+	// then its JSON-encoded arguments. Used by FormatCall.
+	CallFormat string
+
+	// ResultFormat is a fmt-style template with two verbs: the call's
+	// name, then the result text. Used by FormatResult.
+	ResultFormat string
+}
+
+// NewRegexParser builds a [FunctionCallParser] from a user-supplied pattern
+// and rendering templates, for text-based tool-call encodings not covered by
+// a built-in parser.
+func NewRegexParser(opts RegexParserOptions) FunctionCallParser {
+	return &regexParser{opts: opts}
+}
+
+type regexParser struct{ opts RegexParserOptions }
+
+func (p *regexParser) ParseCalls(text string) (calls []*af.FunctionCallContent, remaining string, ok bool) {
+	names := p.opts.Pattern.SubexpNames()
+	nameIdx, argsIdx := -1, -1
+	for i, n := range names {
+		switch n {
+		case "name":
+			nameIdx = i
+		case "arguments", "parameters":
+			argsIdx = i
+		}
+	}
+	if nameIdx == -1 || argsIdx == -1 {
+		return nil, "", false
+	}
+
+	matches := p.opts.Pattern.FindAllStringSubmatchIndex(text, -1)
+	if len(matches) == 0 {
+		return nil, "", false
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		name := text[m[2*nameIdx]:m[2*nameIdx+1]]
+		args := text[m[2*argsIdx]:m[2*argsIdx+1]]
+		if name == "" {
+			continue
+		}
+		if !json.Valid([]byte(args)) {
+			continue
+		}
+		calls = append(calls, &af.FunctionCallContent{
+			CallID:    nextParserCallID(),
+			Name:      name,
+			Arguments: args,
+		})
+		b.WriteString(text[last:m[0]])
+		last = m[1]
+	}
+	if len(calls) == 0 {
+		return nil, "", false
+	}
+	b.WriteString(text[last:])
+	return calls, strings.TrimSpace(b.String()), true
+}
+
+func (p *regexParser) FormatCall(call *af.FunctionCallContent) string {
+	return fmt.Sprintf(p.opts.CallFormat, call.Name, argsOrEmptyObject(call.Arguments))
+}
+
+func (p *regexParser) FormatResult(call *af.FunctionCallContent, result string) string {
+	return fmt.Sprintf(p.opts.ResultFormat, call.Name, result)
+}
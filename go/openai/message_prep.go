@@ -10,22 +10,23 @@ import (
 
 // chatRequest is the OpenAI Chat Completions API request body.
 type chatRequest struct {
-	Model            string          `json:"model"`
-	Messages         []chatMessage   `json:"messages"`
-	Temperature      *float64        `json:"temperature,omitempty"`
-	TopP             *float64        `json:"top_p,omitempty"`
-	MaxTokens        *int            `json:"max_completion_tokens,omitempty"`
-	Stop             []string        `json:"stop,omitempty"`
-	Seed             *int            `json:"seed,omitempty"`
-	FrequencyPenalty *float64        `json:"frequency_penalty,omitempty"`
-	PresencePenalty  *float64        `json:"presence_penalty,omitempty"`
-	Tools            []toolSpec      `json:"tools,omitempty"`
-	ToolChoice       any             `json:"tool_choice,omitempty"`
-	User             string          `json:"user,omitempty"`
-	Stream           bool            `json:"stream,omitempty"`
-	StreamOptions    *streamOptions  `json:"stream_options,omitempty"`
-	ResponseFormat   any             `json:"response_format,omitempty"`
-	Store            *bool           `json:"store,omitempty"`
+	Model            string            `json:"model"`
+	Messages         []chatMessage     `json:"messages"`
+	Temperature      *float64          `json:"temperature,omitempty"`
+	TopP             *float64          `json:"top_p,omitempty"`
+	MaxTokens        *int              `json:"max_completion_tokens,omitempty"`
+	Stop             []string          `json:"stop,omitempty"`
+	Seed             *int              `json:"seed,omitempty"`
+	FrequencyPenalty *float64          `json:"frequency_penalty,omitempty"`
+	PresencePenalty  *float64          `json:"presence_penalty,omitempty"`
+	Tools            []toolSpec        `json:"tools,omitempty"`
+	ToolChoice       any               `json:"tool_choice,omitempty"`
+	User             string            `json:"user,omitempty"`
+	Stream           bool              `json:"stream,omitempty"`
+	StreamOptions    *streamOptions    `json:"stream_options,omitempty"`
+	ResponseFormat   any               `json:"response_format,omitempty"`
+	Grammar          string            `json:"grammar,omitempty"`
+	Store            *bool             `json:"store,omitempty"`
 	Metadata         map[string]string `json:"metadata,omitempty"`
 }
 
@@ -34,11 +35,11 @@ type streamOptions struct {
 }
 
 type chatMessage struct {
-	Role       string          `json:"role"`
-	Content    any             `json:"content,omitempty"` // string or []contentPart
-	Name       string          `json:"name,omitempty"`
-	ToolCalls  []toolCall      `json:"tool_calls,omitempty"`
-	ToolCallID string          `json:"tool_call_id,omitempty"`
+	Role       string     `json:"role"`
+	Content    any        `json:"content,omitempty"` // string or []contentPart
+	Name       string     `json:"name,omitempty"`
+	ToolCalls  []toolCall `json:"tool_calls,omitempty"`
+	ToolCallID string     `json:"tool_call_id,omitempty"`
 }
 
 type contentPart struct {
@@ -52,8 +53,12 @@ type imageURL struct {
 }
 
 type toolCall struct {
-	ID       string       `json:"id"`
-	Type     string       `json:"type"`
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	// Index identifies which tool call a streaming delta belongs to; only
+	// the tool_calls deltas within a chunkDelta set it (the API omits it on
+	// non-streamed messages).
+	Index    int          `json:"index,omitempty"`
 	Function functionCall `json:"function"`
 }
 
@@ -62,6 +67,45 @@ type functionCall struct {
 	Arguments string `json:"arguments"`
 }
 
+// responseFormatSpec is the OpenAI `response_format` request field.
+type responseFormatSpec struct {
+	Type       string                    `json:"type"`
+	JSONSchema *responseFormatJSONSchema `json:"json_schema,omitempty"`
+}
+
+type responseFormatJSONSchema struct {
+	Name   string          `json:"name"`
+	Schema json.RawMessage `json:"schema,omitempty"`
+	Strict bool            `json:"strict,omitempty"`
+}
+
+// convertResponseFormat translates an [af.ResponseFormat] into the OpenAI
+// `response_format` field and, for llama.cpp/Foundry-Local compatible
+// endpoints, the sibling `grammar` field.
+func convertResponseFormat(rf af.ResponseFormat) (responseFormat any, grammar string) {
+	switch v := rf.(type) {
+	case nil:
+		return nil, ""
+	case *af.ResponseFormatText:
+		return responseFormatSpec{Type: "text"}, ""
+	case *af.ResponseFormatJSONObject:
+		return responseFormatSpec{Type: "json_object"}, ""
+	case *af.ResponseFormatJSONSchema:
+		return responseFormatSpec{
+			Type: "json_schema",
+			JSONSchema: &responseFormatJSONSchema{
+				Name:   v.Name,
+				Schema: v.Schema,
+				Strict: v.Strict,
+			},
+		}, ""
+	case *af.ResponseFormatGrammar:
+		return nil, v.GBNF
+	default:
+		return nil, ""
+	}
+}
+
 type toolSpec struct {
 	Type     string       `json:"type"`
 	Function functionSpec `json:"function"`
@@ -73,8 +117,17 @@ type functionSpec struct {
 	Parameters  json.RawMessage `json:"parameters,omitempty"`
 }
 
-// buildRequest converts framework types into an OpenAI API request.
-func buildRequest(messages []af.Message, opts *af.ChatOptions, defaultModel string) *chatRequest {
+// buildRequest converts framework types into an OpenAI API request. When
+// parser is non-nil, assistant tool calls and tool results are rendered as
+// plain text in that parser's encoding instead of `tool_calls`/the `tool`
+// role, so a non-tool-native model sees its own style of tool-call
+// formatting on the next turn.
+//
+// Fields always appear in the same order (system instructions, then tools,
+// then message history) so that, with [af.ChatOptions.EnablePromptCache]
+// set, OpenAI's automatic prefix cache can match repeated turns of a
+// conversation.
+func buildRequest(messages []af.Message, opts *af.ChatOptions, defaultModel string, parser FunctionCallParser) *chatRequest {
 	req := &chatRequest{
 		Model: defaultModel,
 	}
@@ -90,9 +143,16 @@ func buildRequest(messages []af.Message, opts *af.ChatOptions, defaultModel stri
 		req.FrequencyPenalty = opts.FrequencyPenalty
 		req.PresencePenalty = opts.PresencePenalty
 		req.User = opts.User
+		if req.User == "" && opts.EnablePromptCache && opts.CacheKey != "" {
+			// OpenAI's prompt cache is automatic for a stable request
+			// prefix, but routing repeat requests to the same cache
+			// partition needs a stable `user` value when the caller
+			// hasn't already set one.
+			req.User = opts.CacheKey
+		}
 		req.Store = opts.Store
 		req.Metadata = opts.Metadata
-		req.ResponseFormat = opts.ResponseFormat
+		req.ResponseFormat, req.Grammar = convertResponseFormat(opts.ResponseFormat)
 
 		// Convert tools
 		for _, t := range opts.Tools {
@@ -110,14 +170,22 @@ func buildRequest(messages []af.Message, opts *af.ChatOptions, defaultModel stri
 		req.ToolChoice = convertToolChoice(opts.ToolChoice)
 	}
 
-	req.Messages = convertMessages(messages)
+	req.Messages = convertMessages(messages, parser)
 	return req
 }
 
 // convertMessages translates framework Messages into OpenAI chat messages.
-func convertMessages(messages []af.Message) []chatMessage {
+// When parser is non-nil, assistant [af.FunctionCallContent] and
+// [af.RoleTool] messages are rendered as plain text via the parser instead
+// of `tool_calls`/the `tool` role.
+func convertMessages(messages []af.Message, parser FunctionCallParser) []chatMessage {
 	result := make([]chatMessage, 0, len(messages))
 
+	var callNames map[string]string
+	if parser != nil {
+		callNames = functionCallNames(messages)
+	}
+
 	for _, msg := range messages {
 		cm := chatMessage{
 			Role: string(msg.Role),
@@ -128,9 +196,17 @@ func convertMessages(messages []af.Message) []chatMessage {
 		case af.RoleTool:
 			// Tool messages carry a single function result
 			for _, c := range msg.Contents {
-				if fr, ok := c.(*af.FunctionResultContent); ok {
+				fr, ok := c.(*af.FunctionResultContent)
+				if !ok {
+					continue
+				}
+				resultStr, _ := marshalResult(fr.Result)
+				if parser != nil {
+					call := &af.FunctionCallContent{CallID: fr.CallID, Name: callNames[fr.CallID]}
+					cm.Role = string(af.RoleUser)
+					cm.Content = parser.FormatResult(call, resultStr)
+				} else {
 					cm.ToolCallID = fr.CallID
-					resultStr, _ := marshalResult(fr.Result)
 					cm.Content = resultStr
 				}
 			}
@@ -143,6 +219,10 @@ func convertMessages(messages []af.Message) []chatMessage {
 				case *af.TextContent:
 					textParts = append(textParts, v.Text)
 				case *af.FunctionCallContent:
+					if parser != nil {
+						textParts = append(textParts, parser.FormatCall(v))
+						continue
+					}
 					cm.ToolCalls = append(cm.ToolCalls, toolCall{
 						ID:   v.CallID,
 						Type: "function",
@@ -173,6 +253,21 @@ func convertMessages(messages []af.Message) []chatMessage {
 	return result
 }
 
+// functionCallNames indexes every [af.FunctionCallContent] across messages
+// by CallID, so a later [af.RoleTool] message's result can be rendered with
+// the name of the function it came from.
+func functionCallNames(messages []af.Message) map[string]string {
+	names := make(map[string]string)
+	for _, msg := range messages {
+		for _, c := range msg.Contents {
+			if fc, ok := c.(*af.FunctionCallContent); ok {
+				names[fc.CallID] = fc.Name
+			}
+		}
+	}
+	return names
+}
+
 // convertContentParts converts framework Content items into OpenAI content parts.
 func convertContentParts(contents af.Contents) []contentPart {
 	var parts []contentPart
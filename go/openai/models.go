@@ -0,0 +1,64 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package openai
+
+import af "github.com/microsoft/agent-framework/go/agentframework"
+
+// Models is the built-in [af.ModelInfo] catalog for OpenAI models, also
+// pre-registered with [af.RegisterModel] (see init below) so
+// [af.DefaultCatalog] and [af.ValidateModelID] recognize them out of the
+// box. Capabilities and limits drift over time — override or add entries
+// via [af.RegisterModel] rather than relying on this staying current.
+var Models = map[string]af.ModelInfo{
+	"gpt-4o": {
+		ID:                "gpt-4o",
+		ContextWindow:     128_000,
+		MaxOutputTokens:   16_384,
+		InputModalities:   []af.Modality{af.ModalityText, af.ModalityImage},
+		OutputModalities:  []af.Modality{af.ModalityText},
+		SupportsTools:     true,
+		SupportsStreaming: true,
+	},
+	"gpt-4o-mini": {
+		ID:                "gpt-4o-mini",
+		ContextWindow:     128_000,
+		MaxOutputTokens:   16_384,
+		InputModalities:   []af.Modality{af.ModalityText, af.ModalityImage},
+		OutputModalities:  []af.Modality{af.ModalityText},
+		SupportsTools:     true,
+		SupportsStreaming: true,
+	},
+	"gpt-4.1": {
+		ID:                "gpt-4.1",
+		ContextWindow:     1_047_576,
+		MaxOutputTokens:   32_768,
+		InputModalities:   []af.Modality{af.ModalityText, af.ModalityImage},
+		OutputModalities:  []af.Modality{af.ModalityText},
+		SupportsTools:     true,
+		SupportsStreaming: true,
+	},
+	"gpt-4.1-mini": {
+		ID:                "gpt-4.1-mini",
+		ContextWindow:     1_047_576,
+		MaxOutputTokens:   32_768,
+		InputModalities:   []af.Modality{af.ModalityText, af.ModalityImage},
+		OutputModalities:  []af.Modality{af.ModalityText},
+		SupportsTools:     true,
+		SupportsStreaming: true,
+	},
+	"o3": {
+		ID:                "o3",
+		ContextWindow:     200_000,
+		MaxOutputTokens:   100_000,
+		InputModalities:   []af.Modality{af.ModalityText, af.ModalityImage},
+		OutputModalities:  []af.Modality{af.ModalityText},
+		SupportsTools:     true,
+		SupportsStreaming: true,
+	},
+}
+
+func init() {
+	for _, info := range Models {
+		af.RegisterModel(info)
+	}
+}
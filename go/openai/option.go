@@ -12,13 +12,14 @@ import (
 
 // clientConfig holds resolved configuration for the OpenAI client.
 type clientConfig struct {
-	baseURL         string
-	organization    string
-	httpClient      *http.Client
-	headers         map[string]string
-	model           string
-	azureCredential azcore.TokenCredential
-	chatMiddleware  []af.ChatMiddleware
+	baseURL            string
+	organization       string
+	httpClient         *http.Client
+	headers            map[string]string
+	model              string
+	azureCredential    azcore.TokenCredential
+	chatMiddleware     []af.ChatMiddleware
+	functionCallParser FunctionCallParser
 }
 
 // Option configures an OpenAI [Client].
@@ -60,3 +61,22 @@ func WithAzureCredential(cred azcore.TokenCredential) Option {
 func WithChatMiddleware(mw ...af.ChatMiddleware) Option {
 	return func(c *clientConfig) { c.chatMiddleware = append(c.chatMiddleware, mw...) }
 }
+
+// WithFunctionCallParser enables text-based function-call parsing for
+// endpoints that don't emit OpenAI's `tool_calls` wire format, using the
+// parser registered under name (the built-ins are "hermes", "llama3",
+// "mistral", and "qwen"; add your own with [RegisterParser]). Responses are
+// scanned for the format's encoding and converted to [af.FunctionCallContent];
+// on the next turn, assistant tool calls and tool results are rendered back
+// into that same text encoding instead of `tool_calls`/the `tool` role, so
+// multi-turn tool loops work without native tool-call support.
+//
+// An unknown name is a no-op: the client behaves as if the option weren't
+// passed.
+func WithFunctionCallParser(name string) Option {
+	return func(c *clientConfig) {
+		if p, ok := lookupParser(name); ok {
+			c.functionCallParser = p
+		}
+	}
+}
@@ -31,9 +31,17 @@ type respMessage struct {
 }
 
 type usage struct {
-	PromptTokens     int `json:"prompt_tokens"`
-	CompletionTokens int `json:"completion_tokens"`
-	TotalTokens      int `json:"total_tokens"`
+	PromptTokens        int                  `json:"prompt_tokens"`
+	CompletionTokens    int                  `json:"completion_tokens"`
+	TotalTokens         int                  `json:"total_tokens"`
+	PromptTokensDetails *promptTokensDetails `json:"prompt_tokens_details,omitempty"`
+}
+
+// promptTokensDetails reports the prompt-cache hit portion of PromptTokens,
+// present when the request opted into prompt caching (see
+// [af.ChatOptions.EnablePromptCache]).
+type promptTokensDetails struct {
+	CachedTokens int `json:"cached_tokens"`
 }
 
 // chatCompletionChunk is a single SSE chunk in streaming mode.
@@ -71,6 +79,9 @@ func parseChatResponse(raw *chatCompletionResponse) *af.ChatResponse {
 			OutputTokens: raw.Usage.CompletionTokens,
 			TotalTokens:  raw.Usage.TotalTokens,
 		}
+		if raw.Usage.PromptTokensDetails != nil {
+			resp.Usage.CachedInputTokens = raw.Usage.PromptTokensDetails.CachedTokens
+		}
 	}
 
 	if len(raw.Choices) > 0 {
@@ -99,48 +110,6 @@ func parseChatResponse(raw *chatCompletionResponse) *af.ChatResponse {
 	return resp
 }
 
-// parseChunk converts a streaming chunk into a ChatResponseUpdate.
-func parseChunk(chunk *chatCompletionChunk) *af.ChatResponseUpdate {
-	update := &af.ChatResponseUpdate{
-		ResponseID: chunk.ID,
-		ModelID:    chunk.Model,
-	}
-
-	if chunk.Usage != nil {
-		update.Usage = af.UsageDetails{
-			InputTokens:  chunk.Usage.PromptTokens,
-			OutputTokens: chunk.Usage.CompletionTokens,
-			TotalTokens:  chunk.Usage.TotalTokens,
-		}
-	}
-
-	if len(chunk.Choices) > 0 {
-		c := chunk.Choices[0]
-
-		if c.Delta.Role != "" {
-			update.Role = af.Role(c.Delta.Role)
-		}
-
-		if c.FinishReason != nil {
-			update.FinishReason = mapFinishReason(*c.FinishReason)
-		}
-
-		if c.Delta.Content != nil && *c.Delta.Content != "" {
-			update.Contents = append(update.Contents, &af.TextContent{Text: *c.Delta.Content})
-		}
-
-		for _, tc := range c.Delta.ToolCalls {
-			update.Contents = append(update.Contents, &af.FunctionCallContent{
-				CallID:    tc.ID,
-				Name:      tc.Function.Name,
-				Arguments: tc.Function.Arguments,
-			})
-		}
-	}
-
-	return update
-}
-
 // unmarshalChatResponse parses the JSON response body.
 func unmarshalChatResponse(data []byte) (*chatCompletionResponse, error) {
 	var resp chatCompletionResponse
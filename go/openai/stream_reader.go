@@ -0,0 +1,215 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package openai
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+// toolCallAccumulator reassembles one tool call's id, name, and arguments
+// from the index-keyed deltas OpenAI streams them across multiple chunks.
+type toolCallAccumulator struct {
+	id   string
+	name string
+	args strings.Builder
+}
+
+// toolCallKey identifies one tool call's accumulator by the choice it
+// belongs to and its index within that choice's tool_calls list.
+type toolCallKey struct {
+	choice int
+	index  int
+}
+
+// StreamReader consumes a single OpenAI chat-completions SSE stream. It
+// handles `data:` framing, the `[DONE]` sentinel, and keepalive comments,
+// and reassembles multi-chunk tool-call argument deltas (OpenAI streams
+// each tool call's arguments incrementally, keyed by choice and index)
+// into complete [af.FunctionCallContent] items. A call is emitted as soon
+// as the stream moves on to the next tool call, or at the latest when the
+// turn's finish_reason arrives.
+//
+// Obtain one from [Client.StreamChat]. Call Next in a loop until ok is
+// false, or call Aggregate to drain the stream and merge it into a single
+// [af.ChatResponse]. Callers must Close the reader.
+type StreamReader struct {
+	body    io.ReadCloser
+	scanner *bufio.Scanner
+
+	toolCalls map[toolCallKey]*toolCallAccumulator
+	order     []toolCallKey
+	openKey   *toolCallKey
+
+	updates []af.ChatResponseUpdate
+}
+
+// newStreamReader wraps an SSE response body.
+func newStreamReader(body io.ReadCloser) *StreamReader {
+	scanner := bufio.NewScanner(body)
+	// Allow large SSE lines (some responses can be substantial).
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	return &StreamReader{
+		body:      body,
+		scanner:   scanner,
+		toolCalls: make(map[toolCallKey]*toolCallAccumulator),
+	}
+}
+
+// Next reads and returns the next update from the stream. ok is false once
+// the stream is exhausted (the `[DONE]` sentinel or EOF); err is non-nil on
+// a read or parse failure.
+func (r *StreamReader) Next() (af.ChatResponseUpdate, bool, error) {
+	for r.scanner.Scan() {
+		line := r.scanner.Text()
+
+		// SSE format: lines starting with "data: ". Blank lines and
+		// keepalive comment lines (starting with ":") are ignored.
+		if !strings.HasPrefix(line, "data: ") {
+			continue
+		}
+
+		data := strings.TrimSpace(strings.TrimPrefix(line, "data: "))
+		if data == "[DONE]" {
+			return af.ChatResponseUpdate{}, false, nil
+		}
+
+		var chunk chatCompletionChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			// Skip malformed chunks rather than aborting.
+			continue
+		}
+
+		update, ok := r.absorb(&chunk)
+		if !ok {
+			continue
+		}
+		r.updates = append(r.updates, update)
+		return update, true, nil
+	}
+
+	if err := r.scanner.Err(); err != nil {
+		return af.ChatResponseUpdate{}, false, fmt.Errorf("%w: read SSE stream: %v", af.ErrService, err)
+	}
+	return af.ChatResponseUpdate{}, false, nil
+}
+
+// absorb folds chunk into the reader's tool-call accumulators and returns
+// the update to surface for it, if any. A chunk carrying only a tool-call
+// argument fragment (no role, text, finish reason, or usage) produces no
+// update — its content is folded into the accumulator and surfaces once the
+// tool call completes.
+func (r *StreamReader) absorb(chunk *chatCompletionChunk) (af.ChatResponseUpdate, bool) {
+	update := af.ChatResponseUpdate{
+		ResponseID: chunk.ID,
+		ModelID:    chunk.Model,
+	}
+
+	if chunk.Usage != nil {
+		update.Usage = af.UsageDetails{
+			InputTokens:  chunk.Usage.PromptTokens,
+			OutputTokens: chunk.Usage.CompletionTokens,
+			TotalTokens:  chunk.Usage.TotalTokens,
+		}
+		if chunk.Usage.PromptTokensDetails != nil {
+			update.Usage.CachedInputTokens = chunk.Usage.PromptTokensDetails.CachedTokens
+		}
+	}
+
+	if len(chunk.Choices) > 0 {
+		c := chunk.Choices[0]
+
+		if c.Delta.Role != "" {
+			update.Role = af.Role(c.Delta.Role)
+		}
+
+		if c.Delta.Content != nil && *c.Delta.Content != "" {
+			update.Contents = append(update.Contents, &af.TextContent{Text: *c.Delta.Content})
+		}
+
+		for _, tc := range c.Delta.ToolCalls {
+			key := toolCallKey{choice: c.Index, index: tc.Index}
+
+			// The model has moved on to a new tool call; the previous one
+			// won't receive any more argument fragments, so emit it now
+			// instead of waiting for finish_reason.
+			if r.openKey != nil && *r.openKey != key {
+				if content, ok := r.flush(*r.openKey); ok {
+					update.Contents = append(update.Contents, content)
+				}
+			}
+			r.openKey = &key
+
+			acc, ok := r.toolCalls[key]
+			if !ok {
+				acc = &toolCallAccumulator{}
+				r.toolCalls[key] = acc
+				r.order = append(r.order, key)
+			}
+			if tc.ID != "" {
+				acc.id = tc.ID
+			}
+			if tc.Function.Name != "" {
+				acc.name = tc.Function.Name
+			}
+			acc.args.WriteString(tc.Function.Arguments)
+		}
+
+		if c.FinishReason != nil {
+			update.FinishReason = mapFinishReason(*c.FinishReason)
+			for _, key := range r.order {
+				if content, ok := r.flush(key); ok {
+					update.Contents = append(update.Contents, content)
+				}
+			}
+			r.order = nil
+			r.openKey = nil
+		}
+	}
+
+	update.Raw = chunk
+
+	emit := update.Role != "" || update.FinishReason != "" || len(update.Contents) > 0 || update.Usage.TotalTokens > 0
+	return update, emit
+}
+
+// flush removes key's accumulator, if still present, and returns it as a
+// completed [af.FunctionCallContent]. ok is false if key was already
+// flushed (e.g. via an out-of-order finish_reason race).
+func (r *StreamReader) flush(key toolCallKey) (*af.FunctionCallContent, bool) {
+	acc, ok := r.toolCalls[key]
+	if !ok {
+		return nil, false
+	}
+	delete(r.toolCalls, key)
+	return &af.FunctionCallContent{
+		CallID:    acc.id,
+		Name:      acc.name,
+		Arguments: acc.args.String(),
+	}, true
+}
+
+// Aggregate drains any remaining updates from the stream and returns the
+// merged [af.ChatResponse]. After calling this, the stream is exhausted.
+func (r *StreamReader) Aggregate() (*af.ChatResponse, error) {
+	for {
+		_, ok, err := r.Next()
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			break
+		}
+	}
+	return af.ChatResponseFromUpdates(r.updates), nil
+}
+
+// Close releases the underlying response body.
+func (r *StreamReader) Close() error {
+	return r.body.Close()
+}
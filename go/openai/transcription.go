@@ -0,0 +1,188 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+// defaultTranscriptionModel is used when [WithModel] isn't set.
+const defaultTranscriptionModel = "whisper-1"
+
+// defaultSpeechModel is used by [TranscriptionClient.Synthesize] when
+// [WithModel] isn't set.
+const defaultSpeechModel = "tts-1"
+
+// TranscriptionClient implements [agentframework.TranscriptionClient] using
+// the OpenAI audio API (transcriptions, translations, and speech). Create
+// one with [NewTranscriptionClient].
+type TranscriptionClient struct {
+	tp    transport
+	model string
+}
+
+var _ af.TranscriptionClient = (*TranscriptionClient)(nil)
+
+// NewTranscriptionClient creates an OpenAI [TranscriptionClient] with the
+// given API key and options. [WithModel] sets the transcription/translation
+// model (default "whisper-1"); [Synthesize] uses "tts-1" unless overridden.
+func NewTranscriptionClient(apiKey string, opts ...Option) *TranscriptionClient {
+	cfg := &clientConfig{}
+	for _, o := range opts {
+		o(cfg)
+	}
+	return &TranscriptionClient{tp: newHTTPTransport(apiKey, cfg), model: cfg.model}
+}
+
+// Transcribe implements [agentframework.TranscriptionClient].
+func (c *TranscriptionClient) Transcribe(ctx context.Context, audio *af.AudioContent, opts *af.TranscriptionOptions) (*af.AudioTranscriptionContent, error) {
+	return c.transcribeOrTranslate(ctx, "/audio/transcriptions", audio, opts)
+}
+
+// Translate implements [agentframework.TranscriptionClient]. The OpenAI
+// translations endpoint doesn't accept a source-language hint, so
+// opts.Language is ignored; opts.Prompt still applies.
+func (c *TranscriptionClient) Translate(ctx context.Context, audio *af.AudioContent, opts *af.TranscriptionOptions) (*af.AudioTranscriptionContent, error) {
+	return c.transcribeOrTranslate(ctx, "/audio/translations", audio, opts)
+}
+
+func (c *TranscriptionClient) transcribeOrTranslate(ctx context.Context, path string, audio *af.AudioContent, opts *af.TranscriptionOptions) (*af.AudioTranscriptionContent, error) {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+
+	model := c.model
+	if model == "" {
+		model = defaultTranscriptionModel
+	}
+	if err := w.WriteField("model", model); err != nil {
+		return nil, fmt.Errorf("%w: write model field: %v", af.ErrService, err)
+	}
+	if err := w.WriteField("response_format", "verbose_json"); err != nil {
+		return nil, fmt.Errorf("%w: write response_format field: %v", af.ErrService, err)
+	}
+	if opts != nil {
+		if path == "/audio/transcriptions" && opts.Language != "" {
+			if err := w.WriteField("language", opts.Language); err != nil {
+				return nil, fmt.Errorf("%w: write language field: %v", af.ErrService, err)
+			}
+		}
+		if opts.Prompt != "" {
+			if err := w.WriteField("prompt", opts.Prompt); err != nil {
+				return nil, fmt.Errorf("%w: write prompt field: %v", af.ErrService, err)
+			}
+		}
+	}
+
+	filename, reader, err := audioReader(audio)
+	if err != nil {
+		return nil, err
+	}
+	part, err := w.CreateFormFile("file", filename)
+	if err != nil {
+		return nil, fmt.Errorf("%w: create form file: %v", af.ErrService, err)
+	}
+	if _, err := io.Copy(part, reader); err != nil {
+		return nil, fmt.Errorf("%w: write audio data: %v", af.ErrService, err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("%w: close multipart writer: %v", af.ErrService, err)
+	}
+
+	resp, err := c.tp.doMultipart(ctx, http.MethodPost, path, w.FormDataContentType(), &body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Text     string `json:"text"`
+		Language string `json:"language"`
+		Segments []struct {
+			Start float64 `json:"start"`
+			End   float64 `json:"end"`
+			Text  string  `json:"text"`
+		} `json:"segments"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("%w: parse transcription response: %v", af.ErrService, err)
+	}
+
+	segments := make([]af.AudioSegment, len(result.Segments))
+	for i, s := range result.Segments {
+		segments[i] = af.AudioSegment{Start: s.Start, End: s.End, Text: s.Text}
+	}
+	return &af.AudioTranscriptionContent{Text: result.Text, Language: result.Language, Segments: segments}, nil
+}
+
+// Synthesize implements [agentframework.TranscriptionClient] using the
+// OpenAI text-to-speech endpoint. call.Voice selects the voice (e.g.
+// "alloy"); call.Format selects the audio encoding (e.g. "mp3"), defaulting
+// to the API's own default when empty.
+func (c *TranscriptionClient) Synthesize(ctx context.Context, call *af.SpeechCallContent) (*af.SpeechResultContent, error) {
+	model := c.model
+	if model == "" {
+		model = defaultSpeechModel
+	}
+
+	req := map[string]any{
+		"model": model,
+		"input": call.Text,
+		"voice": call.Voice,
+	}
+	if call.Format != "" {
+		req["response_format"] = call.Format
+	}
+
+	resp, err := c.tp.do(ctx, http.MethodPost, "/audio/speech", req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: read synthesized audio: %v", af.ErrService, err)
+	}
+
+	return &af.SpeechResultContent{
+		CallID:    call.CallID,
+		Data:      data,
+		MediaType: resp.Header.Get("Content-Type"),
+	}, nil
+}
+
+// audioReader returns a filename and reader for audio's content, from
+// either inline data or a URI. Only "data:" URIs are supported; a
+// service-hosted or external URI must first be fetched by the caller.
+func audioReader(audio *af.AudioContent) (filename string, reader io.Reader, err error) {
+	ext := extensionFor(audio.MediaType)
+	if len(audio.Data) > 0 {
+		return "audio" + ext, bytes.NewReader(audio.Data), nil
+	}
+	return "", nil, fmt.Errorf("openai: AudioContent has no inline Data to upload (URI-based audio isn't fetched automatically)")
+}
+
+// extensionFor maps a MIME media type to a file extension OpenAI's audio
+// API can infer a format from. Falls back to ".wav" when unrecognized.
+func extensionFor(mediaType string) string {
+	switch mediaType {
+	case "audio/mpeg", "audio/mp3":
+		return ".mp3"
+	case "audio/wav", "audio/x-wav", "audio/wave":
+		return ".wav"
+	case "audio/m4a", "audio/mp4":
+		return ".m4a"
+	case "audio/webm":
+		return ".webm"
+	default:
+		return ".wav"
+	}
+}
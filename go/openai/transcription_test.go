@@ -0,0 +1,85 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package openai_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+	"github.com/microsoft/agent-framework/go/openai"
+)
+
+func TestTranscriptionClient_Transcribe(t *testing.T) {
+	var gotModel string
+	httpClient := newMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		if !strings.HasSuffix(req.URL.Path, "/audio/transcriptions") {
+			t.Errorf("path = %q", req.URL.Path)
+		}
+		if err := req.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("ParseMultipartForm: %v", err)
+		}
+		gotModel = req.FormValue("model")
+		return jsonResponse(200, map[string]any{
+			"text":     "hello world",
+			"language": "english",
+			"segments": []map[string]any{{"start": 0.0, "end": 1.2, "text": "hello world"}},
+		}), nil
+	})
+
+	client := openai.NewTranscriptionClient("test-key", openai.WithHTTPClient(httpClient))
+	result, err := client.Transcribe(context.Background(),
+		&af.AudioContent{Data: []byte("fake audio"), MediaType: "audio/wav"},
+		&af.TranscriptionOptions{Language: "en"},
+	)
+	if err != nil {
+		t.Fatalf("Transcribe: %v", err)
+	}
+	if result.Text != "hello world" || len(result.Segments) != 1 {
+		t.Errorf("result = %+v", result)
+	}
+	if gotModel != "whisper-1" {
+		t.Errorf("model = %q, want whisper-1 default", gotModel)
+	}
+}
+
+func TestTranscriptionClient_Transcribe_RequiresInlineData(t *testing.T) {
+	client := openai.NewTranscriptionClient("test-key")
+	_, err := client.Transcribe(context.Background(), &af.AudioContent{URI: "https://example.com/clip.wav"}, nil)
+	if err == nil {
+		t.Fatal("expected error for URI-only AudioContent")
+	}
+}
+
+func TestTranscriptionClient_Synthesize(t *testing.T) {
+	httpClient := newMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		if !strings.HasSuffix(req.URL.Path, "/audio/speech") {
+			t.Errorf("path = %q", req.URL.Path)
+		}
+		return &http.Response{
+			StatusCode: 200,
+			Header:     http.Header{"Content-Type": []string{"audio/mpeg"}},
+			Body:       io.NopCloser(strings.NewReader("fake mp3 bytes")),
+		}, nil
+	})
+
+	client := openai.NewTranscriptionClient("test-key", openai.WithHTTPClient(httpClient))
+	result, err := client.Synthesize(context.Background(), &af.SpeechCallContent{
+		CallID: "call-1",
+		Voice:  "alloy",
+		Format: "mp3",
+		Text:   "hi there",
+	})
+	if err != nil {
+		t.Fatalf("Synthesize: %v", err)
+	}
+	if string(result.Data) != "fake mp3 bytes" || result.MediaType != "audio/mpeg" {
+		t.Errorf("result = %+v", result)
+	}
+	if result.CallID != "call-1" {
+		t.Errorf("CallID = %q", result.CallID)
+	}
+}
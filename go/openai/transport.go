@@ -22,6 +22,7 @@ const defaultBaseURL = "https://api.openai.com/v1"
 // The default implementation uses net/http; tests inject a mock.
 type transport interface {
 	do(ctx context.Context, method, path string, body any) (*http.Response, error)
+	doMultipart(ctx context.Context, method, path, contentType string, body io.Reader) (*http.Response, error)
 }
 
 // httpTransport is the default transport using net/http.
@@ -61,15 +62,29 @@ func (t *httpTransport) do(ctx context.Context, method, path string, body any) (
 		}
 		bodyReader = bytes.NewReader(b)
 	}
+	return t.doRaw(ctx, method, path, "application/json", bodyReader)
+}
+
+// doMultipart sends a multipart/form-data request, used for endpoints like
+// file upload that don't take a JSON body.
+func (t *httpTransport) doMultipart(ctx context.Context, method, path, contentType string, body io.Reader) (*http.Response, error) {
+	return t.doRaw(ctx, method, path, contentType, body)
+}
 
+// doRaw sends a request with an already-encoded body, applying
+// authentication and shared headers, and translates a non-2xx response
+// into a typed error.
+func (t *httpTransport) doRaw(ctx context.Context, method, path, contentType string, bodyReader io.Reader) (*http.Response, error) {
 	url := t.baseURL + path
 	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
 	if err != nil {
 		return nil, fmt.Errorf("create request: %w", err)
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	
+	if bodyReader != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+
 	// Handle authentication
 	if t.azureCredential != nil {
 		// Azure AD token authentication
@@ -86,7 +101,7 @@ func (t *httpTransport) do(ctx context.Context, method, path string, body any) (
 		// API key authentication (skip if Azure "api-key" header is set)
 		req.Header.Set("Authorization", "Bearer "+t.apiKey)
 	}
-	
+
 	if t.org != "" {
 		req.Header.Set("OpenAI-Organization", t.org)
 	}
@@ -116,6 +131,12 @@ func parseErrorResponse(resp *http.Response) error {
 			Message string `json:"message"`
 			Type    string `json:"type"`
 			Code    string `json:"code"`
+
+			// InnerError carries the per-category content filter
+			// evaluation Azure OpenAI attaches to content_filter errors.
+			InnerError struct {
+				ContentFilterResult contentFilterResultWire `json:"content_filter_result"`
+			} `json:"innererror"`
 		} `json:"error"`
 	}
 	_ = json.Unmarshal(body, &apiErr)
@@ -134,6 +155,7 @@ func parseErrorResponse(resp *http.Response) error {
 	switch {
 	case apiErr.Error.Code == "content_filter":
 		svcErr.Err = af.ErrContentFilter
+		svcErr.Details = apiErr.Error.InnerError.ContentFilterResult.toContentFilterResultContent()
 	case resp.StatusCode == 401 || resp.StatusCode == 403:
 		svcErr.Err = af.ErrAuth
 	case resp.StatusCode == 400:
@@ -144,3 +166,42 @@ func parseErrorResponse(resp *http.Response) error {
 
 	return svcErr
 }
+
+// contentFilterCategoryWire is the wire shape of a single content filter
+// category within an OpenAI/Azure OpenAI content_filter error response.
+type contentFilterCategoryWire struct {
+	Filtered bool   `json:"filtered"`
+	Severity string `json:"severity"`
+	Detected bool   `json:"detected"`
+}
+
+// contentFilterResultWire is the wire shape of the innererror.content_filter_result
+// object attached to a content_filter error response.
+type contentFilterResultWire struct {
+	Hate                  contentFilterCategoryWire `json:"hate"`
+	SelfHarm              contentFilterCategoryWire `json:"self_harm"`
+	Sexual                contentFilterCategoryWire `json:"sexual"`
+	Violence              contentFilterCategoryWire `json:"violence"`
+	Jailbreak             contentFilterCategoryWire `json:"jailbreak"`
+	ProtectedMaterialCode contentFilterCategoryWire `json:"protected_material_code"`
+	ProtectedMaterialText contentFilterCategoryWire `json:"protected_material_text"`
+}
+
+func (w contentFilterCategoryWire) toResult() af.ContentFilterCategoryResult {
+	return af.ContentFilterCategoryResult{Severity: w.Severity, Filtered: w.Filtered, Detected: w.Detected}
+}
+
+// toContentFilterResultContent converts the wire shape into the shared
+// [af.ContentFilterResultContent], even when the response carried no
+// innererror (in which case every category is reported unfiltered).
+func (w contentFilterResultWire) toContentFilterResultContent() *af.ContentFilterResultContent {
+	return &af.ContentFilterResultContent{
+		Hate:                  w.Hate.toResult(),
+		SelfHarm:              w.SelfHarm.toResult(),
+		Sexual:                w.Sexual.toResult(),
+		Violence:              w.Violence.toResult(),
+		Jailbreak:             w.Jailbreak.toResult(),
+		ProtectedMaterialCode: w.ProtectedMaterialCode.toResult(),
+		ProtectedMaterialText: w.ProtectedMaterialText.toResult(),
+	}
+}
@@ -46,13 +46,12 @@ func main() {
 
 	client := newChatClient()
 
-
 	// Define tools.
 	weatherTool := af.NewTypedTool("get_weather",
 		"Get the current weather for a location.",
 		func(ctx context.Context, args struct {
 			Location string `json:"location" jsonschema:"description=City name or location,required"`
-			Unit     string `json:"unit"     jsonschema:"description=Temperature unit,enum=celsius|fahrenheit"`
+			Unit     string `json:"unit,omitempty" jsonschema:"description=Temperature unit,enum=celsius|fahrenheit"`
 		}) (any, error) {
 			// Simulated weather API
 			unit := args.Unit
@@ -151,6 +150,10 @@ func main() {
 			if resp.Usage.TotalTokens > 0 {
 				fmt.Printf("  [tokens: %d in, %d out]\n",
 					resp.Usage.InputTokens, resp.Usage.OutputTokens)
+				if resp.Usage.CachedInputTokens > 0 || resp.Usage.CacheCreationTokens > 0 {
+					fmt.Printf("  [cache: %d read, %d written]\n",
+						resp.Usage.CachedInputTokens, resp.Usage.CacheCreationTokens)
+				}
 			}
 		}
 		fmt.Println()
@@ -167,9 +170,9 @@ func newChatClient() *openai.Client {
 		if model == "" {
 			model = "gpt-4o"
 		}
-		
+
 		fmt.Printf("Using Azure AI Foundry: %s\n", endpoint)
-		
+
 		// If no key provided, use Azure AD authentication
 		if key == "" {
 			fmt.Println("Using Azure AD authentication (DefaultAzureCredential)")
@@ -185,7 +188,7 @@ func newChatClient() *openai.Client {
 				openai.WithAzureCredential(cred),
 			)
 		}
-		
+
 		// API key authentication
 		fmt.Println("Using API key authentication")
 		return openai.New(key,
@@ -0,0 +1,166 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+// maxFetchBytes caps how much of a remote "file" part's uri this server will
+// download, so a malicious or oversized attachment can't exhaust memory.
+const maxFetchBytes = 10 << 20 // 10 MiB
+
+// allowedFetchSchemes lists the uri schemes a "file" part may be fetched
+// over; anything else (e.g. file://) is rejected.
+var allowedFetchSchemes = map[string]bool{"http": true, "https": true}
+
+var fetchClient = &http.Client{Timeout: 10 * time.Second}
+
+// a2aPartsToContents converts A2A message parts into [af.Content], fetching
+// and decoding file attachments so multi-modal models receive them directly
+// rather than a bare URI the model can't reach.
+func a2aPartsToContents(ctx context.Context, parts []a2aPart) (af.Contents, error) {
+	var contents af.Contents
+	for _, part := range parts {
+		switch part.Kind {
+		case "text":
+			if part.Text != "" {
+				contents = append(contents, &af.TextContent{Text: part.Text})
+			}
+
+		case "file":
+			c, err := a2aFileToContent(ctx, part.File)
+			if err != nil {
+				return nil, fmt.Errorf("file part: %w", err)
+			}
+			contents = append(contents, c)
+
+		case "data":
+			var v any
+			if err := json.Unmarshal(part.Data, &v); err != nil {
+				return nil, fmt.Errorf("data part: %w", err)
+			}
+			contents = append(contents, &af.StructuredDataContent{Data: v})
+
+		default:
+			log.Printf("[a2a] ignoring unsupported part kind %q", part.Kind)
+		}
+	}
+	return contents, nil
+}
+
+// a2aFileToContent decodes an A2A "file" part's inline bytes, or fetches its
+// uri, into an [af.DataContent]. If the uri can't be fetched (disallowed
+// scheme, too large, network error), it falls back to an [af.URIContent]
+// reference rather than failing the whole message.
+func a2aFileToContent(ctx context.Context, file *a2aFile) (af.Content, error) {
+	if file == nil {
+		return nil, fmt.Errorf("missing file payload")
+	}
+	if file.Bytes != "" {
+		return &af.DataContent{
+			URI:       "data:" + file.MimeType + ";base64," + file.Bytes,
+			MediaType: file.MimeType,
+		}, nil
+	}
+	if file.URI == "" {
+		return nil, fmt.Errorf("file part has neither bytes nor uri")
+	}
+
+	data, mediaType, err := fetchFile(ctx, file.URI)
+	if err != nil {
+		log.Printf("[a2a] failed to fetch file %s: %v; passing through as a URI reference", file.URI, err)
+		return &af.URIContent{URI: file.URI, MediaType: file.MimeType}, nil
+	}
+	if mediaType == "" {
+		mediaType = file.MimeType
+	}
+	return &af.DataContent{
+		URI:       "data:" + mediaType + ";base64," + base64.StdEncoding.EncodeToString(data),
+		MediaType: mediaType,
+	}, nil
+}
+
+// fetchFile retrieves uri's content over HTTP(S), enforcing
+// [allowedFetchSchemes] and [maxFetchBytes].
+func fetchFile(ctx context.Context, uri string) (data []byte, mediaType string, err error) {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid uri: %w", err)
+	}
+	if !allowedFetchSchemes[parsed.Scheme] {
+		return nil, "", fmt.Errorf("scheme %q not allowed", parsed.Scheme)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	resp, err := fetchClient.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxFetchBytes+1))
+	if err != nil {
+		return nil, "", err
+	}
+	if len(body) > maxFetchBytes {
+		return nil, "", fmt.Errorf("exceeds %d byte limit", maxFetchBytes)
+	}
+	return body, resp.Header.Get("Content-Type"), nil
+}
+
+// contentsToA2AParts converts [af.Content] produced by the agent — e.g.
+// binary artifacts from a tool call — back into A2A message parts.
+func contentsToA2AParts(contents af.Contents) []a2aPart {
+	var parts []a2aPart
+	for _, c := range contents {
+		switch v := c.(type) {
+		case *af.TextContent:
+			if v.Text != "" {
+				parts = append(parts, a2aPart{Kind: "text", Text: v.Text})
+			}
+
+		case *af.DataContent:
+			parts = append(parts, a2aPart{Kind: "file", File: dataURIToA2AFile(v.URI, v.MediaType)})
+
+		case *af.URIContent:
+			parts = append(parts, a2aPart{Kind: "file", File: &a2aFile{URI: v.URI, MimeType: v.MediaType}})
+
+		case *af.StructuredDataContent:
+			data, err := json.Marshal(v.Data)
+			if err != nil {
+				log.Printf("[a2a] failed to encode structured data part: %v", err)
+				continue
+			}
+			parts = append(parts, a2aPart{Kind: "data", Data: data})
+		}
+	}
+	return parts
+}
+
+// dataURIToA2AFile splits a "data:<mediaType>;base64,<bytes>" URI back into
+// an A2A file part's mimeType/bytes fields.
+func dataURIToA2AFile(dataURI, mediaType string) *a2aFile {
+	const marker = ";base64,"
+	if idx := strings.Index(dataURI, marker); idx >= 0 {
+		return &a2aFile{MimeType: mediaType, Bytes: dataURI[idx+len(marker):]}
+	}
+	return &a2aFile{MimeType: mediaType, URI: dataURI}
+}
@@ -0,0 +1,452 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package main
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Principal is the verified identity of an authenticated request.
+type Principal struct {
+	Subject string
+	Scopes  []string
+	Claims  map[string]any
+}
+
+// HasScope reports whether the principal was granted scope.
+func (p *Principal) HasScope(scope string) bool {
+	if p == nil {
+		return false
+	}
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+type principalCtxKey struct{}
+
+// withPrincipal attaches principal to ctx, for handlers downstream of
+// [agentServer.authenticate] to read via [PrincipalFromContext].
+func withPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalCtxKey{}, principal)
+}
+
+// PrincipalFromContext returns the principal authenticated by an
+// [Authenticator] for this request, if any.
+func PrincipalFromContext(ctx context.Context) *Principal {
+	p, _ := ctx.Value(principalCtxKey{}).(*Principal)
+	return p
+}
+
+// ErrUnauthenticated is returned by an [Authenticator] when the request
+// carries no, or invalid, credentials.
+var ErrUnauthenticated = errors.New("unauthenticated")
+
+// Authenticator verifies a request's credentials and reports what security
+// scheme it implements, so [newAgentServer] can both enforce auth and
+// advertise it accurately in agent-card.json.
+type Authenticator interface {
+	// Authenticate verifies r's credentials and returns the resulting
+	// [Principal], or [ErrUnauthenticated] (optionally wrapped) if the
+	// request is not authenticated.
+	Authenticate(r *http.Request) (*Principal, error)
+
+	// SecurityScheme describes this authenticator as an A2A/OpenAPI-style
+	// security scheme, for advertising in agent-card.json.
+	SecurityScheme() map[string]any
+}
+
+func extractBearer(r *http.Request) string {
+	h := r.Header.Get("Authorization")
+	const prefix = "Bearer "
+	if len(h) > len(prefix) && h[:len(prefix)] == prefix {
+		return h[len(prefix):]
+	}
+	return ""
+}
+
+// ── Static key ─────────────────────────────────────────────────────
+
+// staticKeyAuthenticator authenticates requests against a single shared
+// bearer token, granting the fixed set of scopes configured at construction.
+type staticKeyAuthenticator struct {
+	key    string
+	scopes []string
+}
+
+// NewStaticKeyAuthenticator authenticates requests whose bearer token
+// equals key, the same behavior [newAgentServer] previously hardcoded.
+func NewStaticKeyAuthenticator(key string, scopes ...string) Authenticator {
+	return &staticKeyAuthenticator{key: key, scopes: scopes}
+}
+
+func (a *staticKeyAuthenticator) Authenticate(r *http.Request) (*Principal, error) {
+	if token := extractBearer(r); token != "" && token == a.key {
+		return &Principal{Subject: "static-key", Scopes: a.scopes}, nil
+	}
+	return nil, ErrUnauthenticated
+}
+
+func (a *staticKeyAuthenticator) SecurityScheme() map[string]any {
+	return map[string]any{"type": "apiKey", "scheme": "bearer"}
+}
+
+// ── JWT (JWKS) ─────────────────────────────────────────────────────
+
+// JWTAuthenticatorOption configures a [JWTAuthenticator].
+type JWTAuthenticatorOption func(*JWTAuthenticator)
+
+// WithJWKSRefreshInterval overrides how often the JWKS key set is
+// refreshed in the background. Default is 1 hour.
+func WithJWKSRefreshInterval(d time.Duration) JWTAuthenticatorOption {
+	return func(a *JWTAuthenticator) { a.jwks.refreshInterval = d }
+}
+
+// WithRequiredScope rejects tokens that don't carry scope among their
+// "scope" (space-delimited) or "scp" (array) claim.
+func WithRequiredScope(scope string) JWTAuthenticatorOption {
+	return func(a *JWTAuthenticator) { a.requiredScope = scope }
+}
+
+// JWTAuthenticator verifies RS256-signed bearer JWTs against a JWKS
+// endpoint, checking issuer, audience, expiry, and (if configured) a
+// required scope. Keys are refreshed periodically so rotation on the
+// identity provider's side doesn't require a restart.
+type JWTAuthenticator struct {
+	issuer        string
+	audience      string
+	requiredScope string
+	jwks          *jwksCache
+}
+
+// NewJWTAuthenticator verifies tokens signed by a key published at jwksURL,
+// requiring iss == issuer and aud to contain audience.
+func NewJWTAuthenticator(jwksURL, issuer, audience string, opts ...JWTAuthenticatorOption) *JWTAuthenticator {
+	a := &JWTAuthenticator{
+		issuer:   issuer,
+		audience: audience,
+		jwks:     newJWKSCache(jwksURL),
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	a.jwks.start()
+	return a
+}
+
+func (a *JWTAuthenticator) Authenticate(r *http.Request) (*Principal, error) {
+	token := extractBearer(r)
+	if token == "" {
+		return nil, ErrUnauthenticated
+	}
+
+	claims, err := a.verify(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnauthenticated, err)
+	}
+
+	if iss, _ := claims["iss"].(string); iss != a.issuer {
+		return nil, fmt.Errorf("%w: unexpected issuer %q", ErrUnauthenticated, iss)
+	}
+	if !audienceMatches(claims["aud"], a.audience) {
+		return nil, fmt.Errorf("%w: token not valid for this audience", ErrUnauthenticated)
+	}
+	if exp, ok := claims["exp"].(float64); ok && time.Now().After(time.Unix(int64(exp), 0)) {
+		return nil, fmt.Errorf("%w: token expired", ErrUnauthenticated)
+	}
+
+	scopes := parseScopes(claims)
+	if a.requiredScope != "" && !containsString(scopes, a.requiredScope) {
+		return nil, fmt.Errorf("%w: missing required scope %q", ErrUnauthenticated, a.requiredScope)
+	}
+
+	sub, _ := claims["sub"].(string)
+	return &Principal{Subject: sub, Scopes: scopes, Claims: claims}, nil
+}
+
+func (a *JWTAuthenticator) SecurityScheme() map[string]any {
+	return map[string]any{
+		"type":             "oauth2",
+		"bearerFormat":     "JWT",
+		"openIdConnectUrl": a.jwks.url,
+	}
+}
+
+// verify checks token's signature against the cached JWKS and returns its
+// claims, without validating issuer/audience/expiry (the caller does that).
+func (a *JWTAuthenticator) verify(token string) (map[string]any, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("malformed JWT")
+	}
+
+	header, err := decodeJWTSegment(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("decode header: %w", err)
+	}
+	var hdr struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := json.Unmarshal(header, &hdr); err != nil {
+		return nil, fmt.Errorf("parse header: %w", err)
+	}
+	if hdr.Alg != "RS256" {
+		return nil, fmt.Errorf("unsupported alg %q", hdr.Alg)
+	}
+
+	key, err := a.jwks.key(hdr.Kid)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyRS256(parts[0]+"."+parts[1], parts[2], key); err != nil {
+		return nil, err
+	}
+
+	payload, err := decodeJWTSegment(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("decode payload: %w", err)
+	}
+	var claims map[string]any
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("parse claims: %w", err)
+	}
+	return claims, nil
+}
+
+func decodeJWTSegment(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(s)
+}
+
+// verifyRS256 checks that sig (base64url, no padding) is a valid RS256
+// signature over signedContent (the JWT's "header.payload").
+func verifyRS256(signedContent, sig string, key *rsa.PublicKey) error {
+	sigBytes, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil {
+		return fmt.Errorf("decode signature: %w", err)
+	}
+	hashed := sha256.Sum256([]byte(signedContent))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, hashed[:], sigBytes); err != nil {
+		return fmt.Errorf("signature verification failed: %w", err)
+	}
+	return nil
+}
+
+func audienceMatches(aud any, want string) bool {
+	switch v := aud.(type) {
+	case string:
+		return v == want
+	case []any:
+		for _, a := range v {
+			if s, ok := a.(string); ok && s == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parseScopes reads the "scope" (OAuth2, space-delimited string) or "scp"
+// (array form some providers use) claim into a slice.
+func parseScopes(claims map[string]any) []string {
+	if s, ok := claims["scope"].(string); ok {
+		return strings.Fields(s)
+	}
+	if arr, ok := claims["scp"].([]any); ok {
+		scopes := make([]string, 0, len(arr))
+		for _, v := range arr {
+			if s, ok := v.(string); ok {
+				scopes = append(scopes, s)
+			}
+		}
+		return scopes
+	}
+	return nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// ── JWKS cache ─────────────────────────────────────────────────────
+
+// jwksCache fetches and periodically refreshes a JSON Web Key Set, caching
+// the response with its ETag so unchanged key sets are a cheap conditional
+// GET. Rotation on the identity provider's side (a new kid appearing, an
+// old one disappearing) is picked up on the next refresh without a restart.
+type jwksCache struct {
+	url             string
+	client          *http.Client
+	refreshInterval time.Duration
+
+	mu   sync.RWMutex
+	keys map[string]*rsa.PublicKey
+	etag string
+}
+
+func newJWKSCache(url string) *jwksCache {
+	return &jwksCache{
+		url:             url,
+		client:          &http.Client{Timeout: 10 * time.Second},
+		refreshInterval: time.Hour,
+		keys:            make(map[string]*rsa.PublicKey),
+	}
+}
+
+// start performs an initial synchronous fetch (best-effort) and launches
+// the background refresh loop.
+func (c *jwksCache) start() {
+	if err := c.refresh(context.Background()); err != nil {
+		log.Printf("[auth] initial JWKS fetch from %s failed: %v", c.url, err)
+	}
+	go func() {
+		ticker := time.NewTicker(c.refreshInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			if err := c.refresh(context.Background()); err != nil {
+				log.Printf("[auth] JWKS refresh from %s failed: %v", c.url, err)
+			}
+		}
+	}()
+}
+
+func (c *jwksCache) key(kid string) (*rsa.PublicKey, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	key, ok := c.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("no JWKS key for kid %q", kid)
+	}
+	return key, nil
+}
+
+func (c *jwksCache) refresh(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
+	if err != nil {
+		return err
+	}
+	c.mu.RLock()
+	etag := c.etag
+	c.mu.RUnlock()
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	var jwks struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&jwks); err != nil {
+		return fmt.Errorf("decode JWKS: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(jwks.Keys))
+	for _, k := range jwks.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+		pub, err := rsaPublicKeyFromJWK(k.N, k.E)
+		if err != nil {
+			log.Printf("[auth] skipping JWKS key %s: %v", k.Kid, err)
+			continue
+		}
+		keys[k.Kid] = pub
+	}
+
+	c.mu.Lock()
+	c.keys = keys
+	c.etag = resp.Header.Get("ETag")
+	c.mu.Unlock()
+	return nil
+}
+
+func rsaPublicKeyFromJWK(nB64, eB64 string) (*rsa.PublicKey, error) {
+	nBytes, err := base64.RawURLEncoding.DecodeString(nB64)
+	if err != nil {
+		return nil, fmt.Errorf("decode n: %w", err)
+	}
+	eBytes, err := base64.RawURLEncoding.DecodeString(eB64)
+	if err != nil {
+		return nil, fmt.Errorf("decode e: %w", err)
+	}
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(nBytes),
+		E: int(new(big.Int).SetBytes(eBytes).Int64()),
+	}, nil
+}
+
+// ── OIDC discovery ───────────────────────────────────────────────────
+
+// NewOIDCAuthenticator discovers issuerURL's "/.well-known/openid-configuration"
+// document, validates its advertised issuer matches issuerURL, and builds a
+// [JWTAuthenticator] pointed at the discovered jwks_uri.
+func NewOIDCAuthenticator(ctx context.Context, issuerURL, audience string, opts ...JWTAuthenticatorOption) (*JWTAuthenticator, error) {
+	discoveryURL := strings.TrimRight(issuerURL, "/") + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, discoveryURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := (&http.Client{Timeout: 10 * time.Second}).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch %s: %w", discoveryURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch %s: unexpected status %d", discoveryURL, resp.StatusCode)
+	}
+
+	var doc struct {
+		Issuer  string `json:"issuer"`
+		JWKSURI string `json:"jwks_uri"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("decode discovery document: %w", err)
+	}
+	if doc.Issuer != issuerURL {
+		return nil, fmt.Errorf("discovery document issuer %q does not match %q", doc.Issuer, issuerURL)
+	}
+	if doc.JWKSURI == "" {
+		return nil, fmt.Errorf("discovery document has no jwks_uri")
+	}
+
+	a := NewJWTAuthenticator(doc.JWKSURI, doc.Issuer, audience, opts...)
+	return a, nil
+}
@@ -0,0 +1,330 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+// sqlBranchingStore is an [af.BranchingMessageStore] backed by any
+// database/sql driver, so an "edit and resubmit" conversation history
+// survives a restart the same way [sqlSessionStore] does for whole
+// sessions. Every message is a row with a parent pointer (mirroring
+// [af.InMemoryBranchingStore]'s in-memory tree), and a small meta table
+// tracks the active branch so it stays durable across processes.
+//
+// The caller must create three tables ahead of time:
+//
+//	CREATE TABLE <table>_messages (id TEXT PRIMARY KEY, parent_id TEXT, data TEXT)
+//	CREATE TABLE <table>_branches (id TEXT PRIMARY KEY, tip TEXT, parent_branch TEXT, fork_point TEXT)
+//	CREATE TABLE <table>_meta (key TEXT PRIMARY KEY, value TEXT)
+type sqlBranchingStore struct {
+	db     *sql.DB
+	prefix string
+}
+
+// NewSQLBranchingStore adapts db into an [af.BranchingMessageStore], storing
+// branches and messages under tables named "<table>_messages",
+// "<table>_branches", and "<table>_meta" (see the schema in the package
+// doc comment, which the caller must create ahead of time). Any
+// database/sql driver works, since only standard SQL is used.
+func NewSQLBranchingStore(ctx context.Context, db *sql.DB, table string) (af.BranchingMessageStore, error) {
+	s := &sqlBranchingStore{db: db, prefix: table}
+	if _, err := s.currentBranch(ctx); err != nil {
+		if err := s.setMeta(ctx, "current", rootBranch); err != nil {
+			return nil, fmt.Errorf("initialize branching store: %w", err)
+		}
+		if _, err := s.db.ExecContext(ctx,
+			fmt.Sprintf("INSERT INTO %s_branches (id, tip, parent_branch, fork_point) VALUES (?, ?, ?, ?) ON CONFLICT(id) DO NOTHING", s.prefix),
+			rootBranch, "", "", ""); err != nil {
+			return nil, fmt.Errorf("initialize branching store: %w", err)
+		}
+	}
+	return s, nil
+}
+
+// rootBranch is the branch a fresh [sqlBranchingStore] starts on.
+const rootBranch = "root"
+
+var _ af.BranchingMessageStore = (*sqlBranchingStore)(nil)
+
+func (s *sqlBranchingStore) currentBranch(ctx context.Context) (string, error) {
+	row := s.db.QueryRowContext(ctx, fmt.Sprintf("SELECT value FROM %s_meta WHERE key = 'current'", s.prefix))
+	var branch string
+	if err := row.Scan(&branch); err != nil {
+		return "", err
+	}
+	return branch, nil
+}
+
+func (s *sqlBranchingStore) setMeta(ctx context.Context, key, value string) error {
+	_, err := s.db.ExecContext(ctx,
+		fmt.Sprintf("INSERT INTO %s_meta (key, value) VALUES (?, ?) ON CONFLICT(key) DO UPDATE SET value = excluded.value", s.prefix),
+		key, value)
+	return err
+}
+
+func (s *sqlBranchingStore) branchTip(ctx context.Context, branchID string) (string, error) {
+	row := s.db.QueryRowContext(ctx, fmt.Sprintf("SELECT tip FROM %s_branches WHERE id = ?", s.prefix), branchID)
+	var tip string
+	if err := row.Scan(&tip); err != nil {
+		if err == sql.ErrNoRows {
+			return "", fmt.Errorf("%w: branch %q not found", af.ErrSession, branchID)
+		}
+		return "", err
+	}
+	return tip, nil
+}
+
+// lineage walks parent pointers from tipID back to the root and returns the
+// messages in root-to-tip order.
+func (s *sqlBranchingStore) lineage(ctx context.Context, tipID string) ([]af.Message, error) {
+	var reversed []af.Message
+	for id := tipID; id != ""; {
+		row := s.db.QueryRowContext(ctx, fmt.Sprintf("SELECT parent_id, data FROM %s_messages WHERE id = ?", s.prefix), id)
+		var parentID string
+		var data string
+		if err := row.Scan(&parentID, &data); err != nil {
+			if err == sql.ErrNoRows {
+				break
+			}
+			return nil, err
+		}
+		var msg af.Message
+		if err := json.Unmarshal([]byte(data), &msg); err != nil {
+			return nil, fmt.Errorf("decode message %s: %w", id, err)
+		}
+		reversed = append(reversed, msg)
+		id = parentID
+	}
+	msgs := make([]af.Message, len(reversed))
+	for i, m := range reversed {
+		msgs[len(reversed)-1-i] = m
+	}
+	return msgs, nil
+}
+
+func (s *sqlBranchingStore) ListMessages(ctx context.Context) ([]af.Message, error) {
+	current, err := s.currentBranch(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("load current branch: %w", err)
+	}
+	tip, err := s.branchTip(ctx, current)
+	if err != nil {
+		return nil, err
+	}
+	return s.lineage(ctx, tip)
+}
+
+func (s *sqlBranchingStore) AddMessages(ctx context.Context, msgs []af.Message) error {
+	current, err := s.currentBranch(ctx)
+	if err != nil {
+		return fmt.Errorf("load current branch: %w", err)
+	}
+	parent, err := s.branchTip(ctx, current)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for i := range msgs {
+		if msgs[i].MessageID == "" {
+			msgs[i].MessageID = s.generateID(ctx)
+		}
+		data, err := json.Marshal(msgs[i])
+		if err != nil {
+			return fmt.Errorf("encode message: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx,
+			fmt.Sprintf("INSERT INTO %s_messages (id, parent_id, data) VALUES (?, ?, ?)", s.prefix),
+			msgs[i].MessageID, parent, string(data)); err != nil {
+			return err
+		}
+		parent = msgs[i].MessageID
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		fmt.Sprintf("INSERT INTO %s_branches (id, tip) VALUES (?, ?) ON CONFLICT(id) DO UPDATE SET tip = excluded.tip", s.prefix),
+		current, parent); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *sqlBranchingStore) generateID(ctx context.Context) string {
+	var n int
+	row := s.db.QueryRowContext(ctx, fmt.Sprintf("SELECT count(*) FROM %s_messages", s.prefix))
+	_ = row.Scan(&n)
+	return "msg-" + strconv.Itoa(n+1)
+}
+
+// Fork creates a new branch at atMessageID, extending the cut point forward
+// through any unanswered tool calls (see [af.BranchingMessageStore.Fork]),
+// and switches to it.
+func (s *sqlBranchingStore) Fork(ctx context.Context, atMessageID string) (string, error) {
+	current, err := s.currentBranch(ctx)
+	if err != nil {
+		return "", fmt.Errorf("load current branch: %w", err)
+	}
+	tip, err := s.branchTip(ctx, current)
+	if err != nil {
+		return "", err
+	}
+	full, err := s.lineage(ctx, tip)
+	if err != nil {
+		return "", err
+	}
+
+	cut := -1
+	for i, m := range full {
+		if m.MessageID == atMessageID {
+			cut = i
+			break
+		}
+	}
+	if cut == -1 {
+		return "", fmt.Errorf("%w: message %q not found", af.ErrSession, atMessageID)
+	}
+
+	pending := map[string]bool{}
+	trackToolCalls(full[:cut+1], pending)
+	forkTip := atMessageID
+	for i := cut + 1; i < len(full) && len(pending) > 0; i++ {
+		trackToolCalls([]af.Message{full[i]}, pending)
+		forkTip = full[i].MessageID
+	}
+
+	var branchCount int
+	row := s.db.QueryRowContext(ctx, fmt.Sprintf("SELECT count(*) FROM %s_branches", s.prefix))
+	if err := row.Scan(&branchCount); err != nil {
+		return "", err
+	}
+	branchID := "branch-" + strconv.Itoa(branchCount+1)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx,
+		fmt.Sprintf("INSERT INTO %s_branches (id, tip, parent_branch, fork_point) VALUES (?, ?, ?, ?)", s.prefix),
+		branchID, forkTip, current, atMessageID); err != nil {
+		return "", err
+	}
+	if _, err := tx.ExecContext(ctx,
+		fmt.Sprintf("INSERT INTO %s_meta (key, value) VALUES ('current', ?) ON CONFLICT(key) DO UPDATE SET value = excluded.value", s.prefix),
+		branchID); err != nil {
+		return "", err
+	}
+	if err := tx.Commit(); err != nil {
+		return "", err
+	}
+	return branchID, nil
+}
+
+// trackToolCalls records each af.FunctionCallContent's CallID in pending and
+// clears it on a matching af.FunctionResultContent.
+func trackToolCalls(msgs []af.Message, pending map[string]bool) {
+	for _, m := range msgs {
+		for _, c := range m.Contents {
+			switch v := c.(type) {
+			case *af.FunctionCallContent:
+				pending[v.CallID] = true
+			case *af.FunctionResultContent:
+				delete(pending, v.CallID)
+			}
+		}
+	}
+}
+
+func (s *sqlBranchingStore) SwitchBranch(ctx context.Context, branchID string) error {
+	if _, err := s.branchTip(ctx, branchID); err != nil {
+		return err
+	}
+	return s.setMeta(ctx, "current", branchID)
+}
+
+func (s *sqlBranchingStore) ListBranches(ctx context.Context) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf("SELECT id FROM %s_branches ORDER BY id", s.prefix))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// Branches returns every branch's [af.BranchInfo], sorted by ID.
+func (s *sqlBranchingStore) Branches(ctx context.Context) ([]af.BranchInfo, error) {
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf("SELECT id, parent_branch, fork_point FROM %s_branches ORDER BY id", s.prefix))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var infos []af.BranchInfo
+	for rows.Next() {
+		var info af.BranchInfo
+		if err := rows.Scan(&info.ID, &info.ParentID, &info.ForkPoint); err != nil {
+			return nil, err
+		}
+		infos = append(infos, info)
+	}
+	return infos, rows.Err()
+}
+
+// Truncate discards every message after messageID on the active branch, in
+// place, without creating a new branch (unlike [sqlBranchingStore.Fork]).
+func (s *sqlBranchingStore) Truncate(ctx context.Context, messageID string) error {
+	current, err := s.currentBranch(ctx)
+	if err != nil {
+		return fmt.Errorf("load current branch: %w", err)
+	}
+	tip, err := s.branchTip(ctx, current)
+	if err != nil {
+		return err
+	}
+	full, err := s.lineage(ctx, tip)
+	if err != nil {
+		return err
+	}
+	found := false
+	for _, m := range full {
+		if m.MessageID == messageID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("%w: message %q not in active branch", af.ErrSession, messageID)
+	}
+
+	_, err = s.db.ExecContext(ctx,
+		fmt.Sprintf("UPDATE %s_branches SET tip = ? WHERE id = ?", s.prefix), messageID, current)
+	return err
+}
+
+func (s *sqlBranchingStore) Serialize() (map[string]any, error) {
+	return map[string]any{"backend": "sql", "table": s.prefix}, nil
+}
@@ -0,0 +1,99 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+// driveInfo describes one mounted filesystem, as returned by the
+// platform-specific enumerateDrives implementation.
+type driveInfo struct {
+	Mountpoint string
+	FSType     string
+	TotalBytes uint64
+	FreeBytes  uint64
+}
+
+// pseudoFSTypes lists filesystem types that don't represent real storage
+// and are excluded from get_disk_space results unless include_pseudo is set.
+var pseudoFSTypes = map[string]bool{
+	"tmpfs":       true,
+	"proc":        true,
+	"sysfs":       true,
+	"overlay":     true,
+	"devtmpfs":    true,
+	"cgroup":      true,
+	"cgroup2":     true,
+	"devpts":      true,
+	"mqueue":      true,
+	"securityfs":  true,
+	"debugfs":     true,
+	"tracefs":     true,
+	"configfs":    true,
+	"pstore":      true,
+	"bpf":         true,
+	"autofs":      true,
+	"binfmt_misc": true,
+}
+
+// diskSpaceTool returns a tool that reports disk space for every mounted
+// filesystem on the host machine, real filesystems only by default.
+func diskSpaceTool() af.Tool {
+	return af.NewTypedTool(
+		"get_disk_space",
+		"Gets available disk space for all drives/filesystems on the host machine",
+		func(ctx context.Context, args struct {
+			IncludePseudo bool `json:"include_pseudo,omitempty" jsonschema:"description=Include pseudo filesystems such as tmpfs, proc, and overlay"`
+		}) (any, error) {
+			all, err := enumerateDrives()
+			if err != nil {
+				return nil, &af.ToolError{
+					ToolName: "get_disk_space",
+					Message:  fmt.Sprintf("failed to enumerate drives: %v", err),
+				}
+			}
+
+			drives := make([]map[string]any, 0, len(all))
+			for _, d := range all {
+				if !args.IncludePseudo && pseudoFSTypes[d.FSType] {
+					continue
+				}
+
+				usedBytes := d.TotalBytes - d.FreeBytes
+				totalKB := d.TotalBytes / 1024
+				freeKB := d.FreeBytes / 1024
+				usedKB := usedBytes / 1024
+
+				percentUsed := "0.0%"
+				if d.TotalBytes > 0 {
+					percentUsed = fmt.Sprintf("%.1f%%", float64(usedKB)/float64(totalKB)*100)
+				}
+
+				drives = append(drives, map[string]any{
+					"mountpoint":     d.Mountpoint,
+					"fstype":         d.FSType,
+					"total_readable": formatBytes(d.TotalBytes),
+					"free_readable":  formatBytes(d.FreeBytes),
+					"used_readable":  formatBytes(usedBytes),
+					"total_kb":       totalKB,
+					"free_kb":        freeKB,
+					"used_kb":        usedKB,
+					"percent_used":   percentUsed,
+				})
+			}
+
+			if len(drives) == 0 {
+				return nil, &af.ToolError{
+					ToolName: "get_disk_space",
+					Message:  "no drives found",
+				}
+			}
+
+			return map[string]any{"drives": drives}, nil
+		},
+	)
+}
@@ -0,0 +1,49 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+//go:build darwin || dragonfly || freebsd || openbsd
+
+package main
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/unix"
+)
+
+// enumerateDrives lists every mounted filesystem via getfsstat(2), which
+// returns capacity and mount info for all of them in a single call.
+func enumerateDrives() ([]driveInfo, error) {
+	n, err := unix.Getfsstat(nil, unix.MNT_NOWAIT)
+	if err != nil {
+		return nil, fmt.Errorf("getfsstat: %w", err)
+	}
+
+	stats := make([]unix.Statfs_t, n)
+	if _, err := unix.Getfsstat(stats, unix.MNT_NOWAIT); err != nil {
+		return nil, fmt.Errorf("getfsstat: %w", err)
+	}
+
+	drives := make([]driveInfo, 0, len(stats))
+	for _, s := range stats {
+		blockSize := uint64(s.Bsize)
+		drives = append(drives, driveInfo{
+			Mountpoint: unixBytesToString(s.Mntonname[:]),
+			FSType:     unixBytesToString(s.Fstypename[:]),
+			TotalBytes: s.Blocks * blockSize,
+			FreeBytes:  s.Bfree * blockSize,
+		})
+	}
+
+	return drives, nil
+}
+
+// unixBytesToString converts a NUL-terminated fixed-size byte array field
+// (as used by statfs's Mntonname/Fstypename) to a Go string.
+func unixBytesToString(b []byte) string {
+	for i, c := range b {
+		if c == 0 {
+			return string(b[:i])
+		}
+	}
+	return string(b)
+}
@@ -0,0 +1,51 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+//go:build linux
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+)
+
+// enumerateDrives parses /proc/mounts for every mounted filesystem and
+// statfs's each one for its capacity.
+func enumerateDrives() ([]driveInfo, error) {
+	f, err := os.Open("/proc/mounts")
+	if err != nil {
+		return nil, fmt.Errorf("open /proc/mounts: %w", err)
+	}
+	defer f.Close()
+
+	var drives []driveInfo
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 {
+			continue
+		}
+		mountpoint, fstype := fields[1], fields[2]
+
+		var stat syscall.Statfs_t
+		if err := syscall.Statfs(mountpoint, &stat); err != nil {
+			continue // unmounted between reading the line and statfs, or inaccessible
+		}
+
+		blockSize := uint64(stat.Bsize)
+		drives = append(drives, driveInfo{
+			Mountpoint: mountpoint,
+			FSType:     fstype,
+			TotalBytes: stat.Blocks * blockSize,
+			FreeBytes:  stat.Bfree * blockSize,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read /proc/mounts: %w", err)
+	}
+
+	return drives, nil
+}
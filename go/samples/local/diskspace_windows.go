@@ -0,0 +1,48 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+//go:build windows
+
+package main
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/windows"
+)
+
+// enumerateDrives returns disk usage for every ready drive letter A–Z.
+func enumerateDrives() ([]driveInfo, error) {
+	var drives []driveInfo
+
+	for _, letter := range "ABCDEFGHIJKLMNOPQRSTUVWXYZ" {
+		root := string(letter) + ":\\"
+		rootPtr, err := windows.UTF16PtrFromString(root)
+		if err != nil {
+			return nil, fmt.Errorf("encode drive path %s: %w", root, err)
+		}
+
+		var freeBytesAvailable, totalBytes, totalFreeBytes uint64
+		if err := windows.GetDiskFreeSpaceEx(rootPtr, &freeBytesAvailable, &totalBytes, &totalFreeBytes); err != nil {
+			continue // drive doesn't exist or isn't ready
+		}
+
+		drives = append(drives, driveInfo{
+			Mountpoint: root,
+			FSType:     volumeFSType(rootPtr),
+			TotalBytes: totalBytes,
+			FreeBytes:  totalFreeBytes,
+		})
+	}
+
+	return drives, nil
+}
+
+// volumeFSType returns root's filesystem name (e.g. "NTFS", "FAT32"), or
+// "unknown" if it can't be determined.
+func volumeFSType(root *uint16) string {
+	var fsNameBuf [32]uint16
+	if err := windows.GetVolumeInformation(root, nil, 0, nil, nil, nil, &fsNameBuf[0], uint32(len(fsNameBuf))); err != nil {
+		return "unknown"
+	}
+	return windows.UTF16ToString(fsNameBuf[:])
+}
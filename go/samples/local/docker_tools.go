@@ -0,0 +1,486 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"sync"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/client"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+// DockerToolset exposes the host's Docker Engine as agent tools, backed by
+// github.com/docker/docker/client rather than shelling out to the docker
+// CLI, so DOCKER_HOST, TLS env vars, and rootless sockets behave the same
+// way they would for the CLI itself. The underlying client is created
+// lazily and shared across every tool GetTools returns.
+type DockerToolset struct {
+	mu  sync.Mutex
+	cli *client.Client
+}
+
+// client returns the shared Docker API client, negotiating the API version
+// against whatever daemon DOCKER_HOST (or the platform default) points at,
+// constructing it on first use.
+func (d *DockerToolset) client() (*client.Client, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.cli != nil {
+		return d.cli, nil
+	}
+	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	if err != nil {
+		return nil, err
+	}
+	d.cli = cli
+	return cli, nil
+}
+
+// dockerUnavailableError reports that the Docker daemon couldn't be reached
+// at all, as distinct from [dockerOperationError], so the agent can tell
+// the user to start Docker rather than retrying the same call.
+func dockerUnavailableError(toolName string, err error) error {
+	return &af.ToolError{ToolName: toolName, Message: fmt.Sprintf("docker unavailable: %v", err), Err: af.ErrToolExecution}
+}
+
+// dockerOperationError reports that a request reached the daemon but failed
+// (e.g. no such container), as distinct from [dockerUnavailableError].
+func dockerOperationError(toolName string, err error) error {
+	return &af.ToolError{ToolName: toolName, Message: fmt.Sprintf("operation failed: %v", err), Err: af.ErrToolExecution}
+}
+
+// classifyDockerError turns an error from an API call into a
+// [dockerUnavailableError] or a [dockerOperationError]. [d.client] only
+// constructs the client and doesn't contact the daemon — API version
+// negotiation happens lazily on the first real request — so "Docker isn't
+// running" typically doesn't surface until here, not from [d.client]. Any
+// error client.IsErrConnectionFailed recognizes (the daemon socket/pipe
+// refusing or timing out) is classified as unavailable; anything else is
+// treated as a failed-but-reached operation.
+func classifyDockerError(toolName string, err error) error {
+	if client.IsErrConnectionFailed(err) {
+		return dockerUnavailableError(toolName, err)
+	}
+	return dockerOperationError(toolName, err)
+}
+
+// GetTools returns the Docker-backed tools: list_docker_images,
+// list_docker_containers, inspect_container, pull_image, container_logs,
+// and system_usage.
+func (d *DockerToolset) GetTools() []af.Tool {
+	return []af.Tool{
+		d.listImagesTool(),
+		d.listContainersTool(),
+		d.inspectContainerTool(),
+		d.pullImageTool(),
+		d.containerLogsTool(),
+		d.systemUsageTool(),
+	}
+}
+
+// listImagesTool returns a tool that lists Docker images on the host.
+func (d *DockerToolset) listImagesTool() af.Tool {
+	const name = "list_docker_images"
+	return af.NewTool(name, "Lists Docker images available on the host machine",
+		json.RawMessage(`{"type":"object","properties":{}}`),
+		func(ctx context.Context, args json.RawMessage) (any, error) {
+			cli, err := d.client()
+			if err != nil {
+				return nil, dockerUnavailableError(name, err)
+			}
+
+			images, err := cli.ImageList(ctx, image.ListOptions{})
+			if err != nil {
+				return nil, classifyDockerError(name, err)
+			}
+
+			out := make([]map[string]any, 0, len(images))
+			for _, img := range images {
+				out = append(out, map[string]any{
+					"id":         img.ID,
+					"repo_tags":  img.RepoTags,
+					"size_bytes": img.Size,
+					"created_at": img.Created,
+					"containers": img.Containers,
+				})
+			}
+			return map[string]any{"count": len(out), "images": out}, nil
+		},
+	)
+}
+
+// listContainersTool returns a tool that lists containers on the host,
+// optionally filtered by status or label.
+func (d *DockerToolset) listContainersTool() af.Tool {
+	const name = "list_docker_containers"
+	return af.NewTypedTool(name, "Lists Docker containers on the host machine, optionally filtered by status or label",
+		func(ctx context.Context, args struct {
+			All    bool   `json:"all,omitempty" jsonschema:"description=Include stopped containers, not just running ones"`
+			Status string `json:"status,omitempty" jsonschema:"description=Filter by container status,enum=created|running|paused|restarting|removing|exited|dead"`
+			Label  string `json:"label,omitempty" jsonschema:"description=Filter by label, as key or key=value"`
+		}) (any, error) {
+			cli, err := d.client()
+			if err != nil {
+				return nil, dockerUnavailableError(name, err)
+			}
+
+			f := filters.NewArgs()
+			if args.Status != "" {
+				f.Add("status", args.Status)
+			}
+			if args.Label != "" {
+				f.Add("label", args.Label)
+			}
+
+			containers, err := cli.ContainerList(ctx, container.ListOptions{All: args.All, Filters: f})
+			if err != nil {
+				return nil, classifyDockerError(name, err)
+			}
+
+			out := make([]map[string]any, 0, len(containers))
+			for _, c := range containers {
+				out = append(out, map[string]any{
+					"id":      c.ID,
+					"names":   c.Names,
+					"image":   c.Image,
+					"state":   c.State,
+					"status":  c.Status,
+					"labels":  c.Labels,
+					"created": c.Created,
+				})
+			}
+			return map[string]any{"count": len(out), "containers": out}, nil
+		},
+	)
+}
+
+// inspectContainerTool returns a tool that fetches a single container's
+// full inspect output.
+func (d *DockerToolset) inspectContainerTool() af.Tool {
+	const name = "inspect_container"
+	return af.NewTypedTool(name, "Returns detailed configuration and state for a single Docker container",
+		func(ctx context.Context, args struct {
+			ID string `json:"id" jsonschema:"description=Container ID or name,required"`
+		}) (any, error) {
+			cli, err := d.client()
+			if err != nil {
+				return nil, dockerUnavailableError(name, err)
+			}
+
+			info, err := cli.ContainerInspect(ctx, args.ID)
+			if err != nil {
+				return nil, classifyDockerError(name, err)
+			}
+			return info, nil
+		},
+	)
+}
+
+// pullImageTool returns a tool that pulls a Docker image, logging each
+// progress event the daemon streams back as an intermediate [agent]
+// message rather than blocking silently until the pull completes.
+func (d *DockerToolset) pullImageTool() af.Tool {
+	const name = "pull_image"
+	return af.NewTypedTool(name, "Pulls a Docker image from its registry, reporting progress as it downloads",
+		func(ctx context.Context, args struct {
+			Reference string `json:"reference" jsonschema:"description=Image reference to pull, e.g. alpine:3.19,required"`
+		}) (any, error) {
+			cli, err := d.client()
+			if err != nil {
+				return nil, dockerUnavailableError(name, err)
+			}
+
+			rc, err := cli.ImagePull(ctx, args.Reference, image.PullOptions{})
+			if err != nil {
+				return nil, classifyDockerError(name, err)
+			}
+			defer rc.Close()
+
+			lastStatus, err := logPullProgress(name, args.Reference, rc)
+			if err != nil {
+				return nil, classifyDockerError(name, err)
+			}
+			return map[string]any{"reference": args.Reference, "status": lastStatus}, nil
+		},
+	)
+}
+
+// pullProgressEvent is one line of the newline-delimited JSON progress
+// stream returned by the Docker Engine API for an image pull.
+type pullProgressEvent struct {
+	Status string `json:"status"`
+	ID     string `json:"id,omitempty"`
+}
+
+// logPullProgress logs each pull progress event as it arrives and returns
+// the last status line seen, for callers that want a final summary.
+func logPullProgress(toolName, reference string, r io.Reader) (string, error) {
+	var last string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		var ev pullProgressEvent
+		if err := json.Unmarshal(scanner.Bytes(), &ev); err != nil {
+			continue
+		}
+		last = ev.Status
+		if ev.ID != "" {
+			log.Printf("[agent] %s %s: %s (%s)", toolName, reference, ev.Status, ev.ID)
+		} else {
+			log.Printf("[agent] %s %s: %s", toolName, reference, ev.Status)
+		}
+	}
+	return last, scanner.Err()
+}
+
+// containerLogsTool returns a tool that fetches a bounded tail of a
+// container's combined stdout/stderr logs.
+func (d *DockerToolset) containerLogsTool() af.Tool {
+	const name = "container_logs"
+	return af.NewTypedTool(name, "Returns the most recent log lines from a Docker container",
+		func(ctx context.Context, args struct {
+			ID   string `json:"id" jsonschema:"description=Container ID or name,required"`
+			Tail int    `json:"tail,omitempty" jsonschema:"description=Number of lines to return from the end of the log,default=100"`
+		}) (any, error) {
+			cli, err := d.client()
+			if err != nil {
+				return nil, dockerUnavailableError(name, err)
+			}
+
+			tail := args.Tail
+			if tail <= 0 {
+				tail = 100
+			}
+
+			rc, err := cli.ContainerLogs(ctx, args.ID, container.LogsOptions{
+				ShowStdout: true,
+				ShowStderr: true,
+				Tail:       strconv.Itoa(tail),
+			})
+			if err != nil {
+				return nil, classifyDockerError(name, err)
+			}
+			defer rc.Close()
+
+			data, err := io.ReadAll(rc)
+			if err != nil {
+				return nil, classifyDockerError(name, err)
+			}
+			return map[string]any{"id": args.ID, "logs": string(data)}, nil
+		},
+	)
+}
+
+// systemUsageArgs selects which resource types systemUsageTool computes,
+// modeled on the Docker Engine API's own type-filtered disk-usage endpoint.
+type systemUsageArgs struct {
+	Types   []string `json:"types,omitempty" jsonschema:"description=Resource types to include; defaults to all,enum=containers|images|volumes|filesystem"`
+	Verbose bool     `json:"verbose,omitempty" jsonschema:"description=Include a per-item breakdown in addition to per-type totals"`
+}
+
+// systemUsageTypes is the full set of resource types systemUsageTool
+// understands, used both to validate Types and as the default when it's
+// omitted.
+var systemUsageTypes = []string{"containers", "images", "volumes", "filesystem"}
+
+// systemUsageTool returns a tool that aggregates host resource usage —
+// Docker containers, images, and volumes via the same client the other
+// Docker tools share, plus host filesystem usage via enumerateDrives — in
+// one call, similar to `docker system df`. Requesting a subset of types
+// skips the others entirely rather than computing and discarding them, so
+// a caller that only wants filesystem usage doesn't pay for a DiskUsage
+// call against the daemon.
+func (d *DockerToolset) systemUsageTool() af.Tool {
+	const name = "system_usage"
+	return af.NewTypedTool(name, "Reports what's using disk space on the host: Docker containers, images, volumes, and/or host filesystems, with active vs. reclaimable breakdowns",
+		func(ctx context.Context, args systemUsageArgs) (any, error) {
+			wanted := args.Types
+			if len(wanted) == 0 {
+				wanted = systemUsageTypes
+			}
+			want := make(map[string]bool, len(wanted))
+			for _, t := range wanted {
+				if !contains(systemUsageTypes, t) {
+					return nil, &af.ToolError{ToolName: name, Message: fmt.Sprintf("unknown type %q", t), Err: af.ErrToolExecution}
+				}
+				want[t] = true
+			}
+
+			out := map[string]any{}
+
+			if want["containers"] || want["images"] || want["volumes"] {
+				cli, err := d.client()
+				if err != nil {
+					return nil, dockerUnavailableError(name, err)
+				}
+
+				var objectTypes []types.DiskUsageObject
+				if want["containers"] {
+					objectTypes = append(objectTypes, types.ContainerObject)
+				}
+				if want["images"] {
+					objectTypes = append(objectTypes, types.ImageObject)
+				}
+				if want["volumes"] {
+					objectTypes = append(objectTypes, types.VolumeObject)
+				}
+
+				du, err := cli.DiskUsage(ctx, types.DiskUsageOptions{Types: objectTypes})
+				if err != nil {
+					return nil, classifyDockerError(name, err)
+				}
+
+				if want["containers"] {
+					out["containers"] = summarizeContainerUsage(du.Containers, args.Verbose)
+				}
+				if want["images"] {
+					out["images"] = summarizeImageUsage(du.Images, args.Verbose)
+				}
+				if want["volumes"] {
+					out["volumes"] = summarizeVolumeUsage(du.Volumes, args.Verbose)
+				}
+			}
+
+			if want["filesystem"] {
+				drives, err := enumerateDrives()
+				if err != nil {
+					return nil, &af.ToolError{ToolName: name, Message: fmt.Sprintf("failed to enumerate drives: %v", err), Err: af.ErrToolExecution}
+				}
+				out["filesystem"] = summarizeFilesystemUsage(drives, args.Verbose)
+			}
+
+			return out, nil
+		},
+	)
+}
+
+// summarizeContainerUsage totals container disk usage (writable layer plus
+// root filesystem), splitting it into active (running) vs. reclaimable
+// (stopped) bytes.
+func summarizeContainerUsage(containers []*container.Summary, verbose bool) map[string]any {
+	var active, reclaimable int64
+	items := make([]map[string]any, 0, len(containers))
+	for _, c := range containers {
+		size := c.SizeRw + c.SizeRootFs
+		if c.State == "running" {
+			active += size
+		} else {
+			reclaimable += size
+		}
+		if verbose {
+			items = append(items, map[string]any{
+				"id":          c.ID,
+				"names":       c.Names,
+				"state":       c.State,
+				"size_bytes":  size,
+				"reclaimable": c.State != "running",
+			})
+		}
+	}
+	out := map[string]any{"active_bytes": active, "reclaimable_bytes": reclaimable, "count": len(containers)}
+	if verbose {
+		out["items"] = items
+	}
+	return out
+}
+
+// summarizeImageUsage totals image disk usage, treating an image with no
+// containers referencing it as reclaimable.
+func summarizeImageUsage(images []*image.Summary, verbose bool) map[string]any {
+	var active, reclaimable int64
+	items := make([]map[string]any, 0, len(images))
+	for _, img := range images {
+		inUse := img.Containers > 0
+		if inUse {
+			active += img.Size
+		} else {
+			reclaimable += img.Size
+		}
+		if verbose {
+			items = append(items, map[string]any{
+				"id":          img.ID,
+				"repo_tags":   img.RepoTags,
+				"size_bytes":  img.Size,
+				"containers":  img.Containers,
+				"reclaimable": !inUse,
+			})
+		}
+	}
+	out := map[string]any{"active_bytes": active, "reclaimable_bytes": reclaimable, "count": len(images)}
+	if verbose {
+		out["items"] = items
+	}
+	return out
+}
+
+// summarizeVolumeUsage totals volume disk usage, treating a volume with no
+// active mounts (RefCount == 0) as reclaimable.
+func summarizeVolumeUsage(volumes []*volume.Volume, verbose bool) map[string]any {
+	var active, reclaimable int64
+	items := make([]map[string]any, 0, len(volumes))
+	for _, v := range volumes {
+		var size int64
+		var refCount int64
+		if v.UsageData != nil {
+			size = v.UsageData.Size
+			refCount = v.UsageData.RefCount
+		}
+		if refCount > 0 {
+			active += size
+		} else {
+			reclaimable += size
+		}
+		if verbose {
+			items = append(items, map[string]any{
+				"name":        v.Name,
+				"size_bytes":  size,
+				"ref_count":   refCount,
+				"reclaimable": refCount == 0,
+			})
+		}
+	}
+	out := map[string]any{"active_bytes": active, "reclaimable_bytes": reclaimable, "count": len(volumes)}
+	if verbose {
+		out["items"] = items
+	}
+	return out
+}
+
+// summarizeFilesystemUsage totals host filesystem usage across every
+// mounted drive enumerateDrives returned. Unlike Docker-managed resources,
+// host filesystem space isn't "reclaimable" by this tool, so only used vs.
+// free is reported.
+func summarizeFilesystemUsage(drives []driveInfo, verbose bool) map[string]any {
+	var total, free uint64
+	items := make([]map[string]any, 0, len(drives))
+	for _, d := range drives {
+		total += d.TotalBytes
+		free += d.FreeBytes
+		if verbose {
+			items = append(items, map[string]any{
+				"mountpoint":  d.Mountpoint,
+				"fstype":      d.FSType,
+				"total_bytes": d.TotalBytes,
+				"free_bytes":  d.FreeBytes,
+				"used_bytes":  d.TotalBytes - d.FreeBytes,
+			})
+		}
+	}
+	out := map[string]any{"total_bytes": total, "free_bytes": free, "used_bytes": total - free, "count": len(drives)}
+	if verbose {
+		out["items"] = items
+	}
+	return out
+}
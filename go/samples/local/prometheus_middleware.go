@@ -0,0 +1,87 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PrometheusMiddleware returns a [af.FunctionMiddleware], a sibling of
+// [ToolCallLoggingMiddleware], that instruments every tool call with real
+// Prometheus collectors instead of log lines: a call counter broken down by
+// outcome, a duration histogram, and an in-flight gauge. Register reg with
+// whatever [prometheus.Registry] the process already exposes on /metrics.
+func PrometheusMiddleware(reg prometheus.Registerer) af.FunctionMiddleware {
+	calls := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "agentframework_tool_calls_total",
+		Help: "Total tool invocations, by tool and outcome.",
+	}, []string{"tool", "status"})
+
+	duration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "agentframework_tool_duration_seconds",
+		Help:    "Tool invocation latency in seconds.",
+		Buckets: prometheus.ExponentialBuckets(0.005, 2, 12),
+	}, []string{"tool"})
+
+	inflight := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "agentframework_tool_inflight",
+		Help: "Tool invocations currently in progress.",
+	}, []string{"tool"})
+
+	reg.MustRegister(calls, duration, inflight)
+
+	return func(next af.FunctionHandler) af.FunctionHandler {
+		return func(ctx context.Context, tool af.Tool, args json.RawMessage) (any, error) {
+			name := tool.Name()
+
+			inflight.WithLabelValues(name).Inc()
+			defer inflight.WithLabelValues(name).Dec()
+
+			start := time.Now()
+			result, err := next(ctx, tool, args)
+			duration.WithLabelValues(name).Observe(time.Since(start).Seconds())
+
+			calls.WithLabelValues(name, toolCallStatus(err)).Inc()
+			return result, err
+		}
+	}
+}
+
+// toolCallStatus classifies err for the agentframework_tool_calls_total
+// status label: a tool returning [*af.ToolError] reports the failure as the
+// tool's own doing, as distinct from an error from the invocation machinery
+// itself (an unknown tool, a middleware panic recovery, etc).
+func toolCallStatus(err error) string {
+	if err == nil {
+		return "success"
+	}
+	var toolErr *af.ToolError
+	if errors.As(err, &toolErr) {
+		return "tool_error"
+	}
+	return "internal_error"
+}
+
+// TokenUsageObserver returns an [af.ChatOptions.OnUsage] callback that
+// records token spend as a Prometheus counter, labeled by direction and by
+// model (model isn't carried on [af.UsageDetails] itself, so callers supply
+// it — typically the model ID they configured the [af.ChatClient] with).
+func TokenUsageObserver(reg prometheus.Registerer, model string) func(af.UsageDetails) {
+	tokens := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "agentframework_tokens_total",
+		Help: "Total tokens consumed, by direction and model.",
+	}, []string{"direction", "model"})
+	reg.MustRegister(tokens)
+
+	return func(u af.UsageDetails) {
+		tokens.WithLabelValues("input", model).Add(float64(u.InputTokens))
+		tokens.WithLabelValues("output", model).Add(float64(u.OutputTokens))
+	}
+}
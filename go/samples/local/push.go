@@ -0,0 +1,328 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// pushWorkers is the number of concurrent goroutines delivering task
+// completion webhooks.
+const pushWorkers = 4
+
+// pushBackoff is the delay schedule for retrying a failed webhook delivery.
+// Each delay is jittered by up to an additional 50% to avoid retry storms
+// against the same webhook endpoint.
+var pushBackoff = []time.Duration{time.Second, 5 * time.Second, 30 * time.Second}
+
+// pushNotificationConfig is a per-task webhook registered via A2A
+// "tasks/pushNotificationConfig/set". When the task reaches a terminal
+// state, its final [Task] representation is POSTed to URL.
+type pushNotificationConfig struct {
+	URL            string                `json:"url"`
+	Token          string                `json:"token,omitempty"`
+	Authentication *pushNotificationAuth `json:"authentication,omitempty"`
+}
+
+// pushNotificationAuth describes how the webhook receiver authenticates the
+// delivery, beyond the bearer Token.
+type pushNotificationAuth struct {
+	Schemes     []string `json:"schemes,omitempty"`
+	Credentials string   `json:"credentials,omitempty"`
+}
+
+// taskPushConfigSetParams is the params for A2A
+// "tasks/pushNotificationConfig/set".
+type taskPushConfigSetParams struct {
+	TaskID                 string                 `json:"taskId"`
+	PushNotificationConfig pushNotificationConfig `json:"pushNotificationConfig"`
+}
+
+// taskPushConfigGetParams is the params for A2A
+// "tasks/pushNotificationConfig/get".
+type taskPushConfigGetParams struct {
+	TaskID string `json:"taskId"`
+}
+
+// errUnsafeWebhookURL is returned by validateWebhookURL and
+// resolveWebhookAddr for a URL this server refuses to ever deliver a push
+// notification to.
+var errUnsafeWebhookURL = errors.New("webhook url is not allowed")
+
+// parseWebhookURL parses rawURL and checks that it's an http(s) URL with a
+// hostname, without resolving anything yet.
+func parseWebhookURL(rawURL string) (*url.URL, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errUnsafeWebhookURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("%w: scheme %q is not http(s)", errUnsafeWebhookURL, u.Scheme)
+	}
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("%w: no host", errUnsafeWebhookURL)
+	}
+	return u, nil
+}
+
+// isSafeWebhookAddr reports whether addr is a public, routable unicast
+// address — rejecting loopback, private, and link-local ranges (including
+// the 169.254.169.254 cloud metadata address).
+func isSafeWebhookAddr(addr net.IP) bool {
+	return addr.IsGlobalUnicast() && !addr.IsPrivate() && !addr.IsLoopback() &&
+		!addr.IsLinkLocalUnicast() && !addr.IsLinkLocalMulticast()
+}
+
+// resolveWebhookAddr resolves host and returns the first address a dial
+// should use, after checking that every address host resolved to is safe
+// per [isSafeWebhookAddr]. Call this immediately before dialing (not
+// earlier) and pin the actual TCP connect to the returned address — e.g. via
+// [dialPinnedWebhookAddr] — rather than resolving host a second time: two
+// independent resolutions of the same hostname let an attacker controlling
+// DNS answer the validation lookup safely and the dial's lookup with a
+// private/metadata address (DNS rebinding).
+func resolveWebhookAddr(host string) (net.IP, error) {
+	addrs, err := net.LookupIP(host)
+	if err != nil {
+		return nil, fmt.Errorf("%w: resolve %q: %v", errUnsafeWebhookURL, host, err)
+	}
+	for _, addr := range addrs {
+		if !isSafeWebhookAddr(addr) {
+			return nil, fmt.Errorf("%w: %q resolves to non-public address %s", errUnsafeWebhookURL, host, addr)
+		}
+	}
+	return addrs[0], nil
+}
+
+// validateWebhookURL rejects a registered webhook URL before it's ever
+// accepted, closing off the server-side request forgery this endpoint would
+// otherwise enable: a caller that can register a webhook could otherwise
+// make the server itself issue outbound requests to arbitrary internal or
+// cloud-metadata targets on task completion. See [parseWebhookURL] and
+// [resolveWebhookAddr] for the checks applied.
+//
+// This only guards registration; it resolves host independently of
+// [pushNotifier.send]'s own resolution, so it does not by itself close the
+// DNS-rebinding window between registration and delivery — [pushNotifier.send]
+// re-resolves and pins the dial to what it resolves.
+func validateWebhookURL(rawURL string) error {
+	u, err := parseWebhookURL(rawURL)
+	if err != nil {
+		return err
+	}
+	_, err = resolveWebhookAddr(u.Hostname())
+	return err
+}
+
+// handlePushConfigSet registers (or replaces) the webhook for a task.
+func (s *agentServer) handlePushConfigSet(w http.ResponseWriter, r *http.Request, rpcReq *jsonRPCRequest) {
+	var params taskPushConfigSetParams
+	if err := json.Unmarshal(rpcReq.Params, &params); err != nil {
+		s.writeRPCError(w, rpcReq.ID, -32602, "Invalid params")
+		return
+	}
+
+	if err := validateWebhookURL(params.PushNotificationConfig.URL); err != nil {
+		log.Printf("[push] pushNotificationConfig/set id=%s: rejected: %v", params.TaskID, err)
+		s.writeRPCError(w, rpcReq.ID, -32602, "Invalid params: "+err.Error())
+		return
+	}
+
+	task, err := s.taskStore.Load(r.Context(), params.TaskID)
+	if err != nil {
+		log.Printf("[push] pushNotificationConfig/set id=%s: %v", params.TaskID, err)
+		s.writeRPCError(w, rpcReq.ID, -32001, "Task not found")
+		return
+	}
+
+	cfg := params.PushNotificationConfig
+	task.PushConfig = &cfg
+	if err := s.taskStore.Save(r.Context(), task); err != nil {
+		log.Printf("[push] pushNotificationConfig/set id=%s: save failed: %v", params.TaskID, err)
+		s.writeRPCError(w, rpcReq.ID, -32000, "Failed to persist push config")
+		return
+	}
+
+	log.Printf("[push] registered webhook %s for task %s", cfg.URL, params.TaskID)
+	s.writeRPCResult(w, rpcReq.ID, cfg)
+}
+
+// handlePushConfigGet returns the webhook registered for a task, if any.
+func (s *agentServer) handlePushConfigGet(w http.ResponseWriter, r *http.Request, rpcReq *jsonRPCRequest) {
+	var params taskPushConfigGetParams
+	if err := json.Unmarshal(rpcReq.Params, &params); err != nil {
+		s.writeRPCError(w, rpcReq.ID, -32602, "Invalid params")
+		return
+	}
+
+	task, err := s.taskStore.Load(r.Context(), params.TaskID)
+	if err != nil {
+		log.Printf("[push] pushNotificationConfig/get id=%s: %v", params.TaskID, err)
+		s.writeRPCError(w, rpcReq.ID, -32001, "Task not found")
+		return
+	}
+	if task.PushConfig == nil {
+		s.writeRPCError(w, rpcReq.ID, -32002, "No push notification config registered for this task")
+		return
+	}
+
+	s.writeRPCResult(w, rpcReq.ID, *task.PushConfig)
+}
+
+// pushNotifier delivers terminal-task webhooks through a small worker pool,
+// retrying failed deliveries with jittered exponential backoff per
+// [pushBackoff]. Delivery outcome is recorded back onto the [Task] so it is
+// visible through "tasks/get".
+type pushNotifier struct {
+	store  TaskStore
+	client *http.Client
+	queue  chan string
+}
+
+func newPushNotifier(store TaskStore) *pushNotifier {
+	n := &pushNotifier{
+		store: store,
+		client: &http.Client{
+			Timeout:   10 * time.Second,
+			Transport: &http.Transport{DialContext: dialPinnedWebhookAddr},
+		},
+		queue: make(chan string, 256),
+	}
+	for i := 0; i < pushWorkers; i++ {
+		go n.worker()
+	}
+	return n
+}
+
+// pinnedWebhookAddrKey is the context key [pushNotifier.send] uses to carry
+// the address [resolveWebhookAddr] already validated through to
+// [dialPinnedWebhookAddr], so the TCP dial connects to that exact address
+// instead of net/http resolving the hostname again.
+type pinnedWebhookAddrKey struct{}
+
+// dialPinnedWebhookAddr is the pushNotifier http.Client's
+// Transport.DialContext. If ctx carries an address pinned by
+// [pushNotifier.send], it dials that address (keeping addr's port) instead
+// of resolving addr's hostname itself; otherwise it dials addr normally.
+// The original hostname is left in place for the request's Host header and,
+// since it's untouched here, for TLS SNI and certificate verification too —
+// only the IP actually connected to is pinned.
+func dialPinnedWebhookAddr(ctx context.Context, network, addr string) (net.Conn, error) {
+	if pinned, ok := ctx.Value(pinnedWebhookAddrKey{}).(net.IP); ok {
+		_, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		addr = net.JoinHostPort(pinned.String(), port)
+	}
+	var d net.Dialer
+	return d.DialContext(ctx, network, addr)
+}
+
+// enqueue schedules a delivery attempt for taskID's registered webhook, if
+// any. Non-blocking: if the queue is full, the delivery is dropped and
+// logged rather than blocking the caller (typically a running task's
+// goroutine).
+func (n *pushNotifier) enqueue(taskID string) {
+	select {
+	case n.queue <- taskID:
+	default:
+		log.Printf("[push] delivery queue full, dropping notification for task %s", taskID)
+	}
+}
+
+func (n *pushNotifier) worker() {
+	for taskID := range n.queue {
+		n.deliver(taskID)
+	}
+}
+
+// deliver POSTs the task's current state to its registered webhook, retrying
+// on failure per [pushBackoff], then records the outcome on the task.
+func (n *pushNotifier) deliver(taskID string) {
+	ctx := context.Background()
+	task, err := n.store.Load(ctx, taskID)
+	if err != nil || task.PushConfig == nil {
+		return
+	}
+	cfg := task.PushConfig
+
+	var lastErr error
+	for attempt := 0; attempt <= len(pushBackoff); attempt++ {
+		if attempt > 0 {
+			delay := pushBackoff[attempt-1]
+			delay += time.Duration(rand.Int63n(int64(delay) / 2))
+			time.Sleep(delay)
+		}
+		if lastErr = n.send(ctx, cfg, task); lastErr == nil {
+			break
+		}
+		log.Printf("[push] task %s delivery attempt %d/%d failed: %v", taskID, attempt+1, len(pushBackoff)+1, lastErr)
+	}
+
+	task, err = n.store.Load(ctx, taskID)
+	if err != nil {
+		return
+	}
+	if lastErr != nil {
+		task.PushDeliveryState = "failed"
+		task.PushDeliveryError = lastErr.Error()
+	} else {
+		task.PushDeliveryState = "delivered"
+		task.PushDeliveryError = ""
+	}
+	if err := n.store.Save(ctx, task); err != nil {
+		log.Printf("[push] failed to record delivery status for task %s: %v", taskID, err)
+	}
+}
+
+func (n *pushNotifier) send(ctx context.Context, cfg *pushNotificationConfig, task *Task) error {
+	// Re-resolve immediately before dialing: the hostname could have been
+	// reassigned or could resolve differently now (DNS rebinding) than it
+	// did when the webhook was registered. The resolved address is pinned
+	// onto ctx so the Transport's dial below connects to the address that
+	// was actually validated, instead of resolving the hostname a second,
+	// independent time.
+	u, err := parseWebhookURL(cfg.URL)
+	if err != nil {
+		return err
+	}
+	addr, err := resolveWebhookAddr(u.Hostname())
+	if err != nil {
+		return err
+	}
+	ctx = context.WithValue(ctx, pinnedWebhookAddrKey{}, addr)
+
+	body, err := json.Marshal(taskToResult(task))
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if cfg.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+cfg.Token)
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
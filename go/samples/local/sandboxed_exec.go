@@ -0,0 +1,149 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"time"
+)
+
+// SandboxedExec is the only sanctioned way for a tool in this package to run
+// a subprocess. Any tool added to GetTools that calls os/exec directly
+// instead is a review-blocking violation: it bypasses the binary allowlist,
+// argument validation, timeout, output caps, and (on Linux) privilege-drop
+// and network isolation that SandboxConfig enforces here.
+//
+// There is currently no exec-based tool in this package — listDockerImagesTool
+// was migrated to the Docker Engine API client (see docker_tools.go) before
+// this helper was added — so SandboxedExec has no caller yet. It's added in
+// anticipation of the next tool that legitimately needs to shell out.
+type SandboxConfig struct {
+	// AllowedBinaries is the set of executable names (not paths) SandboxedExec
+	// will run. name is checked against this list before exec.LookPath is
+	// even attempted.
+	AllowedBinaries []string
+
+	// ArgPatterns validates args positionally: ArgPatterns[i], if non-nil,
+	// must match args[i]. A nil entry leaves that position unconstrained.
+	// An invocation with more args than ArgPatterns is rejected outright —
+	// every argument must have a slot.
+	ArgPatterns []*regexp.Regexp
+
+	// Timeout bounds the subprocess's wall-clock runtime. Zero means no
+	// additional timeout beyond ctx's own deadline.
+	Timeout time.Duration
+
+	// MaxOutputBytes caps how much of stdout and stderr are retained, each
+	// independently. Zero means unbounded.
+	MaxOutputBytes int
+
+	// DropToUID and DropToGID, on Linux, run the subprocess as the given
+	// non-root uid/gid instead of inheriting the agent process's identity.
+	// Ignored on other platforms.
+	DropToUID *uint32
+	DropToGID *uint32
+
+	// IsolateNetwork, on Linux, runs the subprocess in a new network
+	// namespace with no interfaces configured, so it can't reach the
+	// network at all. Ignored on other platforms.
+	IsolateNetwork bool
+}
+
+// SandboxResult holds a completed subprocess's captured output.
+type SandboxResult struct {
+	Stdout    []byte
+	Stderr    []byte
+	Truncated bool
+}
+
+// capWriter accumulates up to limit bytes into buf, discarding the rest but
+// still reporting a successful write (as io.Writer requires) and flagging
+// truncation through *truncated.
+type capWriter struct {
+	buf       *bytes.Buffer
+	limit     int
+	truncated *bool
+}
+
+func (w *capWriter) Write(p []byte) (int, error) {
+	if w.limit <= 0 {
+		return len(p), nil
+	}
+	remaining := w.limit - w.buf.Len()
+	if remaining <= 0 {
+		*w.truncated = true
+		return len(p), nil
+	}
+	n := len(p)
+	if n > remaining {
+		n = remaining
+		*w.truncated = true
+	}
+	w.buf.Write(p[:n])
+	return len(p), nil
+}
+
+// SandboxedExec validates name and args against cfg, then runs the command
+// with the requested timeout, output caps, and (on Linux) privilege and
+// network restrictions applied.
+func SandboxedExec(ctx context.Context, cfg SandboxConfig, name string, args ...string) (*SandboxResult, error) {
+	if !contains(cfg.AllowedBinaries, name) {
+		return nil, fmt.Errorf("sandboxed exec: %q is not an allowlisted binary", name)
+	}
+	if len(args) > len(cfg.ArgPatterns) {
+		return nil, fmt.Errorf("sandboxed exec: %d arguments given but only %d positional patterns configured", len(args), len(cfg.ArgPatterns))
+	}
+	for i, arg := range args {
+		if pattern := cfg.ArgPatterns[i]; pattern != nil && !pattern.MatchString(arg) {
+			return nil, fmt.Errorf("sandboxed exec: argument %d (%q) doesn't match the required shape", i, arg)
+		}
+	}
+
+	path, err := exec.LookPath(name)
+	if err != nil {
+		return nil, fmt.Errorf("sandboxed exec: %w", err)
+	}
+
+	if cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.Timeout)
+		defer cancel()
+	}
+
+	cmd := exec.CommandContext(ctx, path, args...)
+	applySandboxAttrs(cmd, cfg)
+
+	var truncated bool
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &capWriter{buf: &stdout, limit: cfg.MaxOutputBytes, truncated: &truncated}
+	cmd.Stderr = &capWriter{buf: &stderr, limit: cfg.MaxOutputBytes, truncated: &truncated}
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("sandboxed exec: %s: %w", name, ctx.Err())
+		}
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("sandboxed exec: %s: %w", name, err)
+		}
+		// A non-zero exit still returns captured output alongside the error,
+		// so callers can surface stderr to the model.
+		return &SandboxResult{Stdout: stdout.Bytes(), Stderr: stderr.Bytes(), Truncated: truncated}, fmt.Errorf("sandboxed exec: %s: %w", name, err)
+	}
+
+	return &SandboxResult{Stdout: stdout.Bytes(), Stderr: stderr.Bytes(), Truncated: truncated}, nil
+}
+
+func contains(set []string, v string) bool {
+	for _, s := range set {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
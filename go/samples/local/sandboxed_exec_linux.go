@@ -0,0 +1,38 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+//go:build linux
+
+package main
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// applySandboxAttrs wires cfg's Linux-only restrictions into cmd: a
+// credential drop to a non-root uid/gid, and/or a fresh network namespace
+// with no interfaces configured so the subprocess can't reach the network.
+func applySandboxAttrs(cmd *exec.Cmd, cfg SandboxConfig) {
+	if cfg.DropToUID == nil && cfg.DropToGID == nil && !cfg.IsolateNetwork {
+		return
+	}
+
+	attr := &syscall.SysProcAttr{}
+	if cfg.DropToUID != nil || cfg.DropToGID != nil {
+		cred := &syscall.Credential{
+			Uid: uint32(syscall.Getuid()),
+			Gid: uint32(syscall.Getgid()),
+		}
+		if cfg.DropToUID != nil {
+			cred.Uid = *cfg.DropToUID
+		}
+		if cfg.DropToGID != nil {
+			cred.Gid = *cfg.DropToGID
+		}
+		attr.Credential = cred
+	}
+	if cfg.IsolateNetwork {
+		attr.Cloneflags |= syscall.CLONE_NEWNET
+	}
+	cmd.SysProcAttr = attr
+}
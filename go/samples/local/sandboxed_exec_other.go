@@ -0,0 +1,19 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+//go:build !linux
+
+package main
+
+import (
+	"log"
+	"os/exec"
+)
+
+// applySandboxAttrs is a no-op on non-Linux platforms: privilege drop and
+// network-namespace isolation have no portable equivalent here, so a
+// request for either is logged rather than silently ignored.
+func applySandboxAttrs(cmd *exec.Cmd, cfg SandboxConfig) {
+	if cfg.DropToUID != nil || cfg.DropToGID != nil || cfg.IsolateNetwork {
+		log.Printf("[agent] sandboxed exec: uid drop and network isolation are Linux-only; running %s without them", cmd.Path)
+	}
+}
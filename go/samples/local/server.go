@@ -3,13 +3,17 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"strings"
 	"sync"
+	"time"
 
 	af "github.com/microsoft/agent-framework/go/agentframework"
 )
@@ -70,50 +74,161 @@ type a2aMessage struct {
 	Metadata         map[string]any `json:"metadata,omitempty"`
 }
 
-// a2aPart is a content part in an A2A message.
+// a2aPart is a content part in an A2A message: "text", "file", or "data".
 type a2aPart struct {
-	Kind string `json:"kind"`
-	Text string `json:"text,omitempty"`
+	Kind string          `json:"kind"`
+	Text string          `json:"text,omitempty"`
+	File *a2aFile        `json:"file,omitempty"`
+	Data json.RawMessage `json:"data,omitempty"`
 }
 
-// taskGetParams is the params for A2A "tasks/get".
+// a2aFile is the payload of an A2A "file" part: inline base64 Bytes or a
+// remote URI (mutually exclusive).
+type a2aFile struct {
+	Name     string `json:"name,omitempty"`
+	MimeType string `json:"mimeType,omitempty"`
+	Bytes    string `json:"bytes,omitempty"`
+	URI      string `json:"uri,omitempty"`
+}
+
+// taskGetParams is the params for A2A "tasks/get", "tasks/cancel", and
+// "tasks/resubscribe" — all three identify a task by id.
 type taskGetParams struct {
 	ID string `json:"id"`
 }
 
+// taskResult is the A2A Task representation returned by "tasks/get" and
+// "tasks/cancel".
+type taskResult struct {
+	ID                string       `json:"id"`
+	ContextID         string       `json:"contextId,omitempty"`
+	State             TaskState    `json:"state"`
+	History           []a2aMessage `json:"history,omitempty"`
+	Artifacts         []a2aMessage `json:"artifacts,omitempty"`
+	Error             string       `json:"error,omitempty"`
+	PushDeliveryState string       `json:"pushDeliveryState,omitempty"`
+	PushDeliveryError string       `json:"pushDeliveryError,omitempty"`
+}
+
 // ── Server ───────────────────────────────────────────────────────────
 
 // agentServer is the HTTP handler for the agent.
 type agentServer struct {
-	agent    *af.Agent
-	apiKey   string
-	port     string
-	mu       sync.Mutex
-	sessions map[string]*af.Session
-	mux      *http.ServeMux
+	agent         *af.Agent
+	apiKey        string
+	port          string
+	sessionStore  SessionStore
+	authenticator Authenticator
+	tracer        af.Tracer
+	logger        *slog.Logger
+	metrics       *promMeter
+	mux           *http.ServeMux
+	handler       http.Handler
+
+	taskStore TaskStore
+	push      *pushNotifier
+
+	runtimeMu sync.Mutex
+	runtimes  map[string]*taskRuntime
+}
+
+// ServerOption configures optional behavior of [newAgentServer].
+type ServerOption func(*agentServer)
+
+// WithTaskStore overrides the default in-memory [TaskStore] used to persist
+// A2A long-running task state, so it can survive beyond a single server
+// instance (e.g. a Redis- or SQL-backed store).
+func WithTaskStore(store TaskStore) ServerOption {
+	return func(s *agentServer) {
+		s.taskStore = store
+	}
+}
+
+// WithSessionStore overrides the default in-memory [SessionStore] used to
+// hold per-conversation [af.Session]s, so conversations can survive a
+// restart and be shared across replicas (e.g. a Redis- or SQL-backed
+// store).
+func WithSessionStore(store SessionStore) ServerOption {
+	return func(s *agentServer) {
+		s.sessionStore = store
+	}
+}
+
+// WithAuthenticator overrides how /invoke and the A2A JSON-RPC endpoint
+// authenticate requests. Without this option, a non-empty apiKey passed to
+// [newAgentServer] is wrapped in a [staticKeyAuthenticator]; pass
+// [NewJWTAuthenticator] or [NewOIDCAuthenticator] instead for real bearer
+// tokens.
+func WithAuthenticator(a Authenticator) ServerOption {
+	return func(s *agentServer) {
+		s.authenticator = a
+	}
+}
+
+// WithTracerProvider sets the [af.Tracer] used to emit spans around incoming
+// requests and the agent runs they trigger. Defaults to [af.NoopTracer].
+func WithTracerProvider(tracer af.Tracer) ServerOption {
+	return func(s *agentServer) {
+		s.tracer = tracer
+	}
+}
+
+// WithLogger overrides the [slog.Logger] used for the server's structured
+// request logs. Defaults to [slog.Default].
+func WithLogger(logger *slog.Logger) ServerOption {
+	return func(s *agentServer) {
+		s.logger = logger
+	}
 }
 
-// newAgentServer creates a server. If apiKey is empty, /invoke is unauthenticated.
-func newAgentServer(agent *af.Agent, apiKey string, port string) *agentServer {
+// newAgentServer creates a server. If apiKey is empty and no [WithAuthenticator]
+// option is given, /invoke and the A2A endpoint are unauthenticated.
+func newAgentServer(agent *af.Agent, apiKey string, port string, opts ...ServerOption) *agentServer {
 	s := &agentServer{
-		agent:    agent,
-		apiKey:   apiKey,
-		port:     port,
-		sessions: make(map[string]*af.Session),
-		mux:      http.NewServeMux(),
+		agent:        agent,
+		apiKey:       apiKey,
+		port:         port,
+		sessionStore: newInMemorySessionStore(),
+		tracer:       af.NoopTracer,
+		logger:       slog.Default(),
+		metrics:      newPromMeter(),
+		mux:          http.NewServeMux(),
+		taskStore:    newInMemoryTaskStore(),
+		runtimes:     make(map[string]*taskRuntime),
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	if s.authenticator == nil && apiKey != "" {
+		s.authenticator = NewStaticKeyAuthenticator(apiKey)
+	}
+	s.push = newPushNotifier(s.taskStore)
 	s.mux.HandleFunc("GET /health", s.handleHealth)
+	s.mux.HandleFunc("GET /metrics", s.handleMetrics)
 	s.mux.HandleFunc("GET /.well-known/agent-card.json", s.handleAgentCard)
 	s.mux.HandleFunc("GET /.well-known/agent.json", s.handleAgentCard)
 	s.mux.HandleFunc("POST /invoke", s.handleInvoke)
-	// A2A JSON-RPC endpoint — handles message/send, tasks/get at the root path.
+	// A2A JSON-RPC endpoint — handles message/send, message/stream, tasks/get,
+	// tasks/cancel, and tasks/resubscribe at the root path.
 	s.mux.HandleFunc("POST /", s.handleA2A)
+	s.handler = tracingHandler(s.tracer, s.metrics, s.mux)
 	return s
 }
 
+// authenticate verifies r against s.authenticator, if one is configured.
+// With no authenticator, every request is allowed through unauthenticated
+// (nil principal, nil error) — the same "optional auth" behavior the server
+// always had around a bare apiKey.
+func (s *agentServer) authenticate(r *http.Request) (*Principal, error) {
+	if s.authenticator == nil {
+		return nil, nil
+	}
+	return s.authenticator.Authenticate(r)
+}
+
 func (s *agentServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	log.Printf("[http] %s %s from %s", r.Method, r.URL.Path, r.RemoteAddr)
-	s.mux.ServeHTTP(w, r)
+	s.logger.Info("http request", "method", r.Method, "path", r.URL.Path, "remote_addr", r.RemoteAddr)
+	s.handler.ServeHTTP(w, r)
 }
 
 func (s *agentServer) handleHealth(w http.ResponseWriter, _ *http.Request) {
@@ -140,15 +255,11 @@ func (s *agentServer) handleAgentCard(w http.ResponseWriter, r *http.Request) {
 	baseURL := s.resolveBaseURL(r)
 	card["url"] = baseURL + "/"
 
-	authSchemes := []map[string]any{}
-	if s.apiKey != "" {
-		authSchemes = append(authSchemes, map[string]any{
-			"scheme": "bearer",
-		})
-	}
-	card["authentication"] = map[string]any{
-		"schemes": authSchemes,
+	securitySchemes := map[string]any{}
+	if s.authenticator != nil {
+		securitySchemes["default"] = s.authenticator.SecurityScheme()
 	}
+	card["securitySchemes"] = securitySchemes
 
 	writeJSON(w, http.StatusOK, card)
 }
@@ -156,102 +267,481 @@ func (s *agentServer) handleAgentCard(w http.ResponseWriter, r *http.Request) {
 // ── A2A JSON-RPC handler ─────────────────────────────────────────────
 
 func (s *agentServer) handleA2A(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
 	var rpcReq jsonRPCRequest
 	if err := json.NewDecoder(r.Body).Decode(&rpcReq); err != nil {
-		log.Printf("[a2a] bad JSON-RPC request: %v", err)
+		s.logger.Warn("bad JSON-RPC request", "remote_addr", r.RemoteAddr, "error", err)
 		s.writeRPCError(w, nil, -32700, "Parse error")
 		return
 	}
 
-	log.Printf("[a2a] method=%s id=%s", rpcReq.Method, string(rpcReq.ID))
+	defer func() {
+		s.logger.Info("a2a request handled",
+			"method", rpcReq.Method,
+			"rpc_id", string(rpcReq.ID),
+			"remote_addr", r.RemoteAddr,
+			"duration_ms", time.Since(start).Milliseconds(),
+		)
+	}()
 
 	switch rpcReq.Method {
 	case "message/send":
 		s.handleMessageSend(w, r, &rpcReq)
+	case "message/stream":
+		s.handleMessageStream(w, r, &rpcReq)
 	case "tasks/get":
-		s.handleTasksGet(w, &rpcReq)
+		s.handleTasksGet(w, r, &rpcReq)
+	case "tasks/cancel":
+		s.handleTasksCancel(w, r, &rpcReq)
+	case "tasks/resubscribe":
+		s.handleTasksResubscribe(w, r, &rpcReq)
+	case "tasks/pushNotificationConfig/set":
+		s.handlePushConfigSet(w, r, &rpcReq)
+	case "tasks/pushNotificationConfig/get":
+		s.handlePushConfigGet(w, r, &rpcReq)
 	default:
-		log.Printf("[a2a] unknown method: %s", rpcReq.Method)
+		s.logger.Warn("unknown a2a method", "method", rpcReq.Method, "rpc_id", string(rpcReq.ID))
 		s.writeRPCError(w, rpcReq.ID, -32601, fmt.Sprintf("Method not found: %s", rpcReq.Method))
 	}
 }
 
 func (s *agentServer) handleMessageSend(w http.ResponseWriter, r *http.Request, rpcReq *jsonRPCRequest) {
-	// Auth check.
-	if s.apiKey != "" {
-		token := extractBearer(r)
-		if token != s.apiKey {
-			log.Printf("[a2a] unauthorized request from %s", r.RemoteAddr)
-			s.writeRPCError(w, rpcReq.ID, -32000, "Unauthorized")
-			return
-		}
+	start := time.Now()
+	principal, err := s.authenticate(r)
+	if err != nil {
+		s.logger.Warn("unauthorized request", "method", rpcReq.Method, "rpc_id", string(rpcReq.ID), "remote_addr", r.RemoteAddr, "error", err)
+		s.writeRPCError(w, rpcReq.ID, -32000, "Unauthorized")
+		return
 	}
+	r = r.WithContext(withPrincipal(r.Context(), principal))
 
 	var params messageSendParams
 	if err := json.Unmarshal(rpcReq.Params, &params); err != nil {
-		log.Printf("[a2a] bad message/send params: %v", err)
+		s.logger.Warn("bad message/send params", "rpc_id", string(rpcReq.ID), "error", err)
 		s.writeRPCError(w, rpcReq.ID, -32602, "Invalid params")
 		return
 	}
 
-	// Extract text from message parts.
-	var inputTexts []string
-	for _, part := range params.Message.Parts {
-		if part.Kind == "text" && part.Text != "" {
-			inputTexts = append(inputTexts, part.Text)
-		}
+	contents, err := a2aPartsToContents(r.Context(), params.Message.Parts)
+	if err != nil {
+		s.logger.Warn("message/send invalid content", "rpc_id", string(rpcReq.ID), "context_id", params.Message.ContextID, "error", err)
+		s.writeRPCError(w, rpcReq.ID, -32602, fmt.Sprintf("Invalid message part: %v", err))
+		return
 	}
-	input := strings.Join(inputTexts, "\n")
-
-	if input == "" {
-		log.Printf("[a2a] message/send with no text content")
-		s.writeRPCError(w, rpcReq.ID, -32602, "No text content in message")
+	if len(contents) == 0 {
+		s.logger.Warn("message/send with no content", "rpc_id", string(rpcReq.ID), "context_id", params.Message.ContextID)
+		s.writeRPCError(w, rpcReq.ID, -32602, "No content in message")
 		return
 	}
 
 	contextID := params.Message.ContextID
-	log.Printf("[a2a] message/send context=%s input=%q", contextID, input)
 
 	// Get or create session from contextId.
 	session := s.getOrCreateSession(contextID)
 
-	// Run the agent.
-	resp, err := s.agent.Run(r.Context(),
-		[]af.Message{af.NewUserMessage(input)},
-		af.WithSession(session),
+	userMsg := af.Message{Role: af.RoleUser, Contents: contents}
+	taskID, taskCtx, rt := s.startTask(contextID, params.Message)
+	go s.runTask(taskCtx, rt, taskID, contextID, session, userMsg)
+
+	// message/send blocks for the result, but the run above is still driven
+	// by the task's own goroutine and cancelable context, so a concurrent
+	// "tasks/cancel" for this id takes effect even while we wait here.
+	select {
+	case <-rt.done:
+	case <-r.Context().Done():
+		s.writeRPCError(w, rpcReq.ID, -32000, "Request canceled")
+		return
+	}
+
+	task, err := s.taskStore.Load(r.Context(), taskID)
+	if err != nil {
+		s.logger.Warn("message/send task result unavailable", "rpc_id", string(rpcReq.ID), "context_id", contextID, "task_id", taskID, "error", err)
+		s.writeRPCError(w, rpcReq.ID, -32000, "Task result unavailable")
+		return
+	}
+	if task.State != TaskCompleted || len(task.Artifacts) == 0 {
+		s.writeRPCError(w, rpcReq.ID, -32000, fmt.Sprintf("Agent error: %s", task.Error))
+		return
+	}
+
+	result := task.Artifacts[0]
+	s.logger.Info("message/send completed",
+		"rpc_id", string(rpcReq.ID),
+		"context_id", contextID,
+		"task_id", taskID,
+		"remote_addr", r.RemoteAddr,
+		"duration_ms", time.Since(start).Milliseconds(),
 	)
+	s.writeRPCResult(w, rpcReq.ID, result)
+}
+
+// startTask allocates a task id, registers its cancelable context and
+// live-event runtime, and persists its initial (submitted) state.
+func (s *agentServer) startTask(contextID string, userMessage a2aMessage) (string, context.Context, *taskRuntime) {
+	taskID := newTaskID()
+	ctx, cancel := context.WithCancel(context.Background())
+	rt := newTaskRuntime(cancel)
+
+	s.runtimeMu.Lock()
+	s.runtimes[taskID] = rt
+	s.runtimeMu.Unlock()
+
+	task := &Task{ID: taskID, ContextID: contextID, State: TaskSubmitted, History: []a2aMessage{userMessage}}
+	if err := s.taskStore.Save(context.Background(), task); err != nil {
+		log.Printf("[a2a] failed to save task %s: %v", taskID, err)
+	}
+	return taskID, ctx, rt
+}
+
+// runTask drives one task to completion: it streams the agent's response,
+// publishing each chunk to rt for "tasks/resubscribe" subscribers, then
+// records the task's terminal state.
+func (s *agentServer) runTask(ctx context.Context, rt *taskRuntime, taskID, contextID string, session *af.Session, userMsg af.Message) {
+	start := time.Now()
+	s.setTaskState(taskID, TaskWorking)
+
+	stream, err := s.agent.RunStream(ctx, []af.Message{userMsg}, af.WithSession(session))
 	if err != nil {
-		log.Printf("[a2a] agent error: %v", err)
-		s.writeRPCError(w, rpcReq.ID, -32000, fmt.Sprintf("Agent error: %v", err))
+		s.logger.Error("agent stream error", "task_id", taskID, "context_id", contextID, "error", err)
+		s.finishTask(taskID, contextID, TaskFailed, nil, err)
 		return
 	}
+	defer stream.Close()
+
+	var updates []af.AgentResponseUpdate
+	for {
+		update, ok, err := stream.Next(ctx)
+		if err != nil {
+			if errors.Is(ctx.Err(), context.Canceled) {
+				s.logger.Info("task canceled", "task_id", taskID, "context_id", contextID)
+				s.finishTask(taskID, contextID, TaskCanceled, nil, nil)
+			} else {
+				s.logger.Error("task stream error", "task_id", taskID, "context_id", contextID, "error", err)
+				s.finishTask(taskID, contextID, TaskFailed, nil, err)
+			}
+			return
+		}
+		if !ok {
+			break
+		}
+		updates = append(updates, update)
+		if text := update.Text(); text != "" {
+			rt.publish(a2aStreamChunk{a2aMessage: a2aMessage{
+				Kind:      "message",
+				Role:      "agent",
+				ContextID: contextID,
+				Parts:     []a2aPart{{Kind: "text", Text: text}},
+			}})
+		}
+	}
 
-	output := resp.Text()
-	log.Printf("[a2a] response=%q", output)
+	s.saveSession(contextID, session)
 
-	// Return an A2A AgentMessage response.
+	final := af.AgentResponseFromUpdates(updates)
+	var parts []a2aPart
+	if len(final.Messages) > 0 {
+		parts = contentsToA2AParts(final.Messages[0].Contents)
+	}
+	if len(parts) == 0 {
+		parts = []a2aPart{{Kind: "text"}}
+	}
 	result := a2aMessage{
 		Kind:      "message",
 		Role:      "agent",
-		MessageID: fmt.Sprintf("resp-%s", string(rpcReq.ID)),
 		ContextID: contextID,
-		Parts: []a2aPart{
-			{Kind: "text", Text: output},
+		Parts:     parts,
+		Metadata: map[string]any{
+			"usage": map[string]int{
+				"inputTokens":  final.Usage.InputTokens,
+				"outputTokens": final.Usage.OutputTokens,
+				"totalTokens":  final.Usage.TotalTokens,
+			},
 		},
 	}
+	s.logger.Info("task completed",
+		"task_id", taskID,
+		"context_id", contextID,
+		"duration_ms", time.Since(start).Milliseconds(),
+		"input_tokens", final.Usage.InputTokens,
+		"output_tokens", final.Usage.OutputTokens,
+	)
+	s.metrics.Counter("agent_tokens_input_total").Add(ctx, int64(final.Usage.InputTokens))
+	s.metrics.Counter("agent_tokens_output_total").Add(ctx, int64(final.Usage.OutputTokens))
+	s.finishTask(taskID, contextID, TaskCompleted, []a2aMessage{result}, nil)
+}
 
-	s.writeRPCResult(w, rpcReq.ID, result)
+// setTaskState updates a task's lifecycle state in the store.
+func (s *agentServer) setTaskState(taskID string, state TaskState) {
+	task, err := s.taskStore.Load(context.Background(), taskID)
+	if err != nil {
+		log.Printf("[a2a] setTaskState(%s): %v", taskID, err)
+		return
+	}
+	task.State = state
+	if err := s.taskStore.Save(context.Background(), task); err != nil {
+		log.Printf("[a2a] setTaskState(%s) save: %v", taskID, err)
+	}
+}
+
+// finishTask records a task's terminal state and notifies any
+// "tasks/resubscribe" subscribers with a final event.
+func (s *agentServer) finishTask(taskID, contextID string, state TaskState, artifacts []a2aMessage, taskErr error) {
+	task, err := s.taskStore.Load(context.Background(), taskID)
+	if err != nil {
+		task = &Task{ID: taskID, ContextID: contextID}
+	}
+	task.State = state
+	task.Artifacts = artifacts
+	task.History = append(task.History, artifacts...)
+	if taskErr != nil {
+		task.Error = taskErr.Error()
+	}
+	if err := s.taskStore.Save(context.Background(), task); err != nil {
+		log.Printf("[a2a] finishTask(%s) save: %v", taskID, err)
+	}
+	if task.PushConfig != nil {
+		s.push.enqueue(taskID)
+	}
+
+	s.runtimeMu.Lock()
+	rt := s.runtimes[taskID]
+	s.runtimeMu.Unlock()
+	if rt == nil {
+		return
+	}
+
+	final := a2aMessage{Kind: "message", Role: "agent", ContextID: contextID}
+	if len(artifacts) > 0 {
+		final = artifacts[0]
+	}
+	rt.publish(a2aStreamChunk{a2aMessage: final, Final: true})
+	rt.markDone()
+}
+
+// a2aStreamChunk is a single event in an A2A "message/stream" response: an
+// incremental agent message, with Final set on the last event.
+type a2aStreamChunk struct {
+	a2aMessage
+	Final bool `json:"final"`
 }
 
-func (s *agentServer) handleTasksGet(w http.ResponseWriter, rpcReq *jsonRPCRequest) {
-	// We don't support long-running tasks; return not-found.
+// handleMessageStream handles A2A "message/stream": like message/send, but
+// streams the agent's response as a series of Server-Sent Events, each
+// carrying a JSON-RPC response whose result is an [a2aStreamChunk].
+func (s *agentServer) handleMessageStream(w http.ResponseWriter, r *http.Request, rpcReq *jsonRPCRequest) {
+	principal, err := s.authenticate(r)
+	if err != nil {
+		s.logger.Warn("unauthorized request", "method", rpcReq.Method, "rpc_id", string(rpcReq.ID), "remote_addr", r.RemoteAddr, "error", err)
+		s.writeRPCError(w, rpcReq.ID, -32000, "Unauthorized")
+		return
+	}
+	r = r.WithContext(withPrincipal(r.Context(), principal))
+
+	var params messageSendParams
+	if err := json.Unmarshal(rpcReq.Params, &params); err != nil {
+		s.logger.Warn("bad message/stream params", "rpc_id", string(rpcReq.ID), "error", err)
+		s.writeRPCError(w, rpcReq.ID, -32602, "Invalid params")
+		return
+	}
+
+	contents, err := a2aPartsToContents(r.Context(), params.Message.Parts)
+	if err != nil {
+		s.logger.Warn("message/stream invalid content", "rpc_id", string(rpcReq.ID), "context_id", params.Message.ContextID, "error", err)
+		s.writeRPCError(w, rpcReq.ID, -32602, fmt.Sprintf("Invalid message part: %v", err))
+		return
+	}
+	if len(contents) == 0 {
+		s.logger.Warn("message/stream with no content", "rpc_id", string(rpcReq.ID), "context_id", params.Message.ContextID)
+		s.writeRPCError(w, rpcReq.ID, -32602, "No content in message")
+		return
+	}
+
+	contextID := params.Message.ContextID
+	session := s.getOrCreateSession(contextID)
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		s.writeRPCError(w, rpcReq.ID, -32000, "Streaming not supported")
+		return
+	}
+
+	userMsg := af.Message{Role: af.RoleUser, Contents: contents}
+	taskID, taskCtx, rt := s.startTask(contextID, params.Message)
+	go s.runTask(taskCtx, rt, taskID, contextID, session, userMsg)
+
+	buffered, live, unsubscribe := rt.subscribe()
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	for _, chunk := range buffered {
+		s.writeSSEResult(w, flusher, rpcReq.ID, chunk)
+		if chunk.Final {
+			return
+		}
+	}
+
+	for {
+		select {
+		case <-r.Context().Done():
+			// The client disconnected; the task keeps running in the
+			// background and can be resumed with "tasks/resubscribe".
+			log.Printf("[a2a] message/stream client disconnected, task %s continues", taskID)
+			return
+		case chunk, ok := <-live:
+			if !ok {
+				return
+			}
+			s.writeSSEResult(w, flusher, rpcReq.ID, chunk)
+			if chunk.Final {
+				log.Printf("[a2a] message/stream complete context=%s task=%s", contextID, taskID)
+				return
+			}
+		}
+	}
+}
+
+// writeSSEResult writes a single JSON-RPC response as one SSE `data:` event
+// and flushes it to the client immediately.
+func (s *agentServer) writeSSEResult(w http.ResponseWriter, flusher http.Flusher, id json.RawMessage, result any) {
+	resp := jsonRPCResponse{JSONRPC: "2.0", ID: id, Result: result}
+	data, err := json.Marshal(resp)
+	if err != nil {
+		log.Printf("[a2a] failed to marshal SSE event: %v", err)
+		return
+	}
+	fmt.Fprintf(w, "data: %s\n\n", data)
+	flusher.Flush()
+}
+
+func (s *agentServer) handleTasksGet(w http.ResponseWriter, r *http.Request, rpcReq *jsonRPCRequest) {
 	var params taskGetParams
 	if err := json.Unmarshal(rpcReq.Params, &params); err != nil {
 		s.writeRPCError(w, rpcReq.ID, -32602, "Invalid params")
 		return
 	}
-	log.Printf("[a2a] tasks/get id=%s (not supported, returning error)", params.ID)
-	s.writeRPCError(w, rpcReq.ID, -32001, "Task not found (this agent only supports synchronous message/send)")
+
+	task, err := s.taskStore.Load(r.Context(), params.ID)
+	if err != nil {
+		log.Printf("[a2a] tasks/get id=%s: %v", params.ID, err)
+		s.writeRPCError(w, rpcReq.ID, -32001, "Task not found")
+		return
+	}
+
+	s.writeRPCResult(w, rpcReq.ID, taskToResult(task))
+}
+
+// handleTasksCancel handles A2A "tasks/cancel": it cancels the per-task
+// context so the in-flight agent run (if any) stops, and marks the task
+// canceled if it hadn't already reached a terminal state.
+func (s *agentServer) handleTasksCancel(w http.ResponseWriter, r *http.Request, rpcReq *jsonRPCRequest) {
+	var params taskGetParams
+	if err := json.Unmarshal(rpcReq.Params, &params); err != nil {
+		s.writeRPCError(w, rpcReq.ID, -32602, "Invalid params")
+		return
+	}
+
+	s.runtimeMu.Lock()
+	rt := s.runtimes[params.ID]
+	s.runtimeMu.Unlock()
+	if rt == nil {
+		log.Printf("[a2a] tasks/cancel id=%s: not found", params.ID)
+		s.writeRPCError(w, rpcReq.ID, -32001, "Task not found")
+		return
+	}
+
+	log.Printf("[a2a] tasks/cancel id=%s", params.ID)
+	rt.cancel()
+
+	// Wait for the task's own goroutine to observe the cancellation and
+	// record the terminal state, rather than racing to read it ourselves.
+	select {
+	case <-rt.done:
+	case <-r.Context().Done():
+	}
+
+	task, err := s.taskStore.Load(r.Context(), params.ID)
+	if err != nil {
+		s.writeRPCError(w, rpcReq.ID, -32001, "Task not found")
+		return
+	}
+	s.writeRPCResult(w, rpcReq.ID, taskToResult(task))
+}
+
+// handleTasksResubscribe handles A2A "tasks/resubscribe": it replays the
+// task's buffered events over SSE and then tails any further live events,
+// letting a client reattach to a task after losing its "message/stream"
+// connection.
+func (s *agentServer) handleTasksResubscribe(w http.ResponseWriter, r *http.Request, rpcReq *jsonRPCRequest) {
+	var params taskGetParams
+	if err := json.Unmarshal(rpcReq.Params, &params); err != nil {
+		s.writeRPCError(w, rpcReq.ID, -32602, "Invalid params")
+		return
+	}
+
+	s.runtimeMu.Lock()
+	rt := s.runtimes[params.ID]
+	s.runtimeMu.Unlock()
+	if rt == nil {
+		log.Printf("[a2a] tasks/resubscribe id=%s: not found", params.ID)
+		s.writeRPCError(w, rpcReq.ID, -32001, "Task not found")
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		s.writeRPCError(w, rpcReq.ID, -32000, "Streaming not supported")
+		return
+	}
+
+	buffered, live, unsubscribe := rt.subscribe()
+	defer unsubscribe()
+
+	log.Printf("[a2a] tasks/resubscribe id=%s replaying=%d", params.ID, len(buffered))
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	for _, chunk := range buffered {
+		s.writeSSEResult(w, flusher, rpcReq.ID, chunk)
+		if chunk.Final {
+			return
+		}
+	}
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case chunk, ok := <-live:
+			if !ok {
+				return
+			}
+			s.writeSSEResult(w, flusher, rpcReq.ID, chunk)
+			if chunk.Final {
+				return
+			}
+		}
+	}
+}
+
+// taskToResult converts a [Task] into the A2A Task representation returned
+// by "tasks/get" and "tasks/cancel".
+func taskToResult(task *Task) taskResult {
+	return taskResult{
+		ID:                task.ID,
+		ContextID:         task.ContextID,
+		State:             task.State,
+		History:           task.History,
+		Artifacts:         task.Artifacts,
+		Error:             task.Error,
+		PushDeliveryState: task.PushDeliveryState,
+		PushDeliveryError: task.PushDeliveryError,
+	}
 }
 
 func (s *agentServer) writeRPCResult(w http.ResponseWriter, id json.RawMessage, result any) {
@@ -275,22 +765,19 @@ func (s *agentServer) writeRPCError(w http.ResponseWriter, id json.RawMessage, c
 // ── Simple /invoke handler ───────────────────────────────────────────
 
 func (s *agentServer) handleInvoke(w http.ResponseWriter, r *http.Request) {
-	log.Printf("[agent] invoke received")
-
-	// Auth check.
-	if s.apiKey != "" {
-		token := extractBearer(r)
-		if token != s.apiKey {
-			log.Printf("[agent] unauthorized invoke attempt from %s", r.RemoteAddr)
-			writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
-			return
-		}
-		log.Printf("[agent] auth=OK")
+	start := time.Now()
+
+	principal, err := s.authenticate(r)
+	if err != nil {
+		s.logger.Warn("unauthorized invoke attempt", "remote_addr", r.RemoteAddr, "error", err)
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		return
 	}
+	r = r.WithContext(withPrincipal(r.Context(), principal))
 
 	var req InvokeRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		log.Printf("[agent] bad request: %v", err)
+		s.logger.Warn("bad invoke request", "remote_addr", r.RemoteAddr, "error", err)
 		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid request body"})
 		return
 	}
@@ -300,11 +787,6 @@ func (s *agentServer) handleInvoke(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if req.ConversationID != "" {
-		log.Printf("[agent] conversation=%s", req.ConversationID)
-	}
-	log.Printf("[agent] user input=%q", req.Input)
-
 	session := s.getOrCreateSession(req.ConversationID)
 
 	resp, err := s.agent.Run(r.Context(),
@@ -312,16 +794,27 @@ func (s *agentServer) handleInvoke(w http.ResponseWriter, r *http.Request) {
 		af.WithSession(session),
 	)
 	if err != nil {
-		log.Printf("[agent] error: %v", err)
+		s.logger.Error("invoke failed", "context_id", req.ConversationID, "remote_addr", r.RemoteAddr, "error", err)
 		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "agent execution failed"})
 		return
 	}
+	s.saveSession(req.ConversationID, session)
 
 	out := InvokeResponse{
 		Output: resp.Text(),
 	}
 	body, _ := json.Marshal(out)
-	log.Printf("[agent] response sent (%d bytes)", len(body))
+
+	s.logger.Info("invoke completed",
+		"context_id", req.ConversationID,
+		"remote_addr", r.RemoteAddr,
+		"duration_ms", time.Since(start).Milliseconds(),
+		"input_tokens", resp.Usage.InputTokens,
+		"output_tokens", resp.Usage.OutputTokens,
+	)
+	s.metrics.Counter("agent_tokens_input_total").Add(r.Context(), int64(resp.Usage.InputTokens))
+	s.metrics.Counter("agent_tokens_output_total").Add(r.Context(), int64(resp.Usage.OutputTokens))
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	_, _ = w.Write(body)
@@ -331,25 +824,33 @@ func (s *agentServer) getOrCreateSession(id string) *af.Session {
 	if id == "" {
 		return s.agent.NewSession()
 	}
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	sess, ok := s.sessions[id]
-	if !ok {
+	ctx := context.Background()
+	sess, err := s.sessionStore.Get(ctx, id)
+	if err != nil {
+		if !errors.Is(err, ErrSessionNotFound) {
+			log.Printf("[session] failed to load %s: %v", id, err)
+		}
 		sess = s.agent.NewSession()
-		s.sessions[id] = sess
+		if err := s.sessionStore.Put(ctx, id, sess); err != nil {
+			log.Printf("[session] failed to save %s: %v", id, err)
+		}
 	}
 	return sess
 }
 
-func extractBearer(r *http.Request) string {
-	h := r.Header.Get("Authorization")
-	const prefix = "Bearer "
-	if len(h) > len(prefix) && h[:len(prefix)] == prefix {
-		return h[len(prefix):]
+// saveSession writes session back to the session store under id, so that a
+// backend like Redis or SQL (which deserializes a fresh [af.Session] on
+// every [agentServer.getOrCreateSession]) picks up the turn's new messages.
+func (s *agentServer) saveSession(id string, session *af.Session) {
+	if id == "" {
+		return
+	}
+	if err := s.sessionStore.Put(context.Background(), id, session); err != nil {
+		log.Printf("[session] failed to save %s: %v", id, err)
 	}
-	return ""
 }
 
+
 func writeJSON(w http.ResponseWriter, status int, v any) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
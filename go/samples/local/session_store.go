@@ -0,0 +1,202 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+// ErrSessionNotFound is returned by a [SessionStore] when no session exists
+// for an id.
+var ErrSessionNotFound = errors.New("session not found")
+
+// SessionStore persists [af.Session]s independently of the process that
+// created them, so conversations survive a restart and can be shared across
+// replicas. The default implementation is in-memory; see
+// [NewRedisSessionStore] and [NewSQLSessionStore] for shared backends.
+type SessionStore interface {
+	Get(ctx context.Context, id string) (*af.Session, error)
+	Put(ctx context.Context, id string, session *af.Session) error
+	Delete(ctx context.Context, id string) error
+}
+
+// ── In-memory ──────────────────────────────────────────────────────
+
+// sessionEntry pairs a session with its expiry time.
+type sessionEntry struct {
+	session *af.Session
+	expires time.Time
+}
+
+// inMemorySessionStore is the default [SessionStore]. If ttl is non-zero, an
+// entry not touched by Get or Put within ttl is evicted lazily the next time
+// it is looked up.
+type inMemorySessionStore struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]sessionEntry
+}
+
+// SessionStoreOption configures the default in-memory [SessionStore].
+type SessionStoreOption func(*inMemorySessionStore)
+
+// WithSessionTTL evicts sessions that haven't been touched by Get or Put for
+// longer than ttl. A ttl of zero (the default) disables eviction.
+func WithSessionTTL(ttl time.Duration) SessionStoreOption {
+	return func(s *inMemorySessionStore) {
+		s.ttl = ttl
+	}
+}
+
+func newInMemorySessionStore(opts ...SessionStoreOption) *inMemorySessionStore {
+	s := &inMemorySessionStore{entries: make(map[string]sessionEntry)}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *inMemorySessionStore) Get(_ context.Context, id string) (*af.Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.entries[id]
+	if !ok {
+		return nil, ErrSessionNotFound
+	}
+	if s.ttl > 0 && time.Now().After(entry.expires) {
+		delete(s.entries, id)
+		return nil, ErrSessionNotFound
+	}
+	return entry.session, nil
+}
+
+func (s *inMemorySessionStore) Put(_ context.Context, id string, session *af.Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry := sessionEntry{session: session}
+	if s.ttl > 0 {
+		entry.expires = time.Now().Add(s.ttl)
+	}
+	s.entries[id] = entry
+	return nil
+}
+
+func (s *inMemorySessionStore) Delete(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, id)
+	return nil
+}
+
+// ── Redis ──────────────────────────────────────────────────────────
+
+// RedisClient is the minimal surface a Redis client needs to implement to
+// back a [SessionStore] via [NewRedisSessionStore] — a thin wrapper around
+// github.com/redis/go-redis/v9 or similar satisfies this easily.
+type RedisClient interface {
+	Get(ctx context.Context, key string) (string, error)
+	Set(ctx context.Context, key string, value string, ttl time.Duration) error
+	Del(ctx context.Context, key string) error
+}
+
+// redisSessionStore is a [SessionStore] backed by a [RedisClient]. Each
+// session is stored under key "session:<id>" as its [af.Session]
+// JSON encoding.
+type redisSessionStore struct {
+	client RedisClient
+	ttl    time.Duration
+}
+
+// NewRedisSessionStore adapts client into a [SessionStore]. ttl (if
+// non-zero) is passed through to client.Set on every Put.
+func NewRedisSessionStore(client RedisClient, ttl time.Duration) SessionStore {
+	return &redisSessionStore{client: client, ttl: ttl}
+}
+
+func (s *redisSessionStore) Get(ctx context.Context, id string) (*af.Session, error) {
+	data, err := s.client.Get(ctx, redisSessionKey(id))
+	if err != nil {
+		return nil, err
+	}
+	if data == "" {
+		return nil, ErrSessionNotFound
+	}
+	session := af.NewSession()
+	if err := json.Unmarshal([]byte(data), session); err != nil {
+		return nil, fmt.Errorf("decode session %s: %w", id, err)
+	}
+	return session, nil
+}
+
+func (s *redisSessionStore) Put(ctx context.Context, id string, session *af.Session) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("encode session %s: %w", id, err)
+	}
+	return s.client.Set(ctx, redisSessionKey(id), string(data), s.ttl)
+}
+
+func (s *redisSessionStore) Delete(ctx context.Context, id string) error {
+	return s.client.Del(ctx, redisSessionKey(id))
+}
+
+func redisSessionKey(id string) string {
+	return "session:" + id
+}
+
+// ── SQL ────────────────────────────────────────────────────────────
+
+// sqlSessionStore is a [SessionStore] backed by any database/sql driver,
+// storing each session's JSON encoding in a single table with columns
+// (id TEXT PRIMARY KEY, data TEXT).
+type sqlSessionStore struct {
+	db    *sql.DB
+	table string
+}
+
+// NewSQLSessionStore adapts db into a [SessionStore], persisting sessions in
+// table (which the caller must create ahead of time). Any database/sql
+// driver works, since only standard SQL is used.
+func NewSQLSessionStore(db *sql.DB, table string) SessionStore {
+	return &sqlSessionStore{db: db, table: table}
+}
+
+func (s *sqlSessionStore) Get(ctx context.Context, id string) (*af.Session, error) {
+	row := s.db.QueryRowContext(ctx, fmt.Sprintf("SELECT data FROM %s WHERE id = ?", s.table), id)
+	var data string
+	if err := row.Scan(&data); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrSessionNotFound
+		}
+		return nil, err
+	}
+	session := af.NewSession()
+	if err := json.Unmarshal([]byte(data), session); err != nil {
+		return nil, fmt.Errorf("decode session %s: %w", id, err)
+	}
+	return session, nil
+}
+
+func (s *sqlSessionStore) Put(ctx context.Context, id string, session *af.Session) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("encode session %s: %w", id, err)
+	}
+	_, err = s.db.ExecContext(ctx,
+		fmt.Sprintf("INSERT INTO %s (id, data) VALUES (?, ?) ON CONFLICT(id) DO UPDATE SET data = excluded.data", s.table),
+		id, string(data))
+	return err
+}
+
+func (s *sqlSessionStore) Delete(ctx context.Context, id string) error {
+	_, err := s.db.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE id = ?", s.table), id)
+	return err
+}
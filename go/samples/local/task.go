@@ -0,0 +1,153 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// TaskState is the lifecycle state of an A2A long-running task.
+type TaskState string
+
+const (
+	TaskSubmitted     TaskState = "submitted"
+	TaskWorking       TaskState = "working"
+	TaskInputRequired TaskState = "input-required"
+	TaskCompleted     TaskState = "completed"
+	TaskFailed        TaskState = "failed"
+	TaskCanceled      TaskState = "canceled"
+)
+
+// ErrTaskNotFound is returned by a [TaskStore] when no task exists for an id.
+var ErrTaskNotFound = errors.New("task not found")
+
+// Task is the persisted state of a single A2A long-running task: its
+// lifecycle state, the message history exchanged so far, and any artifacts
+// (tool results, generated files) it produced.
+type Task struct {
+	ID        string
+	ContextID string
+	State     TaskState
+	History   []a2aMessage
+	Artifacts []a2aMessage
+	Error     string
+
+	// PushConfig is the webhook registered via
+	// "tasks/pushNotificationConfig/set", if any.
+	PushConfig *pushNotificationConfig
+	// PushDeliveryState is "", "delivered", or "failed" and reflects the
+	// outcome of the most recent webhook delivery attempt.
+	PushDeliveryState string
+	PushDeliveryError string
+}
+
+// TaskStore persists [Task] state so it can be queried via A2A "tasks/get"
+// independently of which server process handled the originating
+// "message/send" or "message/stream". The default implementation is
+// in-memory; pass a different implementation via [WithTaskStore] to back
+// tasks with Redis, SQL, or another shared store.
+type TaskStore interface {
+	Save(ctx context.Context, task *Task) error
+	Load(ctx context.Context, id string) (*Task, error)
+}
+
+// inMemoryTaskStore is the default [TaskStore].
+type inMemoryTaskStore struct {
+	mu    sync.RWMutex
+	tasks map[string]*Task
+}
+
+func newInMemoryTaskStore() *inMemoryTaskStore {
+	return &inMemoryTaskStore{tasks: make(map[string]*Task)}
+}
+
+func (s *inMemoryTaskStore) Save(_ context.Context, task *Task) error {
+	cp := *task
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tasks[task.ID] = &cp
+	return nil
+}
+
+func (s *inMemoryTaskStore) Load(_ context.Context, id string) (*Task, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	task, ok := s.tasks[id]
+	if !ok {
+		return nil, ErrTaskNotFound
+	}
+	cp := *task
+	return &cp, nil
+}
+
+// taskRuntime holds the in-process state needed to drive a running task:
+// its cancel func and the buffered/live event fan-out used by
+// "tasks/resubscribe". This is kept separate from [TaskStore] because
+// cancellation and live SSE fan-out are inherently per-process, even when a
+// task's [Task] state is persisted to a shared backend.
+type taskRuntime struct {
+	cancel context.CancelFunc
+	// done is closed once the task reaches a terminal state.
+	done     chan struct{}
+	doneOnce sync.Once
+
+	mu     sync.Mutex
+	events []a2aStreamChunk
+	subs   map[chan a2aStreamChunk]struct{}
+}
+
+func newTaskRuntime(cancel context.CancelFunc) *taskRuntime {
+	return &taskRuntime{cancel: cancel, done: make(chan struct{}), subs: make(map[chan a2aStreamChunk]struct{})}
+}
+
+// markDone closes the done channel, waking anything blocked waiting for the
+// task to reach a terminal state. Safe to call more than once.
+func (r *taskRuntime) markDone() {
+	r.doneOnce.Do(func() { close(r.done) })
+}
+
+// publish buffers chunk for later replay and fans it out to any live
+// "tasks/resubscribe" subscribers. Slow subscribers drop events rather than
+// block the task.
+func (r *taskRuntime) publish(chunk a2aStreamChunk) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, chunk)
+	for ch := range r.subs {
+		select {
+		case ch <- chunk:
+		default:
+		}
+	}
+}
+
+// subscribe returns the events buffered so far plus a channel that receives
+// events published after this call. Call unsubscribe when done to release
+// the channel.
+func (r *taskRuntime) subscribe() (buffered []a2aStreamChunk, live <-chan a2aStreamChunk, unsubscribe func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ch := make(chan a2aStreamChunk, 16)
+	r.subs[ch] = struct{}{}
+	buffered = append([]a2aStreamChunk(nil), r.events...)
+	unsubscribe = func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if _, ok := r.subs[ch]; ok {
+			delete(r.subs, ch)
+			close(ch)
+		}
+	}
+	return buffered, ch, unsubscribe
+}
+
+// newTaskID generates a random A2A task identifier.
+func newTaskID() string {
+	var b [8]byte
+	_, _ = rand.Read(b[:])
+	return fmt.Sprintf("task-%x", b)
+}
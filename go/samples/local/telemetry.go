@@ -0,0 +1,188 @@
+// Copyright (c) Microsoft. All rights reserved.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	af "github.com/microsoft/agent-framework/go/agentframework"
+)
+
+// traceparentHeader and tracestateHeader are the W3C Trace Context headers
+// (https://www.w3.org/TR/trace-context/) propagated from an incoming
+// request onto the request span, so traces stay connected across a
+// caller's own OpenTelemetry instrumentation even though this server
+// doesn't depend on the real SDK.
+const (
+	traceparentHeader = "traceparent"
+	tracestateHeader  = "tracestate"
+)
+
+// tracingHandler wraps next with a request-scoped [af.Span], the
+// dependency-free equivalent of otelhttp.NewHandler: it starts a span per
+// request (propagating an incoming traceparent/tracestate as attributes),
+// and records request count and latency on meter.
+func tracingHandler(tracer af.Tracer, meter af.Meter, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attrs := []af.KeyValue{
+			af.Attr("http.method", r.Method),
+			af.Attr("http.path", r.URL.Path),
+		}
+		if tp := r.Header.Get(traceparentHeader); tp != "" {
+			attrs = append(attrs, af.Attr("traceparent", tp))
+		}
+		if ts := r.Header.Get(tracestateHeader); ts != "" {
+			attrs = append(attrs, af.Attr("tracestate", ts))
+		}
+
+		ctx, span := tracer.Start(r.Context(), "http.request", attrs...)
+		defer span.End()
+		start := time.Now()
+
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r.WithContext(ctx))
+
+		duration := time.Since(start)
+		span.SetAttributes(af.Attr("http.status_code", rec.status))
+
+		labels := []af.KeyValue{
+			af.Attr("http.method", r.Method),
+			af.Attr("http.path", r.URL.Path),
+			af.Attr("http.status_code", rec.status),
+		}
+		meter.Counter("http_server_requests_total").Add(ctx, 1, labels...)
+		meter.Histogram("http_server_request_duration_ms").Record(ctx, float64(duration.Milliseconds()), labels...)
+	})
+}
+
+// statusRecorder captures the status code written by the wrapped handler so
+// [tracingHandler] can attach it to the request span and metrics after the
+// handler returns.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// ── Prometheus-exposition meter ──────────────────────────────────────
+
+// promMeter is a minimal, dependency-free [af.Meter] that accumulates
+// counters and histograms in memory and renders them in the Prometheus text
+// exposition format via [promMeter.writeTo]. Attributes passed to
+// [af.Counter.Add] and [af.Histogram.Record] are accepted for interface
+// compatibility but aggregated into a single series per instrument name,
+// rather than exploded into Prometheus label dimensions — swap in a real
+// OpenTelemetry meter via a future option if per-label breakdown matters.
+type promMeter struct {
+	mu         sync.Mutex
+	counters   map[string]*promCounter
+	histograms map[string]*promHistogram
+}
+
+func newPromMeter() *promMeter {
+	return &promMeter{
+		counters:   make(map[string]*promCounter),
+		histograms: make(map[string]*promHistogram),
+	}
+}
+
+func (m *promMeter) Counter(name string) af.Counter {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c, ok := m.counters[name]
+	if !ok {
+		c = &promCounter{}
+		m.counters[name] = c
+	}
+	return c
+}
+
+func (m *promMeter) Histogram(name string) af.Histogram {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	h, ok := m.histograms[name]
+	if !ok {
+		h = &promHistogram{}
+		m.histograms[name] = h
+	}
+	return h
+}
+
+// writeTo renders every registered instrument in Prometheus text exposition
+// format (https://prometheus.io/docs/instrumenting/exposition_formats/).
+func (m *promMeter) writeTo(w io.Writer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	names := make([]string, 0, len(m.counters))
+	for name := range m.counters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "# TYPE %s counter\n%s %d\n", name, name, m.counters[name].value())
+	}
+
+	names = names[:0]
+	for name := range m.histograms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		count, sum := m.histograms[name].snapshot()
+		fmt.Fprintf(w, "# TYPE %s histogram\n%s_count %d\n%s_sum %g\n", name, name, count, name, sum)
+	}
+}
+
+type promCounter struct {
+	mu  sync.Mutex
+	val int64
+}
+
+func (c *promCounter) Add(_ context.Context, delta int64, _ ...af.KeyValue) {
+	c.mu.Lock()
+	c.val += delta
+	c.mu.Unlock()
+}
+
+func (c *promCounter) value() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.val
+}
+
+type promHistogram struct {
+	mu    sync.Mutex
+	count int64
+	sum   float64
+}
+
+func (h *promHistogram) Record(_ context.Context, value float64, _ ...af.KeyValue) {
+	h.mu.Lock()
+	h.count++
+	h.sum += value
+	h.mu.Unlock()
+}
+
+func (h *promHistogram) snapshot() (count int64, sum float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.count, h.sum
+}
+
+// handleMetrics serves the server's accumulated metrics in Prometheus text
+// exposition format.
+func (s *agentServer) handleMetrics(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	s.metrics.writeTo(w)
+}
@@ -8,7 +8,9 @@ import (
 	"fmt"
 	"log/slog"
 	"regexp"
+	"strconv"
 	"strings"
+	"sync/atomic"
 
 	af "github.com/microsoft/agent-framework/go/agentframework"
 )
@@ -18,15 +20,67 @@ import (
 // Also matches when wrapped in markdown code fences: ```json ... ```
 var toolCallPattern = regexp.MustCompile(`(?s)^\s*(?:` + "`" + `{3}(?:json)?\s*)?\[\s*\{.*\}\s*\](?:\s*` + "`" + `{3})?\s*$`)
 
+// anthropicInvokeBlockPattern finds a top-level <function_calls>...</function_calls>
+// envelope anywhere in the text, allowing surrounding prose.
+var anthropicInvokeBlockPattern = regexp.MustCompile(`(?s)<function_calls>(.*?)</function_calls>`)
+
+// anthropicInvokePattern matches a single <invoke name="...">...</invoke> element.
+var anthropicInvokePattern = regexp.MustCompile(`(?s)<invoke\s+name="([^"]+)"\s*>(.*?)</invoke>`)
+
+// anthropicParameterPattern matches a single <parameter name="...">value</parameter> element.
+var anthropicParameterPattern = regexp.MustCompile(`(?s)<parameter\s+name="([^"]+)"\s*>(.*?)</parameter>`)
+
+// ToolCallWorkaroundFormat selects one of the text-based tool-call encodings
+// that [ToolCallWorkaroundMiddleware] knows how to detect and convert.
+type ToolCallWorkaroundFormat int
+
+const (
+	// FormatJSONArray matches `[{"fn": {...}}]`-style text, the format
+	// emitted by most local OpenAI-compatible runtimes.
+	FormatJSONArray ToolCallWorkaroundFormat = 1 << iota
+
+	// FormatAnthropicXML matches the `<function_calls><invoke name="fn">
+	// <parameter name="x">1</parameter></invoke></function_calls>` envelope
+	// used by some Claude-family models and fine-tunes trained on it.
+	FormatAnthropicXML
+)
+
+// ToolCallWorkaroundOptions configures which text-based tool-call encodings
+// [ToolCallWorkaroundMiddleware] looks for. The zero value matches both.
+type ToolCallWorkaroundOptions struct {
+	Formats ToolCallWorkaroundFormat
+}
+
+// ToolCallWorkaroundOption configures [ToolCallWorkaroundOptions].
+type ToolCallWorkaroundOption func(*ToolCallWorkaroundOptions)
+
+// WithToolCallFormats restricts detection to the given formats instead of
+// trying all of them.
+func WithToolCallFormats(formats ...ToolCallWorkaroundFormat) ToolCallWorkaroundOption {
+	return func(o *ToolCallWorkaroundOptions) {
+		var combined ToolCallWorkaroundFormat
+		for _, f := range formats {
+			combined |= f
+		}
+		o.Formats = combined
+	}
+}
+
 // ToolCallWorkaroundMiddleware detects text responses that contain tool calls
-// in JSON format and converts them to proper FunctionCallContent objects.
+// encoded as text (JSON arrays, or the Anthropic-style XML envelope) and
+// converts them to proper FunctionCallContent objects.
 //
 // This is a workaround for local inference runtimes that don't emit structured
 // tool_calls in the OpenAI wire format.
 //
 // IMPORTANT: This must be used as ChatMiddleware (not AgentMiddleware) so the
 // conversion happens BEFORE the agent's tool execution logic runs.
-func ToolCallWorkaroundMiddleware(logger *slog.Logger) af.ChatMiddleware {
+func ToolCallWorkaroundMiddleware(logger *slog.Logger, opts ...ToolCallWorkaroundOption) af.ChatMiddleware {
+	options := ToolCallWorkaroundOptions{Formats: FormatJSONArray | FormatAnthropicXML}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	return func(next af.ChatHandler) af.ChatHandler {
 		return func(ctx context.Context, messages []af.Message, opts *af.ChatOptions) (*af.ChatResponse, error) {
 			resp, err := next(ctx, messages, opts)
@@ -34,7 +88,6 @@ func ToolCallWorkaroundMiddleware(logger *slog.Logger) af.ChatMiddleware {
 				return resp, err
 			}
 
-			// Process each message in the response
 			for i := range resp.Messages {
 				msg := &resp.Messages[i]
 				if msg.Role != af.RoleAssistant {
@@ -46,43 +99,41 @@ func ToolCallWorkaroundMiddleware(logger *slog.Logger) af.ChatMiddleware {
 					continue
 				}
 
-				// Get the text content
 				text := extractText(msg)
 				if text == "" {
 					continue
 				}
 
-				// Check if it matches tool call pattern
-				if !toolCallPattern.MatchString(text) {
-					continue
+				if options.Formats&FormatAnthropicXML != 0 {
+					if toolCalls, remaining, ok := tryParseAnthropicXML(text); ok {
+						logger.Info("converted XML tool-call block to function calls",
+							"count", len(toolCalls))
+						msg.Contents = buildWorkaroundContents(remaining, toolCalls)
+						resp.FinishReason = af.FinishReasonToolCalls
+						continue
+					}
 				}
 
-				logger.Debug("detected potential tool call in text",
-					"text", text)
+				if options.Formats&FormatJSONArray != 0 {
+					if !toolCallPattern.MatchString(text) {
+						continue
+					}
 
-				// Try to parse as tool calls
-				toolCalls, err := parseToolCalls(text)
-				if err != nil {
-					logger.Debug("failed to parse tool calls",
-						"error", err)
-					continue
-				}
+					logger.Debug("detected potential tool call in text", "text", text)
 
-				if len(toolCalls) == 0 {
-					continue
-				}
+					toolCalls, err := parseToolCalls(text)
+					if err != nil {
+						logger.Debug("failed to parse tool calls", "error", err)
+						continue
+					}
+					if len(toolCalls) == 0 {
+						continue
+					}
 
-				logger.Info("converted text to tool calls",
-					"count", len(toolCalls))
-
-				// Replace text content with function call content
-				msg.Contents = make([]af.Content, len(toolCalls))
-				for j, tc := range toolCalls {
-					msg.Contents[j] = tc
+					logger.Info("converted text to tool calls", "count", len(toolCalls))
+					msg.Contents = buildWorkaroundContents("", toolCalls)
+					resp.FinishReason = af.FinishReasonToolCalls
 				}
-
-				// Set finish reason to indicate tool calls
-				resp.FinishReason = af.FinishReasonToolCalls
 			}
 
 			return resp, nil
@@ -90,6 +141,19 @@ func ToolCallWorkaroundMiddleware(logger *slog.Logger) af.ChatMiddleware {
 	}
 }
 
+// buildWorkaroundContents assembles a message's Contents from any leftover
+// prose text plus the extracted function calls.
+func buildWorkaroundContents(remainingText string, calls []*af.FunctionCallContent) af.Contents {
+	contents := make(af.Contents, 0, len(calls)+1)
+	if remainingText != "" {
+		contents = append(contents, &af.TextContent{Text: remainingText})
+	}
+	for _, tc := range calls {
+		contents = append(contents, tc)
+	}
+	return contents
+}
+
 // hasOnlyTextContent checks if a message contains only text content.
 func hasOnlyTextContent(msg *af.Message) bool {
 	if len(msg.Contents) == 0 {
@@ -159,3 +223,208 @@ func parseToolCalls(text string) ([]*af.FunctionCallContent, error) {
 
 	return result, nil
 }
+
+// tryParseAnthropicXML looks for a <function_calls> envelope anywhere in text.
+// On success it returns the extracted calls and the surrounding prose with the
+// XML block removed; ok is false if no envelope was found or it failed to parse.
+func tryParseAnthropicXML(text string) (calls []*af.FunctionCallContent, remaining string, ok bool) {
+	loc := anthropicInvokeBlockPattern.FindStringSubmatchIndex(text)
+	if loc == nil {
+		return nil, "", false
+	}
+
+	inner := text[loc[2]:loc[3]]
+	invokes := anthropicInvokePattern.FindAllStringSubmatch(inner, -1)
+	if len(invokes) == 0 {
+		return nil, "", false
+	}
+
+	calls = make([]*af.FunctionCallContent, 0, len(invokes))
+	for i, invoke := range invokes {
+		name := invoke[1]
+		body := invoke[2]
+
+		args := make(map[string]any)
+		for _, param := range anthropicParameterPattern.FindAllStringSubmatch(body, -1) {
+			args[param[1]] = coerceParameterValue(strings.TrimSpace(param[2]))
+		}
+
+		argsJSON, err := json.Marshal(args)
+		if err != nil {
+			return nil, "", false
+		}
+
+		calls = append(calls, &af.FunctionCallContent{
+			CallID:    fmt.Sprintf("call_local_%d", i),
+			Name:      name,
+			Arguments: string(argsJSON),
+		})
+	}
+
+	remaining = strings.TrimSpace(text[:loc[0]] + text[loc[1]:])
+	return calls, remaining, true
+}
+
+// coerceParameterValue converts the raw text of an Anthropic XML <parameter>
+// into a bool/number when it unambiguously looks like one, otherwise it
+// preserves the original string.
+func coerceParameterValue(raw string) any {
+	if b, err := strconv.ParseBool(raw); err == nil {
+		return b
+	}
+	if n, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return n
+	}
+	if f, err := strconv.ParseFloat(raw, 64); err == nil {
+		return f
+	}
+	return raw
+}
+
+// streamCallSeq generates unique suffixes for synthetic call IDs produced by
+// ToolCallWorkaroundStream, since unlike the non-streaming path there is no
+// single response to index calls within.
+var streamCallSeq atomic.Int64
+
+// ToolCallWorkaroundStream wraps a streaming [af.ResponseStream] of
+// [af.ChatResponseUpdate] and applies the same text-based tool-call detection
+// as [ToolCallWorkaroundMiddleware]. This is needed because ChatMiddleware
+// (and therefore ToolCallWorkaroundMiddleware) only runs on the non-streaming
+// Response path — a client's StreamResponse bypasses it entirely, so a local
+// runtime that emits tool calls as plain text gets no workaround when the
+// caller streams.
+//
+// Text deltas are buffered until the accumulated buffer becomes a complete,
+// depth-balanced JSON array or a closed Anthropic `</function_calls>` block.
+// Once recognized, a sentinel update carrying an empty [af.TextContent]
+// is emitted to signal "discard the text you've buffered so far", followed by
+// one update per extracted [af.FunctionCallContent]. If the stream ends
+// before anything is recognized, the buffered text is flushed as-is so no
+// output is silently dropped.
+func ToolCallWorkaroundStream(ctx context.Context, src *af.ResponseStream[af.ChatResponseUpdate], logger *slog.Logger, opts ...ToolCallWorkaroundOption) *af.ResponseStream[af.ChatResponseUpdate] {
+	options := ToolCallWorkaroundOptions{Formats: FormatJSONArray | FormatAnthropicXML}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return af.NewResponseStream[af.ChatResponseUpdate](ctx, func(ctx context.Context, ch chan<- af.ChatResponseUpdate) error {
+		defer src.Close()
+
+		var buf strings.Builder
+		resolved := false
+
+		send := func(u af.ChatResponseUpdate) error {
+			select {
+			case ch <- u:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		for {
+			u, ok, err := src.Next(ctx)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				if buf.Len() > 0 && !resolved {
+					if err := send(af.ChatResponseUpdate{
+						Contents: af.Contents{&af.TextContent{Text: buf.String()}},
+						Role:     af.RoleAssistant,
+					}); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+
+			text := u.Text()
+			if resolved || text == "" {
+				if err := send(u); err != nil {
+					return err
+				}
+				continue
+			}
+
+			buf.WriteString(text)
+
+			var calls []*af.FunctionCallContent
+			switch {
+			case options.Formats&FormatAnthropicXML != 0 && strings.Contains(buf.String(), "</function_calls>"):
+				if parsed, _, ok := tryParseAnthropicXML(buf.String()); ok {
+					calls = parsed
+				}
+			case options.Formats&FormatJSONArray != 0 && isBalancedJSON(buf.String()):
+				trimmed := strings.TrimSpace(buf.String())
+				if toolCallPattern.MatchString(trimmed) {
+					if parsed, err := parseToolCalls(trimmed); err == nil {
+						calls = parsed
+					}
+				}
+			default:
+				continue
+			}
+
+			if calls == nil {
+				// Balanced but not a recognized tool-call shape — keep buffering
+				// in case more text arrives that does match.
+				continue
+			}
+
+			logger.Info("converted streamed text to tool calls", "count", len(calls))
+
+			if err := send(af.ChatResponseUpdate{
+				Contents: af.Contents{&af.TextContent{Text: ""}},
+				Role:     af.RoleAssistant,
+			}); err != nil {
+				return err
+			}
+			for _, c := range calls {
+				c.CallID = fmt.Sprintf("call_local_stream_%d_%s", streamCallSeq.Add(1), c.CallID)
+				if err := send(af.ChatResponseUpdate{
+					Contents:     af.Contents{c},
+					Role:         af.RoleAssistant,
+					FinishReason: af.FinishReasonToolCalls,
+				}); err != nil {
+					return err
+				}
+			}
+			resolved = true
+		}
+	})
+}
+
+// isBalancedJSON reports whether s contains at least one complete,
+// depth-balanced `{}`/`[]` structure, counting brackets outside string
+// literals. This lets the streaming detector recognize "the buffer might now
+// be a complete JSON array" without attempting json.Unmarshal on every token.
+func isBalancedJSON(s string) bool {
+	depth := 0
+	started := false
+	inString := false
+	escaped := false
+	for _, r := range s {
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case r == '\\':
+				escaped = true
+			case r == '"':
+				inString = false
+			}
+			continue
+		}
+		switch r {
+		case '"':
+			inString = true
+		case '{', '[':
+			depth++
+			started = true
+		case '}', ']':
+			depth--
+		}
+	}
+	return started && depth == 0
+}
@@ -3,19 +3,14 @@
 package main
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"log"
 	"os"
-	"os/exec"
-	"strings"
 	"time"
 
 	af "github.com/microsoft/agent-framework/go/agentframework"
-
-	"golang.org/x/sys/windows"
 )
 
 // GetTools returns the tool definitions for the local assistant.
@@ -24,7 +19,7 @@ func GetTools() []af.Tool {
 		"Get the current weather for a location.",
 		func(ctx context.Context, args struct {
 			Location string `json:"location" jsonschema:"description=City name or location,required"`
-			Unit     string `json:"unit"     jsonschema:"description=Temperature unit,enum=celsius|fahrenheit"`
+			Unit     string `json:"unit,omitempty" jsonschema:"description=Temperature unit,enum=celsius|fahrenheit"`
 		}) (any, error) {
 			unit := args.Unit
 			if unit == "" {
@@ -57,7 +52,9 @@ func GetTools() []af.Tool {
 		},
 	)
 
-	return []af.Tool{weatherTool, timeTool, listFilesTool(), listDockerImagesTool(), diskSpaceTool()}
+	tools := []af.Tool{weatherTool, timeTool, listFilesTool(), diskSpaceTool()}
+	tools = append(tools, (&DockerToolset{}).GetTools()...)
+	return tools
 }
 
 // listFilesTool returns the list_local_files tool that lists files in the
@@ -106,95 +103,6 @@ func listFilesTool() af.Tool {
 	)
 }
 
-// listDockerImagesTool returns a tool that lists Docker images on the host.
-func listDockerImagesTool() af.Tool {
-	return af.NewTool(
-		"list_docker_images",
-		"Lists Docker images available on the host machine",
-		json.RawMessage(`{"type":"object","properties":{}}`),
-		func(ctx context.Context, args json.RawMessage) (any, error) {
-			cmd := exec.CommandContext(ctx, "docker", "images", "--format", "{{.Repository}}\t{{.Tag}}\t{{.ID}}\t{{.Size}}\t{{.CreatedSince}}")
-			var stdout, stderr bytes.Buffer
-			cmd.Stdout = &stdout
-			cmd.Stderr = &stderr
-
-			if err := cmd.Run(); err != nil {
-				return nil, &af.ToolError{
-					ToolName: "list_docker_images",
-					Message:  fmt.Sprintf("docker command failed: %v — %s", err, stderr.String()),
-				}
-			}
-
-			var images []map[string]string
-			for _, line := range strings.Split(strings.TrimSpace(stdout.String()), "\n") {
-				if line == "" {
-					continue
-				}
-				parts := strings.SplitN(line, "\t", 5)
-				img := map[string]string{"repository": "", "tag": "", "id": "", "size": "", "created": ""}
-				if len(parts) > 0 { img["repository"] = parts[0] }
-				if len(parts) > 1 { img["tag"] = parts[1] }
-				if len(parts) > 2 { img["id"] = parts[2] }
-				if len(parts) > 3 { img["size"] = parts[3] }
-				if len(parts) > 4 { img["created"] = parts[4] }
-				images = append(images, img)
-			}
-
-			return map[string]any{
-				"count":  len(images),
-				"images": images,
-			}, nil
-		},
-	)
-}
-
-// diskSpaceTool returns a tool that reports disk space for all drives.
-func diskSpaceTool() af.Tool {
-	return af.NewTool(
-		"get_disk_space",
-		"Gets available disk space for all drives on the host machine",
-		json.RawMessage(`{"type":"object","properties":{}}`),
-		func(ctx context.Context, args json.RawMessage) (any, error) {
-			var drives []map[string]any
-
-			for _, letter := range "ABCDEFGHIJKLMNOPQRSTUVWXYZ" {
-				root := string(letter) + ":\\"
-				rootPtr, _ := windows.UTF16PtrFromString(root)
-
-				var freeBytesAvailable, totalBytes, totalFreeBytes uint64
-				err := windows.GetDiskFreeSpaceEx(rootPtr, &freeBytesAvailable, &totalBytes, &totalFreeBytes)
-				if err != nil {
-					continue // drive doesn't exist or isn't ready
-				}
-
-				totalKB := totalBytes / 1024
-				freeKB := totalFreeBytes / 1024
-				usedKB := totalKB - freeKB
-
-				drives = append(drives, map[string]any{
-					"drive":          root,
-					"total_readable":  formatBytes(totalBytes),
-					"free_readable":   formatBytes(totalFreeBytes),
-					"used_readable":   formatBytes(totalBytes - totalFreeBytes),
-					"total_kb":        totalKB,
-					"free_kb":         freeKB,
-					"used_kb":         usedKB,
-					"percent_used":    fmt.Sprintf("%.1f%%", float64(usedKB)/float64(totalKB)*100),
-				})
-			}
-
-			if len(drives) == 0 {
-				return nil, &af.ToolError{
-					ToolName: "get_disk_space",
-					Message:  "no drives found",
-				}
-			}
-
-			return map[string]any{"drives": drives}, nil
-		},
-	)
-}
-
 func formatBytes(b uint64) string {
 	switch {
 	case b >= 1<<40: